@@ -38,20 +38,92 @@ const (
 
 // TelemetrySpec defines the desired state of Telemetry
 type TelemetrySpec struct {
+	// +optional
+	Log *LogSpec `json:"log,omitempty"`
+
 	// +optional
 	Trace *TraceSpec `json:"trace,omitempty"`
 
 	// +optional
 	Metric *MetricSpec `json:"metric,omitempty"`
+
+	// Enrichments defines additional Kubernetes metadata that the trace and metric gateways add to the resource attributes of all telemetry data.
+	// +optional
+	Enrichments *EnrichmentSpec `json:"enrichments,omitempty"`
+
+	// Cluster defines the identity of the cluster that the log, trace and metric components add to all telemetry data.
+	// +optional
+	Cluster *ClusterSpec `json:"cluster,omitempty"`
 }
 
+// ClusterSpec defines the cluster name and further static attributes that identify the cluster in the backends.
+// +kubebuilder:validation:XValidation:rule="!has(self.attributes) || self.attributes.all(k, k.matches('^[a-zA-Z0-9._-]+$') && !self.attributes[k].contains('\\n'))",message="attribute keys must consist of alphanumeric characters, '.', '_' or '-', and values must not contain line breaks"
+type ClusterSpec struct {
+	// Name is added as `k8s.cluster.name` resource attribute and as `cluster_identifier` field of the Fluent Bit log records.
+	// If not defined, the address of the Kubernetes API server is used.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._-]+$`
+	Name string `json:"name,omitempty"`
+
+	// Attributes are static attributes, like the environment or region, that are added as resource attributes and as fields of the Fluent Bit log records.
+	// Resource attributes that are already set by the application are not overwritten.
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// EnrichmentSpec defines the Kubernetes metadata that is added to the resource attributes of the telemetry data, in addition to the default metadata.
+type EnrichmentSpec struct {
+	// PodLabels lists the keys of Pod labels that are added as `k8s.pod.labels.<key>` resource attributes.
+	// +optional
+	PodLabels []string `json:"podLabels,omitempty"`
+
+	// PodAnnotations lists the keys of Pod annotations that are added as `k8s.pod.annotations.<key>` resource attributes.
+	// +optional
+	PodAnnotations []string `json:"podAnnotations,omitempty"`
+
+	// NamespaceLabels lists the keys of Namespace labels that are added as `k8s.namespace.labels.<key>` resource attributes.
+	// +optional
+	NamespaceLabels []string `json:"namespaceLabels,omitempty"`
+
+	// NamespaceAnnotations lists the keys of Namespace annotations that are added as `k8s.namespace.annotations.<key>` resource attributes.
+	// +optional
+	NamespaceAnnotations []string `json:"namespaceAnnotations,omitempty"`
+
+	// Metadata lists additional metadata attributes. The container image attributes are only added to data that has the `k8s.container.name` resource attribute.
+	// +optional
+	Metadata []EnrichmentMetadata `json:"metadata,omitempty"`
+}
+
+// EnrichmentMetadata is a metadata attribute that is not added by default.
+// +kubebuilder:validation:Enum=k8s.pod.uid;k8s.pod.start_time;k8s.replicaset.name;k8s.replicaset.uid;container.image.name;container.image.tag
+type EnrichmentMetadata string
+
 // MetricSpec defines the behavior of the metric gateway
+type LogSpec struct {
+	// Agent defines which implementation collects the application logs of the LogPipelines on the nodes. The default is `FluentBit`.
+	// With `OpenTelemetry`, the logs of the LogPipelines that only have OTLP outputs and no custom filters are collected by an OpenTelemetry Collector. All other LogPipelines are still served by Fluent Bit.
+	// +kubebuilder:validation:Enum=FluentBit;OpenTelemetry
+	// +optional
+	Agent LogAgentType `json:"agent,omitempty"`
+}
+
+type LogAgentType string
+
+const (
+	FluentBitLogAgentType     LogAgentType = "FluentBit"
+	OpenTelemetryLogAgentType LogAgentType = "OpenTelemetry"
+)
+
 type MetricSpec struct {
 	Gateway MetricGatewaySpec `json:"gateway,omitempty"`
 }
 
 type MetricGatewaySpec struct {
 	Scaling Scaling `json:"scaling,omitempty"`
+
+	// PersistentQueue defines the volume that stores the queues of the outputs with a persistent queue.
+	// +optional
+	PersistentQueue *PersistentQueue `json:"persistentQueue,omitempty"`
 }
 
 // TraceSpec defines the behavior of the trace gateway
@@ -61,6 +133,41 @@ type TraceSpec struct {
 
 type TraceGatewaySpec struct {
 	Scaling Scaling `json:"scaling,omitempty"`
+
+	// PersistentQueue defines the volume that stores the queues of the outputs with a persistent queue.
+	// +optional
+	PersistentQueue *PersistentQueue `json:"persistentQueue,omitempty"`
+
+	// Receivers enables additional protocols on which the trace gateway accepts spans, besides OTLP and OpenCensus.
+	// +optional
+	Receivers *TraceGatewayReceivers `json:"receivers,omitempty"`
+}
+
+// TraceGatewayReceivers defines the additional protocols on which the trace gateway accepts spans, so that workloads that are instrumented with Zipkin or Jaeger libraries can be onboarded without re-instrumentation.
+type TraceGatewayReceivers struct {
+	// Zipkin enables the Zipkin receiver, which accepts spans in the Zipkin v1 and v2 formats at the Service `telemetry-trace-collector-zipkin` on port 9411.
+	// +optional
+	Zipkin ReceiverSpec `json:"zipkin,omitempty"`
+
+	// Jaeger enables the Jaeger receiver, which accepts spans at the Service `telemetry-trace-collector-jaeger` with the gRPC protocol on port 14250 and the Thrift HTTP protocol on port 14268.
+	// +optional
+	Jaeger ReceiverSpec `json:"jaeger,omitempty"`
+}
+
+type ReceiverSpec struct {
+	// Enabled activates the receiver. Default is false.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// PersistentQueue defines the volume of the gateway that stores the persistent queues. If no PersistentVolumeClaim is given, an emptyDir volume is used,
+// which keeps the queued data across restarts of the collector container, but not across rescheduling of the Pod.
+type PersistentQueue struct {
+	// PersistentVolumeClaimName is the name of an existing PersistentVolumeClaim in the namespace of the gateway.
+	// Because the queue files cannot be shared between replicas, the PersistentVolumeClaim is only used with the Static scaling strategy and one replica.
+	// With any other scaling, it is ignored, an emptyDir volume is used, and the PersistentQueueHealthy condition reports the problem.
+	// +optional
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName,omitempty"`
 }
 
 // Scaling defines which strategy is used for scaling the gateway, with detailed configuration options for each strategy type.
@@ -68,13 +175,18 @@ type Scaling struct {
 	// Type of scaling strategy. Default is none, using a fixed amount of replicas.
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:validation:Enum=Static
+	// +kubebuilder:validation:Enum=Static;Autoscaling
 	Type ScalingStrategyType `json:"type,omitempty"`
 
 	// Static is a scaling strategy allowing you to define a custom amount of replicas to be used for the gateway. Present only if Type =
 	// StaticScalingStrategyType.
 	// +optional
 	Static *StaticScaling `json:"static,omitempty"`
+
+	// Autoscaling is a scaling strategy that adjusts the amount of replicas of the gateway to its CPU and memory utilization. Present only if Type =
+	// AutoscalingStrategyType.
+	// +optional
+	Autoscaling *AutoscalingScaling `json:"autoscaling,omitempty"`
 }
 
 // +enum
@@ -82,6 +194,7 @@ type ScalingStrategyType string
 
 const (
 	StaticScalingStrategyType ScalingStrategyType = "Static"
+	AutoscalingStrategyType   ScalingStrategyType = "Autoscaling"
 )
 
 type StaticScaling struct {
@@ -90,6 +203,28 @@ type StaticScaling struct {
 	Replicas int32 `json:"replicas,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!has(self.minReplicas) || !has(self.maxReplicas) || self.minReplicas <= self.maxReplicas",message="minReplicas must not be greater than maxReplicas"
+type AutoscalingScaling struct {
+	// MinReplicas defines the minimum number of pods to run the gateway. Default is 2, or MaxReplicas if it is lower. Minimum is 1.
+	// +kubebuilder:validation:Minimum=1
+	MinReplicas int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas defines the maximum number of pods to run the gateway. Default is 10.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+
+	// TargetCPUUtilizationPercentage defines the average CPU utilization of the pods, in percent of the requested CPU, at which the gateway is scaled out. Default is 80.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	TargetCPUUtilizationPercentage int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// TargetMemoryUtilizationPercentage defines the average memory utilization of the pods, in percent of the requested memory, at which the gateway is scaled out.
+	// If not defined, the memory utilization is not considered.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	TargetMemoryUtilizationPercentage int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+}
+
 // TelemetryStatus defines the observed state of Telemetry
 type TelemetryStatus struct {
 	Status `json:",inline"`
@@ -98,15 +233,48 @@ type TelemetryStatus struct {
 	// If all Conditions are met, State is expected to be in StateReady.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
-	// endpoints for trace and metric gateway.
+	// endpoints for log, trace, and metric gateway.
 	// +nullable
 	GatewayEndpoints GatewayEndpoints `json:"endpoints,omitempty"`
+
+	// Pipelines contains a summary of the pipelines of each signal type.
+	// +optional
+	Pipelines PipelineSummaries `json:"pipelines,omitempty"`
 	// add other fields to status subresource here
 }
 
 type GatewayEndpoints struct {
+	//logs contains the endpoints for log gateway supporting OTLP.
+	Logs *OTLPEndpoints `json:"logs,omitempty"`
 	//traces contains the endpoints for trace gateway supporting OTLP.
 	Traces *OTLPEndpoints `json:"traces,omitempty"`
+	//metrics contains the endpoints for metric gateway supporting OTLP.
+	Metrics *OTLPEndpoints `json:"metrics,omitempty"`
+}
+
+type PipelineSummaries struct {
+	// Logs summarizes the LogPipelines and NamespacedLogPipelines.
+	Logs *PipelineSummary `json:"logs,omitempty"`
+	// Metrics summarizes the MetricPipelines. Present only if the metric components are enabled.
+	Metrics *PipelineSummary `json:"metrics,omitempty"`
+	// Traces summarizes the TracePipelines and NamespacedTracePipelines.
+	Traces *PipelineSummary `json:"traces,omitempty"`
+}
+
+// PipelineSummary contains the number of pipelines of a signal type by their state.
+type PipelineSummary struct {
+	// Total is the number of pipelines.
+	Total int `json:"total"`
+	// Running is the number of pipelines in the Running state.
+	Running int `json:"running"`
+	// Pending is the number of pipelines in the Pending state, including the pipelines that are blocked.
+	Pending int `json:"pending"`
+	// Suspended is the number of pipelines that are suspended with `spec.suspend`.
+	Suspended int `json:"suspended"`
+	// Blocked lists the names of the pipelines that are not deployed because the maximum number of pipelines is reached.
+	// Namespaced pipelines are listed as `<namespace>/<name>`.
+	// +optional
+	Blocked []string `json:"blocked,omitempty"`
 }
 
 type OTLPEndpoints struct {
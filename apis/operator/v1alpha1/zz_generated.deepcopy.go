@@ -26,14 +26,101 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingScaling) DeepCopyInto(out *AutoscalingScaling) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingScaling.
+func (in *AutoscalingScaling) DeepCopy() *AutoscalingScaling {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingScaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
+	*out = *in
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
+func (in *ClusterSpec) DeepCopy() *ClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnrichmentSpec) DeepCopyInto(out *EnrichmentSpec) {
+	*out = *in
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceLabels != nil {
+		in, out := &in.NamespaceLabels, &out.NamespaceLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceAnnotations != nil {
+		in, out := &in.NamespaceAnnotations, &out.NamespaceAnnotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make([]EnrichmentMetadata, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnrichmentSpec.
+func (in *EnrichmentSpec) DeepCopy() *EnrichmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EnrichmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewayEndpoints) DeepCopyInto(out *GatewayEndpoints) {
 	*out = *in
+	if in.Logs != nil {
+		in, out := &in.Logs, &out.Logs
+		*out = new(OTLPEndpoints)
+		**out = **in
+	}
 	if in.Traces != nil {
 		in, out := &in.Traces, &out.Traces
 		*out = new(OTLPEndpoints)
 		**out = **in
 	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(OTLPEndpoints)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayEndpoints.
@@ -46,10 +133,30 @@ func (in *GatewayEndpoints) DeepCopy() *GatewayEndpoints {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogSpec) DeepCopyInto(out *LogSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSpec.
+func (in *LogSpec) DeepCopy() *LogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricGatewaySpec) DeepCopyInto(out *MetricGatewaySpec) {
 	*out = *in
 	in.Scaling.DeepCopyInto(&out.Scaling)
+	if in.PersistentQueue != nil {
+		in, out := &in.PersistentQueue, &out.PersistentQueue
+		*out = new(PersistentQueue)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricGatewaySpec.
@@ -93,6 +200,86 @@ func (in *OTLPEndpoints) DeepCopy() *OTLPEndpoints {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistentQueue) DeepCopyInto(out *PersistentQueue) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentQueue.
+func (in *PersistentQueue) DeepCopy() *PersistentQueue {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistentQueue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineSummaries) DeepCopyInto(out *PipelineSummaries) {
+	*out = *in
+	if in.Logs != nil {
+		in, out := &in.Logs, &out.Logs
+		*out = new(PipelineSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(PipelineSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Traces != nil {
+		in, out := &in.Traces, &out.Traces
+		*out = new(PipelineSummary)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineSummaries.
+func (in *PipelineSummaries) DeepCopy() *PipelineSummaries {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineSummaries)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineSummary) DeepCopyInto(out *PipelineSummary) {
+	*out = *in
+	if in.Blocked != nil {
+		in, out := &in.Blocked, &out.Blocked
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineSummary.
+func (in *PipelineSummary) DeepCopy() *PipelineSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReceiverSpec) DeepCopyInto(out *ReceiverSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReceiverSpec.
+func (in *ReceiverSpec) DeepCopy() *ReceiverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReceiverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Scaling) DeepCopyInto(out *Scaling) {
 	*out = *in
@@ -101,6 +288,11 @@ func (in *Scaling) DeepCopyInto(out *Scaling) {
 		*out = new(StaticScaling)
 		**out = **in
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingScaling)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scaling.
@@ -205,6 +397,11 @@ func (in *TelemetryList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TelemetrySpec) DeepCopyInto(out *TelemetrySpec) {
 	*out = *in
+	if in.Log != nil {
+		in, out := &in.Log, &out.Log
+		*out = new(LogSpec)
+		**out = **in
+	}
 	if in.Trace != nil {
 		in, out := &in.Trace, &out.Trace
 		*out = new(TraceSpec)
@@ -215,6 +412,16 @@ func (in *TelemetrySpec) DeepCopyInto(out *TelemetrySpec) {
 		*out = new(MetricSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Enrichments != nil {
+		in, out := &in.Enrichments, &out.Enrichments
+		*out = new(EnrichmentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(ClusterSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TelemetrySpec.
@@ -239,6 +446,7 @@ func (in *TelemetryStatus) DeepCopyInto(out *TelemetryStatus) {
 		}
 	}
 	in.GatewayEndpoints.DeepCopyInto(&out.GatewayEndpoints)
+	in.Pipelines.DeepCopyInto(&out.Pipelines)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TelemetryStatus.
@@ -251,10 +459,37 @@ func (in *TelemetryStatus) DeepCopy() *TelemetryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TraceGatewayReceivers) DeepCopyInto(out *TraceGatewayReceivers) {
+	*out = *in
+	out.Zipkin = in.Zipkin
+	out.Jaeger = in.Jaeger
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TraceGatewayReceivers.
+func (in *TraceGatewayReceivers) DeepCopy() *TraceGatewayReceivers {
+	if in == nil {
+		return nil
+	}
+	out := new(TraceGatewayReceivers)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TraceGatewaySpec) DeepCopyInto(out *TraceGatewaySpec) {
 	*out = *in
 	in.Scaling.DeepCopyInto(&out.Scaling)
+	if in.PersistentQueue != nil {
+		in, out := &in.PersistentQueue, &out.PersistentQueue
+		*out = new(PersistentQueue)
+		**out = **in
+	}
+	if in.Receivers != nil {
+		in, out := &in.Receivers, &out.Receivers
+		*out = new(TraceGatewayReceivers)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TraceGatewaySpec.
@@ -25,12 +25,20 @@ type LogPipelineSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
+	// If true, the pipeline is removed from the Fluent Bit and log gateway configuration and no logs are shipped to its outputs. The pipeline keeps its referenced Secrets, which are still validated. Default is false.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
 	// Defines where to collect logs, including selector mechanisms.
 	Input   Input    `json:"input,omitempty"`
 	Filters []Filter `json:"filters,omitempty"`
-	// [Fluent Bit output](https://docs.fluentbit.io/manual/pipeline/outputs) where you want to push the logs. Only one output can be specified.
-	Output Output      `json:"output,omitempty"`
-	Files  []FileMount `json:"files,omitempty"`
+	// [Fluent Bit output](https://docs.fluentbit.io/manual/pipeline/outputs) where you want to push the logs. Only one output can be specified. To push the logs to further destinations, use `additionalOutputs`.
+	Output Output `json:"output,omitempty"`
+	// Defines further outputs where you want to push the logs. Every output receives the same logs. The `grafana-loki` output is not supported as an additional output.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=3
+	AdditionalOutputs []NamedOutput `json:"additionalOutputs,omitempty"`
+	Files             []FileMount   `json:"files,omitempty"`
 	// A list of mappings from Kubernetes Secret keys to environment variables. Mapped keys are mounted as environment variables, so that they are available as [Variables](https://docs.fluentbit.io/manual/administration/configuring-fluent-bit/classic-mode/variables) in the sections.
 	Variables []VariableRef `json:"variables,omitempty"`
 }
@@ -51,6 +59,60 @@ type ApplicationInput struct {
 	KeepAnnotations bool `json:"keepAnnotations,omitempty"`
 	// Defines whether to drop all Kubernetes labels. The default is `false`.
 	DropLabels bool `json:"dropLabels,omitempty"`
+	// Concatenates log lines that belong to the same log record, like stack traces, to a single record.
+	Multiline *MultilineInput `json:"multiline,omitempty"`
+	// Parses the log line of a container into structured fields of the record.
+	Parse *ParseInput `json:"parse,omitempty"`
+}
+
+// MultilinePreset is a built-in rule set that detects multiline log records of a specific language.
+// +kubebuilder:validation:Enum=java;go;python
+type MultilinePreset string
+
+const (
+	MultilinePresetJava   MultilinePreset = "java"
+	MultilinePresetGo     MultilinePreset = "go"
+	MultilinePresetPython MultilinePreset = "python"
+)
+
+// MultilineInput describes how log lines are concatenated to multiline log records. The options are mutually exclusive.
+// +kubebuilder:validation:XValidation:rule="has(self.presets) != has(self.custom)",message="exactly one of 'presets' or 'custom' must be defined"
+type MultilineInput struct {
+	// Concatenates stack traces of the specified languages. The options are `java`, `go`, and `python`.
+	Presets []MultilinePreset `json:"presets,omitempty"`
+	// Concatenates log lines based on custom regular expressions.
+	Custom *CustomMultiline `json:"custom,omitempty"`
+}
+
+// CustomMultiline describes a multiline log record with regular expressions.
+type CustomMultiline struct {
+	// Regular expression that matches the first line of a multiline log record.
+	// +kubebuilder:validation:MinLength=1
+	StartRegex string `json:"startRegex"`
+	// Regular expression that matches the continuation lines of a multiline log record. If not set, all lines that don't match `startRegex` are continuation lines.
+	ContinueRegex string `json:"continueRegex,omitempty"`
+}
+
+// ParseFormat is the format of the log line that is parsed.
+// +kubebuilder:validation:Enum=json;regex
+type ParseFormat string
+
+const (
+	ParseFormatJSON  ParseFormat = "json"
+	ParseFormatRegex ParseFormat = "regex"
+)
+
+// ParseInput describes how the log line is parsed into structured fields.
+// +kubebuilder:validation:XValidation:rule="self.format == 'regex' ? has(self.regex) : !has(self.regex)",message="regex must be defined if and only if the format is regex"
+type ParseInput struct {
+	// Format of the log line. The options are `json` and `regex`.
+	Format ParseFormat `json:"format"`
+	// Regular expression with named capture groups that is used if the format is `regex`. Each named group becomes a field of the record.
+	Regex string `json:"regex,omitempty"`
+	// Record key that holds the content to parse. The default is `log`.
+	Key string `json:"key,omitempty"`
+	// Defines whether to keep the original content in the record after successful parsing. The default is `false`.
+	KeepOriginal bool `json:"keepOriginal,omitempty"`
 }
 
 // InputNamespaces describes whether application logs from specific Namespaces are selected. The options are mutually exclusive. System Namespaces are excluded by default from the collection.
@@ -109,6 +171,78 @@ type HTTPOutput struct {
 	Dedot bool `json:"dedot,omitempty"`
 }
 
+// NativeLokiOutput configures an output to a Loki instance, compatible with the Fluent Bit Loki output plugin.
+type NativeLokiOutput struct {
+	// Defines the host of the Loki instance.
+	Host ValueType `json:"host,omitempty"`
+	// Defines the port of the Loki instance. Default is 443.
+	Port string `json:"port,omitempty"`
+	// Defines the URI of the push API. Default is "/loki/api/v1/push".
+	// +kubebuilder:validation:Pattern=`^/`
+	URI string `json:"uri,omitempty"`
+	// Defines the tenant ID that is sent as `X-Scope-OrgID` header, if Loki runs in multi-tenancy mode.
+	TenantID ValueType `json:"tenantID,omitempty"`
+	// Defines the basic auth user.
+	User ValueType `json:"user,omitempty"`
+	// Defines the basic auth password.
+	Password ValueType `json:"password,omitempty"`
+	// Defines the static labels that are set for each log stream.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Defines the keys of the log record whose values are used as labels of the log stream, for example, `$kubernetes['namespace_name']`.
+	LabelKeys []string `json:"labelKeys,omitempty"`
+	// Defines the keys that are removed from the log record before it is sent.
+	RemoveKeys []string `json:"removeKeys,omitempty"`
+	// Configures TLS for the Loki instance.
+	TLSConfig TLSConfig `json:"tls,omitempty"`
+}
+
+// ElasticsearchOutput configures an output to an Elasticsearch or OpenSearch cluster, compatible with the Fluent Bit Elasticsearch output plugin.
+type ElasticsearchOutput struct {
+	// Defines the host of the Elasticsearch or OpenSearch cluster.
+	Host ValueType `json:"host,omitempty"`
+	// Defines the port of the cluster. Default is 443.
+	Port string `json:"port,omitempty"`
+	// Defines the path prefix of the cluster API, if the cluster runs behind a reverse proxy.
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path,omitempty"`
+	// Defines the index to which the logs are written. Default is `kyma-logs`. Ignored if `logstashFormat` is enabled.
+	Index string `json:"index,omitempty"`
+	// If enabled, the logs are written to a daily index named `<logstashPrefix>-YYYY.MM.DD`. Default is `false`.
+	LogstashFormat bool `json:"logstashFormat,omitempty"`
+	// Defines the prefix of the daily index if `logstashFormat` is enabled. Default is `kyma-logs`.
+	LogstashPrefix string `json:"logstashPrefix,omitempty"`
+	// Defines the basic auth user.
+	User ValueType `json:"user,omitempty"`
+	// Defines the basic auth password.
+	Password ValueType `json:"password,omitempty"`
+	// Defines the compression algorithm to use.
+	// +kubebuilder:validation:Enum=gzip
+	Compress string `json:"compress,omitempty"`
+	// Configures TLS for the Elasticsearch or OpenSearch cluster.
+	TLSConfig TLSConfig `json:"tls,omitempty"`
+}
+
+// SplunkOutput configures an output to the HTTP Event Collector (HEC) of Splunk, compatible with the Fluent Bit Splunk output plugin.
+type SplunkOutput struct {
+	// Defines the host of the Splunk HTTP Event Collector.
+	Host ValueType `json:"host,omitempty"`
+	// Defines the port of the Splunk HTTP Event Collector. Default is 8088.
+	Port string `json:"port,omitempty"`
+	// Defines the token to authenticate at the HTTP Event Collector.
+	Token ValueType `json:"token,omitempty"`
+	// Defines the Splunk index to which the events are written. If not defined, the default index of the token is used.
+	Index string `json:"index,omitempty"`
+	// Defines the source of the events.
+	Source string `json:"source,omitempty"`
+	// Defines the source type of the events.
+	SourceType string `json:"sourceType,omitempty"`
+	// Defines the compression algorithm to use.
+	// +kubebuilder:validation:Enum=gzip
+	Compress string `json:"compress,omitempty"`
+	// Configures TLS for the Splunk HTTP Event Collector.
+	TLSConfig TLSConfig `json:"tls,omitempty"`
+}
+
 type TLSConfig struct {
 	// Indicates if TLS is disabled or enabled. Default is `false`.
 	Disabled bool `json:"disabled,omitempty"`
@@ -130,6 +264,51 @@ type Output struct {
 	HTTP *HTTPOutput `json:"http,omitempty"`
 	// The grafana-loki output is not supported anymore. For integration with a custom Loki installation, use the `custom` output and follow [Installing a custom Loki stack in Kyma](https://github.com/kyma-project/examples/tree/main/loki).
 	Loki *LokiOutput `json:"grafana-loki,omitempty"`
+	// Configures an output to a Loki instance, compatible with the Fluent Bit Loki output plugin.
+	NativeLoki *NativeLokiOutput `json:"loki,omitempty"`
+	// Configures an output to an Elasticsearch or OpenSearch cluster, compatible with the Fluent Bit Elasticsearch output plugin.
+	Elasticsearch *ElasticsearchOutput `json:"elasticsearch,omitempty"`
+	// Configures an output to the Splunk HTTP Event Collector, compatible with the Fluent Bit Splunk output plugin.
+	Splunk *SplunkOutput `json:"splunk,omitempty"`
+	// Configures an OTLP output. Fluent Bit forwards the logs to a log gateway, which ships them with an [OTLP exporter](https://github.com/open-telemetry/opentelemetry-collector/blob/main/exporter/otlpexporter/README.md).
+	Otlp *OtlpOutput `json:"otlp,omitempty"`
+}
+
+// NamedOutput defines an additional output of a LogPipeline.
+type NamedOutput struct {
+	// Name of the output. Must be unique within the pipeline and must not be `default`.
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:XValidation:rule="self != 'default'",message="the name default is reserved for spec.output"
+	Name string `json:"name"`
+
+	Output `json:",inline"`
+}
+
+// AllOutputs returns the output defined in `spec.output`, named `default`, followed by all additional outputs.
+func (lps *LogPipelineSpec) AllOutputs() []NamedOutput {
+	outputs := []NamedOutput{{Name: DefaultOutputName, Output: lps.Output}}
+	return append(outputs, lps.AdditionalOutputs...)
+}
+
+// HasOtlpOutput returns true if any output of the pipeline is an OTLP output.
+func (lps *LogPipelineSpec) HasOtlpOutput() bool {
+	for _, output := range lps.AllOutputs() {
+		if output.IsOtlpDefined() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasOnlyOtlpOutputs returns true if all outputs of the pipeline are OTLP outputs.
+func (lps *LogPipelineSpec) HasOnlyOtlpOutputs() bool {
+	for _, output := range lps.AllOutputs() {
+		if !output.IsOtlpDefined() {
+			return false
+		}
+	}
+	return true
 }
 
 func (i *Input) IsDefined() bool {
@@ -148,6 +327,37 @@ func (o *Output) IsLokiDefined() bool {
 	return o.Loki != nil && o.Loki.URL.IsDefined()
 }
 
+func (o *Output) IsNativeLokiDefined() bool {
+	return o.NativeLoki != nil && o.NativeLoki.Host.IsDefined()
+}
+
+func (o *Output) IsElasticsearchDefined() bool {
+	return o.Elasticsearch != nil && o.Elasticsearch.Host.IsDefined()
+}
+
+func (o *Output) IsSplunkDefined() bool {
+	return o.Splunk != nil && o.Splunk.Host.IsDefined()
+}
+
+func (o *Output) IsOtlpDefined() bool {
+	return o.Otlp != nil
+}
+
+// GetTLSConfig returns the TLS configuration of the output, or nil if the output does not support TLS configuration.
+func (o *Output) GetTLSConfig() *TLSConfig {
+	switch {
+	case o.IsHTTPDefined():
+		return &o.HTTP.TLSConfig
+	case o.IsNativeLokiDefined():
+		return &o.NativeLoki.TLSConfig
+	case o.IsElasticsearchDefined():
+		return &o.Elasticsearch.TLSConfig
+	case o.IsSplunkDefined():
+		return &o.Splunk.TLSConfig
+	}
+	return nil
+}
+
 func (o *Output) IsAnyDefined() bool {
 	return o.pluginCount() > 0
 }
@@ -167,6 +377,18 @@ func (o *Output) pluginCount() int {
 	if o.IsLokiDefined() {
 		plugins++
 	}
+	if o.IsNativeLokiDefined() {
+		plugins++
+	}
+	if o.IsElasticsearchDefined() {
+		plugins++
+	}
+	if o.IsSplunkDefined() {
+		plugins++
+	}
+	if o.IsOtlpDefined() {
+		plugins++
+	}
 	return plugins
 }
 
@@ -187,8 +409,9 @@ type LogPipelineConditionType string
 
 // These are the valid statuses of LogPipeline.
 const (
-	LogPipelinePending LogPipelineConditionType = "Pending"
-	LogPipelineRunning LogPipelineConditionType = "Running"
+	LogPipelinePending   LogPipelineConditionType = "Pending"
+	LogPipelineRunning   LogPipelineConditionType = "Running"
+	LogPipelineSuspended LogPipelineConditionType = "Suspended"
 )
 
 // LogPipelineCondition contains details for the current condition of this LogPipeline.
@@ -197,7 +420,7 @@ type LogPipelineCondition struct {
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 	// Reason of last transition.
 	Reason string `json:"reason,omitempty"`
-	// The possible transition types are:<br>- `Running`: The instance is ready and usable.<br>- `Pending`: The pipeline is being activated.
+	// The possible transition types are:<br>- `Running`: The instance is ready and usable.<br>- `Pending`: The pipeline is being activated.<br>- `Suspended`: The pipeline is suspended with `spec.suspend`.
 	Type LogPipelineConditionType `json:"type,omitempty"`
 }
 
@@ -207,6 +430,8 @@ type LogPipelineStatus struct {
 	Conditions []LogPipelineCondition `json:"conditions,omitempty"`
 	// Is active when the LogPipeline uses a `custom` output or filter; see [unsupported mode](https://github.com/kyma-project/telemetry-manager/blob/main/docs/user/02-logs.md#unsupported-mode).
 	UnsupportedMode bool `json:"unsupportedMode,omitempty"`
+	// Shows the state of each output of the pipeline.
+	Outputs []OutputStatus `json:"outputs,omitempty"`
 }
 
 func NewLogPipelineCondition(reason string, condType LogPipelineConditionType) *LogPipelineCondition {
@@ -277,7 +502,12 @@ func (lp *LogPipeline) ContainsCustomPlugin() bool {
 			return true
 		}
 	}
-	return lp.Spec.Output.IsCustomDefined()
+	for _, output := range lp.Spec.AllOutputs() {
+		if output.IsCustomDefined() {
+			return true
+		}
+	}
+	return false
 }
 
 // +kubebuilder:object:root=true
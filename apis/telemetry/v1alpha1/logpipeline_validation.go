@@ -20,7 +20,23 @@ func (lp *LogPipeline) Validate(vc *LogPipelineValidationConfig) error {
 }
 
 func (lp *LogPipeline) validateOutput(deniedOutputPlugins []string) error {
-	output := lp.Spec.Output
+	if err := validateSingleOutput(lp.Spec.Output, deniedOutputPlugins); err != nil {
+		return err
+	}
+
+	for _, output := range lp.Spec.AdditionalOutputs {
+		if output.IsLokiDefined() {
+			return fmt.Errorf("additional output '%s': grafana-loki output is not supported as an additional output", output.Name)
+		}
+		if err := validateSingleOutput(output.Output, deniedOutputPlugins); err != nil {
+			return fmt.Errorf("additional output '%s': %w", output.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func validateSingleOutput(output Output, deniedOutputPlugins []string) error {
 	if err := checkSingleOutputPlugin(output); err != nil {
 		return err
 	}
@@ -37,6 +53,30 @@ func (lp *LogPipeline) validateOutput(deniedOutputPlugins []string) error {
 		}
 	}
 
+	if output.IsNativeLokiDefined() {
+		if err := validateNativeLokiOutput(output.NativeLoki); err != nil {
+			return err
+		}
+	}
+
+	if output.IsElasticsearchDefined() {
+		if err := validateElasticsearchOutput(output.Elasticsearch); err != nil {
+			return err
+		}
+	}
+
+	if output.IsSplunkDefined() {
+		if err := validateSplunkOutput(output.Splunk); err != nil {
+			return err
+		}
+	}
+
+	if output.IsOtlpDefined() {
+		if err := validateOtlpOutput(output.Otlp); err != nil {
+			return err
+		}
+	}
+
 	return validateCustomOutput(deniedOutputPlugins, output.Custom)
 }
 
@@ -50,6 +90,16 @@ func checkSingleOutputPlugin(output Output) error {
 	return nil
 }
 
+func validateOtlpOutput(otlpOutput *OtlpOutput) error {
+	if !otlpOutput.Endpoint.IsDefined() {
+		return fmt.Errorf("otlp output must have an endpoint configured")
+	}
+	if secretRefAndValueIsPresent(otlpOutput.Endpoint) {
+		return fmt.Errorf("otlp output endpoint must have either a value or secret key reference")
+	}
+	return nil
+}
+
 func validateLokiOutput(lokiOutput *LokiOutput) error {
 	if lokiOutput.URL.Value != "" && !validURL(lokiOutput.URL.Value) {
 		return fmt.Errorf("invalid hostname '%s'", lokiOutput.URL.Value)
@@ -92,6 +142,76 @@ func validateHTTPOutput(httpOutput *HTTPOutput) error {
 	return nil
 }
 
+func validateNativeLokiOutput(lokiOutput *NativeLokiOutput) error {
+	if err := validateHost("loki", lokiOutput.Host); err != nil {
+		return err
+	}
+	if lokiOutput.URI != "" && !strings.HasPrefix(lokiOutput.URI, "/") {
+		return fmt.Errorf("uri must start with /")
+	}
+	if secretRefAndValueIsPresent(lokiOutput.TenantID) {
+		return fmt.Errorf("loki output tenant ID must have either a value or secret key reference")
+	}
+	if secretRefAndValueIsPresent(lokiOutput.User) {
+		return fmt.Errorf("loki output user must have either a value or secret key reference")
+	}
+	if secretRefAndValueIsPresent(lokiOutput.Password) {
+		return fmt.Errorf("loki output password must have either a value or secret key reference")
+	}
+	return nil
+}
+
+func validateElasticsearchOutput(elasticsearchOutput *ElasticsearchOutput) error {
+	if err := validateHost("elasticsearch", elasticsearchOutput.Host); err != nil {
+		return err
+	}
+	if elasticsearchOutput.Path != "" && !strings.HasPrefix(elasticsearchOutput.Path, "/") {
+		return fmt.Errorf("path must start with /")
+	}
+	if elasticsearchOutput.LogstashPrefix != "" && !elasticsearchOutput.LogstashFormat {
+		return fmt.Errorf("elasticsearch output logstash prefix requires logstash format to be enabled")
+	}
+	if secretRefAndValueIsPresent(elasticsearchOutput.User) {
+		return fmt.Errorf("elasticsearch output user must have either a value or secret key reference")
+	}
+	if secretRefAndValueIsPresent(elasticsearchOutput.Password) {
+		return fmt.Errorf("elasticsearch output password must have either a value or secret key reference")
+	}
+	return nil
+}
+
+func validateSplunkOutput(splunkOutput *SplunkOutput) error {
+	if err := validateHost("splunk", splunkOutput.Host); err != nil {
+		return err
+	}
+	if !splunkOutput.Token.IsDefined() {
+		return fmt.Errorf("splunk output must have a token configured")
+	}
+	if secretRefAndValueIsPresent(splunkOutput.Token) {
+		return fmt.Errorf("splunk output token must have either a value or secret key reference")
+	}
+	return nil
+}
+
+// validateHost validates the host of an output that is sent to Fluent Bit as a host name, and not as a URL.
+func validateHost(outputType string, host ValueType) error {
+	if secretRefAndValueIsPresent(host) {
+		return fmt.Errorf("%s output host must have either a value or secret key reference", outputType)
+	}
+	if host.Value == "" {
+		return nil
+	}
+
+	isValidHostname, err := validHostname(host.Value)
+	if err != nil {
+		return fmt.Errorf("error validating hostname: %w", err)
+	}
+	if !isValidHostname {
+		return fmt.Errorf("invalid hostname '%s'", host.Value)
+	}
+	return nil
+}
+
 func validURL(host string) bool {
 	host = strings.Trim(host, " ")
 
@@ -206,5 +326,67 @@ func (lp *LogPipeline) validateInput() error {
 		return fmt.Errorf("invalid log pipeline definition: Can only define one 'input.application.namespaces' selector - either 'include', 'exclude', or 'system'")
 	}
 
+	if err := validateMultiline(input.Application.Multiline); err != nil {
+		return err
+	}
+
+	return validateParse(input.Application.Parse)
+}
+
+func validateMultiline(multiline *MultilineInput) error {
+	if multiline == nil {
+		return nil
+	}
+
+	if (len(multiline.Presets) > 0) == (multiline.Custom != nil) {
+		return fmt.Errorf("invalid log pipeline definition: Must define either 'input.application.multiline.presets' or 'input.application.multiline.custom'")
+	}
+
+	if multiline.Custom == nil {
+		return nil
+	}
+
+	if multiline.Custom.StartRegex == "" {
+		return fmt.Errorf("invalid log pipeline definition: 'input.application.multiline.custom.startRegex' must not be empty")
+	}
+
+	// The expressions are rendered as quoted rules of a Fluent Bit multiline parser
+	for _, expr := range []string{multiline.Custom.StartRegex, multiline.Custom.ContinueRegex} {
+		if strings.ContainsAny(expr, "\"\n\r") {
+			return fmt.Errorf("invalid log pipeline definition: Regular expressions in 'input.application.multiline.custom' must not contain double quotes or line breaks")
+		}
+	}
+
+	return nil
+}
+
+func validateParse(parse *ParseInput) error {
+	if parse == nil {
+		return nil
+	}
+
+	switch parse.Format {
+	case ParseFormatJSON:
+		if parse.Regex != "" {
+			return fmt.Errorf("invalid log pipeline definition: 'input.application.parse.regex' is only supported for the format 'regex'")
+		}
+	case ParseFormatRegex:
+		if parse.Regex == "" {
+			return fmt.Errorf("invalid log pipeline definition: 'input.application.parse.regex' must be defined for the format 'regex'")
+		}
+		if strings.ContainsAny(parse.Regex, "\n\r") {
+			return fmt.Errorf("invalid log pipeline definition: 'input.application.parse.regex' must not contain line breaks")
+		}
+		if !strings.Contains(parse.Regex, "(?<") && !strings.Contains(parse.Regex, "(?P<") {
+			return fmt.Errorf("invalid log pipeline definition: 'input.application.parse.regex' must contain at least one named capture group")
+		}
+	default:
+		return fmt.Errorf("invalid log pipeline definition: Unsupported 'input.application.parse.format' '%s'", parse.Format)
+	}
+
+	if strings.ContainsAny(parse.Key, " \t\n\r") {
+		return fmt.Errorf("invalid log pipeline definition: 'input.application.parse.key' must not contain whitespace")
+	}
+
 	return nil
 }
@@ -39,6 +39,193 @@ func TestContainsMultipleOutputPlugins(t *testing.T) {
 	require.Contains(t, result.Error(), "multiple output plugins are defined, you must define only one output")
 }
 
+func TestValidateOtlpOutputWithoutEndpoint(t *testing.T) {
+	logPipeline := &LogPipeline{
+		Spec: LogPipelineSpec{
+			Output: Output{
+				Otlp: &OtlpOutput{},
+			},
+		}}
+	vc := getLogPipelineValidationConfig()
+	result := logPipeline.validateOutput(vc.DeniedOutPutPlugins)
+
+	require.Error(t, result)
+	require.Contains(t, result.Error(), "otlp output must have an endpoint configured")
+}
+
+func TestValidateNativeOutputs(t *testing.T) {
+	secretRef := &ValueFromSource{
+		SecretKeyRef: &SecretKeyRef{
+			Name:      "secret",
+			Key:       "key",
+			Namespace: "default",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		given         Output
+		expectedError string
+	}{
+		{
+			name: "valid loki output",
+			given: Output{
+				NativeLoki: &NativeLokiOutput{
+					Host:     ValueType{Value: "loki.example.com"},
+					URI:      "/loki/api/v1/push",
+					Password: ValueType{ValueFrom: secretRef},
+				},
+			},
+		},
+		{
+			name: "loki output with invalid uri",
+			given: Output{
+				NativeLoki: &NativeLokiOutput{
+					Host: ValueType{Value: "loki.example.com"},
+					URI:  "loki/api/v1/push",
+				},
+			},
+			expectedError: "uri must start with /",
+		},
+		{
+			name: "loki output with invalid host",
+			given: Output{
+				NativeLoki: &NativeLokiOutput{
+					Host: ValueType{Value: "https://loki.example.com"},
+				},
+			},
+			expectedError: "invalid hostname 'https://loki.example.com'",
+		},
+		{
+			name: "loki output with tenant ID value and secret key reference",
+			given: Output{
+				NativeLoki: &NativeLokiOutput{
+					Host:     ValueType{Value: "loki.example.com"},
+					TenantID: ValueType{Value: "tenant", ValueFrom: secretRef},
+				},
+			},
+			expectedError: "loki output tenant ID must have either a value or secret key reference",
+		},
+		{
+			name: "valid elasticsearch output",
+			given: Output{
+				Elasticsearch: &ElasticsearchOutput{
+					Host:           ValueType{ValueFrom: secretRef},
+					LogstashFormat: true,
+					LogstashPrefix: "my-logs",
+				},
+			},
+		},
+		{
+			name: "elasticsearch output with logstash prefix but without logstash format",
+			given: Output{
+				Elasticsearch: &ElasticsearchOutput{
+					Host:           ValueType{Value: "opensearch.example.com"},
+					LogstashPrefix: "my-logs",
+				},
+			},
+			expectedError: "elasticsearch output logstash prefix requires logstash format to be enabled",
+		},
+		{
+			name: "elasticsearch output with host value and secret key reference",
+			given: Output{
+				Elasticsearch: &ElasticsearchOutput{
+					Host: ValueType{Value: "opensearch.example.com", ValueFrom: secretRef},
+				},
+			},
+			expectedError: "elasticsearch output host must have either a value or secret key reference",
+		},
+		{
+			name: "valid splunk output",
+			given: Output{
+				Splunk: &SplunkOutput{
+					Host:  ValueType{Value: "splunk.example.com"},
+					Token: ValueType{ValueFrom: secretRef},
+				},
+			},
+		},
+		{
+			name: "splunk output without token",
+			given: Output{
+				Splunk: &SplunkOutput{
+					Host: ValueType{Value: "splunk.example.com"},
+				},
+			},
+			expectedError: "splunk output must have a token configured",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logPipeline := &LogPipeline{
+				Spec: LogPipelineSpec{
+					Output: tt.given,
+				},
+			}
+			vc := getLogPipelineValidationConfig()
+			result := logPipeline.validateOutput(vc.DeniedOutPutPlugins)
+
+			if tt.expectedError == "" {
+				require.NoError(t, result)
+				return
+			}
+			require.Error(t, result)
+			require.Contains(t, result.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestValidateAdditionalOutputs(t *testing.T) {
+	tests := []struct {
+		name          string
+		given         []NamedOutput
+		expectedError string
+	}{
+		{
+			name: "valid additional output",
+			given: []NamedOutput{
+				{Name: "archive", Output: Output{Otlp: &OtlpOutput{Endpoint: ValueType{Value: "localhost:4317"}}}},
+			},
+		},
+		{
+			name: "multiple plugins in additional output",
+			given: []NamedOutput{
+				{Name: "archive", Output: Output{
+					Otlp: &OtlpOutput{Endpoint: ValueType{Value: "localhost:4317"}},
+					HTTP: &HTTPOutput{Host: ValueType{Value: "localhost"}},
+				}},
+			},
+			expectedError: "additional output 'archive': multiple output plugins are defined",
+		},
+		{
+			name: "loki as additional output",
+			given: []NamedOutput{
+				{Name: "loki", Output: Output{Loki: &LokiOutput{URL: ValueType{Value: "http://loki:3100"}}}},
+			},
+			expectedError: "grafana-loki output is not supported as an additional output",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logPipeline := &LogPipeline{
+				Spec: LogPipelineSpec{
+					Output:            Output{HTTP: &HTTPOutput{Host: ValueType{Value: "localhost"}}},
+					AdditionalOutputs: tt.given,
+				}}
+			vc := getLogPipelineValidationConfig()
+			result := logPipeline.validateOutput(vc.DeniedOutPutPlugins)
+
+			if tt.expectedError == "" {
+				require.NoError(t, result)
+				return
+			}
+			require.Error(t, result)
+			require.Contains(t, result.Error(), tt.expectedError)
+		})
+	}
+}
+
 func TestDeniedOutputPlugins(t *testing.T) {
 	logPipeline := &LogPipeline{
 		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
@@ -378,3 +565,113 @@ func TestValidateWithInvalidContainerSelectors(t *testing.T) {
 	err := logPipeline.validateInput()
 	require.Error(t, err)
 }
+
+func TestValidateMultilineAndParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		application ApplicationInput
+		errContains string
+	}{
+		{
+			name: "presets",
+			application: ApplicationInput{
+				Multiline: &MultilineInput{Presets: []MultilinePreset{MultilinePresetJava, MultilinePresetGo}},
+			},
+		},
+		{
+			name: "custom",
+			application: ApplicationInput{
+				Multiline: &MultilineInput{Custom: &CustomMultiline{StartRegex: `^\d{4}-\d{2}-\d{2}`}},
+			},
+		},
+		{
+			name: "presets and custom",
+			application: ApplicationInput{
+				Multiline: &MultilineInput{
+					Presets: []MultilinePreset{MultilinePresetJava},
+					Custom:  &CustomMultiline{StartRegex: `^\d{4}-\d{2}-\d{2}`},
+				},
+			},
+			errContains: "Must define either 'input.application.multiline.presets' or 'input.application.multiline.custom'",
+		},
+		{
+			name: "neither presets nor custom",
+			application: ApplicationInput{
+				Multiline: &MultilineInput{},
+			},
+			errContains: "Must define either 'input.application.multiline.presets' or 'input.application.multiline.custom'",
+		},
+		{
+			name: "custom without start regex",
+			application: ApplicationInput{
+				Multiline: &MultilineInput{Custom: &CustomMultiline{ContinueRegex: `^\s+`}},
+			},
+			errContains: "'input.application.multiline.custom.startRegex' must not be empty",
+		},
+		{
+			name: "custom with quotes",
+			application: ApplicationInput{
+				Multiline: &MultilineInput{Custom: &CustomMultiline{StartRegex: `^"start`}},
+			},
+			errContains: "must not contain double quotes or line breaks",
+		},
+		{
+			name: "json parse",
+			application: ApplicationInput{
+				Parse: &ParseInput{Format: ParseFormatJSON, Key: "message", KeepOriginal: true},
+			},
+		},
+		{
+			name: "json parse with regex",
+			application: ApplicationInput{
+				Parse: &ParseInput{Format: ParseFormatJSON, Regex: `^(?<level>\w+)`},
+			},
+			errContains: "'input.application.parse.regex' is only supported for the format 'regex'",
+		},
+		{
+			name: "regex parse",
+			application: ApplicationInput{
+				Parse: &ParseInput{Format: ParseFormatRegex, Regex: `^(?<level>\w+) (?<message>.*)$`},
+			},
+		},
+		{
+			name: "regex parse without regex",
+			application: ApplicationInput{
+				Parse: &ParseInput{Format: ParseFormatRegex},
+			},
+			errContains: "'input.application.parse.regex' must be defined for the format 'regex'",
+		},
+		{
+			name: "regex parse without named groups",
+			application: ApplicationInput{
+				Parse: &ParseInput{Format: ParseFormatRegex, Regex: `^(\w+) (.*)$`},
+			},
+			errContains: "must contain at least one named capture group",
+		},
+		{
+			name: "unsupported format",
+			application: ApplicationInput{
+				Parse: &ParseInput{Format: "logfmt"},
+			},
+			errContains: "Unsupported 'input.application.parse.format' 'logfmt'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logPipeline := &LogPipeline{
+				Spec: LogPipelineSpec{
+					Input: Input{Application: tt.application},
+				},
+			}
+
+			err := logPipeline.validateInput()
+			if tt.errContains == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 //nolint:gochecknoinits // SchemeBuilder's registration is required.
@@ -54,11 +55,69 @@ type MetricPipeline struct {
 
 // MetricPipelineSpec defines the desired state of MetricPipeline.
 type MetricPipelineSpec struct {
+	// Determines which pipelines are deployed if more pipelines exist than the maximum number of MetricPipelines. Pipelines with a higher priority are deployed first; pipelines with the same priority are deployed in the order of their creation. Default is 0.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+	// If true, the pipeline is removed from the gateway configuration and no metrics are shipped to its outputs. The pipeline keeps its slot within the maximum number of MetricPipelines, and its referenced Secrets are still validated and synced into the gateway. If all MetricPipelines are suspended, the gateway is removed and stops receiving metrics. Default is false.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
 	// Configures different inputs to send additional metrics to the metric gateway.
 	Input MetricPipelineInput `json:"input,omitempty"`
 
 	// Configures the metric gateway.
 	Output MetricPipelineOutput `json:"output,omitempty"`
+
+	// Defines further destinations for shipping metrics. Every output receives the same metrics.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=3
+	AdditionalOutputs []NamedMetricPipelineOutput `json:"additionalOutputs,omitempty"`
+	// Configures which metrics are dropped before they are shipped to the outputs. If not defined, all metrics are shipped.
+	Filters *MetricPipelineFilters `json:"filters,omitempty"`
+	// Configures how the attributes of metric data points are modified before they are shipped to the outputs.
+	Transforms *MetricPipelineTransforms `json:"transforms,omitempty"`
+}
+
+// MetricPipelineFilters defines the metrics to drop. A metric is dropped if any of the defined filters matches.
+type MetricPipelineFilters struct {
+	// Drops metrics with the given names.
+	DropMetricNames []string `json:"dropMetricNames,omitempty"`
+	// Drops metrics with the given resource attribute values.
+	DropResourceAttributes []MetricPipelineResourceAttribute `json:"dropResourceAttributes,omitempty"`
+	// Keeps only metrics that originate from the given namespaces. Metrics that are not related to a namespace are kept.
+	KeepNamespaces []string `json:"keepNamespaces,omitempty"`
+	// Drops data points that match any of the given [OTTL](https://github.com/open-telemetry/opentelemetry-collector-contrib/blob/main/pkg/ottl/README.md) conditions. The conditions are evaluated in the `datapoint` context.
+	Conditions []string `json:"conditions,omitempty"`
+}
+
+// MetricPipelineResourceAttribute defines a resource attribute value to match.
+type MetricPipelineResourceAttribute struct {
+	// Key of the resource attribute.
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+	// Value of the resource attribute.
+	Value string `json:"value"`
+}
+
+// MetricPipelineTransforms defines modifications of the data point attributes.
+type MetricPipelineTransforms struct {
+	// Renames data point attributes. If the target attribute exists already, it is overwritten.
+	RenameAttributes []MetricPipelineAttributeRename `json:"renameAttributes,omitempty"`
+	// Removes the given data point attributes, for example, labels with a high cardinality.
+	DropAttributes []string `json:"dropAttributes,omitempty"`
+	// Applies the given [OTTL](https://github.com/open-telemetry/opentelemetry-collector-contrib/blob/main/pkg/ottl/README.md) statements in the `datapoint` context. The statements are applied after the renamed and dropped attributes.
+	Statements []string `json:"statements,omitempty"`
+}
+
+// MetricPipelineAttributeRename defines the new name of a data point attribute.
+type MetricPipelineAttributeRename struct {
+	// Current name of the attribute.
+	// +kubebuilder:validation:MinLength=1
+	From string `json:"from"`
+	// New name of the attribute.
+	// +kubebuilder:validation:MinLength=1
+	To string `json:"to"`
 }
 
 // MetricPipelineInput defines the input configuration section.
@@ -75,44 +134,204 @@ type MetricPipelineApplicationInput struct {
 	Runtime MetricPipelineContainerRuntimeInput `json:"runtime,omitempty"`
 	// Configures istio-proxy metrics scraping.
 	Istio MetricPipelineIstioInput `json:"istio,omitempty"`
+	// Configures the collection of metrics about the state of the cluster's Kubernetes resources.
+	Cluster MetricPipelineClusterInput `json:"cluster,omitempty"`
 }
 
 // MetricPipelinePrometheusInput defines the Prometheus scraping section.
 type MetricPipelinePrometheusInput struct {
 	// If enabled, Pods marked with `prometheus.io/scrape=true` annotation will be scraped.
 	Enabled bool `json:"enabled,omitempty"`
+	// Describes whether metrics from specific Namespaces are selected. If not defined, metrics from all Namespaces are selected.
+	Namespaces *NamespaceSelector `json:"namespaces,omitempty"`
+	// Interval in which the targets are scraped, for example, `15s` or `1m`. The default is `30s`.
+	// The annotated workloads are scraped by all MetricPipelines together, so the shortest interval of all MetricPipelines applies to them.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('5s')",message="interval must be at least 5s"
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Maximum number of samples that are accepted per scrape of a target. If a target exposes more samples, the scrape fails. The default is `50000`.
+	// The annotated workloads are scraped by all MetricPipelines together, so the largest limit of all MetricPipelines applies to them.
+	// +kubebuilder:validation:Minimum=1
+	SampleLimit *int `json:"sampleLimit,omitempty"`
+	// Configures the diagnostic metrics of the scrape jobs, like `up` or `scrape_duration_seconds`.
+	DiagnosticMetrics DiagnosticMetrics `json:"diagnosticMetrics,omitempty"`
+	// Defines additional targets that are scraped without the `prometheus.io/scrape` annotation.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=10
+	Targets []PrometheusScrapeTarget `json:"targets,omitempty"`
+}
+
+// DiagnosticMetrics defines whether the diagnostic metrics of an input are shipped.
+type DiagnosticMetrics struct {
+	// If enabled, the diagnostic metrics are shipped. The default is `false`.
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// PrometheusScrapeTarget defines an additional target of the Prometheus input, which selects Pods or Services by their labels.
+// +kubebuilder:validation:XValidation:rule="has(self.pods) != has(self.services)",message="exactly one of 'pods' or 'services' must be defined"
+type PrometheusScrapeTarget struct {
+	// Name of the target. It must be unique within the MetricPipeline.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// Scrapes the Pods that match the selector.
+	Pods *ScrapeTargetSelector `json:"pods,omitempty"`
+	// Scrapes the endpoints of the Services that match the selector.
+	Services *ScrapeTargetSelector `json:"services,omitempty"`
+	// HTTP path of the metrics endpoint. The default is `/metrics`.
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path,omitempty"`
+	// Scheme of the metrics endpoint. If not defined, `https` is used for Pods with an Istio sidecar, and `http` is used otherwise.
+	// Like for annotated workloads, `https` endpoints are scraped with the Istio client certificate, so they can only be scraped if Istio is installed.
+	// +kubebuilder:validation:Enum=http;https
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// ScrapeTargetSelector selects the resources to scrape by their labels and the port of their metrics endpoint.
+type ScrapeTargetSelector struct {
+	// Labels that a resource must have to be scraped.
+	// +kubebuilder:validation:MinProperties=1
+	MatchLabels map[string]string `json:"matchLabels"`
+	// Name or number of the port that exposes the metrics. For Pods, the port must be declared in the container spec.
+	// +kubebuilder:validation:XIntOrString
+	Port intstr.IntOrString `json:"port"`
 }
 
 // MetricPipelineContainerRuntimeInput defines the runtime scraping section.
 type MetricPipelineContainerRuntimeInput struct {
 	// If enabled, workload-related Kubernetes metrics will be scraped.
 	Enabled bool `json:"enabled,omitempty"`
+	// Describes whether metrics from specific Namespaces are selected. If not defined, metrics from all Namespaces are selected.
+	Namespaces *NamespaceSelector `json:"namespaces,omitempty"`
+	// Configures the resources for which runtime metrics are scraped in addition to the Pods and containers.
+	Resources MetricPipelineRuntimeResources `json:"resources,omitempty"`
+}
+
+// MetricPipelineRuntimeResources defines the additional resources of the runtime input.
+type MetricPipelineRuntimeResources struct {
+	// Configures the metrics of the Nodes, like CPU, memory, filesystem, and network usage.
+	Node MetricPipelineRuntimeResource `json:"node,omitempty"`
+	// Configures the metrics of the Pod volumes, like the capacity and the available bytes of a PersistentVolumeClaim.
+	Volume MetricPipelineRuntimeResource `json:"volume,omitempty"`
+}
+
+// MetricPipelineRuntimeResource defines whether the runtime metrics of a resource are scraped.
+type MetricPipelineRuntimeResource struct {
+	// If enabled, the metrics of the resource are scraped. The default is `false`.
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// MetricPipelineClusterInput defines the cluster state section.
+type MetricPipelineClusterInput struct {
+	// If enabled, metrics about the state of the Kubernetes resources are collected by a single collector instance for the whole cluster,
+	// for example, the replicas of Deployments, the failed Pods of Jobs, or the conditions of Nodes.
+	Enabled bool `json:"enabled,omitempty"`
+	// Describes whether metrics from specific Namespaces are selected. If not defined, metrics from all Namespaces are selected. Metrics of cluster-scoped resources like Nodes are always selected.
+	Namespaces *NamespaceSelector `json:"namespaces,omitempty"`
+	// Configures the `k8s.event.count` metric, which counts the Kubernetes events by their reason.
+	Events MetricPipelineClusterEvents `json:"events,omitempty"`
+}
+
+// MetricPipelineClusterEvents defines whether metrics are derived from Kubernetes events.
+type MetricPipelineClusterEvents struct {
+	// If enabled, the Kubernetes events are counted. The default is `false`.
+	Enabled bool `json:"enabled,omitempty"`
 }
 
 // MetricPipelineIstioInput defines the Istio scraping section.
 type MetricPipelineIstioInput struct {
 	// If enabled, metrics for istio-proxy containers are scraped from Pods that have had the istio-proxy sidecar injected.
 	Enabled bool `json:"enabled,omitempty"`
+	// Describes whether metrics from specific Namespaces are selected. If not defined, metrics from all Namespaces are selected.
+	Namespaces *NamespaceSelector `json:"namespaces,omitempty"`
 }
 
 // MetricPipelineOutput defines the output configuration section.
+// +kubebuilder:validation:XValidation:rule="(has(self.otlp) ? 1 : 0) + (has(self.prometheusRemoteWrite) ? 1 : 0) + (has(self.prometheus) ? 1 : 0) == 1",message="exactly one of otlp, prometheusRemoteWrite, or prometheus must be defined"
 type MetricPipelineOutput struct {
 	// Defines an output using the OpenTelemetry protocol.
-	Otlp *OtlpOutput `json:"otlp"`
+	Otlp *OtlpOutput `json:"otlp,omitempty"`
+	// Defines an output that pushes the metrics to a backend using the Prometheus remote-write protocol.
+	PrometheusRemoteWrite *PrometheusRemoteWriteOutput `json:"prometheusRemoteWrite,omitempty"`
+	// Defines an output that exposes the metrics in the Prometheus exposition format on a port of the metric gateway, so that a Prometheus server can scrape them.
+	Prometheus *PrometheusOutput `json:"prometheus,omitempty"`
+}
+
+// PrometheusRemoteWriteOutput defines a backend that receives the metrics using the Prometheus remote-write protocol.
+type PrometheusRemoteWriteOutput struct {
+	// Defines the URL of the remote-write endpoint, for example, `https://prometheus.example.com/api/v1/write`.
+	// +kubebuilder:validation:Required
+	Endpoint ValueType `json:"endpoint"`
+	// Defines authentication options for the remote-write requests.
+	Authentication *AuthenticationOptions `json:"authentication,omitempty"`
+	// Defines custom headers to be added to the remote-write requests.
+	Headers []Header `json:"headers,omitempty"`
+	// Defines TLS options for the connection to the remote-write endpoint.
+	TLS *OtlpTLS `json:"tls,omitempty"`
+	// Defines labels that are added to every time series that is written to the endpoint.
+	ExternalLabels map[string]string `json:"externalLabels,omitempty"`
+	// If enabled, the resource attributes of the metrics, like `k8s.namespace.name`, are converted to labels of the time series. Default is false.
+	ResourceAttributesAsLabels bool `json:"resourceAttributesAsLabels,omitempty"`
+	// Defines the timeout of a single remote-write request, for example `10s`. Default is 5s.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Defines how remote-write requests that failed with a retryable error are retried.
+	Retry *OtlpRetry `json:"retry,omitempty"`
+}
+
+// PrometheusOutput defines a Prometheus exposition endpoint that is served by the metric gateway.
+type PrometheusOutput struct {
+	// Defines the port of the metric gateway on which the metrics are exposed at the `/metrics` path. The port must be unique among all MetricPipelines and must not be a port that the gateway uses itself. Default is 8889.
+	// +kubebuilder:validation:Minimum=1024
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default:=8889
+	Port int32 `json:"port,omitempty"`
+	// Defines a prefix that is added to the names of all exposed metrics.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_][a-zA-Z0-9_]*$`
+	MetricPrefix string `json:"metricPrefix,omitempty"`
+	// Defines labels that are added to all exposed metrics.
+	ConstLabels map[string]string `json:"constLabels,omitempty"`
+	// If enabled, the resource attributes of the metrics, like `k8s.namespace.name`, are converted to labels. Default is false.
+	ResourceAttributesAsLabels bool `json:"resourceAttributesAsLabels,omitempty"`
+	// Defines how long a metric is still exposed after the gateway received it for the last time, for example `10m`. Default is 5m.
+	MetricExpiration *metav1.Duration `json:"metricExpiration,omitempty"`
+}
+
+// NamedMetricPipelineOutput defines an additional output of a MetricPipeline.
+type NamedMetricPipelineOutput struct {
+	// Name of the output. Must be unique within the pipeline and must not be `default`.
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:XValidation:rule="self != 'default'",message="the name default is reserved for spec.output"
+	Name string `json:"name"`
+
+	MetricPipelineOutput `json:",inline"`
+}
+
+// AllOutputs returns the output defined in `spec.output`, named `default`, followed by all additional outputs.
+func (mps *MetricPipelineSpec) AllOutputs() []NamedMetricPipelineOutput {
+	outputs := []NamedMetricPipelineOutput{{Name: DefaultOutputName, MetricPipelineOutput: mps.Output}}
+	return append(outputs, mps.AdditionalOutputs...)
 }
 
 // MetricPipelineStatus defines the observed state of MetricPipeline.
 type MetricPipelineStatus struct {
 	// An array of conditions describing the status of the pipeline.
 	Conditions []MetricPipelineCondition `json:"conditions,omitempty"`
+	// Shows the state of each output of the pipeline.
+	Outputs []OutputStatus `json:"outputs,omitempty"`
+	// Conditions describing the health of the data flow of the pipeline, evaluated from the self-monitoring metrics of the gateway. The condition of type `TelemetryFlowHealthy` has one of the reasons `FlowHealthy`, `BufferFillingUp`, `SomeDataDropped`, `AllDataDropped`, or `FlowHealthProbingFailed`.
+	// +listType=map
+	// +listMapKey=type
+	HealthConditions []metav1.Condition `json:"healthConditions,omitempty"`
 }
 
 type MetricPipelineConditionType string
 
 // These are the valid statuses of MetricPipeline.
 const (
-	MetricPipelinePending MetricPipelineConditionType = "Pending"
-	MetricPipelineRunning MetricPipelineConditionType = "Running"
+	MetricPipelinePending   MetricPipelineConditionType = "Pending"
+	MetricPipelineRunning   MetricPipelineConditionType = "Running"
+	MetricPipelineSuspended MetricPipelineConditionType = "Suspended"
 )
 
 // MetricPipelineCondition contains details for the current condition of this LogPipeline.
@@ -121,7 +340,10 @@ type MetricPipelineCondition struct {
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 	// Reason of last transition.
 	Reason string `json:"reason,omitempty"`
-	// The possible transition types are:<br>- `Running`: The instance is ready and usable.<br>- `Pending`: The pipeline is being activated.
+	// Human-readable details of the last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// The possible transition types are:<br>- `Running`: The instance is ready and usable.<br>- `Pending`: The pipeline is being activated.<br>- `Suspended`: The pipeline is suspended with `spec.suspend`.
 	Type MetricPipelineConditionType `json:"type,omitempty"`
 }
 
@@ -148,7 +370,7 @@ func (tps *MetricPipelineStatus) HasCondition(condition MetricPipelineConditionT
 
 func (tps *MetricPipelineStatus) SetCondition(cond MetricPipelineCondition) {
 	currentCond := tps.GetCondition(cond.Type)
-	if currentCond != nil && currentCond.Reason == cond.Reason {
+	if currentCond != nil && currentCond.Reason == cond.Reason && currentCond.Message == cond.Message {
 		return
 	}
 	if currentCond != nil {
@@ -0,0 +1,95 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/ottl"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
+)
+
+// reservedGatewayPorts are the ports that the metric gateway uses itself, so a Prometheus output cannot expose metrics on them.
+var reservedGatewayPorts = []int32{ports.OTLPHTTP, ports.OTLPGRPC, ports.OpenCensus, ports.Metrics, ports.HealthCheck, ports.Pprof, ports.LoadBalancing}
+
+func (mp *MetricPipeline) Validate() error {
+	if err := mp.validateFilters(); err != nil {
+		return err
+	}
+	if err := mp.validateTransforms(); err != nil {
+		return err
+	}
+	if err := mp.validatePrometheusOutputs(); err != nil {
+		return err
+	}
+	return mp.validatePrometheusTargets()
+}
+
+func (mp *MetricPipeline) validateFilters() error {
+	filters := mp.Spec.Filters
+	if filters == nil {
+		return nil
+	}
+
+	for _, condition := range filters.Conditions {
+		if err := ottl.ValidateCondition(ottl.ContextDataPoint, condition); err != nil {
+			return fmt.Errorf("metric pipeline '%s' has an invalid filter condition: %w", mp.Name, err)
+		}
+	}
+	return nil
+}
+
+func (mp *MetricPipeline) validateTransforms() error {
+	transforms := mp.Spec.Transforms
+	if transforms == nil {
+		return nil
+	}
+
+	for _, statement := range transforms.Statements {
+		if err := ottl.ValidateStatement(ottl.ContextDataPoint, statement); err != nil {
+			return fmt.Errorf("metric pipeline '%s' has an invalid transform statement: %w", mp.Name, err)
+		}
+	}
+	return nil
+}
+
+func (mp *MetricPipeline) validatePrometheusTargets() error {
+	for _, target := range mp.Spec.Input.Application.Prometheus.Targets {
+		selector := target.Pods
+		if selector == nil {
+			selector = target.Services
+		}
+		if selector == nil {
+			continue
+		}
+
+		port := selector.Port
+		if port.Type == intstr.Int && (port.IntVal < 1 || port.IntVal > 65535) {
+			return fmt.Errorf("metric pipeline '%s' has an invalid port %d in target '%s'", mp.Name, port.IntVal, target.Name)
+		}
+		if port.Type == intstr.String && port.StrVal == "" {
+			return fmt.Errorf("metric pipeline '%s' has an empty port in target '%s'", mp.Name, target.Name)
+		}
+	}
+	return nil
+}
+
+func (mp *MetricPipeline) validatePrometheusOutputs() error {
+	usedPorts := make(map[int32]string)
+	for _, output := range mp.Spec.AllOutputs() {
+		if output.Prometheus == nil {
+			continue
+		}
+
+		port := output.Prometheus.Port
+		if slices.Contains(reservedGatewayPorts, port) {
+			return fmt.Errorf("metric pipeline '%s' has a Prometheus output '%s' with the port %d, which is reserved by the metric gateway", mp.Name, output.Name, port)
+		}
+		if other, found := usedPorts[port]; found {
+			return fmt.Errorf("metric pipeline '%s' has the Prometheus outputs '%s' and '%s' with the same port %d", mp.Name, other, output.Name, port)
+		}
+		usedPorts[port] = output.Name
+	}
+	return nil
+}
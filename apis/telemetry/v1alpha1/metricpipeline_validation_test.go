@@ -0,0 +1,113 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestValidateMetricPipeline(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        MetricPipelineSpec
+		expectedErr string
+	}{
+		{
+			name: "no filters and transforms",
+			spec: MetricPipelineSpec{},
+		},
+		{
+			name: "valid filters and transforms",
+			spec: MetricPipelineSpec{
+				Filters: &MetricPipelineFilters{
+					DropMetricNames: []string{"go_gc_duration_seconds"},
+					Conditions:      []string{`attributes["code"] == "200"`},
+				},
+				Transforms: &MetricPipelineTransforms{
+					Statements: []string{`delete_key(attributes, "pod_uid")`},
+				},
+			},
+		},
+		{
+			name: "invalid filter condition",
+			spec: MetricPipelineSpec{
+				Filters: &MetricPipelineFilters{
+					Conditions: []string{`attributes["code"] = "200"`},
+				},
+			},
+			expectedErr: "metric pipeline 'test' has an invalid filter condition",
+		},
+		{
+			name: "invalid transform statement",
+			spec: MetricPipelineSpec{
+				Transforms: &MetricPipelineTransforms{
+					Statements: []string{`delete_key(attributes, "pod_uid"`},
+				},
+			},
+			expectedErr: "metric pipeline 'test' has an invalid transform statement",
+		},
+		{
+			name: "valid prometheus targets",
+			spec: MetricPipelineSpec{
+				Input: MetricPipelineInput{Application: MetricPipelineApplicationInput{Prometheus: MetricPipelinePrometheusInput{
+					Targets: []PrometheusScrapeTarget{
+						{Name: "by-number", Pods: &ScrapeTargetSelector{MatchLabels: map[string]string{"app": "a"}, Port: intstr.FromInt(8080)}},
+						{Name: "by-name", Services: &ScrapeTargetSelector{MatchLabels: map[string]string{"app": "b"}, Port: intstr.FromString("metrics")}},
+					},
+				}}},
+			},
+		},
+		{
+			name: "prometheus target with invalid port",
+			spec: MetricPipelineSpec{
+				Input: MetricPipelineInput{Application: MetricPipelineApplicationInput{Prometheus: MetricPipelinePrometheusInput{
+					Targets: []PrometheusScrapeTarget{
+						{Name: "app", Pods: &ScrapeTargetSelector{MatchLabels: map[string]string{"app": "a"}, Port: intstr.FromInt(70000)}},
+					},
+				}}},
+			},
+			expectedErr: "metric pipeline 'test' has an invalid port 70000 in target 'app'",
+		},
+		{
+			name: "valid prometheus outputs",
+			spec: MetricPipelineSpec{
+				Output: MetricPipelineOutput{Prometheus: &PrometheusOutput{Port: 8889}},
+				AdditionalOutputs: []NamedMetricPipelineOutput{
+					{Name: "other", MetricPipelineOutput: MetricPipelineOutput{Prometheus: &PrometheusOutput{Port: 8890}}},
+				},
+			},
+		},
+		{
+			name: "prometheus output with reserved port",
+			spec: MetricPipelineSpec{
+				Output: MetricPipelineOutput{Prometheus: &PrometheusOutput{Port: 8888}},
+			},
+			expectedErr: "metric pipeline 'test' has a Prometheus output 'default' with the port 8888, which is reserved by the metric gateway",
+		},
+		{
+			name: "prometheus outputs with the same port",
+			spec: MetricPipelineSpec{
+				Output: MetricPipelineOutput{Prometheus: &PrometheusOutput{Port: 8889}},
+				AdditionalOutputs: []NamedMetricPipelineOutput{
+					{Name: "other", MetricPipelineOutput: MetricPipelineOutput{Prometheus: &PrometheusOutput{Port: 8889}}},
+				},
+			},
+			expectedErr: "metric pipeline 'test' has the Prometheus outputs 'default' and 'other' with the same port 8889",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline := &MetricPipeline{ObjectMeta: metav1.ObjectMeta{Name: "test"}, Spec: tt.spec}
+
+			err := pipeline.Validate()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
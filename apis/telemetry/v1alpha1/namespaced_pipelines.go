@@ -0,0 +1,18 @@
+package v1alpha1
+
+import "strings"
+
+// namespacedPipelineNameSeparator separates the Namespace and the name of a namespaced pipeline in the name of the converted pipeline.
+// Pipeline names and output names cannot contain an underscore, so the converted names collide neither with the names of cluster-scoped pipelines nor with output IDs.
+const namespacedPipelineNameSeparator = "__"
+
+// NamespacedPipelineName returns the name under which a namespaced pipeline is rendered together with the cluster-scoped pipelines of the same kind.
+func NamespacedPipelineName(namespace, name string) string {
+	return namespace + namespacedPipelineNameSeparator + name
+}
+
+// SplitNamespacedPipelineName returns the Namespace and the name of the namespaced pipeline that was converted to a pipeline with the given name.
+// It returns false if the name is not the name of a converted namespaced pipeline.
+func SplitNamespacedPipelineName(pipelineName string) (namespace, name string, ok bool) {
+	return strings.Cut(pipelineName, namespacedPipelineNameSeparator)
+}
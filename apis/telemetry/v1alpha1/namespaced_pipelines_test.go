@@ -0,0 +1,87 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSplitNamespacedPipelineName(t *testing.T) {
+	namespace, name, ok := SplitNamespacedPipelineName(NamespacedPipelineName("team-a", "backend"))
+	require.True(t, ok)
+	require.Equal(t, "team-a", namespace)
+	require.Equal(t, "backend", name)
+
+	_, _, ok = SplitNamespacedPipelineName("backend")
+	require.False(t, ok)
+}
+
+func secretValue(namespace string) ValueType {
+	return ValueType{ValueFrom: &ValueFromSource{SecretKeyRef: &SecretKeyRef{Name: "secret", Namespace: namespace, Key: "key"}}}
+}
+
+func TestNamespacedTracePipelineToTracePipeline(t *testing.T) {
+	namespacedPipeline := NamespacedTracePipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "team-a"},
+		Spec: NamespacedTracePipelineSpec{
+			Priority: 5,
+			Suspend:  true,
+			Output: TracePipelineOutput{Otlp: &OtlpOutput{
+				Endpoint: secretValue("kyma-system"),
+				Headers:  []Header{{Name: "Authorization", ValueType: secretValue("")}},
+			}},
+			AdditionalOutputs: []NamedTracePipelineOutput{
+				{Name: "backup", TracePipelineOutput: TracePipelineOutput{Otlp: &OtlpOutput{Endpoint: secretValue("other")}}},
+				{Name: "legacy", TracePipelineOutput: TracePipelineOutput{Zipkin: &ZipkinOutput{Endpoint: secretValue("other")}}},
+			},
+		},
+	}
+
+	pipeline := namespacedPipeline.ToTracePipeline()
+
+	require.Equal(t, "team-a__backend", pipeline.Name)
+	require.Equal(t, "team-a", pipeline.Namespace)
+	require.Equal(t, int32(5), pipeline.Spec.Priority)
+	require.True(t, pipeline.Spec.Suspend)
+	require.Equal(t, []string{"team-a"}, pipeline.Spec.Input.Namespaces.Include)
+	require.Equal(t, "team-a", pipeline.Spec.Output.Otlp.Endpoint.ValueFrom.SecretKeyRef.Namespace)
+	require.Equal(t, "team-a", pipeline.Spec.Output.Otlp.Headers[0].ValueFrom.SecretKeyRef.Namespace)
+	require.Equal(t, "team-a", pipeline.Spec.AdditionalOutputs[0].Otlp.Endpoint.ValueFrom.SecretKeyRef.Namespace)
+	require.Equal(t, "team-a", pipeline.Spec.AdditionalOutputs[1].Zipkin.Endpoint.ValueFrom.SecretKeyRef.Namespace)
+	require.Equal(t, "kyma-system", namespacedPipeline.Spec.Output.Otlp.Endpoint.ValueFrom.SecretKeyRef.Namespace, "the namespaced pipeline must not be modified")
+}
+
+func TestNamespacedLogPipelineToLogPipeline(t *testing.T) {
+	namespacedPipeline := NamespacedLogPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "team-a"},
+		Spec: NamespacedLogPipelineSpec{
+			Suspend: true,
+			Input: NamespacedLogPipelineInput{Application: NamespacedApplicationInput{
+				Containers: NamespacedInputContainers{Include: []ContainerName{"app"}},
+				Multiline:  &NamespacedMultilineInput{Presets: []MultilinePreset{MultilinePresetJava}},
+				Parse:      &NamespacedParseInput{Key: "message"},
+			}},
+			Output: NamespacedLogPipelineOutput{Otlp: &OtlpOutput{
+				Endpoint: secretValue("kyma-system"),
+				TLS:      &OtlpTLS{CA: &ValueType{ValueFrom: secretValue("kyma-system").ValueFrom}},
+			}},
+		},
+	}
+
+	pipeline := namespacedPipeline.ToLogPipeline()
+
+	require.Equal(t, "team-a__backend", pipeline.Name)
+	require.Equal(t, "team-a", pipeline.Namespace)
+	require.True(t, pipeline.Spec.Suspend)
+	application := pipeline.Spec.Input.Application
+	require.Equal(t, []string{"team-a"}, application.Namespaces.Include)
+	require.False(t, application.Namespaces.System)
+	require.Equal(t, []string{"app"}, application.Containers.Include)
+	require.Equal(t, []MultilinePreset{MultilinePresetJava}, application.Multiline.Presets)
+	require.Equal(t, &ParseInput{Format: ParseFormatJSON, Key: "message"}, application.Parse)
+	require.Equal(t, "team-a", pipeline.Spec.Output.Otlp.Endpoint.ValueFrom.SecretKeyRef.Namespace)
+	require.Equal(t, "team-a", pipeline.Spec.Output.Otlp.TLS.CA.ValueFrom.SecretKeyRef.Namespace)
+	require.Empty(t, pipeline.Spec.Filters)
+	require.Empty(t, pipeline.Spec.Files)
+}
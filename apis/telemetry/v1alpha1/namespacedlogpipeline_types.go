@@ -0,0 +1,150 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NamespacedLogPipelineSpec defines the desired state of NamespacedLogPipeline
+type NamespacedLogPipelineSpec struct {
+	// If true, no logs are shipped to the output of the pipeline. The pipeline keeps its slot within the maximum number of LogPipelines per Namespace. Default is false.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+	// Defines from which containers of the Namespace logs are collected.
+	Input NamespacedLogPipelineInput `json:"input,omitempty"`
+	// Defines the destination for shipping the logs. Secrets are always referenced in the Namespace of the pipeline.
+	Output NamespacedLogPipelineOutput `json:"output"`
+}
+
+// NamespacedLogPipelineInput describes a log input for a NamespacedLogPipeline.
+type NamespacedLogPipelineInput struct {
+	// Configures from which containers of the Namespace application logs are collected.
+	Application NamespacedApplicationInput `json:"application,omitempty"`
+}
+
+// NamespacedApplicationInput specifies which application logs of the Namespace are selected and how they are processed.
+type NamespacedApplicationInput struct {
+	// Describes whether application logs from specific containers are selected. The options are mutually exclusive.
+	Containers NamespacedInputContainers `json:"containers,omitempty"`
+	// Defines whether to keep all Kubernetes annotations. The default is `false`.
+	KeepAnnotations bool `json:"keepAnnotations,omitempty"`
+	// Defines whether to drop all Kubernetes labels. The default is `false`.
+	DropLabels bool `json:"dropLabels,omitempty"`
+	// Concatenates log lines that belong to the same log record, like stack traces, to a single record.
+	Multiline *NamespacedMultilineInput `json:"multiline,omitempty"`
+	// Parses the log line of a container as a JSON document into structured fields of the record.
+	Parse *NamespacedParseInput `json:"parse,omitempty"`
+}
+
+// ContainerName is the name of a container in a Pod.
+// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+// +kubebuilder:validation:MaxLength=63
+type ContainerName string
+
+// NamespacedInputContainers describes whether application logs from specific containers are selected. The options are mutually exclusive.
+// +kubebuilder:validation:XValidation:rule="!(has(self.include) && has(self.exclude))",message="only one of include or exclude can be defined"
+type NamespacedInputContainers struct {
+	// Specifies to include only the container logs with the specified container names.
+	Include []ContainerName `json:"include,omitempty"`
+	// Specifies to exclude only the container logs with the specified container names.
+	Exclude []ContainerName `json:"exclude,omitempty"`
+}
+
+// NamespacedMultilineInput describes how log lines are concatenated to multiline log records.
+type NamespacedMultilineInput struct {
+	// Concatenates stack traces of the specified languages. The options are `java`, `go`, and `python`.
+	// +kubebuilder:validation:MinItems=1
+	Presets []MultilinePreset `json:"presets"`
+}
+
+// NamespacedParseInput describes how the log line is parsed as a JSON document into structured fields.
+type NamespacedParseInput struct {
+	// Record key that holds the content to parse. The default is `log`.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_.-]*$`
+	Key string `json:"key,omitempty"`
+	// Defines whether to keep the original content in the record after successful parsing. The default is `false`.
+	KeepOriginal bool `json:"keepOriginal,omitempty"`
+}
+
+// NamespacedLogPipelineOutput describes the destination of a NamespacedLogPipeline.
+type NamespacedLogPipelineOutput struct {
+	// Configures an output to an OTLP endpoint. The logs are shipped by the log gateway.
+	// +kubebuilder:validation:Required
+	Otlp *OtlpOutput `json:"otlp"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[-1].type`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// NamespacedLogPipeline is the Schema for the namespacedlogpipelines API. It ships only the logs of the containers in its own Namespace.
+type NamespacedLogPipeline struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Defines the desired state of NamespacedLogPipeline
+	Spec NamespacedLogPipelineSpec `json:"spec,omitempty"`
+	// Shows the observed state of the NamespacedLogPipeline
+	Status LogPipelineStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// NamespacedLogPipelineList contains a list of NamespacedLogPipeline
+type NamespacedLogPipelineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NamespacedLogPipeline `json:"items"`
+}
+
+//nolint:gochecknoinits // SchemeBuilder's registration is required.
+func init() {
+	SchemeBuilder.Register(&NamespacedLogPipeline{}, &NamespacedLogPipelineList{})
+}
+
+// ToLogPipeline converts the pipeline to a LogPipeline, so that it is rendered like a cluster-scoped pipeline.
+// The LogPipeline keeps the Namespace of the pipeline, gets a name that is unique among all log pipelines, selects only the logs of the Namespace,
+// and references only the Secrets of the Namespace.
+func (nlp *NamespacedLogPipeline) ToLogPipeline() LogPipeline {
+	spec := nlp.Spec.DeepCopy()
+	application := spec.Input.Application
+
+	pipeline := LogPipeline{
+		ObjectMeta: *nlp.ObjectMeta.DeepCopy(),
+		Spec: LogPipelineSpec{
+			Suspend: spec.Suspend,
+			Input: Input{
+				Application: ApplicationInput{
+					Namespaces:      InputNamespaces{Include: []string{nlp.Namespace}},
+					Containers:      InputContainers{Include: toStrings(application.Containers.Include), Exclude: toStrings(application.Containers.Exclude)},
+					KeepAnnotations: application.KeepAnnotations,
+					DropLabels:      application.DropLabels,
+				},
+			},
+			Output: Output{Otlp: spec.Output.Otlp},
+		},
+		Status: *nlp.Status.DeepCopy(),
+	}
+	pipeline.Name = NamespacedPipelineName(nlp.Namespace, nlp.Name)
+
+	if application.Multiline != nil {
+		pipeline.Spec.Input.Application.Multiline = &MultilineInput{Presets: application.Multiline.Presets}
+	}
+	if application.Parse != nil {
+		pipeline.Spec.Input.Application.Parse = &ParseInput{
+			Format:       ParseFormatJSON,
+			Key:          application.Parse.Key,
+			KeepOriginal: application.Parse.KeepOriginal,
+		}
+	}
+
+	setSecretRefsNamespaceInOtlpOutput(pipeline.Spec.Output.Otlp, nlp.Namespace)
+
+	return pipeline
+}
+
+func toStrings(containerNames []ContainerName) []string {
+	var result []string
+	for _, name := range containerNames {
+		result = append(result, string(name))
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NamespacedTracePipelineSpec defines the desired state of NamespacedTracePipeline
+type NamespacedTracePipelineSpec struct {
+	// Determines which pipelines of the Namespace are deployed if more pipelines exist than the maximum number of TracePipelines per Namespace. Pipelines with a higher priority are deployed first; pipelines with the same priority are deployed in the order of their creation. Default is 0.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+	// If true, no traces are shipped to the outputs of the pipeline. The pipeline keeps its slot within the maximum number of TracePipelines per Namespace. Default is false.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+	// Defines a destination for shipping trace data. Only one can be defined per pipeline. Secrets are always referenced in the Namespace of the pipeline.
+	Output TracePipelineOutput `json:"output"`
+	// Defines further destinations for shipping trace data. Every output receives the same traces.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=3
+	AdditionalOutputs []NamedTracePipelineOutput `json:"additionalOutputs,omitempty"`
+	// Configures which traces are shipped to the output. If not defined, all traces are shipped.
+	Sampling *TracePipelineSampling `json:"sampling,omitempty"`
+	// Configures rules to drop or keep spans, and the built-in filter for spans of Kyma-internal traffic.
+	Filters *TracePipelineFilters `json:"filters,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[-1].type`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// NamespacedTracePipeline is the Schema for the namespacedtracepipelines API. It ships only the traces emitted by Pods in its own Namespace.
+type NamespacedTracePipeline struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Defines the desired state of NamespacedTracePipeline
+	Spec NamespacedTracePipelineSpec `json:"spec,omitempty"`
+	// Shows the observed state of the NamespacedTracePipeline
+	Status TracePipelineStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NamespacedTracePipelineList contains a list of NamespacedTracePipeline
+type NamespacedTracePipelineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NamespacedTracePipeline `json:"items"`
+}
+
+//nolint:gochecknoinits // SchemeBuilder's registration is required.
+func init() {
+	SchemeBuilder.Register(&NamespacedTracePipeline{}, &NamespacedTracePipelineList{})
+}
+
+// ToTracePipeline converts the pipeline to a TracePipeline, so that it is rendered like a cluster-scoped pipeline.
+// The TracePipeline keeps the Namespace of the pipeline, gets a name that is unique among all trace pipelines, selects only the traces of the Namespace,
+// and references only the Secrets of the Namespace.
+func (ntp *NamespacedTracePipeline) ToTracePipeline() TracePipeline {
+	spec := ntp.Spec.DeepCopy()
+
+	pipeline := TracePipeline{
+		ObjectMeta: *ntp.ObjectMeta.DeepCopy(),
+		Spec: TracePipelineSpec{
+			Priority:          spec.Priority,
+			Suspend:           spec.Suspend,
+			Input:             TracePipelineInput{Namespaces: &NamespaceSelector{Include: []string{ntp.Namespace}}},
+			Output:            spec.Output,
+			AdditionalOutputs: spec.AdditionalOutputs,
+			Sampling:          spec.Sampling,
+			Filters:           spec.Filters,
+		},
+		Status: *ntp.Status.DeepCopy(),
+	}
+	pipeline.Name = NamespacedPipelineName(ntp.Namespace, ntp.Name)
+
+	for _, output := range pipeline.Spec.AllOutputs() {
+		setSecretRefsNamespaceInTraceOutput(&output.TracePipelineOutput, ntp.Namespace)
+	}
+
+	return pipeline
+}
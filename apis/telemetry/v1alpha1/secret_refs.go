@@ -9,8 +9,9 @@ func (lp *LogPipeline) GetSecretRefs() []SecretKeyRef {
 		}
 	}
 
-	refs = append(refs, lp.GetEnvSecretRefs()...)
-	refs = append(refs, lp.GetTLSSecretRefs()...)
+	for _, output := range lp.Spec.AllOutputs() {
+		refs = append(refs, output.GetSecretRefs()...)
+	}
 
 	return refs
 }
@@ -18,26 +19,69 @@ func (lp *LogPipeline) GetSecretRefs() []SecretKeyRef {
 // GetEnvSecretRefs returns the secret references of a LogPipeline that should be stored in the env secret
 func (lp *LogPipeline) GetEnvSecretRefs() []SecretKeyRef {
 	var refs []SecretKeyRef
+	for _, output := range lp.Spec.AllOutputs() {
+		refs = append(refs, output.getEnvSecretRefs()...)
+	}
+	return refs
+}
 
-	output := lp.Spec.Output
-	if output.IsHTTPDefined() {
-		refs = appendIfSecretRef(refs, output.HTTP.Host)
-		refs = appendIfSecretRef(refs, output.HTTP.User)
-		refs = appendIfSecretRef(refs, output.HTTP.Password)
+func (lp *LogPipeline) GetTLSSecretRefs() []SecretKeyRef {
+	var refs []SecretKeyRef
+	for _, output := range lp.Spec.AllOutputs() {
+		refs = append(refs, output.getTLSSecretRefs()...)
 	}
-	if output.IsLokiDefined() {
-		refs = appendIfSecretRef(refs, output.Loki.URL)
+	return refs
+}
+
+// GetSecretRefs returns the Secret references of the output.
+// The references of an OTLP output are resolved by the log gateway, all others by Fluent Bit.
+func (o *Output) GetSecretRefs() []SecretKeyRef {
+	var refs []SecretKeyRef
+
+	refs = append(refs, o.getEnvSecretRefs()...)
+	refs = append(refs, o.getTLSSecretRefs()...)
+
+	if o.IsOtlpDefined() {
+		refs = append(refs, getRefsInOtlpOutput(o.Otlp)...)
 	}
 
 	return refs
 }
 
-func (lp *LogPipeline) GetTLSSecretRefs() []SecretKeyRef {
+func (o *Output) getEnvSecretRefs() []SecretKeyRef {
 	var refs []SecretKeyRef
 
-	output := lp.Spec.Output
-	if output.IsHTTPDefined() {
-		tlsConfig := output.HTTP.TLSConfig
+	if o.IsHTTPDefined() {
+		refs = appendIfSecretRef(refs, o.HTTP.Host)
+		refs = appendIfSecretRef(refs, o.HTTP.User)
+		refs = appendIfSecretRef(refs, o.HTTP.Password)
+	}
+	if o.IsLokiDefined() {
+		refs = appendIfSecretRef(refs, o.Loki.URL)
+	}
+	if o.IsNativeLokiDefined() {
+		refs = appendIfSecretRef(refs, o.NativeLoki.Host)
+		refs = appendIfSecretRef(refs, o.NativeLoki.TenantID)
+		refs = appendIfSecretRef(refs, o.NativeLoki.User)
+		refs = appendIfSecretRef(refs, o.NativeLoki.Password)
+	}
+	if o.IsElasticsearchDefined() {
+		refs = appendIfSecretRef(refs, o.Elasticsearch.Host)
+		refs = appendIfSecretRef(refs, o.Elasticsearch.User)
+		refs = appendIfSecretRef(refs, o.Elasticsearch.Password)
+	}
+	if o.IsSplunkDefined() {
+		refs = appendIfSecretRef(refs, o.Splunk.Host)
+		refs = appendIfSecretRef(refs, o.Splunk.Token)
+	}
+
+	return refs
+}
+
+func (o *Output) getTLSSecretRefs() []SecretKeyRef {
+	var refs []SecretKeyRef
+
+	if tlsConfig := o.GetTLSConfig(); tlsConfig != nil {
 		if tlsConfig.CA != nil {
 			refs = appendIfSecretRef(refs, *tlsConfig.CA)
 		}
@@ -53,11 +97,41 @@ func (lp *LogPipeline) GetTLSSecretRefs() []SecretKeyRef {
 }
 
 func (tp *TracePipeline) GetSecretRefs() []SecretKeyRef {
-	return getRefsInOtlpOutput(tp.Spec.Output.Otlp)
+	var refs []SecretKeyRef
+	for _, output := range tp.Spec.AllOutputs() {
+		refs = append(refs, output.GetSecretRefs()...)
+	}
+	return refs
+}
+
+// GetSecretRefs returns the Secret references of the output.
+func (o *TracePipelineOutput) GetSecretRefs() []SecretKeyRef {
+	if o.Zipkin != nil {
+		return getRefsInZipkinOutput(o.Zipkin)
+	}
+	if o.Otlp != nil {
+		return getRefsInOtlpOutput(o.Otlp)
+	}
+	return nil
 }
 
 func (mp *MetricPipeline) GetSecretRefs() []SecretKeyRef {
-	return getRefsInOtlpOutput(mp.Spec.Output.Otlp)
+	var refs []SecretKeyRef
+	for _, output := range mp.Spec.AllOutputs() {
+		refs = append(refs, output.GetSecretRefs()...)
+	}
+	return refs
+}
+
+// GetSecretRefs returns the Secret references of the output. A Prometheus exposition output does not reference any Secrets.
+func (o *MetricPipelineOutput) GetSecretRefs() []SecretKeyRef {
+	if o.Otlp != nil {
+		return getRefsInOtlpOutput(o.Otlp)
+	}
+	if o.PrometheusRemoteWrite != nil {
+		return getRefsInPrometheusRemoteWriteOutput(o.PrometheusRemoteWrite)
+	}
+	return nil
 }
 
 func getRefsInOtlpOutput(otlpOut *OtlpOutput) []SecretKeyRef {
@@ -65,9 +139,19 @@ func getRefsInOtlpOutput(otlpOut *OtlpOutput) []SecretKeyRef {
 
 	refs = appendIfSecretRef(refs, otlpOut.Endpoint)
 
-	if otlpOut.Authentication != nil && otlpOut.Authentication.Basic.IsDefined() {
-		refs = appendIfSecretRef(refs, otlpOut.Authentication.Basic.User)
-		refs = appendIfSecretRef(refs, otlpOut.Authentication.Basic.Password)
+	if otlpOut.Authentication != nil {
+		if otlpOut.Authentication.Basic.IsDefined() {
+			refs = appendIfSecretRef(refs, otlpOut.Authentication.Basic.User)
+			refs = appendIfSecretRef(refs, otlpOut.Authentication.Basic.Password)
+		}
+		if otlpOut.Authentication.OAuth2.IsDefined() {
+			refs = appendIfSecretRef(refs, otlpOut.Authentication.OAuth2.TokenURL)
+			refs = appendIfSecretRef(refs, otlpOut.Authentication.OAuth2.ClientID)
+			refs = appendIfSecretRef(refs, otlpOut.Authentication.OAuth2.ClientSecret)
+		}
+		if otlpOut.Authentication.BearerToken != nil {
+			refs = appendIfSecretRef(refs, *otlpOut.Authentication.BearerToken)
+		}
 	}
 
 	for _, header := range otlpOut.Headers {
@@ -89,9 +173,103 @@ func getRefsInOtlpOutput(otlpOut *OtlpOutput) []SecretKeyRef {
 	return refs
 }
 
+// getRefsInPrometheusRemoteWriteOutput returns the Secret references of the remote-write output, which has the same connection settings as an OTLP output.
+func getRefsInPrometheusRemoteWriteOutput(prwOut *PrometheusRemoteWriteOutput) []SecretKeyRef {
+	return getRefsInOtlpOutput(&OtlpOutput{
+		Endpoint:       prwOut.Endpoint,
+		Authentication: prwOut.Authentication,
+		Headers:        prwOut.Headers,
+		TLS:            prwOut.TLS,
+	})
+}
+
+// getRefsInZipkinOutput returns the Secret references of the Zipkin output, which has the same connection settings as an OTLP output.
+func getRefsInZipkinOutput(zipkinOut *ZipkinOutput) []SecretKeyRef {
+	return getRefsInOtlpOutput(&OtlpOutput{
+		Endpoint:       zipkinOut.Endpoint,
+		Authentication: zipkinOut.Authentication,
+		Headers:        zipkinOut.Headers,
+		TLS:            zipkinOut.TLS,
+	})
+}
+
 func appendIfSecretRef(secretKeyRefs []SecretKeyRef, valueType ValueType) []SecretKeyRef {
 	if valueType.Value == "" && valueType.ValueFrom != nil && valueType.ValueFrom.IsSecretKeyRef() {
 		secretKeyRefs = append(secretKeyRefs, *valueType.ValueFrom.SecretKeyRef)
 	}
 	return secretKeyRefs
 }
+
+// setSecretRefsNamespaceInOtlpOutput sets the Namespace of all Secret references of the OTLP output.
+// It restricts a namespaced pipeline to the Secrets of its own Namespace.
+func setSecretRefsNamespaceInOtlpOutput(otlpOut *OtlpOutput, namespace string) {
+	if otlpOut == nil {
+		return
+	}
+
+	setSecretRefNamespace(&otlpOut.Endpoint, namespace)
+
+	if otlpOut.Authentication != nil {
+		if basic := otlpOut.Authentication.Basic; basic != nil {
+			setSecretRefNamespace(&basic.User, namespace)
+			setSecretRefNamespace(&basic.Password, namespace)
+		}
+		if oauth2 := otlpOut.Authentication.OAuth2; oauth2 != nil {
+			setSecretRefNamespace(&oauth2.TokenURL, namespace)
+			setSecretRefNamespace(&oauth2.ClientID, namespace)
+			setSecretRefNamespace(&oauth2.ClientSecret, namespace)
+		}
+		setSecretRefNamespace(otlpOut.Authentication.BearerToken, namespace)
+	}
+
+	for i := range otlpOut.Headers {
+		setSecretRefNamespace(&otlpOut.Headers[i].ValueType, namespace)
+	}
+
+	if otlpOut.TLS != nil {
+		setSecretRefNamespace(otlpOut.TLS.CA, namespace)
+		setSecretRefNamespace(otlpOut.TLS.Cert, namespace)
+		setSecretRefNamespace(otlpOut.TLS.Key, namespace)
+	}
+}
+
+// setSecretRefsNamespaceInTraceOutput sets the Namespace of all Secret references of the trace output.
+func setSecretRefsNamespaceInTraceOutput(output *TracePipelineOutput, namespace string) {
+	setSecretRefsNamespaceInOtlpOutput(output.Otlp, namespace)
+
+	zipkinOut := output.Zipkin
+	if zipkinOut == nil {
+		return
+	}
+
+	setSecretRefNamespace(&zipkinOut.Endpoint, namespace)
+
+	if zipkinOut.Authentication != nil {
+		if basic := zipkinOut.Authentication.Basic; basic != nil {
+			setSecretRefNamespace(&basic.User, namespace)
+			setSecretRefNamespace(&basic.Password, namespace)
+		}
+		if oauth2 := zipkinOut.Authentication.OAuth2; oauth2 != nil {
+			setSecretRefNamespace(&oauth2.TokenURL, namespace)
+			setSecretRefNamespace(&oauth2.ClientID, namespace)
+			setSecretRefNamespace(&oauth2.ClientSecret, namespace)
+		}
+		setSecretRefNamespace(zipkinOut.Authentication.BearerToken, namespace)
+	}
+
+	for i := range zipkinOut.Headers {
+		setSecretRefNamespace(&zipkinOut.Headers[i].ValueType, namespace)
+	}
+
+	if zipkinOut.TLS != nil {
+		setSecretRefNamespace(zipkinOut.TLS.CA, namespace)
+		setSecretRefNamespace(zipkinOut.TLS.Cert, namespace)
+		setSecretRefNamespace(zipkinOut.TLS.Key, namespace)
+	}
+}
+
+func setSecretRefNamespace(valueType *ValueType, namespace string) {
+	if valueType != nil && valueType.ValueFrom != nil && valueType.ValueFrom.SecretKeyRef != nil {
+		valueType.ValueFrom.SecretKeyRef.Namespace = namespace
+	}
+}
@@ -103,6 +103,121 @@ func TestLogPipeline_GetSecretRefs(t *testing.T) {
 				{Name: "creds", Namespace: "default", Key: "url"},
 			},
 		},
+		{
+			name: "splunk output secret refs",
+			given: LogPipeline{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "splunk",
+				},
+				Spec: LogPipelineSpec{
+					Output: Output{
+						Splunk: &SplunkOutput{
+							Host: ValueType{
+								Value: "splunk.example.com",
+							},
+							Token: ValueType{
+								ValueFrom: &ValueFromSource{
+									SecretKeyRef: &SecretKeyRef{
+										Name: "creds", Namespace: "default", Key: "token",
+									},
+								},
+							},
+							TLSConfig: TLSConfig{
+								CA: &ValueType{
+									ValueFrom: &ValueFromSource{
+										SecretKeyRef: &SecretKeyRef{
+											Name: "tls", Namespace: "default", Key: "ca",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []SecretKeyRef{
+				{Name: "creds", Namespace: "default", Key: "token"},
+				{Name: "tls", Namespace: "default", Key: "ca"},
+			},
+		},
+		{
+			name: "otlp output secret refs",
+			given: LogPipeline{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "otlp",
+				},
+				Spec: LogPipelineSpec{
+					Output: Output{
+						Otlp: &OtlpOutput{
+							Endpoint: ValueType{
+								ValueFrom: &ValueFromSource{
+									SecretKeyRef: &SecretKeyRef{
+										Name: "creds", Namespace: "default", Key: "endpoint",
+									},
+								},
+							},
+							Headers: []Header{
+								{
+									Name: "Authorization",
+									ValueType: ValueType{
+										ValueFrom: &ValueFromSource{
+											SecretKeyRef: &SecretKeyRef{
+												Name: "creds", Namespace: "default", Key: "token",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []SecretKeyRef{
+				{Name: "creds", Namespace: "default", Key: "endpoint"},
+				{Name: "creds", Namespace: "default", Key: "token"},
+			},
+		},
+		{
+			name: "additional output secret refs",
+			given: LogPipeline{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fan-out",
+				},
+				Spec: LogPipelineSpec{
+					Output: Output{
+						HTTP: &HTTPOutput{
+							Host: ValueType{
+								ValueFrom: &ValueFromSource{
+									SecretKeyRef: &SecretKeyRef{
+										Name: "creds", Namespace: "default", Key: "host",
+									},
+								},
+							},
+						},
+					},
+					AdditionalOutputs: []NamedOutput{
+						{
+							Name: "archive",
+							Output: Output{
+								Otlp: &OtlpOutput{
+									Endpoint: ValueType{
+										ValueFrom: &ValueFromSource{
+											SecretKeyRef: &SecretKeyRef{
+												Name: "archive-creds", Namespace: "default", Key: "endpoint",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []SecretKeyRef{
+				{Name: "creds", Namespace: "default", Key: "host"},
+				{Name: "archive-creds", Namespace: "default", Key: "endpoint"},
+			},
+		},
 		{
 			name: "output secret refs and variables",
 			given: LogPipeline{
@@ -226,6 +341,60 @@ func TestTracePipeline_GetSecretRefs(t *testing.T) {
 				{Name: "secret-3", Namespace: "default", Key: "myheader"},
 			},
 		},
+		{
+			name:         "oauth2",
+			pipelineName: "test-pipeline",
+			given: OtlpOutput{
+				Authentication: &AuthenticationOptions{
+					OAuth2: &OAuth2Options{
+						TokenURL: ValueType{
+							Value: "https://auth.example.com/token",
+						},
+						ClientID: ValueType{
+							ValueFrom: &ValueFromSource{
+								SecretKeyRef: &SecretKeyRef{
+									Name:      "secret-1",
+									Namespace: "default",
+									Key:       "client-id",
+								}},
+						},
+						ClientSecret: ValueType{
+							ValueFrom: &ValueFromSource{
+								SecretKeyRef: &SecretKeyRef{
+									Name:      "secret-1",
+									Namespace: "default",
+									Key:       "client-secret",
+								}},
+						},
+					},
+				},
+			},
+
+			expected: []SecretKeyRef{
+				{Name: "secret-1", Namespace: "default", Key: "client-id"},
+				{Name: "secret-1", Namespace: "default", Key: "client-secret"},
+			},
+		},
+		{
+			name:         "bearer token",
+			pipelineName: "test-pipeline",
+			given: OtlpOutput{
+				Authentication: &AuthenticationOptions{
+					BearerToken: &ValueType{
+						ValueFrom: &ValueFromSource{
+							SecretKeyRef: &SecretKeyRef{
+								Name:      "secret-1",
+								Namespace: "default",
+								Key:       "token",
+							}},
+					},
+				},
+			},
+
+			expected: []SecretKeyRef{
+				{Name: "secret-1", Namespace: "default", Key: "token"},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -238,6 +407,90 @@ func TestTracePipeline_GetSecretRefs(t *testing.T) {
 	}
 }
 
+func TestTracePipeline_GetSecretRefsWithAdditionalOutputs(t *testing.T) {
+	sut := TracePipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline"},
+		Spec: TracePipelineSpec{
+			Output: TracePipelineOutput{
+				Otlp: &OtlpOutput{
+					Endpoint: ValueType{
+						ValueFrom: &ValueFromSource{
+							SecretKeyRef: &SecretKeyRef{Name: "secret-1", Key: "endpoint"},
+						},
+					},
+				},
+			},
+			AdditionalOutputs: []NamedTracePipelineOutput{
+				{
+					Name: "archive",
+					TracePipelineOutput: TracePipelineOutput{
+						Otlp: &OtlpOutput{
+							Endpoint: ValueType{
+								ValueFrom: &ValueFromSource{
+									SecretKeyRef: &SecretKeyRef{Name: "secret-2", Key: "endpoint"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	require.ElementsMatch(t, []SecretKeyRef{
+		{Name: "secret-1", Key: "endpoint"},
+		{Name: "secret-2", Key: "endpoint"},
+	}, sut.GetSecretRefs())
+}
+
+func TestTracePipeline_GetSecretRefsWithZipkinOutput(t *testing.T) {
+	sut := TracePipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline"},
+		Spec: TracePipelineSpec{
+			Output: TracePipelineOutput{
+				Zipkin: &ZipkinOutput{
+					Endpoint: ValueType{
+						ValueFrom: &ValueFromSource{
+							SecretKeyRef: &SecretKeyRef{Name: "secret-1", Key: "endpoint"},
+						},
+					},
+					Authentication: &AuthenticationOptions{
+						Basic: &BasicAuthOptions{
+							User: ValueType{
+								ValueFrom: &ValueFromSource{
+									SecretKeyRef: &SecretKeyRef{Name: "secret-2", Key: "user"},
+								},
+							},
+							Password: ValueType{
+								ValueFrom: &ValueFromSource{
+									SecretKeyRef: &SecretKeyRef{Name: "secret-2", Key: "password"},
+								},
+							},
+						},
+					},
+					Headers: []Header{
+						{
+							Name: "Authorization",
+							ValueType: ValueType{
+								ValueFrom: &ValueFromSource{
+									SecretKeyRef: &SecretKeyRef{Name: "secret-3", Key: "token"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	require.ElementsMatch(t, []SecretKeyRef{
+		{Name: "secret-1", Key: "endpoint"},
+		{Name: "secret-2", Key: "user"},
+		{Name: "secret-2", Key: "password"},
+		{Name: "secret-3", Key: "token"},
+	}, sut.GetSecretRefs())
+}
+
 func TestMetricPipeline_GetSecretRefs(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -322,3 +575,27 @@ func TestMetricPipeline_GetSecretRefs(t *testing.T) {
 		})
 	}
 }
+
+func TestMetricPipeline_GetSecretRefs_PrometheusOutputs(t *testing.T) {
+	sut := MetricPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline"},
+		Spec: MetricPipelineSpec{
+			Output: MetricPipelineOutput{
+				PrometheusRemoteWrite: &PrometheusRemoteWriteOutput{
+					Endpoint: ValueType{ValueFrom: &ValueFromSource{SecretKeyRef: &SecretKeyRef{Name: "secret-1", Namespace: "default", Key: "endpoint"}}},
+					Authentication: &AuthenticationOptions{
+						BearerToken: &ValueType{ValueFrom: &ValueFromSource{SecretKeyRef: &SecretKeyRef{Name: "secret-2", Namespace: "default", Key: "token"}}},
+					},
+				},
+			},
+			AdditionalOutputs: []NamedMetricPipelineOutput{
+				{Name: "scrape", MetricPipelineOutput: MetricPipelineOutput{Prometheus: &PrometheusOutput{Port: 8889}}},
+			},
+		},
+	}
+
+	require.ElementsMatch(t, []SecretKeyRef{
+		{Name: "secret-1", Namespace: "default", Key: "endpoint"},
+		{Name: "secret-2", Namespace: "default", Key: "token"},
+	}, sut.GetSecretRefs())
+}
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -44,6 +45,18 @@ func (skr *SecretKeyRef) NamespacedName() types.NamespacedName {
 	return types.NamespacedName{Name: skr.Name, Namespace: skr.Namespace}
 }
 
+// NamespaceSelector selects the Namespaces from which telemetry data is collected. If `include` is defined, only the listed Namespaces are selected.
+// Otherwise, all Namespaces except the ones listed in `exclude` are selected. System Namespaces are only selected if `system` is `true` or if they are listed in `include`.
+// +kubebuilder:validation:XValidation:rule="!(has(self.include) && has(self.exclude))",message="only one of include or exclude can be defined"
+type NamespaceSelector struct {
+	// Selects only the specified Namespaces.
+	Include []string `json:"include,omitempty"`
+	// Selects all Namespaces except the specified ones.
+	Exclude []string `json:"exclude,omitempty"`
+	// Set to `true` if selecting all Namespaces must also include the system Namespaces like kube-system, istio-system, and kyma-system.
+	System bool `json:"system,omitempty"`
+}
+
 type LogPipelineValidationConfig struct {
 	DeniedOutPutPlugins []string
 	DeniedFilterPlugins []string
@@ -83,11 +96,55 @@ type OtlpOutput struct {
 	Headers []Header `json:"headers,omitempty"`
 	// Defines TLS options for the OTLP output.
 	TLS *OtlpTLS `json:"tls,omitempty"`
+	// Defines the compression of the exported data. Default is gzip.
+	// +kubebuilder:validation:Enum=gzip;snappy;zstd;none
+	Compression string `json:"compression,omitempty"`
+	// Defines the timeout of a single export request, for example `10s`. Default is 5s.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Defines how exports that failed with a retryable error are retried.
+	Retry *OtlpRetry `json:"retry,omitempty"`
+	// Defines the queue that buffers the data while the backend is not reachable.
+	Queue *OtlpQueue `json:"queue,omitempty"`
+}
+
+type OtlpRetry struct {
+	// Defines the time to wait after the first failure before retrying. Default is 5s.
+	InitialInterval *metav1.Duration `json:"initialInterval,omitempty"`
+	// Defines the upper bound of the time to wait between consecutive retries. Default is 30s.
+	MaxInterval *metav1.Duration `json:"maxInterval,omitempty"`
+	// Defines the maximum time spent on trying to send a batch. After that, the data is dropped. Default is 300s.
+	MaxElapsedTime *metav1.Duration `json:"maxElapsedTime,omitempty"`
 }
 
+type OtlpQueue struct {
+	// Defines the maximum number of batches kept in the queue. If not set, the queue capacity of the gateway is shared among all outputs.
+	// +kubebuilder:validation:Minimum=1
+	Size int `json:"size,omitempty"`
+	// If enabled, the queue is stored on a volume of the gateway instead of in memory, so that the queued data survives a restart of the gateway.
+	Persistent bool `json:"persistent,omitempty"`
+}
+
+// HasPersistentQueue returns true if the queue of the output is stored on a volume.
+func (o *OtlpOutput) HasPersistentQueue() bool {
+	return o != nil && o.Queue != nil && o.Queue.Persistent
+}
+
+// GetSecretRefs returns the Secret references of the output.
+func (o *OtlpOutput) GetSecretRefs() []SecretKeyRef {
+	if o == nil {
+		return nil
+	}
+	return getRefsInOtlpOutput(o)
+}
+
+// +kubebuilder:validation:XValidation:rule="(has(self.basic) ? 1 : 0) + (has(self.oauth2) ? 1 : 0) + (has(self.bearerToken) ? 1 : 0) <= 1",message="only one authentication method can be defined"
 type AuthenticationOptions struct {
 	// Activates `Basic` authentication for the destination providing relevant Secrets.
 	Basic *BasicAuthOptions `json:"basic,omitempty"`
+	// Activates OAuth2 authentication with the client credentials flow. The access token is requested from the token URL and refreshed before it expires.
+	OAuth2 *OAuth2Options `json:"oauth2,omitempty"`
+	// Activates authentication with a static bearer token, which is sent in the `Authorization` header. To rotate the token, update the referenced Secret.
+	BearerToken *ValueType `json:"bearerToken,omitempty"`
 }
 
 type BasicAuthOptions struct {
@@ -100,5 +157,44 @@ type BasicAuthOptions struct {
 }
 
 func (b *BasicAuthOptions) IsDefined() bool {
-	return b.User.IsDefined() && b.Password.IsDefined()
+	return b != nil && b.User.IsDefined() && b.Password.IsDefined()
+}
+
+type OAuth2Options struct {
+	// Contains the URL of the token endpoint or a Secret reference.
+	// +kubebuilder:validation:Required
+	TokenURL ValueType `json:"tokenURL"`
+	// Contains the client ID or a Secret reference.
+	// +kubebuilder:validation:Required
+	ClientID ValueType `json:"clientID"`
+	// Contains the client secret or a Secret reference.
+	// +kubebuilder:validation:Required
+	ClientSecret ValueType `json:"clientSecret"`
+	// Defines the scopes that are requested with the access token.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+func (o *OAuth2Options) IsDefined() bool {
+	return o != nil && o.TokenURL.IsDefined() && o.ClientID.IsDefined() && o.ClientSecret.IsDefined()
+}
+
+// DefaultOutputName is the name under which the output defined in `spec.output` is reported.
+const DefaultOutputName = "default"
+
+// OutputStatus shows the state of a single output of a pipeline.
+type OutputStatus struct {
+	// Name of the output. The output defined in `spec.output` is reported as `default`.
+	Name string `json:"name"`
+	// Reason for the state of the output. Is `OutputReady` if the output is configured completely, `ReferencedSecretMissing` if the output references a Secret that does not exist, or `PrometheusPortConflict` if the port of a Prometheus output is already used by another MetricPipeline.
+	Reason string `json:"reason"`
+}
+
+// OutputID returns an identifier of a pipeline output that is unique among the outputs of all pipelines of the same kind.
+// The default output is identified by the pipeline name only, so that its identifiers are stable when additional outputs are added.
+// Pipeline and output names cannot contain an underscore, so the separator cannot cause collisions.
+func OutputID(pipelineName, outputName string) string {
+	if outputName == "" || outputName == DefaultOutputName {
+		return pipelineName
+	}
+	return pipelineName + "_" + outputName
 }
@@ -22,22 +22,218 @@ import (
 
 // TracePipelineSpec defines the desired state of TracePipeline
 type TracePipelineSpec struct {
+	// Determines which pipelines are deployed if more pipelines exist than the maximum number of TracePipelines. Pipelines with a higher priority are deployed first; pipelines with the same priority are deployed in the order of their creation. Default is 0.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+	// If true, the pipeline is removed from the gateway configuration and no traces are shipped to its outputs. The pipeline keeps its slot within the maximum number of TracePipelines, and its referenced Secrets are still validated and synced into the gateway. If all TracePipelines are suspended, the gateway is removed and stops receiving traces. Default is false.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+	// Configures which traces are accepted by the pipeline.
+	Input TracePipelineInput `json:"input,omitempty"`
 	// Defines a destination for shipping trace data. Only one can be defined per pipeline.
 	Output TracePipelineOutput `json:"output"`
+	// Defines further destinations for shipping trace data. Every output receives the same traces.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=3
+	AdditionalOutputs []NamedTracePipelineOutput `json:"additionalOutputs,omitempty"`
+	// Configures which traces are shipped to the output. If not defined, all traces are shipped.
+	Sampling *TracePipelineSampling `json:"sampling,omitempty"`
+	// Configures rules to drop or keep spans, and the built-in filter for spans of Kyma-internal traffic.
+	Filters *TracePipelineFilters `json:"filters,omitempty"`
+	// Configures metrics that are derived from the spans of the pipeline. The metrics are sent to the metric gateway and shipped by all MetricPipelines.
+	// The metrics are derived from all spans selected by the input, before sampling is applied.
+	Metrics *TracePipelineMetrics `json:"metrics,omitempty"`
+}
+
+// TracePipelineFilters defines which spans are dropped before they are shipped to the outputs.
+type TracePipelineFilters struct {
+	// If enabled, spans of Kyma-internal traffic, such as health checks and the communication of the telemetry components, are not dropped. Use it for debugging only. Default is false.
+	DisableDropNoisySpans bool `json:"disableDropNoisySpans,omitempty"`
+	// If defined, only spans that match at least one of the rules are kept. All other spans are dropped.
+	Keep []SpanFilterRule `json:"keep,omitempty"`
+	// Spans that match at least one of the rules are dropped.
+	Drop []SpanFilterRule `json:"drop,omitempty"`
+}
+
+// SpanFilterRule matches spans. A span matches the rule if it matches all defined criteria.
+// +kubebuilder:validation:MinProperties=1
+type SpanFilterRule struct {
+	// Matches spans emitted by Pods in one of the given Namespaces.
+	// +kubebuilder:validation:MinItems=1
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Matches spans of one of the given services, as in the `service.name` resource attribute.
+	// +kubebuilder:validation:MinItems=1
+	ServiceNames []string `json:"serviceNames,omitempty"`
+	// Matches spans whose `http.url` attribute matches the given regular expression.
+	// +kubebuilder:validation:MinLength=1
+	URLPattern string `json:"urlPattern,omitempty"`
+	// Matches spans whose `user_agent` attribute matches the given regular expression.
+	// +kubebuilder:validation:MinLength=1
+	UserAgentPattern string `json:"userAgentPattern,omitempty"`
+	// Matches spans with the given span attributes.
+	// +kubebuilder:validation:MinItems=1
+	Attributes []AttributeMatcher `json:"attributes,omitempty"`
+	// Matches spans with the given resource attributes.
+	// +kubebuilder:validation:MinItems=1
+	ResourceAttributes []AttributeMatcher `json:"resourceAttributes,omitempty"`
+}
+
+// AttributeMatcher matches an attribute either by its exact value or by a regular expression.
+// +kubebuilder:validation:XValidation:rule="has(self.value) != has(self.pattern)",message="either value or pattern must be defined"
+type AttributeMatcher struct {
+	// Key of the attribute.
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+	// Value that the attribute must be equal to.
+	Value string `json:"value,omitempty"`
+	// Regular expression that the attribute must match.
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// TracePipelineMetrics defines which metrics are derived from spans.
+type TracePipelineMetrics struct {
+	// Configures request rate, error, and duration (RED) metrics per service and span name.
+	SpanMetrics SpanMetrics `json:"spanMetrics,omitempty"`
+	// Configures metrics that describe the requests between services, which form the edges of a service graph.
+	ServiceGraph ServiceGraph `json:"serviceGraph,omitempty"`
+}
+
+// SpanMetrics defines the request rate, error, and duration metrics that are derived from spans.
+type SpanMetrics struct {
+	// If enabled, the `calls` and `duration` metrics are derived from the spans. Default is false.
+	Enabled bool `json:"enabled,omitempty"`
+	// Span or resource attributes that are added as additional attributes to the metrics, for example `http.method`.
+	Dimensions []string `json:"dimensions,omitempty"`
+}
+
+// ServiceGraph defines the service graph metrics that are derived from spans.
+type ServiceGraph struct {
+	// If enabled, the `traces_service_graph_request` metrics are derived from pairs of client and server spans. Default is false.
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// TracePipelineInput defines the input configuration section.
+type TracePipelineInput struct {
+	// Describes whether traces from specific Namespaces are selected. The Namespace of a span is the Namespace of the Pod that emitted it. If not defined, traces from all Namespaces are selected.
+	Namespaces *NamespaceSelector `json:"namespaces,omitempty"`
+}
+
+// TracePipelineSampling defines head-based and tail-based sampling of traces.
+type TracePipelineSampling struct {
+	// Configures probabilistic head sampling. If tail sampling is also configured, the percentage is applied to all traces that are not kept by any tail sampling policy.
+	Probabilistic *ProbabilisticSampling `json:"probabilistic,omitempty"`
+	// Configures tail sampling. A trace is kept if at least one of the defined policies matches.
+	Tail *TailSampling `json:"tail,omitempty"`
+}
+
+// ProbabilisticSampling defines the percentage of traces to keep based on the trace ID.
+type ProbabilisticSampling struct {
+	// Percentage of traces to keep. Must be between 0 and 100.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percentage int32 `json:"percentage"`
+}
+
+// TailSampling defines the policies that decide whether a complete trace is kept.
+type TailSampling struct {
+	// Keeps traces that contain at least one span with status code `ERROR`.
+	KeepErrors bool `json:"keepErrors,omitempty"`
+	// Keeps traces whose duration exceeds the given threshold.
+	Latency *LatencySamplingPolicy `json:"latency,omitempty"`
+	// Keeps traces that contain at least one span with a matching attribute.
+	Attributes []AttributeSamplingPolicy `json:"attributes,omitempty"`
+	// Keeps traces up to the given rate.
+	RateLimit *RateLimitSamplingPolicy `json:"rateLimit,omitempty"`
+}
+
+// LatencySamplingPolicy keeps traces that take longer than the threshold.
+type LatencySamplingPolicy struct {
+	// Minimum duration of a trace in milliseconds to be kept.
+	// +kubebuilder:validation:Minimum=1
+	ThresholdMs int64 `json:"thresholdMs"`
+}
+
+// AttributeSamplingPolicy keeps traces that contain a span with the given attribute value.
+type AttributeSamplingPolicy struct {
+	// Key of the span or resource attribute.
+	Key string `json:"key"`
+	// Values of the attribute to match. At least one value must be defined.
+	// +kubebuilder:validation:MinItems=1
+	Values []string `json:"values"`
+}
+
+// RateLimitSamplingPolicy keeps traces until the given rate of spans is reached.
+type RateLimitSamplingPolicy struct {
+	// Maximum number of spans per second to keep.
+	// +kubebuilder:validation:Minimum=1
+	SpansPerSecond int64 `json:"spansPerSecond"`
 }
 
 // TracePipelineOutput defines the output configuration section.
+// +kubebuilder:validation:XValidation:rule="has(self.otlp) != has(self.zipkin)",message="exactly one of otlp or zipkin must be defined"
 type TracePipelineOutput struct {
 	// Configures the underlying Otel Collector with an [OTLP exporter](https://github.com/open-telemetry/opentelemetry-collector/blob/main/exporter/otlpexporter/README.md). If you switch `protocol`to `http`, an [OTLP HTTP exporter](https://github.com/open-telemetry/opentelemetry-collector/tree/main/exporter/otlphttpexporter) is used.
-	Otlp *OtlpOutput `json:"otlp"`
+	Otlp *OtlpOutput `json:"otlp,omitempty"`
+	// Configures the underlying Otel Collector with a [Zipkin exporter](https://github.com/open-telemetry/opentelemetry-collector-contrib/tree/main/exporter/zipkinexporter), for backends that only ingest the Zipkin v2 format.
+	Zipkin *ZipkinOutput `json:"zipkin,omitempty"`
+}
+
+// ZipkinOutput defines a backend that receives the spans in the Zipkin v2 format.
+type ZipkinOutput struct {
+	// Defines the URL of the Zipkin spans endpoint, for example, `http://zipkin.example.com:9411/api/v2/spans`.
+	// +kubebuilder:validation:Required
+	Endpoint ValueType `json:"endpoint"`
+	// Defines the encoding of the spans. Default is json.
+	// +kubebuilder:default:=json
+	// +kubebuilder:validation:Enum=json;proto
+	Format string `json:"format,omitempty"`
+	// Defines authentication options for the Zipkin output.
+	Authentication *AuthenticationOptions `json:"authentication,omitempty"`
+	// Defines custom headers to be added to the outgoing HTTP requests.
+	Headers []Header `json:"headers,omitempty"`
+	// Defines TLS options for the Zipkin output.
+	TLS *OtlpTLS `json:"tls,omitempty"`
+	// Defines the timeout of a single export request, for example `10s`. Default is 5s.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Defines how exports that failed with a retryable error are retried.
+	Retry *OtlpRetry `json:"retry,omitempty"`
+	// Defines the queue that buffers the data while the backend is not reachable.
+	Queue *OtlpQueue `json:"queue,omitempty"`
+}
+
+// HasPersistentQueue returns true if the queue of the output is stored on a volume.
+func (o *TracePipelineOutput) HasPersistentQueue() bool {
+	if o.Zipkin != nil {
+		return o.Zipkin.Queue != nil && o.Zipkin.Queue.Persistent
+	}
+	return o.Otlp.HasPersistentQueue()
+}
+
+// NamedTracePipelineOutput defines an additional output of a TracePipeline.
+type NamedTracePipelineOutput struct {
+	// Name of the output. Must be unique within the pipeline and must not be `default`.
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:XValidation:rule="self != 'default'",message="the name default is reserved for spec.output"
+	Name string `json:"name"`
+
+	TracePipelineOutput `json:",inline"`
+}
+
+// AllOutputs returns the output defined in `spec.output`, named `default`, followed by all additional outputs.
+func (tps *TracePipelineSpec) AllOutputs() []NamedTracePipelineOutput {
+	outputs := []NamedTracePipelineOutput{{Name: DefaultOutputName, TracePipelineOutput: tps.Output}}
+	return append(outputs, tps.AdditionalOutputs...)
 }
 
 type TracePipelineConditionType string
 
 // These are the valid statuses of TracePipeline.
 const (
-	TracePipelinePending TracePipelineConditionType = "Pending"
-	TracePipelineRunning TracePipelineConditionType = "Running"
+	TracePipelinePending   TracePipelineConditionType = "Pending"
+	TracePipelineRunning   TracePipelineConditionType = "Running"
+	TracePipelineSuspended TracePipelineConditionType = "Suspended"
 )
 
 // TracePipelineCondition contains details for the current condition of this LogPipeline.
@@ -46,7 +242,10 @@ type TracePipelineCondition struct {
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 	// Reason of last transition.
 	Reason string `json:"reason,omitempty"`
-	// The possible transition types are:<br>- `Running`: The instance is ready and usable.<br>- `Pending`: The pipeline is being activated.
+	// Human-readable details of the last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// The possible transition types are:<br>- `Running`: The instance is ready and usable.<br>- `Pending`: The pipeline is being activated.<br>- `Suspended`: The pipeline is suspended with `spec.suspend`.
 	Type TracePipelineConditionType `json:"type,omitempty"`
 }
 
@@ -54,6 +253,12 @@ type TracePipelineCondition struct {
 type TracePipelineStatus struct {
 	// An array of conditions describing the status of the pipeline.
 	Conditions []TracePipelineCondition `json:"conditions,omitempty"`
+	// Shows the state of each output of the pipeline.
+	Outputs []OutputStatus `json:"outputs,omitempty"`
+	// Conditions describing the health of the data flow of the pipeline, evaluated from the self-monitoring metrics of the gateway. The condition of type `TelemetryFlowHealthy` has one of the reasons `FlowHealthy`, `BufferFillingUp`, `SomeDataDropped`, `AllDataDropped`, or `FlowHealthProbingFailed`.
+	// +listType=map
+	// +listMapKey=type
+	HealthConditions []metav1.Condition `json:"healthConditions,omitempty"`
 }
 
 func NewTracePipelineCondition(reason string, condType TracePipelineConditionType) *TracePipelineCondition {
@@ -79,7 +284,7 @@ func (tps *TracePipelineStatus) HasCondition(condition TracePipelineConditionTyp
 
 func (tps *TracePipelineStatus) SetCondition(cond TracePipelineCondition) {
 	currentCond := tps.GetCondition(cond.Type)
-	if currentCond != nil && currentCond.Reason == cond.Reason {
+	if currentCond != nil && currentCond.Reason == cond.Reason && currentCond.Message == cond.Message {
 		return
 	}
 	if currentCond != nil {
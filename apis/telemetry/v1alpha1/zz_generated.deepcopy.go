@@ -22,6 +22,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -30,6 +31,16 @@ func (in *ApplicationInput) DeepCopyInto(out *ApplicationInput) {
 	*out = *in
 	in.Namespaces.DeepCopyInto(&out.Namespaces)
 	in.Containers.DeepCopyInto(&out.Containers)
+	if in.Multiline != nil {
+		in, out := &in.Multiline, &out.Multiline
+		*out = new(MultilineInput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parse != nil {
+		in, out := &in.Parse, &out.Parse
+		*out = new(ParseInput)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationInput.
@@ -42,6 +53,41 @@ func (in *ApplicationInput) DeepCopy() *ApplicationInput {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttributeMatcher) DeepCopyInto(out *AttributeMatcher) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttributeMatcher.
+func (in *AttributeMatcher) DeepCopy() *AttributeMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(AttributeMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttributeSamplingPolicy) DeepCopyInto(out *AttributeSamplingPolicy) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttributeSamplingPolicy.
+func (in *AttributeSamplingPolicy) DeepCopy() *AttributeSamplingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AttributeSamplingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthenticationOptions) DeepCopyInto(out *AuthenticationOptions) {
 	*out = *in
@@ -50,6 +96,16 @@ func (in *AuthenticationOptions) DeepCopyInto(out *AuthenticationOptions) {
 		*out = new(BasicAuthOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(OAuth2Options)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BearerToken != nil {
+		in, out := &in.BearerToken, &out.BearerToken
+		*out = new(ValueType)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationOptions.
@@ -79,6 +135,55 @@ func (in *BasicAuthOptions) DeepCopy() *BasicAuthOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomMultiline) DeepCopyInto(out *CustomMultiline) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomMultiline.
+func (in *CustomMultiline) DeepCopy() *CustomMultiline {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomMultiline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiagnosticMetrics) DeepCopyInto(out *DiagnosticMetrics) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiagnosticMetrics.
+func (in *DiagnosticMetrics) DeepCopy() *DiagnosticMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(DiagnosticMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchOutput) DeepCopyInto(out *ElasticsearchOutput) {
+	*out = *in
+	in.Host.DeepCopyInto(&out.Host)
+	in.User.DeepCopyInto(&out.User)
+	in.Password.DeepCopyInto(&out.Password)
+	in.TLSConfig.DeepCopyInto(&out.TLSConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchOutput.
+func (in *ElasticsearchOutput) DeepCopy() *ElasticsearchOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileMount) DeepCopyInto(out *FileMount) {
 	*out = *in
@@ -210,6 +315,21 @@ func (in *InputNamespaces) DeepCopy() *InputNamespaces {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatencySamplingPolicy) DeepCopyInto(out *LatencySamplingPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatencySamplingPolicy.
+func (in *LatencySamplingPolicy) DeepCopy() *LatencySamplingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(LatencySamplingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LogParser) DeepCopyInto(out *LogParser) {
 	*out = *in
@@ -407,6 +527,13 @@ func (in *LogPipelineSpec) DeepCopyInto(out *LogPipelineSpec) {
 		copy(*out, *in)
 	}
 	in.Output.DeepCopyInto(&out.Output)
+	if in.AdditionalOutputs != nil {
+		in, out := &in.AdditionalOutputs, &out.AdditionalOutputs
+		*out = make([]NamedOutput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Files != nil {
 		in, out := &in.Files, &out.Files
 		*out = make([]FileMount, len(*in))
@@ -441,6 +568,11 @@ func (in *LogPipelineStatus) DeepCopyInto(out *LogPipelineStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]OutputStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogPipelineStatus.
@@ -536,9 +668,10 @@ func (in *MetricPipeline) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricPipelineApplicationInput) DeepCopyInto(out *MetricPipelineApplicationInput) {
 	*out = *in
-	out.Prometheus = in.Prometheus
-	out.Runtime = in.Runtime
-	out.Istio = in.Istio
+	in.Prometheus.DeepCopyInto(&out.Prometheus)
+	in.Runtime.DeepCopyInto(&out.Runtime)
+	in.Istio.DeepCopyInto(&out.Istio)
+	in.Cluster.DeepCopyInto(&out.Cluster)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineApplicationInput.
@@ -551,6 +684,57 @@ func (in *MetricPipelineApplicationInput) DeepCopy() *MetricPipelineApplicationI
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricPipelineAttributeRename) DeepCopyInto(out *MetricPipelineAttributeRename) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineAttributeRename.
+func (in *MetricPipelineAttributeRename) DeepCopy() *MetricPipelineAttributeRename {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricPipelineAttributeRename)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricPipelineClusterEvents) DeepCopyInto(out *MetricPipelineClusterEvents) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineClusterEvents.
+func (in *MetricPipelineClusterEvents) DeepCopy() *MetricPipelineClusterEvents {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricPipelineClusterEvents)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricPipelineClusterInput) DeepCopyInto(out *MetricPipelineClusterInput) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = new(NamespaceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Events = in.Events
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineClusterInput.
+func (in *MetricPipelineClusterInput) DeepCopy() *MetricPipelineClusterInput {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricPipelineClusterInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricPipelineCondition) DeepCopyInto(out *MetricPipelineCondition) {
 	*out = *in
@@ -570,6 +754,12 @@ func (in *MetricPipelineCondition) DeepCopy() *MetricPipelineCondition {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricPipelineContainerRuntimeInput) DeepCopyInto(out *MetricPipelineContainerRuntimeInput) {
 	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = new(NamespaceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Resources = in.Resources
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineContainerRuntimeInput.
@@ -582,10 +772,45 @@ func (in *MetricPipelineContainerRuntimeInput) DeepCopy() *MetricPipelineContain
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricPipelineFilters) DeepCopyInto(out *MetricPipelineFilters) {
+	*out = *in
+	if in.DropMetricNames != nil {
+		in, out := &in.DropMetricNames, &out.DropMetricNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DropResourceAttributes != nil {
+		in, out := &in.DropResourceAttributes, &out.DropResourceAttributes
+		*out = make([]MetricPipelineResourceAttribute, len(*in))
+		copy(*out, *in)
+	}
+	if in.KeepNamespaces != nil {
+		in, out := &in.KeepNamespaces, &out.KeepNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineFilters.
+func (in *MetricPipelineFilters) DeepCopy() *MetricPipelineFilters {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricPipelineFilters)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricPipelineInput) DeepCopyInto(out *MetricPipelineInput) {
 	*out = *in
-	out.Application = in.Application
+	in.Application.DeepCopyInto(&out.Application)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineInput.
@@ -601,6 +826,11 @@ func (in *MetricPipelineInput) DeepCopy() *MetricPipelineInput {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricPipelineIstioInput) DeepCopyInto(out *MetricPipelineIstioInput) {
 	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = new(NamespaceSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineIstioInput.
@@ -653,6 +883,16 @@ func (in *MetricPipelineOutput) DeepCopyInto(out *MetricPipelineOutput) {
 		*out = new(OtlpOutput)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PrometheusRemoteWrite != nil {
+		in, out := &in.PrometheusRemoteWrite, &out.PrometheusRemoteWrite
+		*out = new(PrometheusRemoteWriteOutput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusOutput)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineOutput.
@@ -668,6 +908,29 @@ func (in *MetricPipelineOutput) DeepCopy() *MetricPipelineOutput {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricPipelinePrometheusInput) DeepCopyInto(out *MetricPipelinePrometheusInput) {
 	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = new(NamespaceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SampleLimit != nil {
+		in, out := &in.SampleLimit, &out.SampleLimit
+		*out = new(int)
+		**out = **in
+	}
+	out.DiagnosticMetrics = in.DiagnosticMetrics
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]PrometheusScrapeTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelinePrometheusInput.
@@ -681,128 +944,947 @@ func (in *MetricPipelinePrometheusInput) DeepCopy() *MetricPipelinePrometheusInp
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetricPipelineSpec) DeepCopyInto(out *MetricPipelineSpec) {
+func (in *MetricPipelineResourceAttribute) DeepCopyInto(out *MetricPipelineResourceAttribute) {
 	*out = *in
-	out.Input = in.Input
-	in.Output.DeepCopyInto(&out.Output)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineSpec.
-func (in *MetricPipelineSpec) DeepCopy() *MetricPipelineSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineResourceAttribute.
+func (in *MetricPipelineResourceAttribute) DeepCopy() *MetricPipelineResourceAttribute {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricPipelineSpec)
+	out := new(MetricPipelineResourceAttribute)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetricPipelineStatus) DeepCopyInto(out *MetricPipelineStatus) {
+func (in *MetricPipelineRuntimeResource) DeepCopyInto(out *MetricPipelineRuntimeResource) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]MetricPipelineCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineStatus.
-func (in *MetricPipelineStatus) DeepCopy() *MetricPipelineStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineRuntimeResource.
+func (in *MetricPipelineRuntimeResource) DeepCopy() *MetricPipelineRuntimeResource {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricPipelineStatus)
+	out := new(MetricPipelineRuntimeResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OtlpOutput) DeepCopyInto(out *OtlpOutput) {
+func (in *MetricPipelineRuntimeResources) DeepCopyInto(out *MetricPipelineRuntimeResources) {
 	*out = *in
-	in.Endpoint.DeepCopyInto(&out.Endpoint)
-	if in.Authentication != nil {
-		in, out := &in.Authentication, &out.Authentication
-		*out = new(AuthenticationOptions)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Headers != nil {
-		in, out := &in.Headers, &out.Headers
-		*out = make([]Header, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(OtlpTLS)
-		(*in).DeepCopyInto(*out)
-	}
+	out.Node = in.Node
+	out.Volume = in.Volume
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtlpOutput.
-func (in *OtlpOutput) DeepCopy() *OtlpOutput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineRuntimeResources.
+func (in *MetricPipelineRuntimeResources) DeepCopy() *MetricPipelineRuntimeResources {
 	if in == nil {
 		return nil
 	}
-	out := new(OtlpOutput)
+	out := new(MetricPipelineRuntimeResources)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OtlpTLS) DeepCopyInto(out *OtlpTLS) {
+func (in *MetricPipelineSpec) DeepCopyInto(out *MetricPipelineSpec) {
 	*out = *in
-	if in.CA != nil {
-		in, out := &in.CA, &out.CA
-		*out = new(ValueType)
-		(*in).DeepCopyInto(*out)
+	in.Input.DeepCopyInto(&out.Input)
+	in.Output.DeepCopyInto(&out.Output)
+	if in.AdditionalOutputs != nil {
+		in, out := &in.AdditionalOutputs, &out.AdditionalOutputs
+		*out = make([]NamedMetricPipelineOutput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Cert != nil {
-		in, out := &in.Cert, &out.Cert
-		*out = new(ValueType)
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = new(MetricPipelineFilters)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Key != nil {
-		in, out := &in.Key, &out.Key
-		*out = new(ValueType)
+	if in.Transforms != nil {
+		in, out := &in.Transforms, &out.Transforms
+		*out = new(MetricPipelineTransforms)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtlpTLS.
-func (in *OtlpTLS) DeepCopy() *OtlpTLS {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineSpec.
+func (in *MetricPipelineSpec) DeepCopy() *MetricPipelineSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OtlpTLS)
+	out := new(MetricPipelineSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Output) DeepCopyInto(out *Output) {
+func (in *MetricPipelineStatus) DeepCopyInto(out *MetricPipelineStatus) {
 	*out = *in
-	if in.HTTP != nil {
-		in, out := &in.HTTP, &out.HTTP
-		*out = new(HTTPOutput)
-		(*in).DeepCopyInto(*out)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]MetricPipelineCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Loki != nil {
-		in, out := &in.Loki, &out.Loki
-		*out = new(LokiOutput)
-		(*in).DeepCopyInto(*out)
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]OutputStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthConditions != nil {
+		in, out := &in.HealthConditions, &out.HealthConditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Output.
-func (in *Output) DeepCopy() *Output {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineStatus.
+func (in *MetricPipelineStatus) DeepCopy() *MetricPipelineStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Output)
+	out := new(MetricPipelineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricPipelineTransforms) DeepCopyInto(out *MetricPipelineTransforms) {
+	*out = *in
+	if in.RenameAttributes != nil {
+		in, out := &in.RenameAttributes, &out.RenameAttributes
+		*out = make([]MetricPipelineAttributeRename, len(*in))
+		copy(*out, *in)
+	}
+	if in.DropAttributes != nil {
+		in, out := &in.DropAttributes, &out.DropAttributes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Statements != nil {
+		in, out := &in.Statements, &out.Statements
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineTransforms.
+func (in *MetricPipelineTransforms) DeepCopy() *MetricPipelineTransforms {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricPipelineTransforms)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultilineInput) DeepCopyInto(out *MultilineInput) {
+	*out = *in
+	if in.Presets != nil {
+		in, out := &in.Presets, &out.Presets
+		*out = make([]MultilinePreset, len(*in))
+		copy(*out, *in)
+	}
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = new(CustomMultiline)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultilineInput.
+func (in *MultilineInput) DeepCopy() *MultilineInput {
+	if in == nil {
+		return nil
+	}
+	out := new(MultilineInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamedMetricPipelineOutput) DeepCopyInto(out *NamedMetricPipelineOutput) {
+	*out = *in
+	in.MetricPipelineOutput.DeepCopyInto(&out.MetricPipelineOutput)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedMetricPipelineOutput.
+func (in *NamedMetricPipelineOutput) DeepCopy() *NamedMetricPipelineOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(NamedMetricPipelineOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamedOutput) DeepCopyInto(out *NamedOutput) {
+	*out = *in
+	in.Output.DeepCopyInto(&out.Output)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedOutput.
+func (in *NamedOutput) DeepCopy() *NamedOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(NamedOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamedTracePipelineOutput) DeepCopyInto(out *NamedTracePipelineOutput) {
+	*out = *in
+	in.TracePipelineOutput.DeepCopyInto(&out.TracePipelineOutput)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedTracePipelineOutput.
+func (in *NamedTracePipelineOutput) DeepCopy() *NamedTracePipelineOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(NamedTracePipelineOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceSelector) DeepCopyInto(out *NamespaceSelector) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSelector.
+func (in *NamespaceSelector) DeepCopy() *NamespaceSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedApplicationInput) DeepCopyInto(out *NamespacedApplicationInput) {
+	*out = *in
+	in.Containers.DeepCopyInto(&out.Containers)
+	if in.Multiline != nil {
+		in, out := &in.Multiline, &out.Multiline
+		*out = new(NamespacedMultilineInput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parse != nil {
+		in, out := &in.Parse, &out.Parse
+		*out = new(NamespacedParseInput)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedApplicationInput.
+func (in *NamespacedApplicationInput) DeepCopy() *NamespacedApplicationInput {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedApplicationInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedInputContainers) DeepCopyInto(out *NamespacedInputContainers) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]ContainerName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]ContainerName, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedInputContainers.
+func (in *NamespacedInputContainers) DeepCopy() *NamespacedInputContainers {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedInputContainers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedLogPipeline) DeepCopyInto(out *NamespacedLogPipeline) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedLogPipeline.
+func (in *NamespacedLogPipeline) DeepCopy() *NamespacedLogPipeline {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedLogPipeline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespacedLogPipeline) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedLogPipelineInput) DeepCopyInto(out *NamespacedLogPipelineInput) {
+	*out = *in
+	in.Application.DeepCopyInto(&out.Application)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedLogPipelineInput.
+func (in *NamespacedLogPipelineInput) DeepCopy() *NamespacedLogPipelineInput {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedLogPipelineInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedLogPipelineList) DeepCopyInto(out *NamespacedLogPipelineList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespacedLogPipeline, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedLogPipelineList.
+func (in *NamespacedLogPipelineList) DeepCopy() *NamespacedLogPipelineList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedLogPipelineList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespacedLogPipelineList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedLogPipelineOutput) DeepCopyInto(out *NamespacedLogPipelineOutput) {
+	*out = *in
+	if in.Otlp != nil {
+		in, out := &in.Otlp, &out.Otlp
+		*out = new(OtlpOutput)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedLogPipelineOutput.
+func (in *NamespacedLogPipelineOutput) DeepCopy() *NamespacedLogPipelineOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedLogPipelineOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedLogPipelineSpec) DeepCopyInto(out *NamespacedLogPipelineSpec) {
+	*out = *in
+	in.Input.DeepCopyInto(&out.Input)
+	in.Output.DeepCopyInto(&out.Output)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedLogPipelineSpec.
+func (in *NamespacedLogPipelineSpec) DeepCopy() *NamespacedLogPipelineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedLogPipelineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedMultilineInput) DeepCopyInto(out *NamespacedMultilineInput) {
+	*out = *in
+	if in.Presets != nil {
+		in, out := &in.Presets, &out.Presets
+		*out = make([]MultilinePreset, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedMultilineInput.
+func (in *NamespacedMultilineInput) DeepCopy() *NamespacedMultilineInput {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedMultilineInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedParseInput) DeepCopyInto(out *NamespacedParseInput) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedParseInput.
+func (in *NamespacedParseInput) DeepCopy() *NamespacedParseInput {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedParseInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedTracePipeline) DeepCopyInto(out *NamespacedTracePipeline) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedTracePipeline.
+func (in *NamespacedTracePipeline) DeepCopy() *NamespacedTracePipeline {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedTracePipeline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespacedTracePipeline) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedTracePipelineList) DeepCopyInto(out *NamespacedTracePipelineList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespacedTracePipeline, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedTracePipelineList.
+func (in *NamespacedTracePipelineList) DeepCopy() *NamespacedTracePipelineList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedTracePipelineList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespacedTracePipelineList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedTracePipelineSpec) DeepCopyInto(out *NamespacedTracePipelineSpec) {
+	*out = *in
+	in.Output.DeepCopyInto(&out.Output)
+	if in.AdditionalOutputs != nil {
+		in, out := &in.AdditionalOutputs, &out.AdditionalOutputs
+		*out = make([]NamedTracePipelineOutput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sampling != nil {
+		in, out := &in.Sampling, &out.Sampling
+		*out = new(TracePipelineSampling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = new(TracePipelineFilters)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedTracePipelineSpec.
+func (in *NamespacedTracePipelineSpec) DeepCopy() *NamespacedTracePipelineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedTracePipelineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NativeLokiOutput) DeepCopyInto(out *NativeLokiOutput) {
+	*out = *in
+	in.Host.DeepCopyInto(&out.Host)
+	in.TenantID.DeepCopyInto(&out.TenantID)
+	in.User.DeepCopyInto(&out.User)
+	in.Password.DeepCopyInto(&out.Password)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LabelKeys != nil {
+		in, out := &in.LabelKeys, &out.LabelKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemoveKeys != nil {
+		in, out := &in.RemoveKeys, &out.RemoveKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.TLSConfig.DeepCopyInto(&out.TLSConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NativeLokiOutput.
+func (in *NativeLokiOutput) DeepCopy() *NativeLokiOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(NativeLokiOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2Options) DeepCopyInto(out *OAuth2Options) {
+	*out = *in
+	in.TokenURL.DeepCopyInto(&out.TokenURL)
+	in.ClientID.DeepCopyInto(&out.ClientID)
+	in.ClientSecret.DeepCopyInto(&out.ClientSecret)
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2Options.
+func (in *OAuth2Options) DeepCopy() *OAuth2Options {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2Options)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtlpOutput) DeepCopyInto(out *OtlpOutput) {
+	*out = *in
+	in.Endpoint.DeepCopyInto(&out.Endpoint)
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(AuthenticationOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(OtlpTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(OtlpRetry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Queue != nil {
+		in, out := &in.Queue, &out.Queue
+		*out = new(OtlpQueue)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtlpOutput.
+func (in *OtlpOutput) DeepCopy() *OtlpOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(OtlpOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtlpQueue) DeepCopyInto(out *OtlpQueue) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtlpQueue.
+func (in *OtlpQueue) DeepCopy() *OtlpQueue {
+	if in == nil {
+		return nil
+	}
+	out := new(OtlpQueue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtlpRetry) DeepCopyInto(out *OtlpRetry) {
+	*out = *in
+	if in.InitialInterval != nil {
+		in, out := &in.InitialInterval, &out.InitialInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxInterval != nil {
+		in, out := &in.MaxInterval, &out.MaxInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxElapsedTime != nil {
+		in, out := &in.MaxElapsedTime, &out.MaxElapsedTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtlpRetry.
+func (in *OtlpRetry) DeepCopy() *OtlpRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(OtlpRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtlpTLS) DeepCopyInto(out *OtlpTLS) {
+	*out = *in
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(ValueType)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cert != nil {
+		in, out := &in.Cert, &out.Cert
+		*out = new(ValueType)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(ValueType)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtlpTLS.
+func (in *OtlpTLS) DeepCopy() *OtlpTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(OtlpTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Output) DeepCopyInto(out *Output) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPOutput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Loki != nil {
+		in, out := &in.Loki, &out.Loki
+		*out = new(LokiOutput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NativeLoki != nil {
+		in, out := &in.NativeLoki, &out.NativeLoki
+		*out = new(NativeLokiOutput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Elasticsearch != nil {
+		in, out := &in.Elasticsearch, &out.Elasticsearch
+		*out = new(ElasticsearchOutput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Splunk != nil {
+		in, out := &in.Splunk, &out.Splunk
+		*out = new(SplunkOutput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Otlp != nil {
+		in, out := &in.Otlp, &out.Otlp
+		*out = new(OtlpOutput)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Output.
+func (in *Output) DeepCopy() *Output {
+	if in == nil {
+		return nil
+	}
+	out := new(Output)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputStatus) DeepCopyInto(out *OutputStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputStatus.
+func (in *OutputStatus) DeepCopy() *OutputStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParseInput) DeepCopyInto(out *ParseInput) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParseInput.
+func (in *ParseInput) DeepCopy() *ParseInput {
+	if in == nil {
+		return nil
+	}
+	out := new(ParseInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbabilisticSampling) DeepCopyInto(out *ProbabilisticSampling) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbabilisticSampling.
+func (in *ProbabilisticSampling) DeepCopy() *ProbabilisticSampling {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbabilisticSampling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusOutput) DeepCopyInto(out *PrometheusOutput) {
+	*out = *in
+	if in.ConstLabels != nil {
+		in, out := &in.ConstLabels, &out.ConstLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MetricExpiration != nil {
+		in, out := &in.MetricExpiration, &out.MetricExpiration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusOutput.
+func (in *PrometheusOutput) DeepCopy() *PrometheusOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusRemoteWriteOutput) DeepCopyInto(out *PrometheusRemoteWriteOutput) {
+	*out = *in
+	in.Endpoint.DeepCopyInto(&out.Endpoint)
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(AuthenticationOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(OtlpTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalLabels != nil {
+		in, out := &in.ExternalLabels, &out.ExternalLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(OtlpRetry)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRemoteWriteOutput.
+func (in *PrometheusRemoteWriteOutput) DeepCopy() *PrometheusRemoteWriteOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusRemoteWriteOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusScrapeTarget) DeepCopyInto(out *PrometheusScrapeTarget) {
+	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = new(ScrapeTargetSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = new(ScrapeTargetSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeTarget.
+func (in *PrometheusScrapeTarget) DeepCopy() *PrometheusScrapeTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusScrapeTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitSamplingPolicy) DeepCopyInto(out *RateLimitSamplingPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitSamplingPolicy.
+func (in *RateLimitSamplingPolicy) DeepCopy() *RateLimitSamplingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitSamplingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScrapeTargetSelector) DeepCopyInto(out *ScrapeTargetSelector) {
+	*out = *in
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.Port = in.Port
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScrapeTargetSelector.
+func (in *ScrapeTargetSelector) DeepCopy() *ScrapeTargetSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ScrapeTargetSelector)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -822,6 +1904,94 @@ func (in *SecretKeyRef) DeepCopy() *SecretKeyRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceGraph) DeepCopyInto(out *ServiceGraph) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceGraph.
+func (in *ServiceGraph) DeepCopy() *ServiceGraph {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceGraph)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpanFilterRule) DeepCopyInto(out *SpanFilterRule) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceNames != nil {
+		in, out := &in.ServiceNames, &out.ServiceNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make([]AttributeMatcher, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceAttributes != nil {
+		in, out := &in.ResourceAttributes, &out.ResourceAttributes
+		*out = make([]AttributeMatcher, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpanFilterRule.
+func (in *SpanFilterRule) DeepCopy() *SpanFilterRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SpanFilterRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpanMetrics) DeepCopyInto(out *SpanMetrics) {
+	*out = *in
+	if in.Dimensions != nil {
+		in, out := &in.Dimensions, &out.Dimensions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpanMetrics.
+func (in *SpanMetrics) DeepCopy() *SpanMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(SpanMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SplunkOutput) DeepCopyInto(out *SplunkOutput) {
+	*out = *in
+	in.Host.DeepCopyInto(&out.Host)
+	in.Token.DeepCopyInto(&out.Token)
+	in.TLSConfig.DeepCopyInto(&out.TLSConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SplunkOutput.
+func (in *SplunkOutput) DeepCopy() *SplunkOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(SplunkOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 	*out = *in
@@ -852,6 +2022,38 @@ func (in *TLSConfig) DeepCopy() *TLSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TailSampling) DeepCopyInto(out *TailSampling) {
+	*out = *in
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(LatencySamplingPolicy)
+		**out = **in
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make([]AttributeSamplingPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimitSamplingPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TailSampling.
+func (in *TailSampling) DeepCopy() *TailSampling {
+	if in == nil {
+		return nil
+	}
+	out := new(TailSampling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TracePipeline) DeepCopyInto(out *TracePipeline) {
 	*out = *in
@@ -895,6 +2097,55 @@ func (in *TracePipelineCondition) DeepCopy() *TracePipelineCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracePipelineFilters) DeepCopyInto(out *TracePipelineFilters) {
+	*out = *in
+	if in.Keep != nil {
+		in, out := &in.Keep, &out.Keep
+		*out = make([]SpanFilterRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Drop != nil {
+		in, out := &in.Drop, &out.Drop
+		*out = make([]SpanFilterRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracePipelineFilters.
+func (in *TracePipelineFilters) DeepCopy() *TracePipelineFilters {
+	if in == nil {
+		return nil
+	}
+	out := new(TracePipelineFilters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracePipelineInput) DeepCopyInto(out *TracePipelineInput) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = new(NamespaceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracePipelineInput.
+func (in *TracePipelineInput) DeepCopy() *TracePipelineInput {
+	if in == nil {
+		return nil
+	}
+	out := new(TracePipelineInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TracePipelineList) DeepCopyInto(out *TracePipelineList) {
 	*out = *in
@@ -927,6 +2178,23 @@ func (in *TracePipelineList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracePipelineMetrics) DeepCopyInto(out *TracePipelineMetrics) {
+	*out = *in
+	in.SpanMetrics.DeepCopyInto(&out.SpanMetrics)
+	out.ServiceGraph = in.ServiceGraph
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracePipelineMetrics.
+func (in *TracePipelineMetrics) DeepCopy() *TracePipelineMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(TracePipelineMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TracePipelineOutput) DeepCopyInto(out *TracePipelineOutput) {
 	*out = *in
@@ -935,6 +2203,11 @@ func (in *TracePipelineOutput) DeepCopyInto(out *TracePipelineOutput) {
 		*out = new(OtlpOutput)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Zipkin != nil {
+		in, out := &in.Zipkin, &out.Zipkin
+		*out = new(ZipkinOutput)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracePipelineOutput.
@@ -947,10 +2220,58 @@ func (in *TracePipelineOutput) DeepCopy() *TracePipelineOutput {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracePipelineSampling) DeepCopyInto(out *TracePipelineSampling) {
+	*out = *in
+	if in.Probabilistic != nil {
+		in, out := &in.Probabilistic, &out.Probabilistic
+		*out = new(ProbabilisticSampling)
+		**out = **in
+	}
+	if in.Tail != nil {
+		in, out := &in.Tail, &out.Tail
+		*out = new(TailSampling)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracePipelineSampling.
+func (in *TracePipelineSampling) DeepCopy() *TracePipelineSampling {
+	if in == nil {
+		return nil
+	}
+	out := new(TracePipelineSampling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TracePipelineSpec) DeepCopyInto(out *TracePipelineSpec) {
 	*out = *in
+	in.Input.DeepCopyInto(&out.Input)
 	in.Output.DeepCopyInto(&out.Output)
+	if in.AdditionalOutputs != nil {
+		in, out := &in.AdditionalOutputs, &out.AdditionalOutputs
+		*out = make([]NamedTracePipelineOutput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sampling != nil {
+		in, out := &in.Sampling, &out.Sampling
+		*out = new(TracePipelineSampling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = new(TracePipelineFilters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(TracePipelineMetrics)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracePipelineSpec.
@@ -973,6 +2294,18 @@ func (in *TracePipelineStatus) DeepCopyInto(out *TracePipelineStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]OutputStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthConditions != nil {
+		in, out := &in.HealthConditions, &out.HealthConditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracePipelineStatus.
@@ -1040,3 +2373,51 @@ func (in *VariableRef) DeepCopy() *VariableRef {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZipkinOutput) DeepCopyInto(out *ZipkinOutput) {
+	*out = *in
+	in.Endpoint.DeepCopyInto(&out.Endpoint)
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(AuthenticationOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(OtlpTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(OtlpRetry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Queue != nil {
+		in, out := &in.Queue, &out.Queue
+		*out = new(OtlpQueue)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZipkinOutput.
+func (in *ZipkinOutput) DeepCopy() *ZipkinOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(ZipkinOutput)
+	in.DeepCopyInto(out)
+	return out
+}
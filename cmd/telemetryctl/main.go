@@ -0,0 +1,92 @@
+// telemetryctl renders the effective configuration of telemetry pipelines without access to a cluster.
+//
+// Usage:
+//
+//	telemetryctl render -f <file> [-f <file>...] [-pipeline <name>] [-namespace <namespace>] [-istio-active] [-max-trace-pipelines <n>]
+//		[-max-metric-pipelines <n>] [-max-log-pipelines <n>] [-max-namespaced-pipelines <n>]
+//
+// The files contain the pipelines and, optionally, the Telemetry resource. Use "-" to read from stdin.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kyma-project/telemetry-manager/internal/render"
+)
+
+type fileList []string
+
+func (f *fileList) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *fileList) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) == 0 || args[0] != "render" {
+		return fmt.Errorf("unknown command: expected 'render'")
+	}
+
+	var files fileList
+	var opts render.Options
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	flags.Var(&files, "f", "File with pipelines and an optional Telemetry resource, or - for stdin. Can be repeated.")
+	flags.StringVar(&opts.Pipeline, "pipeline", "", "Name of the only pipeline to render. If empty, all pipelines are rendered together.")
+	flags.StringVar(&opts.Namespace, "namespace", "kyma-system", "Namespace of Telemetry Manager")
+	flags.BoolVar(&opts.IstioActive, "istio-active", false, "Render the metric agent as if Istio was installed")
+	flags.IntVar(&opts.MaxTracePipelines, "max-trace-pipelines", 3, "Maximum number of TracePipelines that are rendered. If 0, no limit is applied.")
+	flags.IntVar(&opts.MaxMetricPipelines, "max-metric-pipelines", 3, "Maximum number of MetricPipelines that are rendered. If 0, no limit is applied.")
+	flags.IntVar(&opts.MaxLogPipelines, "max-log-pipelines", 5, "Maximum number of LogPipelines that are rendered. If 0, no limit is applied.")
+	flags.IntVar(&opts.MaxPipelinesPerNamespace, "max-namespaced-pipelines", 2, "Maximum number of NamespacedLogPipelines and NamespacedTracePipelines per Namespace that are rendered. If 0, no limit is applied.")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("at least one file must be given with -f")
+	}
+
+	var input render.Input
+	for _, file := range files {
+		if err := decodeFile(&input, file, stdin); err != nil {
+			return err
+		}
+	}
+
+	docs, err := render.Render(context.Background(), &input, opts)
+	if err != nil {
+		return err
+	}
+	return render.Write(stdout, docs)
+}
+
+func decodeFile(input *render.Input, file string, stdin io.Reader) error {
+	if file == "-" {
+		return input.Decode(stdin)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := input.Decode(f); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", file, err)
+	}
+	return nil
+}
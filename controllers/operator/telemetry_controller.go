@@ -70,6 +70,14 @@ func (r *TelemetryReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(
 			&v1alpha1.TracePipeline{},
 			handler.EnqueueRequestsFromMapFunc(r.mapTracePipeline),
+			builder.WithPredicates(setup.CreateOrUpdateOrDelete())).
+		Watches(
+			&v1alpha1.NamespacedLogPipeline{},
+			handler.EnqueueRequestsFromMapFunc(r.mapNamespacedLogPipeline),
+			builder.WithPredicates(setup.CreateOrUpdateOrDelete())).
+		Watches(
+			&v1alpha1.NamespacedTracePipeline{},
+			handler.EnqueueRequestsFromMapFunc(r.mapNamespacedTracePipeline),
 			builder.WithPredicates(setup.CreateOrUpdateOrDelete()))
 
 	if r.config.Metrics.Enabled {
@@ -121,6 +129,32 @@ func (r *TelemetryReconciler) mapTracePipeline(ctx context.Context, object clien
 	return r.createTelemetryRequests(ctx)
 }
 
+func (r *TelemetryReconciler) mapNamespacedLogPipeline(ctx context.Context, object client.Object) []reconcile.Request {
+	logPipeline, ok := object.(*v1alpha1.NamespacedLogPipeline)
+	if !ok {
+		logf.FromContext(ctx).Error(nil, "Unable to cast object to NamespacedLogPipeline")
+		return nil
+	}
+	if len(logPipeline.Status.Conditions) == 0 {
+		return nil
+	}
+
+	return r.createTelemetryRequests(ctx)
+}
+
+func (r *TelemetryReconciler) mapNamespacedTracePipeline(ctx context.Context, object client.Object) []reconcile.Request {
+	tracePipeline, ok := object.(*v1alpha1.NamespacedTracePipeline)
+	if !ok {
+		logf.FromContext(ctx).Error(nil, "Unable to cast object to NamespacedTracePipeline")
+		return nil
+	}
+	if len(tracePipeline.Status.Conditions) == 0 {
+		return nil
+	}
+
+	return r.createTelemetryRequests(ctx)
+}
+
 func (r *TelemetryReconciler) mapMetricPipeline(ctx context.Context, object client.Object) []reconcile.Request {
 	tracePipeline, ok := object.(*v1alpha1.MetricPipeline)
 	if !ok {
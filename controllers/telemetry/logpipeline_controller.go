@@ -18,16 +18,25 @@ limitations under the License.
 
 import (
 	"context"
+	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/reconciler/logpipeline"
+	"github.com/kyma-project/telemetry-manager/internal/setup"
 )
 
 // LogPipelineReconciler reconciles a LogPipeline object
@@ -57,6 +66,9 @@ func (r *LogPipelineReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(
 			&appsv1.DaemonSet{},
 			handler.EnqueueRequestForOwner(mgr.GetClient().Scheme(), mgr.GetRESTMapper(), &telemetryv1alpha1.LogPipeline{})).
+		Watches(
+			&appsv1.Deployment{},
+			handler.EnqueueRequestForOwner(mgr.GetClient().Scheme(), mgr.GetRESTMapper(), &telemetryv1alpha1.LogPipeline{})).
 		Watches(
 			&corev1.Service{},
 			handler.EnqueueRequestForOwner(mgr.GetClient().Scheme(), mgr.GetRESTMapper(), &telemetryv1alpha1.LogPipeline{})).
@@ -75,5 +87,78 @@ func (r *LogPipelineReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(
 			&corev1.Secret{},
 			handler.EnqueueRequestForOwner(mgr.GetClient().Scheme(), mgr.GetRESTMapper(), &telemetryv1alpha1.LogPipeline{})).
-		Complete(r)
+		Watches(
+			&networkingv1.NetworkPolicy{},
+			handler.EnqueueRequestForOwner(mgr.GetClient().Scheme(), mgr.GetRESTMapper(), &telemetryv1alpha1.LogPipeline{})).
+		Watches(
+			&telemetryv1alpha1.LogPipeline{},
+			handler.EnqueueRequestsFromMapFunc(r.mapPipelineChanges),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&telemetryv1alpha1.NamespacedLogPipeline{},
+			handler.EnqueueRequestsFromMapFunc(r.mapPipelineChanges),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&operatorv1alpha1.Telemetry{},
+			handler.EnqueueRequestsFromMapFunc(r.mapTelemetryChanges),
+			builder.WithPredicates(setup.CreateOrUpdateOrDelete()),
+		).Complete(r)
+}
+
+func (r *LogPipelineReconciler) mapTelemetryChanges(ctx context.Context, object client.Object) []reconcile.Request {
+	_, ok := object.(*operatorv1alpha1.Telemetry)
+	if !ok {
+		logf.FromContext(ctx).V(1).Error(nil, "Unexpected type: expected Telemetry")
+		return nil
+	}
+
+	requests, err := r.createRequestsForAllPipelines(ctx)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Unable to create reconcile requests")
+	}
+	return requests
+}
+
+// mapPipelineChanges re-enqueues all LogPipelines and NamespacedLogPipelines, because creating or deleting a pipeline can change which NamespacedLogPipelines are within the pipeline limit.
+func (r *LogPipelineReconciler) mapPipelineChanges(ctx context.Context, object client.Object) []reconcile.Request {
+	switch object.(type) {
+	case *telemetryv1alpha1.LogPipeline, *telemetryv1alpha1.NamespacedLogPipeline:
+	default:
+		logf.FromContext(ctx).V(1).Error(nil, "Unexpected type: expected LogPipeline or NamespacedLogPipeline")
+		return nil
+	}
+
+	requests, err := r.createRequestsForAllPipelines(ctx)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Unable to create reconcile requests")
+	}
+	return requests
+}
+
+func (r *LogPipelineReconciler) createRequestsForAllPipelines(ctx context.Context) ([]reconcile.Request, error) {
+	var pipelines telemetryv1alpha1.LogPipelineList
+	var requests []reconcile.Request
+	err := r.List(ctx, &pipelines)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list LogPipelines: %w", err)
+	}
+
+	for i := range pipelines.Items {
+		var pipeline = pipelines.Items[i]
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: pipeline.Name}})
+	}
+
+	var namespacedPipelines telemetryv1alpha1.NamespacedLogPipelineList
+	if err := r.List(ctx, &namespacedPipelines); err != nil {
+		return nil, fmt.Errorf("failed to list NamespacedLogPipelines: %w", err)
+	}
+
+	for i := range namespacedPipelines.Items {
+		var pipeline = namespacedPipelines.Items[i]
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pipeline.Namespace, Name: pipeline.Name}})
+	}
+
+	return requests, nil
 }
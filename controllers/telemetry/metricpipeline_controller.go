@@ -21,8 +21,10 @@ import (
 	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -30,6 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
@@ -76,11 +79,22 @@ func (r *MetricPipelineReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(
 			&networkingv1.NetworkPolicy{},
 			handler.EnqueueRequestForOwner(mgr.GetClient().Scheme(), mgr.GetRESTMapper(), &telemetryv1alpha1.MetricPipeline{})).
+		Watches(
+			&autoscalingv2.HorizontalPodAutoscaler{},
+			handler.EnqueueRequestForOwner(mgr.GetClient().Scheme(), mgr.GetRESTMapper(), &telemetryv1alpha1.MetricPipeline{})).
+		Watches(
+			&policyv1.PodDisruptionBudget{},
+			handler.EnqueueRequestForOwner(mgr.GetClient().Scheme(), mgr.GetRESTMapper(), &telemetryv1alpha1.MetricPipeline{})).
 		Watches(
 			&apiextensionsv1.CustomResourceDefinition{},
 			handler.EnqueueRequestsFromMapFunc(r.mapCRDChanges),
 			builder.WithPredicates(setup.CreateOrDelete()),
 		).
+		Watches(
+			&telemetryv1alpha1.MetricPipeline{},
+			handler.EnqueueRequestsFromMapFunc(r.mapPipelineChanges),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
 		Watches(
 			&operatorv1alpha1.Telemetry{},
 			handler.EnqueueRequestsFromMapFunc(r.mapTelemetryChanges),
@@ -116,6 +130,21 @@ func (r *MetricPipelineReconciler) mapTelemetryChanges(ctx context.Context, obje
 	return requests
 }
 
+// mapPipelineChanges re-enqueues all MetricPipelines, because creating, deleting or re-prioritizing a pipeline can change which pipelines are within the pipeline limit.
+func (r *MetricPipelineReconciler) mapPipelineChanges(ctx context.Context, object client.Object) []reconcile.Request {
+	_, ok := object.(*telemetryv1alpha1.MetricPipeline)
+	if !ok {
+		logf.FromContext(ctx).V(1).Error(nil, "Unexpected type: expected MetricPipeline")
+		return nil
+	}
+
+	requests, err := r.createRequestsForAllPipelines(ctx)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Unable to create reconcile requests")
+	}
+	return requests
+}
+
 func (r *MetricPipelineReconciler) createRequestsForAllPipelines(ctx context.Context) ([]reconcile.Request, error) {
 	var pipelines telemetryv1alpha1.MetricPipelineList
 	var requests []reconcile.Request
@@ -21,14 +21,17 @@ import (
 	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
@@ -74,6 +77,22 @@ func (r *TracePipelineReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(
 			&networkingv1.NetworkPolicy{},
 			handler.EnqueueRequestForOwner(mgr.GetClient().Scheme(), mgr.GetRESTMapper(), &telemetryv1alpha1.TracePipeline{})).
+		Watches(
+			&autoscalingv2.HorizontalPodAutoscaler{},
+			handler.EnqueueRequestForOwner(mgr.GetClient().Scheme(), mgr.GetRESTMapper(), &telemetryv1alpha1.TracePipeline{})).
+		Watches(
+			&policyv1.PodDisruptionBudget{},
+			handler.EnqueueRequestForOwner(mgr.GetClient().Scheme(), mgr.GetRESTMapper(), &telemetryv1alpha1.TracePipeline{})).
+		Watches(
+			&telemetryv1alpha1.TracePipeline{},
+			handler.EnqueueRequestsFromMapFunc(r.mapPipelineChanges),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&telemetryv1alpha1.NamespacedTracePipeline{},
+			handler.EnqueueRequestsFromMapFunc(r.mapPipelineChanges),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
 		Watches(
 			&operatorv1alpha1.Telemetry{},
 			handler.EnqueueRequestsFromMapFunc(r.mapTelemetryChanges),
@@ -95,6 +114,26 @@ func (r *TracePipelineReconciler) mapTelemetryChanges(ctx context.Context, objec
 	return requests
 }
 
+// mapPipelineChanges re-enqueues all TracePipelines and NamespacedTracePipelines, because creating, deleting or re-prioritizing a pipeline can change which pipelines are within the pipeline limit.
+// A changed NamespacedTracePipeline is enqueued itself as well, so that the gateway is deleted after the last NamespacedTracePipeline is deleted.
+func (r *TracePipelineReconciler) mapPipelineChanges(ctx context.Context, object client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+	switch pipeline := object.(type) {
+	case *telemetryv1alpha1.TracePipeline:
+	case *telemetryv1alpha1.NamespacedTracePipeline:
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pipeline.Namespace, Name: pipeline.Name}})
+	default:
+		logf.FromContext(ctx).V(1).Error(nil, "Unexpected type: expected TracePipeline or NamespacedTracePipeline")
+		return nil
+	}
+
+	allRequests, err := r.createRequestsForAllPipelines(ctx)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Unable to create reconcile requests")
+	}
+	return append(requests, allRequests...)
+}
+
 func (r *TracePipelineReconciler) createRequestsForAllPipelines(ctx context.Context) ([]reconcile.Request, error) {
 	var pipelines telemetryv1alpha1.TracePipelineList
 	var requests []reconcile.Request
@@ -108,5 +147,15 @@ func (r *TracePipelineReconciler) createRequestsForAllPipelines(ctx context.Cont
 		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: pipeline.Name}})
 	}
 
+	var namespacedPipelines telemetryv1alpha1.NamespacedTracePipelineList
+	if err := r.List(ctx, &namespacedPipelines); err != nil {
+		return nil, fmt.Errorf("failed to list NamespacedTracePipelines: %w", err)
+	}
+
+	for i := range namespacedPipelines.Items {
+		var pipeline = namespacedPipelines.Items[i]
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pipeline.Namespace, Name: pipeline.Name}})
+	}
+
 	return requests, nil
 }
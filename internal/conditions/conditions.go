@@ -1,36 +1,80 @@
 package conditions
 
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	TypeFlowHealthy            = "TelemetryFlowHealthy"
+	TypePersistentQueueHealthy = "PersistentQueueHealthy"
+)
+
 const (
 	ReasonNoPipelineDeployed      = "NoPipelineDeployed"
 	ReasonReferencedSecretMissing = "ReferencedSecretMissing"
-	ReasonWaitingForLock          = "WaitingForLock"
+	ReasonMaxPipelinesExceeded    = "MaxPipelinesExceeded"
 	ReasonResourceBlocksDeletion  = "ResourceBlocksDeletion"
 	ReasonUnsupportedLokiOutput   = "UnsupportedLokiOutput"
+	ReasonOutputReady             = "OutputReady"
+	ReasonPipelineSuspended       = "PipelineSuspended"
+
+	ReasonPersistentVolumeClaimUsed    = "PersistentVolumeClaimUsed"
+	ReasonPersistentVolumeClaimIgnored = "PersistentVolumeClaimIgnored"
 
 	ReasonFluentBitDSNotReady = "FluentBitDaemonSetNotReady"
 	ReasonFluentBitDSReady    = "FluentBitDaemonSetReady"
 
+	ReasonLogAgentDaemonSetNotReady = "LogAgentDaemonSetNotReady"
+
+	ReasonLogGatewayDeploymentNotReady = "LogGatewayDeploymentNotReady"
+	ReasonLogGatewayDeploymentReady    = "LogGatewayDeploymentReady"
+
 	ReasonMetricGatewayDeploymentNotReady = "MetricGatewayDeploymentNotReady"
 	ReasonMetricGatewayDeploymentReady    = "MetricGatewayDeploymentReady"
+	ReasonPrometheusPortConflict          = "PrometheusPortConflict"
 
 	ReasonTraceGatewayDeploymentNotReady = "TraceGatewayDeploymentNotReady"
 	ReasonTraceGatewayDeploymentReady    = "TraceGatewayDeploymentReady"
+
+	ReasonFlowHealthy             = "FlowHealthy"
+	ReasonBufferFillingUp         = "BufferFillingUp"
+	ReasonSomeDataDropped         = "SomeDataDropped"
+	ReasonAllDataDropped          = "AllDataDropped"
+	ReasonFlowHealthProbingFailed = "FlowHealthProbingFailed"
 )
 
 var message = map[string]string{
 	ReasonNoPipelineDeployed:      "No pipelines have been deployed",
 	ReasonReferencedSecretMissing: "One or more referenced Secrets are missing",
-	ReasonWaitingForLock:          "Waiting for the lock",
+	ReasonMaxPipelinesExceeded:    "Maximum pipeline count limit exceeded",
+	ReasonOutputReady:             "Output is configured completely",
+	ReasonPipelineSuspended:       "The pipeline is suspended and does not ship any data",
 	ReasonUnsupportedLokiOutput:   "grafana-loki output is not supported anymore. For integration with a custom Loki installation, use the `custom` output and follow https://github.com/kyma-project/examples/tree/main/loki",
 
+	ReasonPersistentVolumeClaimUsed:    "The persistent queues are stored on the configured PersistentVolumeClaims",
+	ReasonPersistentVolumeClaimIgnored: "A PersistentVolumeClaim is ignored, because it can only be used with the Static scaling strategy and one replica",
+
 	ReasonFluentBitDSNotReady: "Fluent Bit DaemonSet is not ready",
 	ReasonFluentBitDSReady:    "Fluent Bit DaemonSet is ready",
 
+	ReasonLogAgentDaemonSetNotReady: "Log agent DaemonSet is not ready",
+
+	ReasonLogGatewayDeploymentNotReady: "Log gateway Deployment is not ready",
+	ReasonLogGatewayDeploymentReady:    "Log gateway Deployment is ready",
+
 	ReasonMetricGatewayDeploymentNotReady: "Metric gateway Deployment is not ready",
 	ReasonMetricGatewayDeploymentReady:    "Metric gateway Deployment is ready",
+	ReasonPrometheusPortConflict:          "The port of a Prometheus output is already used by another MetricPipeline",
 
 	ReasonTraceGatewayDeploymentNotReady: "Trace gateway Deployment is not ready",
 	ReasonTraceGatewayDeploymentReady:    "Trace gateway Deployment is ready",
+
+	ReasonFlowHealthy:             "No problems detected in the data flow",
+	ReasonBufferFillingUp:         "Buffer nearing capacity: the incoming data rate exceeds the export rate",
+	ReasonSomeDataDropped:         "Some data dropped: the backend is unreachable or rejects some data",
+	ReasonAllDataDropped:          "All data dropped: the backend is unreachable or rejects all data",
+	ReasonFlowHealthProbingFailed: "Could not determine the health of the data flow",
 }
 
 // CommonMessageFor returns a human-readable message corresponding to a given reason.
@@ -41,3 +85,11 @@ func CommonMessageFor(reason string) string {
 	}
 	return ""
 }
+
+// MessageForMaxPipelinesExceeded returns the message for the MaxPipelinesExceeded reason, naming the pipelines that are deployed instead.
+func MessageForMaxPipelinesExceeded(deployedPipelines []string) string {
+	if len(deployedPipelines) == 0 {
+		return CommonMessageFor(ReasonMaxPipelinesExceeded)
+	}
+	return fmt.Sprintf("%s. Deployed pipelines: %s", CommonMessageFor(ReasonMaxPipelinesExceeded), strings.Join(deployedPipelines, ", "))
+}
@@ -0,0 +1,305 @@
+package flowhealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
+)
+
+// Signal is the suffix of the exporter self-monitoring metrics of a signal type, for example, otelcol_exporter_sent_spans.
+type Signal string
+
+const (
+	SignalTraces  Signal = "spans"
+	SignalMetrics Signal = "metric_points"
+)
+
+const (
+	// minProbeInterval is the minimum time between two samples of the same exporter. Probes that are triggered more often reuse the previous result,
+	// so that the counter deltas always cover a meaningful time window.
+	minProbeInterval = 30 * time.Second
+	// bufferFillingUpThreshold is the utilization of the sending queue above which the buffer is considered to be filling up.
+	bufferFillingUpThreshold = 0.8
+	scrapeTimeout            = 5 * time.Second
+)
+
+// ProbeResult describes the data flow of all exporters of a pipeline since the previous probe.
+type ProbeResult struct {
+	// AllDataDropped is true if all exporters of the pipeline dropped data and none of them sent any data.
+	AllDataDropped bool
+	// SomeDataDropped is true if at least one exporter of the pipeline dropped data.
+	SomeDataDropped bool
+	// BufferFillingUp is true if the sending queue of at least one exporter of the pipeline is almost full.
+	BufferFillingUp bool
+}
+
+type exporterMetrics struct {
+	sent          float64
+	dropped       float64
+	queueSize     float64
+	queueCapacity float64
+}
+
+type exporterHealth struct {
+	sent, dropped   bool
+	bufferFillingUp bool
+}
+
+type exporterState struct {
+	sampledAt time.Time
+	// samples holds the last scraped metrics per gateway Pod, so that Pod restarts can be told apart from a decreasing counter.
+	samples map[string]exporterMetrics
+	health  exporterHealth
+}
+
+// Prober evaluates the data flow of pipeline exporters by scraping the self-monitoring metrics of all gateway replicas.
+// Counters are compared with the previous sample of the same exporter, so a Prober must be reused across reconciliations.
+type Prober struct {
+	client     client.Reader
+	httpClient *http.Client
+	gateway    types.NamespacedName
+	signal     Signal
+	port       int
+	now        func() time.Time
+
+	mu     sync.Mutex
+	states map[string]*exporterState
+}
+
+func NewProber(client client.Reader, gateway types.NamespacedName, signal Signal) *Prober {
+	return &Prober{
+		client:     client,
+		httpClient: &http.Client{Timeout: scrapeTimeout},
+		gateway:    gateway,
+		signal:     signal,
+		port:       ports.Metrics,
+		now:        time.Now,
+		states:     make(map[string]*exporterState),
+	}
+}
+
+// Probe returns the data flow of the given exporters since the previous probe.
+func (p *Prober) Probe(ctx context.Context, exporterIDs []string) (ProbeResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	var scraped map[string]map[string]exporterMetrics
+	var result ProbeResult
+	allDropped := len(exporterIDs) > 0
+
+	for _, exporterID := range exporterIDs {
+		state, found := p.states[exporterID]
+		if !found || now.Sub(state.sampledAt) >= minProbeInterval {
+			if scraped == nil {
+				var err error
+				if scraped, err = p.scrapeGateway(ctx); err != nil {
+					return ProbeResult{}, err
+				}
+			}
+			state = p.updateState(exporterID, state, scraped, now)
+		}
+
+		health := state.health
+		result.SomeDataDropped = result.SomeDataDropped || health.dropped
+		result.BufferFillingUp = result.BufferFillingUp || health.bufferFillingUp
+		allDropped = allDropped && health.dropped && !health.sent
+	}
+	result.AllDataDropped = allDropped
+
+	return result, nil
+}
+
+func (p *Prober) updateState(exporterID string, previous *exporterState, scraped map[string]map[string]exporterMetrics, now time.Time) *exporterState {
+	state := &exporterState{sampledAt: now, samples: make(map[string]exporterMetrics)}
+
+	var sentDelta, droppedDelta float64
+	for pod, exporters := range scraped {
+		current, found := exporters[exporterID]
+		if !found {
+			continue
+		}
+		state.samples[pod] = current
+
+		if current.queueCapacity > 0 && current.queueSize/current.queueCapacity >= bufferFillingUpThreshold {
+			state.health.bufferFillingUp = true
+		}
+
+		if previous == nil {
+			continue
+		}
+		last, found := previous.samples[pod]
+		if !found {
+			// The Pod appeared since the previous probe, so its counters cover an unknown time window and are skipped until the next probe.
+			continue
+		}
+		sentDelta += counterDelta(last.sent, current.sent)
+		droppedDelta += counterDelta(last.dropped, current.dropped)
+	}
+
+	state.health.sent = sentDelta > 0
+	state.health.dropped = droppedDelta > 0
+	p.states[exporterID] = state
+
+	return state
+}
+
+// counterDelta returns the increase of a counter. A decreasing counter means that the collector was restarted and started counting from zero.
+func counterDelta(last, current float64) float64 {
+	if current < last {
+		return current
+	}
+	return current - last
+}
+
+// scrapeGateway returns the exporter metrics of every ready gateway Pod, keyed by Pod name and exporter ID. The Pods are scraped in parallel.
+// A Pod that cannot be scraped is treated as missing data, so that a single Pod that is starting or shutting down does not fail the probe.
+// An error is only returned if none of the ready Pods can be scraped.
+func (p *Prober) scrapeGateway(ctx context.Context) (map[string]map[string]exporterMetrics, error) {
+	var pods corev1.PodList
+	if err := p.client.List(ctx, &pods, &client.ListOptions{
+		LabelSelector: k8slabels.SelectorFromSet(map[string]string{"app.kubernetes.io/name": p.gateway.Name}),
+		Namespace:     p.gateway.Namespace,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list gateway Pods: %w", err)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		scraped = make(map[string]map[string]exporterMetrics)
+		errs    []error
+	)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !isReady(pod) {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			exporters, err := p.scrapePod(ctx, pod)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			scraped[pod.Name] = exporters
+		}()
+	}
+	wg.Wait()
+
+	if len(scraped) == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	for _, err := range errs {
+		logf.FromContext(ctx).V(1).Info("Skipping gateway Pod in flow health probe", "error", err.Error())
+	}
+
+	return scraped, nil
+}
+
+// isReady returns true if the Pod is ready to serve and is not being deleted. Pods that are starting or shutting down are not scraped.
+func isReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func (p *Prober) scrapePod(ctx context.Context, pod *corev1.Pod) (map[string]exporterMetrics, error) {
+	url := fmt.Sprintf("http://%s:%d/metrics", pod.Status.PodIP, p.port)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for Pod %s: %w", pod.Name, err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape Pod %s: %w", pod.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to scrape Pod %s: unexpected status code %d", pod.Name, resp.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics of Pod %s: %w", pod.Name, err)
+	}
+
+	return p.exporterMetricsFrom(families), nil
+}
+
+func (p *Prober) exporterMetricsFrom(families map[string]*dto.MetricFamily) map[string]exporterMetrics {
+	exporters := make(map[string]exporterMetrics)
+	for name, family := range families {
+		name = strings.TrimSuffix(name, "_total")
+		for _, metric := range family.GetMetric() {
+			exporterID := labelValue(metric, "exporter")
+			if exporterID == "" {
+				continue
+			}
+
+			m := exporters[exporterID]
+			switch name {
+			case "otelcol_exporter_sent_" + string(p.signal):
+				m.sent += value(metric)
+			case "otelcol_exporter_send_failed_" + string(p.signal), "otelcol_exporter_enqueue_failed_" + string(p.signal):
+				m.dropped += value(metric)
+			case "otelcol_exporter_queue_size":
+				m.queueSize += value(metric)
+			case "otelcol_exporter_queue_capacity":
+				m.queueCapacity += value(metric)
+			default:
+				continue
+			}
+			exporters[exporterID] = m
+		}
+	}
+	return exporters
+}
+
+func labelValue(metric *dto.Metric, name string) string {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
+func value(metric *dto.Metric) float64 {
+	switch {
+	case metric.Counter != nil:
+		return metric.GetCounter().GetValue()
+	case metric.Gauge != nil:
+		return metric.GetGauge().GetValue()
+	default:
+		return metric.GetUntyped().GetValue()
+	}
+}
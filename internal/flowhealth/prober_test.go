@@ -0,0 +1,203 @@
+package flowhealth
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type gatewayStub struct {
+	metrics string
+}
+
+func (g *gatewayStub) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	_, _ = w.Write([]byte(g.metrics))
+}
+
+func exporterMetricsText(exporterID string, sent, sendFailed, enqueueFailed, queueSize, queueCapacity int) string {
+	return fmt.Sprintf(`# TYPE otelcol_exporter_sent_spans counter
+otelcol_exporter_sent_spans{exporter="%[1]s"} %[2]d
+# TYPE otelcol_exporter_send_failed_spans counter
+otelcol_exporter_send_failed_spans{exporter="%[1]s"} %[3]d
+# TYPE otelcol_exporter_enqueue_failed_spans counter
+otelcol_exporter_enqueue_failed_spans{exporter="%[1]s"} %[4]d
+# TYPE otelcol_exporter_queue_size gauge
+otelcol_exporter_queue_size{exporter="%[1]s"} %[5]d
+# TYPE otelcol_exporter_queue_capacity gauge
+otelcol_exporter_queue_capacity{exporter="%[1]s"} %[6]d
+`, exporterID, sent, sendFailed, enqueueFailed, queueSize, queueCapacity)
+}
+
+func gatewayPod(name, ip string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "kyma-system",
+			Labels:    map[string]string{"app.kubernetes.io/name": "gateway"},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			PodIP:      ip,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
+
+func newTestProber(t *testing.T, stub *gatewayStub, extraPods ...client.Object) (*Prober, *time.Time) {
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+
+	host, portStr, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+
+	fakeClient := fake.NewClientBuilder().WithObjects(gatewayPod("gateway-1", host, true)).WithObjects(extraPods...).Build()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sut := NewProber(fakeClient, types.NamespacedName{Name: "gateway", Namespace: "kyma-system"}, SignalTraces)
+	sut.port = port
+	sut.now = func() time.Time { return now }
+	return sut, &now
+}
+
+func TestProbe(t *testing.T) {
+	tests := []struct {
+		name     string
+		first    string
+		second   string
+		expected ProbeResult
+	}{
+		{
+			name:     "healthy",
+			first:    exporterMetricsText("otlp/test", 10, 0, 0, 0, 100),
+			second:   exporterMetricsText("otlp/test", 20, 0, 0, 10, 100),
+			expected: ProbeResult{},
+		},
+		{
+			name:     "some data dropped",
+			first:    exporterMetricsText("otlp/test", 10, 0, 0, 0, 100),
+			second:   exporterMetricsText("otlp/test", 20, 5, 0, 0, 100),
+			expected: ProbeResult{SomeDataDropped: true},
+		},
+		{
+			name:     "all data dropped",
+			first:    exporterMetricsText("otlp/test", 10, 5, 0, 0, 100),
+			second:   exporterMetricsText("otlp/test", 10, 5, 7, 0, 100),
+			expected: ProbeResult{SomeDataDropped: true, AllDataDropped: true},
+		},
+		{
+			name:     "buffer filling up",
+			first:    exporterMetricsText("otlp/test", 10, 0, 0, 0, 100),
+			second:   exporterMetricsText("otlp/test", 20, 0, 0, 85, 100),
+			expected: ProbeResult{BufferFillingUp: true},
+		},
+		{
+			name:     "collector restarted",
+			first:    exporterMetricsText("otlp/test", 100, 50, 0, 0, 100),
+			second:   exporterMetricsText("otlp/test", 10, 0, 0, 0, 100),
+			expected: ProbeResult{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &gatewayStub{metrics: tt.first}
+			sut, now := newTestProber(t, stub)
+
+			result, err := sut.Probe(context.Background(), []string{"otlp/test"})
+			require.NoError(t, err)
+			require.False(t, result.SomeDataDropped)
+
+			stub.metrics = tt.second
+			*now = now.Add(minProbeInterval)
+
+			result, err = sut.Probe(context.Background(), []string{"otlp/test"})
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestProbeWithMultipleExporters(t *testing.T) {
+	stub := &gatewayStub{metrics: exporterMetricsText("otlp/test", 10, 0, 0, 0, 100)}
+	sut, now := newTestProber(t, stub)
+
+	_, err := sut.Probe(context.Background(), []string{"otlp/test", "otlp/test_archive"})
+	require.NoError(t, err)
+
+	stub.metrics = exporterMetricsText("otlp/test", 10, 5, 0, 0, 100)
+	*now = now.Add(minProbeInterval)
+
+	result, err := sut.Probe(context.Background(), []string{"otlp/test", "otlp/test_archive"})
+	require.NoError(t, err)
+	require.True(t, result.SomeDataDropped)
+	require.False(t, result.AllDataDropped, "the other exporter did not drop data")
+}
+
+func TestProbeReusesResultWithinMinInterval(t *testing.T) {
+	stub := &gatewayStub{metrics: exporterMetricsText("otlp/test", 10, 0, 0, 0, 100)}
+	sut, now := newTestProber(t, stub)
+
+	_, err := sut.Probe(context.Background(), []string{"otlp/test"})
+	require.NoError(t, err)
+
+	stub.metrics = exporterMetricsText("otlp/test", 10, 5, 0, 0, 100)
+	*now = now.Add(minProbeInterval)
+	result, err := sut.Probe(context.Background(), []string{"otlp/test"})
+	require.NoError(t, err)
+	require.True(t, result.AllDataDropped)
+
+	stub.metrics = exporterMetricsText("otlp/test", 20, 5, 0, 0, 100)
+	*now = now.Add(time.Second)
+	result, err = sut.Probe(context.Background(), []string{"otlp/test"})
+	require.NoError(t, err)
+	require.True(t, result.AllDataDropped)
+}
+
+func TestProbeFailsIfGatewayIsNotReachable(t *testing.T) {
+	stub := &gatewayStub{}
+	sut, _ := newTestProber(t, stub)
+	sut.port = 1
+
+	_, err := sut.Probe(context.Background(), []string{"otlp/test"})
+	require.Error(t, err)
+}
+
+func TestProbeSkipsPodsThatAreNotReadyOrCannotBeScraped(t *testing.T) {
+	// Nothing listens on 127.0.0.2, so the scrapes of these Pods fail
+	unreachable := gatewayPod("gateway-unreachable", "127.0.0.2", true)
+	notReady := gatewayPod("gateway-not-ready", "127.0.0.2", false)
+	terminating := gatewayPod("gateway-terminating", "127.0.0.2", true)
+	terminating.DeletionTimestamp = &metav1.Time{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	terminating.Finalizers = []string{"test"}
+
+	stub := &gatewayStub{metrics: exporterMetricsText("otlp/test", 10, 0, 0, 0, 100)}
+	sut, now := newTestProber(t, stub, unreachable, notReady, terminating)
+
+	_, err := sut.Probe(context.Background(), []string{"otlp/test"})
+	require.NoError(t, err)
+
+	stub.metrics = exporterMetricsText("otlp/test", 10, 5, 0, 0, 100)
+	*now = now.Add(minProbeInterval)
+
+	result, err := sut.Probe(context.Background(), []string{"otlp/test"})
+	require.NoError(t, err)
+	require.True(t, result.AllDataDropped, "the data of the reachable Pod must still be evaluated")
+}
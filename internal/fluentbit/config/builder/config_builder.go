@@ -6,6 +6,7 @@ import (
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/fluentbit/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/log"
 )
 
 type PipelineDefaults struct {
@@ -13,6 +14,12 @@ type PipelineDefaults struct {
 	MemoryBufferLimit string
 	StorageType       string
 	FsBufferLimit     string
+	// LogGatewayHost is the address of the log gateway Service that receives the logs of pipelines with an OTLP output.
+	LogGatewayHost string
+	// ClusterName is added as cluster_identifier to all records. If it is empty, the address of the Kubernetes API server is used.
+	ClusterName string
+	// ClusterAttributes are static fields that are added to all records.
+	ClusterAttributes map[string]string
 }
 
 // BuildFluentBitConfig merges Fluent Bit filters and outputs to a single Fluent Bit configuration.
@@ -30,7 +37,9 @@ func BuildFluentBitConfig(pipeline *telemetryv1alpha1.LogPipeline, defaults Pipe
 	var sb strings.Builder
 	sb.WriteString(createRewriteTagFilter(pipeline, defaults))
 	sb.WriteString(createNamespaceGrepFilter(pipeline))
-	sb.WriteString(createRecordModifierFilter(pipeline))
+	sb.WriteString(createMultilineFilter(pipeline, defaults))
+	sb.WriteString(createParseFilter(pipeline))
+	sb.WriteString(createRecordModifierFilter(pipeline, defaults))
 	sb.WriteString(createCustomFilters(pipeline))
 	sb.WriteString(createKubernetesMetadataFilter(pipeline))
 	sb.WriteString(createLuaDedotFilter(pipeline))
@@ -39,17 +48,33 @@ func BuildFluentBitConfig(pipeline *telemetryv1alpha1.LogPipeline, defaults Pipe
 	return sb.String(), nil
 }
 
-func createRecordModifierFilter(pipeline *telemetryv1alpha1.LogPipeline) string {
-	return NewFilterSectionBuilder().
+func createRecordModifierFilter(pipeline *telemetryv1alpha1.LogPipeline, defaults PipelineDefaults) string {
+	clusterName := "${KUBERNETES_SERVICE_HOST}"
+	if defaults.ClusterName != "" {
+		clusterName = defaults.ClusterName
+	}
+
+	sb := NewFilterSectionBuilder().
 		AddConfigParam("name", "record_modifier").
 		AddConfigParam("match", fmt.Sprintf("%s.*", pipeline.Name)).
-		AddConfigParam("record", "cluster_identifier ${KUBERNETES_SERVICE_HOST}").
-		Build()
+		AddConfigParam("record", "cluster_identifier "+clusterName)
+
+	for key, value := range defaults.ClusterAttributes {
+		sb.AddConfigParam("record", fmt.Sprintf("%s %s", key, value))
+	}
+
+	// The log gateway serves all pipelines with an OTLP output, so every record is marked with the name of its pipeline
+	if pipeline.Spec.HasOtlpOutput() {
+		sb.AddConfigParam("record", fmt.Sprintf("%s %s", log.PipelineNameAttribute, pipeline.Name))
+	}
+
+	return sb.Build()
 }
 
+// createLuaDedotFilter creates the dedot filter if any HTTP output of the pipeline requires it.
+// The filter modifies the records, so all outputs of the pipeline receive the dedotted records.
 func createLuaDedotFilter(logPipeline *telemetryv1alpha1.LogPipeline) string {
-	output := logPipeline.Spec.Output
-	if !output.IsHTTPDefined() || !output.HTTP.Dedot {
+	if !requiresDedot(logPipeline) {
 		return ""
 	}
 
@@ -61,12 +86,22 @@ func createLuaDedotFilter(logPipeline *telemetryv1alpha1.LogPipeline) string {
 		Build()
 }
 
+func requiresDedot(logPipeline *telemetryv1alpha1.LogPipeline) bool {
+	for _, output := range logPipeline.Spec.AllOutputs() {
+		if output.IsHTTPDefined() && output.HTTP.Dedot {
+			return true
+		}
+	}
+	return false
+}
+
 func validateCustomSections(pipeline *telemetryv1alpha1.LogPipeline) error {
-	customOutput := pipeline.Spec.Output.Custom
-	if customOutput != "" {
-		_, err := config.ParseCustomSection(customOutput)
-		if err != nil {
-			return err
+	for _, output := range pipeline.Spec.AllOutputs() {
+		if output.Custom != "" {
+			_, err := config.ParseCustomSection(output.Custom)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -18,10 +18,53 @@ func TestCreateRecordModifierFilter(t *testing.T) {
 `
 	logPipeline := &telemetryv1alpha1.LogPipeline{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
 
-	actual := createRecordModifierFilter(logPipeline)
+	actual := createRecordModifierFilter(logPipeline, PipelineDefaults{})
 	require.Equal(t, expected, actual, "Fluent Bit Permanent parser config is invalid")
 }
 
+func TestCreateRecordModifierFilterWithOtlpOutput(t *testing.T) {
+	expected := `[FILTER]
+    name   record_modifier
+    match  foo.*
+    record cluster_identifier ${KUBERNETES_SERVICE_HOST}
+    record kyma.log_pipeline foo
+
+`
+	logPipeline := &telemetryv1alpha1.LogPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Spec: telemetryv1alpha1.LogPipelineSpec{
+			Output: telemetryv1alpha1.Output{
+				Otlp: &telemetryv1alpha1.OtlpOutput{},
+			},
+		},
+	}
+
+	actual := createRecordModifierFilter(logPipeline, PipelineDefaults{})
+	require.Equal(t, expected, actual)
+}
+
+func TestCreateRecordModifierFilterWithCluster(t *testing.T) {
+	expected := `[FILTER]
+    name   record_modifier
+    match  foo.*
+    record cluster_identifier prod-eu-1
+    record environment production
+    record region eu-central-1
+
+`
+	logPipeline := &telemetryv1alpha1.LogPipeline{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+	defaults := PipelineDefaults{
+		ClusterName: "prod-eu-1",
+		ClusterAttributes: map[string]string{
+			"region":      "eu-central-1",
+			"environment": "production",
+		},
+	}
+
+	actual := createRecordModifierFilter(logPipeline, defaults)
+	require.Equal(t, expected, actual)
+}
+
 func TestCreateLuaDedotFilterWithDefinedHostAndDedotSet(t *testing.T) {
 	expected := `[FILTER]
     name   lua
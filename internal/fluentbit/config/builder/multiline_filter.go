@@ -0,0 +1,40 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+// createMultilineFilter concatenates the log lines of a multiline record, like a stack trace, before any other processing happens.
+// The presets are built into Fluent Bit. A custom definition refers to the multiline parser that is rendered by BuildFluentBitPipelineParsersConfig.
+func createMultilineFilter(pipeline *telemetryv1alpha1.LogPipeline, defaults PipelineDefaults) string {
+	multiline := pipeline.Spec.Input.Application.Multiline
+	if multiline == nil {
+		return ""
+	}
+
+	var parsers []string
+	if multiline.Custom != nil {
+		parsers = append(parsers, multilineParserName(pipeline.Name))
+	} else {
+		for _, preset := range multiline.Presets {
+			parsers = append(parsers, string(preset))
+		}
+	}
+
+	return NewFilterSectionBuilder().
+		AddConfigParam("name", "multiline").
+		AddConfigParam("match", fmt.Sprintf("%s.*", pipeline.Name)).
+		AddConfigParam("multiline.key_content", "log").
+		AddConfigParam("multiline.parser", strings.Join(parsers, ",")).
+		AddConfigParam("emitter_name", pipeline.Name+"-multiline").
+		AddConfigParam("emitter_storage.type", defaults.StorageType).
+		AddConfigParam("emitter_mem_buf_limit", defaults.MemoryBufferLimit).
+		Build()
+}
+
+func multilineParserName(pipelineName string) string {
+	return pipelineName + "-multiline"
+}
@@ -0,0 +1,71 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+func TestCreateMultilineFilterNotDefined(t *testing.T) {
+	logPipeline := &telemetryv1alpha1.LogPipeline{ObjectMeta: metav1.ObjectMeta{Name: "logpipeline1"}}
+
+	actual := createMultilineFilter(logPipeline, PipelineDefaults{})
+	require.Empty(t, actual)
+}
+
+func TestCreateMultilineFilterPresets(t *testing.T) {
+	logPipeline := &telemetryv1alpha1.LogPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "logpipeline1"},
+		Spec: telemetryv1alpha1.LogPipelineSpec{
+			Input: telemetryv1alpha1.Input{Application: telemetryv1alpha1.ApplicationInput{
+				Multiline: &telemetryv1alpha1.MultilineInput{
+					Presets: []telemetryv1alpha1.MultilinePreset{telemetryv1alpha1.MultilinePresetJava, telemetryv1alpha1.MultilinePresetPython},
+				}}}}}
+	defaults := PipelineDefaults{
+		StorageType:       "filesystem",
+		MemoryBufferLimit: "10M",
+	}
+
+	expected := `[FILTER]
+    name                  multiline
+    match                 logpipeline1.*
+    emitter_mem_buf_limit 10M
+    emitter_name          logpipeline1-multiline
+    emitter_storage.type  filesystem
+    multiline.key_content log
+    multiline.parser      java,python
+
+`
+	actual := createMultilineFilter(logPipeline, defaults)
+	require.Equal(t, expected, actual)
+}
+
+func TestCreateMultilineFilterCustom(t *testing.T) {
+	logPipeline := &telemetryv1alpha1.LogPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "logpipeline1"},
+		Spec: telemetryv1alpha1.LogPipelineSpec{
+			Input: telemetryv1alpha1.Input{Application: telemetryv1alpha1.ApplicationInput{
+				Multiline: &telemetryv1alpha1.MultilineInput{
+					Custom: &telemetryv1alpha1.CustomMultiline{StartRegex: `^\d{4}-\d{2}-\d{2}`},
+				}}}}}
+	defaults := PipelineDefaults{
+		StorageType:       "filesystem",
+		MemoryBufferLimit: "10M",
+	}
+
+	expected := `[FILTER]
+    name                  multiline
+    match                 logpipeline1.*
+    emitter_mem_buf_limit 10M
+    emitter_name          logpipeline1-multiline
+    emitter_storage.type  filesystem
+    multiline.key_content log
+    multiline.parser      logpipeline1-multiline
+
+`
+	actual := createMultilineFilter(logPipeline, defaults)
+	require.Equal(t, expected, actual)
+}
@@ -5,15 +5,12 @@ import (
 	"strings"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/namespaces"
 )
 
-func systemNamespaces() []string {
-	return []string{"kyma-system", "kube-system", "istio-system", "compass-system"}
-}
-
 func createNamespaceGrepFilter(pipeline *telemetryv1alpha1.LogPipeline) string {
-	namespaces := pipeline.Spec.Input.Application.Namespaces
-	if namespaces.System {
+	selector := pipeline.Spec.Input.Application.Namespaces
+	if selector.System {
 		return ""
 	}
 
@@ -21,19 +18,19 @@ func createNamespaceGrepFilter(pipeline *telemetryv1alpha1.LogPipeline) string {
 		AddConfigParam("Name", "grep").
 		AddConfigParam("Match", fmt.Sprintf("%s.*", pipeline.Name))
 
-	if len(namespaces.Include) > 0 {
+	if len(selector.Include) > 0 {
 		return sectionBuilder.
-			AddConfigParam("Regex", fmt.Sprintf("$kubernetes['namespace_name'] %s", strings.Join(namespaces.Include, "|"))).
+			AddConfigParam("Regex", fmt.Sprintf("$kubernetes['namespace_name'] %s", strings.Join(selector.Include, "|"))).
 			Build()
 	}
 
-	if len(namespaces.Exclude) > 0 {
+	if len(selector.Exclude) > 0 {
 		return sectionBuilder.
-			AddConfigParam("Exclude", fmt.Sprintf("$kubernetes['namespace_name'] %s", strings.Join(namespaces.Exclude, "|"))).
+			AddConfigParam("Exclude", fmt.Sprintf("$kubernetes['namespace_name'] %s", strings.Join(selector.Exclude, "|"))).
 			Build()
 	}
 
 	return sectionBuilder.
-		AddConfigParam("Exclude", fmt.Sprintf("$kubernetes['namespace_name'] %s", strings.Join(systemNamespaces(), "|"))).
+		AddConfigParam("Exclude", fmt.Sprintf("$kubernetes['namespace_name'] %s", strings.Join(namespaces.System(), "|"))).
 		Build()
 }
@@ -2,8 +2,12 @@ package builder
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
 	"github.com/kyma-project/telemetry-manager/internal/utils/envvar"
 )
 
@@ -13,20 +17,41 @@ import (
 // that malformed logs stay in the buffer forever.
 var retryLimit = "300"
 
+// createOutputSection creates an output section for every output of the pipeline.
+// All OTLP outputs share a single output section that forwards the logs to the log gateway, which fans them out to the OTLP backends.
 func createOutputSection(pipeline *telemetryv1alpha1.LogPipeline, defaults PipelineDefaults) string {
-	output := &pipeline.Spec.Output
-	if output.IsCustomDefined() {
-		return generateCustomOutput(output, defaults.FsBufferLimit, pipeline.Name)
+	var sb strings.Builder
+	for _, output := range pipeline.Spec.AllOutputs() {
+		outputID := telemetryv1alpha1.OutputID(pipeline.Name, output.Name)
+		if output.IsCustomDefined() {
+			sb.WriteString(generateCustomOutput(&output.Output, defaults.FsBufferLimit, pipeline.Name, outputID))
+		}
+
+		if output.IsHTTPDefined() {
+			sb.WriteString(generateHTTPOutput(output.HTTP, defaults.FsBufferLimit, pipeline.Name, outputID))
+		}
+
+		if output.IsNativeLokiDefined() {
+			sb.WriteString(generateNativeLokiOutput(output.NativeLoki, defaults.FsBufferLimit, pipeline.Name, outputID))
+		}
+
+		if output.IsElasticsearchDefined() {
+			sb.WriteString(generateElasticsearchOutput(output.Elasticsearch, defaults.FsBufferLimit, pipeline.Name, outputID))
+		}
+
+		if output.IsSplunkDefined() {
+			sb.WriteString(generateSplunkOutput(output.Splunk, defaults.FsBufferLimit, pipeline.Name, outputID))
+		}
 	}
 
-	if output.IsHTTPDefined() {
-		return generateHTTPOutput(output.HTTP, defaults.FsBufferLimit, pipeline.Name)
+	if pipeline.Spec.HasOtlpOutput() {
+		sb.WriteString(generateOtlpOutput(defaults.LogGatewayHost, defaults.FsBufferLimit, pipeline.Name))
 	}
 
-	return ""
+	return sb.String()
 }
 
-func generateCustomOutput(output *telemetryv1alpha1.Output, fsBufferLimit string, name string) string {
+func generateCustomOutput(output *telemetryv1alpha1.Output, fsBufferLimit string, name string, outputID string) string {
 	sb := NewOutputSectionBuilder()
 	customOutputParams := parseMultiline(output.Custom)
 	var outputName string
@@ -38,7 +63,7 @@ func generateCustomOutput(output *telemetryv1alpha1.Output, fsBufferLimit string
 		sb.AddConfigParam(p.Key, p.Value)
 	}
 	if !aliasPresent {
-		sb.AddConfigParam("alias", fmt.Sprintf("%s-%s", name, outputName))
+		sb.AddConfigParam("alias", fmt.Sprintf("%s-%s", outputID, outputName))
 	}
 	sb.AddConfigParam("match", fmt.Sprintf("%s.*", name))
 	sb.AddConfigParam("storage.total_limit_size", fsBufferLimit)
@@ -46,12 +71,12 @@ func generateCustomOutput(output *telemetryv1alpha1.Output, fsBufferLimit string
 	return sb.Build()
 }
 
-func generateHTTPOutput(httpOutput *telemetryv1alpha1.HTTPOutput, fsBufferLimit string, name string) string {
+func generateHTTPOutput(httpOutput *telemetryv1alpha1.HTTPOutput, fsBufferLimit string, name string, outputID string) string {
 	sb := NewOutputSectionBuilder()
 	sb.AddConfigParam("name", "http")
 	sb.AddConfigParam("allow_duplicated_headers", "true")
 	sb.AddConfigParam("match", fmt.Sprintf("%s.*", name))
-	sb.AddConfigParam("alias", fmt.Sprintf("%s-http", name))
+	sb.AddConfigParam("alias", fmt.Sprintf("%s-http", outputID))
 	sb.AddConfigParam("storage.total_limit_size", fsBufferLimit)
 	sb.AddConfigParam("retry_limit", retryLimit)
 	sb.AddIfNotEmpty("uri", httpOutput.URI)
@@ -72,27 +97,129 @@ func generateHTTPOutput(httpOutput *telemetryv1alpha1.HTTPOutput, fsBufferLimit
 		sb.AddConfigParam("http_user", value)
 	}
 
+	addTLSConfigParams(sb, httpOutput.TLSConfig, outputID)
+
+	return sb.Build()
+}
+
+func generateNativeLokiOutput(lokiOutput *telemetryv1alpha1.NativeLokiOutput, fsBufferLimit string, name string, outputID string) string {
+	sb := NewOutputSectionBuilder()
+	sb.AddConfigParam("name", "loki")
+	sb.AddConfigParam("match", fmt.Sprintf("%s.*", name))
+	sb.AddConfigParam("alias", fmt.Sprintf("%s-loki", outputID))
+	sb.AddConfigParam("storage.total_limit_size", fsBufferLimit)
+	sb.AddConfigParam("retry_limit", retryLimit)
+	sb.AddConfigParam("line_format", "json")
+	sb.AddIfNotEmptyOrDefault("port", lokiOutput.Port, "443")
+	sb.AddIfNotEmptyOrDefault("uri", lokiOutput.URI, "/loki/api/v1/push")
+	sb.AddConfigParam("host", resolveValue(lokiOutput.Host, name))
+	sb.AddIfNotEmpty("tenant_id", resolveValue(lokiOutput.TenantID, name))
+	sb.AddIfNotEmpty("http_user", resolveValue(lokiOutput.User, name))
+	sb.AddIfNotEmpty("http_passwd", resolveValue(lokiOutput.Password, name))
+
+	if len(lokiOutput.Labels) > 0 {
+		labels := make([]string, 0, len(lokiOutput.Labels))
+		for key, value := range lokiOutput.Labels {
+			labels = append(labels, fmt.Sprintf("%s=%s", key, value))
+		}
+		sort.Strings(labels)
+		sb.AddConfigParam("labels", strings.Join(labels, ", "))
+	}
+	sb.AddIfNotEmpty("label_keys", strings.Join(lokiOutput.LabelKeys, ", "))
+	sb.AddIfNotEmpty("remove_keys", strings.Join(lokiOutput.RemoveKeys, ", "))
+
+	addTLSConfigParams(sb, lokiOutput.TLSConfig, outputID)
+
+	return sb.Build()
+}
+
+func generateElasticsearchOutput(elasticsearchOutput *telemetryv1alpha1.ElasticsearchOutput, fsBufferLimit string, name string, outputID string) string {
+	const defaultIndex = "kyma-logs"
+
+	sb := NewOutputSectionBuilder()
+	sb.AddConfigParam("name", "es")
+	sb.AddConfigParam("match", fmt.Sprintf("%s.*", name))
+	sb.AddConfigParam("alias", fmt.Sprintf("%s-es", outputID))
+	sb.AddConfigParam("storage.total_limit_size", fsBufferLimit)
+	sb.AddConfigParam("retry_limit", retryLimit)
+	// Recent versions of Elasticsearch and OpenSearch reject mapping types and keys with dots that collide with objects
+	sb.AddConfigParam("suppress_type_name", "on")
+	sb.AddConfigParam("replace_dots", "on")
+	sb.AddIfNotEmptyOrDefault("port", elasticsearchOutput.Port, "443")
+	sb.AddIfNotEmpty("path", elasticsearchOutput.Path)
+	sb.AddIfNotEmpty("compress", elasticsearchOutput.Compress)
+	sb.AddConfigParam("host", resolveValue(elasticsearchOutput.Host, name))
+	sb.AddIfNotEmpty("http_user", resolveValue(elasticsearchOutput.User, name))
+	sb.AddIfNotEmpty("http_passwd", resolveValue(elasticsearchOutput.Password, name))
+
+	if elasticsearchOutput.LogstashFormat {
+		sb.AddConfigParam("logstash_format", "on")
+		sb.AddIfNotEmptyOrDefault("logstash_prefix", elasticsearchOutput.LogstashPrefix, defaultIndex)
+	} else {
+		sb.AddIfNotEmptyOrDefault("index", elasticsearchOutput.Index, defaultIndex)
+	}
+
+	addTLSConfigParams(sb, elasticsearchOutput.TLSConfig, outputID)
+
+	return sb.Build()
+}
+
+func generateSplunkOutput(splunkOutput *telemetryv1alpha1.SplunkOutput, fsBufferLimit string, name string, outputID string) string {
+	sb := NewOutputSectionBuilder()
+	sb.AddConfigParam("name", "splunk")
+	sb.AddConfigParam("match", fmt.Sprintf("%s.*", name))
+	sb.AddConfigParam("alias", fmt.Sprintf("%s-splunk", outputID))
+	sb.AddConfigParam("storage.total_limit_size", fsBufferLimit)
+	sb.AddConfigParam("retry_limit", retryLimit)
+	sb.AddIfNotEmptyOrDefault("port", splunkOutput.Port, "8088")
+	sb.AddIfNotEmpty("compress", splunkOutput.Compress)
+	sb.AddIfNotEmpty("event_index", splunkOutput.Index)
+	sb.AddIfNotEmpty("event_source", splunkOutput.Source)
+	sb.AddIfNotEmpty("event_sourcetype", splunkOutput.SourceType)
+	sb.AddConfigParam("host", resolveValue(splunkOutput.Host, name))
+	sb.AddConfigParam("splunk_token", resolveValue(splunkOutput.Token, name))
+
+	addTLSConfigParams(sb, splunkOutput.TLSConfig, outputID)
+
+	return sb.Build()
+}
+
+// addTLSConfigParams adds the TLS parameters of an output. The certificates and the key are mounted from the TLS config Secret, which is synced by the reconciler.
+func addTLSConfigParams(sb *SectionBuilder, tlsConfig telemetryv1alpha1.TLSConfig, outputID string) {
 	tlsEnabled := "on"
-	if httpOutput.TLSConfig.Disabled {
+	if tlsConfig.Disabled {
 		tlsEnabled = "off"
 	}
 	sb.AddConfigParam("tls", tlsEnabled)
 	tlsVerify := "on"
-	if httpOutput.TLSConfig.SkipCertificateValidation {
+	if tlsConfig.SkipCertificateValidation {
 		tlsVerify = "off"
 	}
 	sb.AddConfigParam("tls.verify", tlsVerify)
 
-	if httpOutput.TLSConfig.CA.IsDefined() {
-		sb.AddConfigParam("tls.ca_file", fmt.Sprintf("/fluent-bit/etc/output-tls-config/%s-ca.crt", name))
+	if tlsConfig.CA.IsDefined() {
+		sb.AddConfigParam("tls.ca_file", fmt.Sprintf("/fluent-bit/etc/output-tls-config/%s-ca.crt", outputID))
 	}
-	if httpOutput.TLSConfig.Cert.IsDefined() {
-		sb.AddConfigParam("tls.crt_file", fmt.Sprintf("/fluent-bit/etc/output-tls-config/%s-cert.crt", name))
+	if tlsConfig.Cert.IsDefined() {
+		sb.AddConfigParam("tls.crt_file", fmt.Sprintf("/fluent-bit/etc/output-tls-config/%s-cert.crt", outputID))
 	}
-	if httpOutput.TLSConfig.Key.IsDefined() {
-		sb.AddConfigParam("tls.key_file", fmt.Sprintf("/fluent-bit/etc/output-tls-config/%s-key.key", name))
+	if tlsConfig.Key.IsDefined() {
+		sb.AddConfigParam("tls.key_file", fmt.Sprintf("/fluent-bit/etc/output-tls-config/%s-key.key", outputID))
 	}
+}
 
+// generateOtlpOutput forwards the logs to the log gateway, which takes care of shipping them to the actual OTLP backend.
+func generateOtlpOutput(logGatewayHost string, fsBufferLimit string, name string) string {
+	sb := NewOutputSectionBuilder()
+	sb.AddConfigParam("name", "opentelemetry")
+	sb.AddConfigParam("match", fmt.Sprintf("%s.*", name))
+	sb.AddConfigParam("alias", fmt.Sprintf("%s-otlp", name))
+	sb.AddConfigParam("storage.total_limit_size", fsBufferLimit)
+	sb.AddConfigParam("retry_limit", retryLimit)
+	sb.AddConfigParam("host", logGatewayHost)
+	sb.AddConfigParam("port", strconv.Itoa(ports.OTLPHTTP))
+	sb.AddConfigParam("logs_uri", "/v1/logs")
+	sb.AddConfigParam("tls", "off")
 	return sb.Build()
 }
 
@@ -75,6 +75,36 @@ func TestCreateOutputSectionWithHTTPOutput(t *testing.T) {
 	require.Equal(t, expected, actual)
 }
 
+func TestCreateOutputSectionWithOtlpOutput(t *testing.T) {
+	expected := `[OUTPUT]
+    name                     opentelemetry
+    match                    foo.*
+    alias                    foo-otlp
+    host                     telemetry-otlp-logs.kyma-system
+    logs_uri                 /v1/logs
+    port                     4318
+    retry_limit              300
+    storage.total_limit_size 1G
+    tls                      off
+
+`
+	logPipeline := &telemetryv1alpha1.LogPipeline{
+		Spec: telemetryv1alpha1.LogPipelineSpec{
+			Output: telemetryv1alpha1.Output{
+				Otlp: &telemetryv1alpha1.OtlpOutput{
+					Endpoint: telemetryv1alpha1.ValueType{Value: "https://backend:4317"},
+				},
+			},
+		},
+	}
+	logPipeline.Name = "foo"
+	pipelineConfig := PipelineDefaults{FsBufferLimit: "1G", LogGatewayHost: "telemetry-otlp-logs.kyma-system"}
+
+	actual := createOutputSection(logPipeline, pipelineConfig)
+	require.NotEmpty(t, actual)
+	require.Equal(t, expected, actual)
+}
+
 func TestCreateOutputSectionWithHTTPOutputWithSecretReference(t *testing.T) {
 	expected := `[OUTPUT]
     name                     http
@@ -167,6 +197,266 @@ func TestCreateOutputSectionWithHTTPOutputWithTLS(t *testing.T) {
 	require.Equal(t, expected, actual)
 }
 
+func TestCreateOutputSectionWithLokiOutput(t *testing.T) {
+	expected := `[OUTPUT]
+    name                     loki
+    match                    foo.*
+    alias                    foo-loki
+    host                     loki.example.com
+    http_passwd              ${FOO_MY_NAMESPACE_SECRET_KEY}
+    http_user                user
+    label_keys               $kubernetes['namespace_name'], $kubernetes['pod_name']
+    labels                   cluster=dev, job=fluent-bit
+    line_format              json
+    port                     443
+    remove_keys              kubernetes, stream
+    retry_limit              300
+    storage.total_limit_size 1G
+    tenant_id                my-tenant
+    tls                      on
+    tls.ca_file              /fluent-bit/etc/output-tls-config/foo-ca.crt
+    tls.verify               on
+    uri                      /loki/api/v1/push
+
+`
+	logPipeline := &telemetryv1alpha1.LogPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Spec: telemetryv1alpha1.LogPipelineSpec{
+			Output: telemetryv1alpha1.Output{
+				NativeLoki: &telemetryv1alpha1.NativeLokiOutput{
+					Host:     telemetryv1alpha1.ValueType{Value: "loki.example.com"},
+					TenantID: telemetryv1alpha1.ValueType{Value: "my-tenant"},
+					User:     telemetryv1alpha1.ValueType{Value: "user"},
+					Password: telemetryv1alpha1.ValueType{
+						ValueFrom: &telemetryv1alpha1.ValueFromSource{
+							SecretKeyRef: &telemetryv1alpha1.SecretKeyRef{
+								Name:      "secret",
+								Key:       "key",
+								Namespace: "my-namespace",
+							},
+						},
+					},
+					Labels:     map[string]string{"job": "fluent-bit", "cluster": "dev"},
+					LabelKeys:  []string{"$kubernetes['namespace_name']", "$kubernetes['pod_name']"},
+					RemoveKeys: []string{"kubernetes", "stream"},
+					TLSConfig: telemetryv1alpha1.TLSConfig{
+						CA: &telemetryv1alpha1.ValueType{Value: "fake-ca-value"},
+					},
+				},
+			},
+		},
+	}
+	pipelineConfig := PipelineDefaults{FsBufferLimit: "1G"}
+
+	actual := createOutputSection(logPipeline, pipelineConfig)
+	require.NotEmpty(t, actual)
+	require.Equal(t, expected, actual)
+}
+
+func TestCreateOutputSectionWithElasticsearchOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   *telemetryv1alpha1.ElasticsearchOutput
+		expected string
+	}{
+		{
+			name: "with index",
+			output: &telemetryv1alpha1.ElasticsearchOutput{
+				Host:     telemetryv1alpha1.ValueType{Value: "opensearch.example.com"},
+				Port:     "9200",
+				Path:     "/es",
+				User:     telemetryv1alpha1.ValueType{Value: "user"},
+				Password: telemetryv1alpha1.ValueType{Value: "password"},
+				Compress: "gzip",
+				TLSConfig: telemetryv1alpha1.TLSConfig{
+					SkipCertificateValidation: true,
+				},
+			},
+			expected: `[OUTPUT]
+    name                     es
+    match                    foo.*
+    alias                    foo-es
+    compress                 gzip
+    host                     opensearch.example.com
+    http_passwd              password
+    http_user                user
+    index                    kyma-logs
+    path                     /es
+    port                     9200
+    replace_dots             on
+    retry_limit              300
+    storage.total_limit_size 1G
+    suppress_type_name       on
+    tls                      on
+    tls.verify               off
+
+`,
+		},
+		{
+			name: "with logstash format",
+			output: &telemetryv1alpha1.ElasticsearchOutput{
+				Host:           telemetryv1alpha1.ValueType{Value: "opensearch.example.com"},
+				LogstashFormat: true,
+				LogstashPrefix: "my-logs",
+			},
+			expected: `[OUTPUT]
+    name                     es
+    match                    foo.*
+    alias                    foo-es
+    host                     opensearch.example.com
+    logstash_format          on
+    logstash_prefix          my-logs
+    port                     443
+    replace_dots             on
+    retry_limit              300
+    storage.total_limit_size 1G
+    suppress_type_name       on
+    tls                      on
+    tls.verify               on
+
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logPipeline := &telemetryv1alpha1.LogPipeline{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: telemetryv1alpha1.LogPipelineSpec{
+					Output: telemetryv1alpha1.Output{Elasticsearch: tt.output},
+				},
+			}
+			pipelineConfig := PipelineDefaults{FsBufferLimit: "1G"}
+
+			actual := createOutputSection(logPipeline, pipelineConfig)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestCreateOutputSectionWithSplunkOutput(t *testing.T) {
+	expected := `[OUTPUT]
+    name                     splunk
+    match                    foo.*
+    alias                    foo-splunk
+    event_index              main
+    event_source             kyma
+    event_sourcetype         _json
+    host                     splunk.example.com
+    port                     8088
+    retry_limit              300
+    splunk_token             ${FOO_MY_NAMESPACE_SECRET_TOKEN}
+    storage.total_limit_size 1G
+    tls                      on
+    tls.verify               on
+
+`
+	logPipeline := &telemetryv1alpha1.LogPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Spec: telemetryv1alpha1.LogPipelineSpec{
+			Output: telemetryv1alpha1.Output{
+				Splunk: &telemetryv1alpha1.SplunkOutput{
+					Host: telemetryv1alpha1.ValueType{Value: "splunk.example.com"},
+					Token: telemetryv1alpha1.ValueType{
+						ValueFrom: &telemetryv1alpha1.ValueFromSource{
+							SecretKeyRef: &telemetryv1alpha1.SecretKeyRef{
+								Name:      "secret",
+								Key:       "token",
+								Namespace: "my-namespace",
+							},
+						},
+					},
+					Index:      "main",
+					Source:     "kyma",
+					SourceType: "_json",
+				},
+			},
+		},
+	}
+	pipelineConfig := PipelineDefaults{FsBufferLimit: "1G"}
+
+	actual := createOutputSection(logPipeline, pipelineConfig)
+	require.NotEmpty(t, actual)
+	require.Equal(t, expected, actual)
+}
+
+func TestCreateOutputSectionWithAdditionalOutputs(t *testing.T) {
+	expected := `[OUTPUT]
+    name                     http
+    match                    foo.*
+    alias                    foo-http
+    allow_duplicated_headers true
+    format                   json
+    host                     localhost
+    port                     443
+    retry_limit              300
+    storage.total_limit_size 1G
+    tls                      on
+    tls.verify               on
+
+[OUTPUT]
+    name                     http
+    match                    foo.*
+    alias                    foo_archive-http
+    allow_duplicated_headers true
+    format                   json
+    host                     archive
+    port                     443
+    retry_limit              300
+    storage.total_limit_size 1G
+    tls                      on
+    tls.ca_file              /fluent-bit/etc/output-tls-config/foo_archive-ca.crt
+    tls.verify               on
+
+[OUTPUT]
+    name                     opentelemetry
+    match                    foo.*
+    alias                    foo-otlp
+    host                     telemetry-otlp-logs.kyma-system
+    logs_uri                 /v1/logs
+    port                     4318
+    retry_limit              300
+    storage.total_limit_size 1G
+    tls                      off
+
+`
+	logPipeline := &telemetryv1alpha1.LogPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Spec: telemetryv1alpha1.LogPipelineSpec{
+			Output: telemetryv1alpha1.Output{
+				HTTP: &telemetryv1alpha1.HTTPOutput{
+					Host: telemetryv1alpha1.ValueType{Value: "localhost"},
+				},
+			},
+			AdditionalOutputs: []telemetryv1alpha1.NamedOutput{
+				{
+					Name: "archive",
+					Output: telemetryv1alpha1.Output{
+						HTTP: &telemetryv1alpha1.HTTPOutput{
+							Host: telemetryv1alpha1.ValueType{Value: "archive"},
+							TLSConfig: telemetryv1alpha1.TLSConfig{
+								CA: &telemetryv1alpha1.ValueType{Value: "fake-ca-value"},
+							},
+						},
+					},
+				},
+				{
+					Name: "otlp",
+					Output: telemetryv1alpha1.Output{
+						Otlp: &telemetryv1alpha1.OtlpOutput{
+							Endpoint: telemetryv1alpha1.ValueType{Value: "https://backend:4317"},
+						},
+					},
+				},
+			},
+		},
+	}
+	pipelineConfig := PipelineDefaults{FsBufferLimit: "1G", LogGatewayHost: "telemetry-otlp-logs.kyma-system"}
+
+	actual := createOutputSection(logPipeline, pipelineConfig)
+	require.Equal(t, expected, actual)
+}
+
 func TestResolveValueWithValue(t *testing.T) {
 	value := telemetryv1alpha1.ValueType{
 		Value: "test",
@@ -0,0 +1,36 @@
+package builder
+
+import (
+	"fmt"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+const defaultParseKey = "log"
+
+// createParseFilter parses the configured key of a record with the parser that is rendered by BuildFluentBitPipelineParsersConfig.
+// All other fields of the record are kept.
+func createParseFilter(pipeline *telemetryv1alpha1.LogPipeline) string {
+	parse := pipeline.Spec.Input.Application.Parse
+	if parse == nil {
+		return ""
+	}
+
+	preserveKey := "off"
+	if parse.KeepOriginal {
+		preserveKey = "on"
+	}
+
+	return NewFilterSectionBuilder().
+		AddConfigParam("name", "parser").
+		AddConfigParam("match", fmt.Sprintf("%s.*", pipeline.Name)).
+		AddIfNotEmptyOrDefault("key_name", parse.Key, defaultParseKey).
+		AddConfigParam("parser", parseParserName(pipeline.Name)).
+		AddConfigParam("reserve_data", "on").
+		AddConfigParam("preserve_key", preserveKey).
+		Build()
+}
+
+func parseParserName(pipelineName string) string {
+	return pipelineName + "-parse"
+}
@@ -0,0 +1,63 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+func TestCreateParseFilterNotDefined(t *testing.T) {
+	logPipeline := &telemetryv1alpha1.LogPipeline{ObjectMeta: metav1.ObjectMeta{Name: "logpipeline1"}}
+
+	actual := createParseFilter(logPipeline)
+	require.Empty(t, actual)
+}
+
+func TestCreateParseFilterDefaultKey(t *testing.T) {
+	logPipeline := &telemetryv1alpha1.LogPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "logpipeline1"},
+		Spec: telemetryv1alpha1.LogPipelineSpec{
+			Input: telemetryv1alpha1.Input{Application: telemetryv1alpha1.ApplicationInput{
+				Parse: &telemetryv1alpha1.ParseInput{Format: telemetryv1alpha1.ParseFormatJSON},
+			}}}}
+
+	expected := `[FILTER]
+    name         parser
+    match        logpipeline1.*
+    key_name     log
+    parser       logpipeline1-parse
+    preserve_key off
+    reserve_data on
+
+`
+	actual := createParseFilter(logPipeline)
+	require.Equal(t, expected, actual)
+}
+
+func TestCreateParseFilterKeepOriginal(t *testing.T) {
+	logPipeline := &telemetryv1alpha1.LogPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "logpipeline1"},
+		Spec: telemetryv1alpha1.LogPipelineSpec{
+			Input: telemetryv1alpha1.Input{Application: telemetryv1alpha1.ApplicationInput{
+				Parse: &telemetryv1alpha1.ParseInput{
+					Format:       telemetryv1alpha1.ParseFormatJSON,
+					Key:          "message",
+					KeepOriginal: true,
+				},
+			}}}}
+
+	expected := `[FILTER]
+    name         parser
+    match        logpipeline1.*
+    key_name     message
+    parser       logpipeline1-parse
+    preserve_key on
+    reserve_data on
+
+`
+	actual := createParseFilter(logPipeline)
+	require.Equal(t, expected, actual)
+}
@@ -35,3 +35,53 @@ func createParserConfig(name, content string) string {
 	sb.WriteByte('\n')
 	return sb.String()
 }
+
+// BuildFluentBitPipelineParsersConfig renders the parsers that the multiline and parse options of the given pipelines refer to.
+func BuildFluentBitPipelineParsersConfig(pipelines []telemetryv1alpha1.LogPipeline) string {
+	sorted := make([]telemetryv1alpha1.LogPipeline, len(pipelines))
+	copy(sorted, pipelines)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	var sb strings.Builder
+	for _, pipeline := range sorted {
+		if !pipeline.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		application := pipeline.Spec.Input.Application
+		if application.Multiline != nil && application.Multiline.Custom != nil {
+			sb.WriteString(createMultilineParserConfig(multilineParserName(pipeline.Name), application.Multiline.Custom))
+		}
+		if application.Parse != nil {
+			sb.WriteString(createParserConfig(parseParserName(pipeline.Name), createParseContent(application.Parse)))
+		}
+	}
+	return sb.String()
+}
+
+func createMultilineParserConfig(name string, custom *telemetryv1alpha1.CustomMultiline) string {
+	continueRegex := custom.ContinueRegex
+	if continueRegex == "" {
+		continueRegex = fmt.Sprintf("^(?!%s)", custom.StartRegex)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("[MULTILINE_PARSER]\n")
+	sb.WriteString("    " + fmt.Sprintf("Name %s\n", name))
+	sb.WriteString("    Type regex\n")
+	sb.WriteString("    Flush_Timeout 1000\n")
+	sb.WriteString("    " + fmt.Sprintf("Rule \"start_state\" \"/%s/\" \"cont\"\n", custom.StartRegex))
+	sb.WriteString("    " + fmt.Sprintf("Rule \"cont\" \"/%s/\" \"cont\"\n", continueRegex))
+	sb.WriteByte('\n')
+	return sb.String()
+}
+
+func createParseContent(parse *telemetryv1alpha1.ParseInput) string {
+	content := fmt.Sprintf("Format %s", parse.Format)
+	if parse.Format == telemetryv1alpha1.ParseFormatRegex {
+		content += fmt.Sprintf("\nRegex %s", parse.Regex)
+	}
+	return content
+}
@@ -0,0 +1,103 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+func TestBuildFluentBitPipelineParsersConfig(t *testing.T) {
+	now := metav1.Now()
+	pipelines := []telemetryv1alpha1.LogPipeline{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "regex"},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Input: telemetryv1alpha1.Input{Application: telemetryv1alpha1.ApplicationInput{
+					Parse: &telemetryv1alpha1.ParseInput{
+						Format: telemetryv1alpha1.ParseFormatRegex,
+						Regex:  `^(?<level>\w+) (?<message>.*)$`,
+					},
+				}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Input: telemetryv1alpha1.Input{Application: telemetryv1alpha1.ApplicationInput{
+					Multiline: &telemetryv1alpha1.MultilineInput{
+						Custom: &telemetryv1alpha1.CustomMultiline{StartRegex: `^\d{4}-\d{2}-\d{2}`},
+					},
+					Parse: &telemetryv1alpha1.ParseInput{Format: telemetryv1alpha1.ParseFormatJSON},
+				}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "presets"},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Input: telemetryv1alpha1.Input{Application: telemetryv1alpha1.ApplicationInput{
+					Multiline: &telemetryv1alpha1.MultilineInput{
+						Presets: []telemetryv1alpha1.MultilinePreset{telemetryv1alpha1.MultilinePresetGo},
+					},
+				}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "deleted", DeletionTimestamp: &now},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Input: telemetryv1alpha1.Input{Application: telemetryv1alpha1.ApplicationInput{
+					Parse: &telemetryv1alpha1.ParseInput{Format: telemetryv1alpha1.ParseFormatJSON},
+				}},
+			},
+		},
+	}
+
+	expected := `[MULTILINE_PARSER]
+    Name custom-multiline
+    Type regex
+    Flush_Timeout 1000
+    Rule "start_state" "/^\d{4}-\d{2}-\d{2}/" "cont"
+    Rule "cont" "/^(?!^\d{4}-\d{2}-\d{2})/" "cont"
+
+[PARSER]
+    Name custom-parse
+    Format json
+
+[PARSER]
+    Name regex-parse
+    Format regex
+    Regex ^(?<level>\w+) (?<message>.*)$
+
+`
+	actual := BuildFluentBitPipelineParsersConfig(pipelines)
+	require.Equal(t, expected, actual)
+	require.Equal(t, "regex", pipelines[0].Name, "input must not be reordered")
+}
+
+func TestBuildFluentBitPipelineParsersConfigWithCustomContinueRegex(t *testing.T) {
+	pipelines := []telemetryv1alpha1.LogPipeline{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Input: telemetryv1alpha1.Input{Application: telemetryv1alpha1.ApplicationInput{
+					Multiline: &telemetryv1alpha1.MultilineInput{
+						Custom: &telemetryv1alpha1.CustomMultiline{StartRegex: `^\S`, ContinueRegex: `^\s+`},
+					},
+				}},
+			},
+		},
+	}
+
+	expected := `[MULTILINE_PARSER]
+    Name custom-multiline
+    Type regex
+    Flush_Timeout 1000
+    Rule "start_state" "/^\S/" "cont"
+    Rule "cont" "/^\s+/" "cont"
+
+`
+	actual := BuildFluentBitPipelineParsersConfig(pipelines)
+	require.Equal(t, expected, actual)
+}
@@ -16,6 +16,18 @@ func getEmitterPostfixByOutput(output *telemetryv1alpha1.Output) string {
 		return "grafana-loki"
 	}
 
+	if output.IsNativeLokiDefined() {
+		return "loki"
+	}
+
+	if output.IsElasticsearchDefined() {
+		return "es"
+	}
+
+	if output.IsSplunkDefined() {
+		return "splunk"
+	}
+
 	if !output.IsCustomDefined() {
 		return ""
 	}
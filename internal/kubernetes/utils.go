@@ -6,8 +6,10 @@ import (
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -117,6 +119,36 @@ func CreateOrUpdateNetworkPolicy(ctx context.Context, c client.Client, desired *
 	return c.Update(ctx, desired)
 }
 
+func CreateOrUpdateHorizontalPodAutoscaler(ctx context.Context, c client.Client, desired *autoscalingv2.HorizontalPodAutoscaler) error {
+	var existing autoscalingv2.HorizontalPodAutoscaler
+	err := c.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, &existing)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		return c.Create(ctx, desired)
+	}
+
+	mergeMetadata(&desired.ObjectMeta, existing.ObjectMeta)
+	return c.Update(ctx, desired)
+}
+
+func CreateOrUpdatePodDisruptionBudget(ctx context.Context, c client.Client, desired *policyv1.PodDisruptionBudget) error {
+	var existing policyv1.PodDisruptionBudget
+	err := c.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, &existing)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		return c.Create(ctx, desired)
+	}
+
+	mergeMetadata(&desired.ObjectMeta, existing.ObjectMeta)
+	return c.Update(ctx, desired)
+}
+
 func CreateOrUpdateSecret(ctx context.Context, c client.Client, desired *corev1.Secret) error {
 	var existing corev1.Secret
 	err := c.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, &existing)
@@ -149,6 +181,12 @@ func CreateOrUpdateDeployment(ctx context.Context, c client.Client, desired *app
 
 	mergeMetadata(&desired.ObjectMeta, existing.ObjectMeta)
 	mergePodAnnotations(&desired.Spec.Template.ObjectMeta, existing.Spec.Template.ObjectMeta)
+
+	// If the replicas are managed by an autoscaler, keep the current value instead of resetting it to the default.
+	if desired.Spec.Replicas == nil {
+		desired.Spec.Replicas = existing.Spec.Replicas
+	}
+
 	return c.Update(ctx, desired)
 }
 
@@ -0,0 +1,114 @@
+// Package namespaces resolves the Namespace selectors of the pipelines into lists of included or excluded Namespaces.
+package namespaces
+
+import (
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+// System returns the Namespaces that are excluded by default, because they contain Kyma and Kubernetes components.
+func System() []string {
+	return []string{"kyma-system", "kube-system", "istio-system", "compass-system"}
+}
+
+// Selection is the resolved form of a NamespaceSelector. At most one of Include and Exclude is set.
+// If both are empty, all Namespaces are selected.
+type Selection struct {
+	Include []string
+	Exclude []string
+}
+
+// SelectsAll returns true if no Namespace is filtered out.
+func (s Selection) SelectsAll() bool {
+	return len(s.Include) == 0 && len(s.Exclude) == 0
+}
+
+// Resolve returns the Namespaces selected by the given selector. A nil selector selects all Namespaces.
+func Resolve(selector *telemetryv1alpha1.NamespaceSelector) Selection {
+	if selector == nil {
+		return Selection{}
+	}
+
+	if len(selector.Include) > 0 {
+		return Selection{Include: selector.Include}
+	}
+
+	exclude := append([]string{}, selector.Exclude...)
+	if !selector.System {
+		exclude = appendMissing(exclude, System()...)
+	}
+	if len(exclude) == 0 {
+		return Selection{}
+	}
+	return Selection{Exclude: exclude}
+}
+
+// Union returns a Selection that selects every Namespace that is selected by at least one of the given selections.
+// A Namespace is only excluded from the union if all selections exclude it and no selection includes it.
+func Union(selections ...Selection) Selection {
+	if len(selections) == 0 {
+		return Selection{}
+	}
+
+	var included []string
+	var excludeSets []map[string]bool
+	for _, s := range selections {
+		if s.SelectsAll() {
+			return Selection{}
+		}
+		included = appendMissing(included, s.Include...)
+		if len(s.Exclude) > 0 {
+			excludeSets = append(excludeSets, toSet(s.Exclude))
+		}
+	}
+
+	if len(excludeSets) == 0 {
+		return Selection{Include: included}
+	}
+
+	includedSet := toSet(included)
+	var excluded []string
+	for _, ns := range firstExclude(selections) {
+		if includedSet[ns] || !containedInAll(ns, excludeSets) {
+			continue
+		}
+		excluded = append(excluded, ns)
+	}
+	return Selection{Exclude: excluded}
+}
+
+func firstExclude(selections []Selection) []string {
+	for _, s := range selections {
+		if len(s.Exclude) > 0 {
+			return s.Exclude
+		}
+	}
+	return nil
+}
+
+func containedInAll(ns string, sets []map[string]bool) bool {
+	for _, set := range sets {
+		if !set[ns] {
+			return false
+		}
+	}
+	return true
+}
+
+func appendMissing(list []string, values ...string) []string {
+	existing := toSet(list)
+	for _, v := range values {
+		if !existing[v] {
+			list = append(list, v)
+			existing[v] = true
+		}
+	}
+	return list
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,99 @@
+package namespaces
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector *telemetryv1alpha1.NamespaceSelector
+		expected Selection
+	}{
+		{
+			name:     "no selector",
+			expected: Selection{},
+		},
+		{
+			name:     "empty selector excludes system namespaces",
+			selector: &telemetryv1alpha1.NamespaceSelector{},
+			expected: Selection{Exclude: System()},
+		},
+		{
+			name:     "system namespaces",
+			selector: &telemetryv1alpha1.NamespaceSelector{System: true},
+			expected: Selection{},
+		},
+		{
+			name:     "include",
+			selector: &telemetryv1alpha1.NamespaceSelector{Include: []string{"kyma-system", "default"}},
+			expected: Selection{Include: []string{"kyma-system", "default"}},
+		},
+		{
+			name:     "exclude",
+			selector: &telemetryv1alpha1.NamespaceSelector{Exclude: []string{"default"}},
+			expected: Selection{Exclude: []string{"default", "kyma-system", "kube-system", "istio-system", "compass-system"}},
+		},
+		{
+			name:     "exclude with system namespaces",
+			selector: &telemetryv1alpha1.NamespaceSelector{Exclude: []string{"default"}, System: true},
+			expected: Selection{Exclude: []string{"default"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, Resolve(tt.selector))
+		})
+	}
+}
+
+func TestUnion(t *testing.T) {
+	tests := []struct {
+		name       string
+		selections []Selection
+		expected   Selection
+	}{
+		{
+			name:     "no selections",
+			expected: Selection{},
+		},
+		{
+			name:       "one selection selects all",
+			selections: []Selection{{Include: []string{"a"}}, {}},
+			expected:   Selection{},
+		},
+		{
+			name:       "includes are merged",
+			selections: []Selection{{Include: []string{"a", "b"}}, {Include: []string{"b", "c"}}},
+			expected:   Selection{Include: []string{"a", "b", "c"}},
+		},
+		{
+			name:       "only namespaces excluded by all selections are excluded",
+			selections: []Selection{{Exclude: []string{"a", "b", "c"}}, {Exclude: []string{"b", "c", "d"}}},
+			expected:   Selection{Exclude: []string{"b", "c"}},
+		},
+		{
+			name:       "included namespaces are not excluded",
+			selections: []Selection{{Exclude: []string{"a", "b"}}, {Include: []string{"b"}}},
+			expected:   Selection{Exclude: []string{"a"}},
+		},
+		{
+			name:       "disjoint excludes select all",
+			selections: []Selection{{Exclude: []string{"a"}}, {Exclude: []string{"b"}}},
+			expected:   Selection{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			union := Union(tt.selections...)
+			require.Equal(t, tt.expected.Include, union.Include)
+			require.Equal(t, tt.expected.Exclude, union.Exclude)
+		})
+	}
+}
@@ -6,8 +6,30 @@ type Base struct {
 }
 
 type Extensions struct {
-	HealthCheck Endpoint `yaml:"health_check,omitempty"`
-	Pprof       Endpoint `yaml:"pprof,omitempty"`
+	HealthCheck Endpoint              `yaml:"health_check,omitempty"`
+	Pprof       Endpoint              `yaml:"pprof,omitempty"`
+	FileStorage *FileStorageExtension `yaml:"file_storage/queue,omitempty"`
+
+	// OTel Collector components with dynamic IDs that are pipeline name based.
+	Dynamic map[string]any `yaml:",inline,omitempty"`
+}
+
+// FileStorageExtension stores the sending queues of the exporters on disk.
+type FileStorageExtension struct {
+	Directory string `yaml:"directory"`
+}
+
+// OAuth2ClientExtension is an authenticator extension that requests access tokens with the OAuth2 client credentials flow.
+type OAuth2ClientExtension struct {
+	TokenURL     string   `yaml:"token_url"`
+	ClientID     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret"`
+	Scopes       []string `yaml:"scopes,omitempty"`
+}
+
+// BearerTokenAuthExtension is an authenticator extension that sends a static bearer token.
+type BearerTokenAuthExtension struct {
+	Token string `yaml:"token"`
 }
 
 type Endpoint struct {
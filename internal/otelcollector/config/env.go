@@ -4,3 +4,10 @@ const (
 	EnvVarCurrentPodIP    = "MY_POD_IP"
 	EnvVarCurrentNodeName = "MY_NODE_NAME"
 )
+
+const (
+	// FileStorageExtensionID is the ID of the extension that stores persistent sending queues.
+	FileStorageExtensionID = "file_storage/queue"
+	// PersistentQueueDirectory is the directory in the collector container where the persistent sending queues are stored.
+	PersistentQueueDirectory = "/var/lib/otelcol/queue"
+)
@@ -4,8 +4,26 @@ type OTLPExporter struct {
 	Endpoint       string            `yaml:"endpoint,omitempty"`
 	Headers        map[string]string `yaml:"headers,omitempty"`
 	TLS            TLS               `yaml:"tls,omitempty"`
+	Compression    string            `yaml:"compression,omitempty"`
+	Timeout        string            `yaml:"timeout,omitempty"`
 	SendingQueue   SendingQueue      `yaml:"sending_queue,omitempty"`
 	RetryOnFailure RetryOnFailure    `yaml:"retry_on_failure,omitempty"`
+	Auth           *Auth             `yaml:"auth,omitempty"`
+}
+
+type ZipkinExporter struct {
+	Endpoint       string            `yaml:"endpoint"`
+	Format         string            `yaml:"format,omitempty"`
+	Headers        map[string]string `yaml:"headers,omitempty"`
+	TLS            TLS               `yaml:"tls,omitempty"`
+	Timeout        string            `yaml:"timeout,omitempty"`
+	SendingQueue   SendingQueue      `yaml:"sending_queue,omitempty"`
+	RetryOnFailure RetryOnFailure    `yaml:"retry_on_failure,omitempty"`
+	Auth           *Auth             `yaml:"auth,omitempty"`
+}
+
+type Auth struct {
+	Authenticator string `yaml:"authenticator"`
 }
 
 type TLS struct {
@@ -17,8 +35,9 @@ type TLS struct {
 }
 
 type SendingQueue struct {
-	Enabled   bool `yaml:"enabled"`
-	QueueSize int  `yaml:"queue_size"`
+	Enabled   bool   `yaml:"enabled"`
+	QueueSize int    `yaml:"queue_size"`
+	Storage   string `yaml:"storage,omitempty"`
 }
 
 type RetryOnFailure struct {
@@ -27,3 +46,32 @@ type RetryOnFailure struct {
 	MaxInterval     string `yaml:"max_interval"`
 	MaxElapsedTime  string `yaml:"max_elapsed_time"`
 }
+
+type PrometheusRemoteWriteExporter struct {
+	Endpoint                      string                        `yaml:"endpoint"`
+	Headers                       map[string]string             `yaml:"headers,omitempty"`
+	TLS                           TLS                           `yaml:"tls,omitempty"`
+	Timeout                       string                        `yaml:"timeout,omitempty"`
+	ExternalLabels                map[string]string             `yaml:"external_labels,omitempty"`
+	ResourceToTelemetryConversion ResourceToTelemetryConversion `yaml:"resource_to_telemetry_conversion"`
+	RemoteWriteQueue              RemoteWriteQueue              `yaml:"remote_write_queue"`
+	RetryOnFailure                RetryOnFailure                `yaml:"retry_on_failure"`
+	Auth                          *Auth                         `yaml:"auth,omitempty"`
+}
+
+type RemoteWriteQueue struct {
+	Enabled   bool `yaml:"enabled"`
+	QueueSize int  `yaml:"queue_size"`
+}
+
+type PrometheusExporter struct {
+	Endpoint                      string                        `yaml:"endpoint"`
+	Namespace                     string                        `yaml:"namespace,omitempty"`
+	ConstLabels                   map[string]string             `yaml:"const_labels,omitempty"`
+	MetricExpiration              string                        `yaml:"metric_expiration,omitempty"`
+	ResourceToTelemetryConversion ResourceToTelemetryConversion `yaml:"resource_to_telemetry_conversion"`
+}
+
+type ResourceToTelemetryConversion struct {
+	Enabled bool `yaml:"enabled"`
+}
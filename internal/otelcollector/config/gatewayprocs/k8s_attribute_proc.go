@@ -1,10 +1,15 @@
 package gatewayprocs
 
 import (
+	"slices"
+
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
 )
 
-func K8sAttributesProcessorConfig() *config.K8sAttributesProcessor {
+// K8sAttributesProcessorConfig returns the configuration of the processor that enriches the data with Kubernetes metadata.
+// Besides the default metadata, the labels, annotations and metadata defined in the given enrichments are extracted.
+func K8sAttributesProcessorConfig(enrichments *operatorv1alpha1.EnrichmentSpec) *config.K8sAttributesProcessor {
 	k8sAttributes := []string{
 		"k8s.pod.name",
 		"k8s.node.name",
@@ -28,13 +33,28 @@ func K8sAttributesProcessorConfig() *config.K8sAttributesProcessor {
 		},
 	}
 
+	extract := config.ExtractK8sMetadata{
+		Metadata: k8sAttributes,
+		Labels:   extractLabels(),
+	}
+
+	if enrichments != nil {
+		for _, metadata := range enrichments.Metadata {
+			if !slices.Contains(extract.Metadata, string(metadata)) {
+				extract.Metadata = append(extract.Metadata, string(metadata))
+			}
+		}
+
+		extract.Labels = append(extract.Labels, extractCustom("pod", "labels", enrichments.PodLabels)...)
+		extract.Labels = append(extract.Labels, extractCustom("namespace", "labels", enrichments.NamespaceLabels)...)
+		extract.Annotations = append(extract.Annotations, extractCustom("pod", "annotations", enrichments.PodAnnotations)...)
+		extract.Annotations = append(extract.Annotations, extractCustom("namespace", "annotations", enrichments.NamespaceAnnotations)...)
+	}
+
 	return &config.K8sAttributesProcessor{
-		AuthType:    "serviceAccount",
-		Passthrough: false,
-		Extract: config.ExtractK8sMetadata{
-			Metadata: k8sAttributes,
-			Labels:   extractLabels(),
-		},
+		AuthType:       "serviceAccount",
+		Passthrough:    false,
+		Extract:        extract,
 		PodAssociation: podAssociations,
 	}
 }
@@ -53,3 +73,17 @@ func extractLabels() []config.ExtractLabel {
 		},
 	}
 }
+
+// extractCustom returns the rules to extract the given label or annotation keys of a Pod or Namespace.
+// The keys are stored as k8s.<from>.<kind>.<key> attributes, following the naming of the OpenTelemetry semantic conventions.
+func extractCustom(from, kind string, keys []string) []config.ExtractLabel {
+	var rules []config.ExtractLabel
+	for _, key := range keys {
+		rules = append(rules, config.ExtractLabel{
+			From:    from,
+			Key:     key,
+			TagName: "k8s." + from + "." + kind + "." + key,
+		})
+	}
+	return rules
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
 )
 
@@ -45,7 +46,7 @@ func TestK8sAttributesProcessorConfig(t *testing.T) {
 		},
 	}
 
-	config := K8sAttributesProcessorConfig()
+	config := K8sAttributesProcessorConfig(nil)
 
 	require.Equal("serviceAccount", config.AuthType)
 	require.Equal(false, config.Passthrough)
@@ -54,3 +55,26 @@ func TestK8sAttributesProcessorConfig(t *testing.T) {
 	require.ElementsMatch(expectedK8sAttributes, config.Extract.Metadata, "Metadata should match")
 	require.ElementsMatch(expectedExtractLabels, config.Extract.Labels, "Labels should match")
 }
+
+func TestK8sAttributesProcessorConfigWithEnrichments(t *testing.T) {
+	require := require.New(t)
+
+	processorConfig := K8sAttributesProcessorConfig(&operatorv1alpha1.EnrichmentSpec{
+		PodLabels:            []string{"team"},
+		PodAnnotations:       []string{"example.com/owner"},
+		NamespaceLabels:      []string{"cost-center"},
+		NamespaceAnnotations: []string{"example.com/contact"},
+		Metadata:             []operatorv1alpha1.EnrichmentMetadata{"k8s.pod.uid", "k8s.replicaset.name", "k8s.pod.uid"},
+	})
+
+	require.Contains(processorConfig.Extract.Metadata, "k8s.pod.uid")
+	require.Contains(processorConfig.Extract.Metadata, "k8s.replicaset.name")
+	require.Len(processorConfig.Extract.Metadata, 10, "Metadata should not contain duplicates")
+
+	require.Contains(processorConfig.Extract.Labels, config.ExtractLabel{From: "pod", Key: "team", TagName: "k8s.pod.labels.team"})
+	require.Contains(processorConfig.Extract.Labels, config.ExtractLabel{From: "namespace", Key: "cost-center", TagName: "k8s.namespace.labels.cost-center"})
+	require.Equal([]config.ExtractLabel{
+		{From: "pod", Key: "example.com/owner", TagName: "k8s.pod.annotations.example.com/owner"},
+		{From: "namespace", Key: "example.com/contact", TagName: "k8s.namespace.annotations.example.com/contact"},
+	}, processorConfig.Extract.Annotations)
+}
@@ -0,0 +1,33 @@
+package gatewayprocs
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/kyma-project/telemetry-manager/internal/namespaces"
+)
+
+const namespaceAttribute = "resource.attributes[\"k8s.namespace.name\"]"
+
+// NamespaceNotSelectedCondition returns an OTTL condition that matches data from Namespaces that are not selected.
+// Data without a Namespace is only matched if the selection lists the included Namespaces.
+// If all Namespaces are selected, an empty string is returned.
+func NamespaceNotSelectedCondition(selection namespaces.Selection) string {
+	if len(selection.Include) > 0 {
+		var parts []string
+		for _, ns := range selection.Include {
+			parts = append(parts, namespaceAttribute+" != "+strconv.Quote(ns))
+		}
+		return strings.Join(parts, " and ")
+	}
+
+	if len(selection.Exclude) > 0 {
+		var parts []string
+		for _, ns := range selection.Exclude {
+			parts = append(parts, namespaceAttribute+" == "+strconv.Quote(ns))
+		}
+		return "(" + strings.Join(parts, " or ") + ")"
+	}
+
+	return ""
+}
@@ -0,0 +1,44 @@
+package gatewayprocs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/telemetry-manager/internal/namespaces"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/ottl"
+)
+
+func TestNamespaceNotSelectedCondition(t *testing.T) {
+	tests := []struct {
+		name      string
+		selection namespaces.Selection
+		expected  string
+	}{
+		{
+			name:      "all namespaces",
+			selection: namespaces.Selection{},
+			expected:  "",
+		},
+		{
+			name:      "include",
+			selection: namespaces.Selection{Include: []string{"a", "b"}},
+			expected:  `resource.attributes["k8s.namespace.name"] != "a" and resource.attributes["k8s.namespace.name"] != "b"`,
+		},
+		{
+			name:      "exclude",
+			selection: namespaces.Selection{Exclude: []string{"a", "b"}},
+			expected:  `(resource.attributes["k8s.namespace.name"] == "a" or resource.attributes["k8s.namespace.name"] == "b")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := NamespaceNotSelectedCondition(tt.selection)
+			require.Equal(t, tt.expected, condition)
+			if condition != "" {
+				require.NoError(t, ottl.ValidateCondition(ottl.ContextMetric, condition))
+			}
+		})
+	}
+}
@@ -1,19 +1,47 @@
 package gatewayprocs
 
 import (
+	"sort"
+
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
 )
 
-func InsertClusterNameProcessorConfig() *config.ResourceProcessor {
-	return &config.ResourceProcessor{
-		Attributes: []config.AttributeAction{
-			{
-				Action: "insert",
-				Key:    "k8s.cluster.name",
-				Value:  "${KUBERNETES_SERVICE_HOST}",
-			},
+// InsertClusterNameProcessorConfig returns the configuration of the processor that adds the cluster name and the static cluster attributes.
+// If no cluster name is configured, the address of the Kubernetes API server is used.
+func InsertClusterNameProcessorConfig(cluster *operatorv1alpha1.ClusterSpec) *config.ResourceProcessor {
+	clusterName := "${KUBERNETES_SERVICE_HOST}"
+	if cluster != nil && cluster.Name != "" {
+		clusterName = cluster.Name
+	}
+
+	attributes := []config.AttributeAction{
+		{
+			Action: "insert",
+			Key:    "k8s.cluster.name",
+			Value:  clusterName,
 		},
 	}
+
+	if cluster != nil {
+		var keys []string
+		for key := range cluster.Attributes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			attributes = append(attributes, config.AttributeAction{
+				Action: "insert",
+				Key:    key,
+				Value:  cluster.Attributes[key],
+			})
+		}
+	}
+
+	return &config.ResourceProcessor{
+		Attributes: attributes,
+	}
 }
 
 func DropKymaAttributesProcessorConfig() *config.ResourceProcessor {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
 )
 
@@ -19,11 +20,43 @@ func TestInsertClusterNameProcessorConfig(t *testing.T) {
 		},
 	}
 
-	config := InsertClusterNameProcessorConfig()
+	config := InsertClusterNameProcessorConfig(nil)
 
 	require.ElementsMatch(expectedAttributeActions, config.Attributes, "Attributes should match")
 }
 
+func TestInsertClusterNameProcessorConfigWithCluster(t *testing.T) {
+	require := require.New(t)
+
+	expectedAttributeActions := []config.AttributeAction{
+		{
+			Action: "insert",
+			Key:    "k8s.cluster.name",
+			Value:  "prod-eu-1",
+		},
+		{
+			Action: "insert",
+			Key:    "deployment.environment",
+			Value:  "production",
+		},
+		{
+			Action: "insert",
+			Key:    "region",
+			Value:  "eu-central-1",
+		},
+	}
+
+	processorConfig := InsertClusterNameProcessorConfig(&operatorv1alpha1.ClusterSpec{
+		Name: "prod-eu-1",
+		Attributes: map[string]string{
+			"region":                 "eu-central-1",
+			"deployment.environment": "production",
+		},
+	})
+
+	require.Equal(expectedAttributeActions, processorConfig.Attributes, "Attributes should be sorted by key")
+}
+
 func TestDropKymaAttributesProcessorConfig(t *testing.T) {
 	require := require.New(t)
 
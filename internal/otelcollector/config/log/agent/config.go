@@ -0,0 +1,61 @@
+package agent
+
+import (
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+)
+
+const (
+	// CheckpointDirectory is the directory in the collector container where the read offsets of the log files are stored.
+	CheckpointDirectory = "/var/lib/otelcol/checkpoints"
+	// LogsDirectory is the directory on the node and in the collector container that contains the container log files.
+	LogsDirectory = "/var/log/pods"
+)
+
+type Config struct {
+	config.Base `yaml:",inline"`
+
+	Receivers  Receivers  `yaml:"receivers"`
+	Processors Processors `yaml:"processors"`
+	Exporters  Exporters  `yaml:"exporters"`
+}
+
+// Receivers contains one filelog receiver per LogPipeline, keyed by the pipeline-based receiver ID.
+type Receivers map[string]FileLogReceiver
+
+type FileLogReceiver struct {
+	Include         []string              `yaml:"include"`
+	Exclude         []string              `yaml:"exclude,omitempty"`
+	IncludeFileName bool                  `yaml:"include_file_name"`
+	IncludeFilePath bool                  `yaml:"include_file_path"`
+	StartAt         string                `yaml:"start_at"`
+	Storage         string                `yaml:"storage"`
+	RetryOnFailure  config.RetryOnFailure `yaml:"retry_on_failure"`
+	Operators       []Operator            `yaml:"operators"`
+}
+
+type Operator struct {
+	ID    string `yaml:"id"`
+	Type  string `yaml:"type"`
+	From  string `yaml:"from,omitempty"`
+	To    string `yaml:"to,omitempty"`
+	Field string `yaml:"field,omitempty"`
+	Value string `yaml:"value,omitempty"`
+}
+
+type Processors struct {
+	config.BaseProcessors `yaml:",inline"`
+
+	K8sAttributes *config.K8sAttributesProcessor `yaml:"k8sattributes,omitempty"`
+
+	// OTel Collector components with dynamic IDs that are pipeline name based.
+	Dynamic map[string]any `yaml:",inline,omitempty"`
+}
+
+type TransformProcessor struct {
+	ErrorMode     string                                `yaml:"error_mode"`
+	LogStatements []config.TransformProcessorStatements `yaml:"log_statements"`
+}
+
+type Exporters struct {
+	OTLP config.OTLPExporter `yaml:"otlp"`
+}
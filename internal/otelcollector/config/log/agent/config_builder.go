@@ -0,0 +1,108 @@
+package agent
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
+)
+
+const checkpointStorageID = "file_storage"
+
+// BuildOptions contains the settings for the log agent config that are not derived from the LogPipeline resources.
+type BuildOptions struct {
+	// GatewayServiceName is the OTLP Service of the log gateway, to which the agent sends all logs.
+	GatewayServiceName types.NamespacedName
+	// AgentName is the name and Namespace of the log agent DaemonSet. The logs of the agent itself are never collected.
+	AgentName types.NamespacedName
+}
+
+// MakeConfig creates the log agent configuration for the given LogPipelines. Every pipeline gets a dedicated filelog receiver
+// that tails the container logs selected by the application input of the pipeline.
+func MakeConfig(pipelines []telemetryv1alpha1.LogPipeline, opts BuildOptions) *Config {
+	cfg := &Config{
+		Base: config.Base{
+			Extensions: makeExtensionsConfig(),
+			Service:    makeServiceConfig(),
+		},
+		Receivers:  make(Receivers),
+		Processors: makeProcessorsConfig(),
+		Exporters:  makeExportersConfig(opts.GatewayServiceName),
+	}
+
+	for i := range pipelines {
+		addComponentsForLogPipeline(&pipelines[i], cfg, opts)
+	}
+
+	return cfg
+}
+
+func addComponentsForLogPipeline(pipeline *telemetryv1alpha1.LogPipeline, cfg *Config, opts BuildOptions) {
+	receiverID := fmt.Sprintf("filelog/%s", pipeline.Name)
+	cfg.Receivers[receiverID] = makeFileLogReceiverConfig(pipeline, opts.AgentName)
+
+	processorIDs := []string{"memory_limiter", "k8sattributes"}
+	if dropMetadata := makeDropMetadataConfig(pipeline.Spec.Input.Application); dropMetadata != nil {
+		dropMetadataID := fmt.Sprintf("transform/drop-metadata-%s", pipeline.Name)
+		cfg.Processors.Dynamic[dropMetadataID] = dropMetadata
+		processorIDs = append(processorIDs, dropMetadataID)
+	}
+	processorIDs = append(processorIDs, "batch")
+
+	cfg.Service.Pipelines[fmt.Sprintf("logs/%s", pipeline.Name)] = config.Pipeline{
+		Receivers:  []string{receiverID},
+		Processors: processorIDs,
+		Exporters:  []string{"otlp"},
+	}
+}
+
+func makeExportersConfig(gatewayServiceName types.NamespacedName) Exporters {
+	return Exporters{
+		OTLP: config.OTLPExporter{
+			Endpoint: fmt.Sprintf("%s.%s.svc.cluster.local:%d", gatewayServiceName.Name, gatewayServiceName.Namespace, ports.OTLPGRPC),
+			TLS: config.TLS{
+				Insecure: true,
+			},
+			SendingQueue: config.SendingQueue{
+				Enabled:   true,
+				QueueSize: 512,
+			},
+			RetryOnFailure: config.RetryOnFailure{
+				Enabled:         true,
+				InitialInterval: "5s",
+				MaxInterval:     "30s",
+				MaxElapsedTime:  "300s",
+			},
+		},
+	}
+}
+
+func makeExtensionsConfig() config.Extensions {
+	return config.Extensions{
+		HealthCheck: config.Endpoint{
+			Endpoint: fmt.Sprintf("${%s}:%d", config.EnvVarCurrentPodIP, ports.HealthCheck),
+		},
+		Dynamic: map[string]any{
+			checkpointStorageID: config.FileStorageExtension{Directory: CheckpointDirectory},
+		},
+	}
+}
+
+func makeServiceConfig() config.Service {
+	return config.Service{
+		Pipelines: make(config.Pipelines),
+		Telemetry: config.Telemetry{
+			Metrics: config.Metrics{
+				Address: fmt.Sprintf("${%s}:%d", config.EnvVarCurrentPodIP, ports.Metrics),
+			},
+			Logs: config.Logs{
+				Level:    "info",
+				Encoding: "json",
+			},
+		},
+		Extensions: []string{"health_check", checkpointStorageID},
+	}
+}
@@ -0,0 +1,77 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
+)
+
+func TestMakeConfig(t *testing.T) {
+	opts := BuildOptions{
+		GatewayServiceName: types.NamespacedName{Name: "logs", Namespace: "telemetry-system"},
+		AgentName:          types.NamespacedName{Name: "telemetry-log-agent", Namespace: "telemetry-system"},
+	}
+
+	t.Run("otlp exporter endpoint", func(t *testing.T) {
+		collectorConfig := MakeConfig([]v1alpha1.LogPipeline{testutils.NewLogPipelineBuilder().WithOtlpOutput("https://localhost").Build()}, opts)
+
+		require.Equal(t, "logs.telemetry-system.svc.cluster.local:4317", collectorConfig.Exporters.OTLP.Endpoint)
+		require.True(t, collectorConfig.Exporters.OTLP.TLS.Insecure)
+	})
+
+	t.Run("extensions", func(t *testing.T) {
+		collectorConfig := MakeConfig([]v1alpha1.LogPipeline{testutils.NewLogPipelineBuilder().WithOtlpOutput("https://localhost").Build()}, opts)
+
+		require.NotEmpty(t, collectorConfig.Extensions.HealthCheck.Endpoint)
+		require.Equal(t, []string{"health_check", "file_storage"}, collectorConfig.Service.Extensions)
+	})
+
+	t.Run("pipeline per log pipeline", func(t *testing.T) {
+		collectorConfig := MakeConfig([]v1alpha1.LogPipeline{
+			testutils.NewLogPipelineBuilder().WithName("test-1").WithOtlpOutput("https://localhost").Build(),
+			testutils.NewLogPipelineBuilder().WithName("test-2").WithOtlpOutput("https://localhost").Build(),
+		}, opts)
+
+		require.Len(t, collectorConfig.Receivers, 2)
+		require.Contains(t, collectorConfig.Receivers, "filelog/test-1")
+		require.Contains(t, collectorConfig.Receivers, "filelog/test-2")
+
+		require.Len(t, collectorConfig.Service.Pipelines, 2)
+		require.Equal(t, []string{"filelog/test-1"}, collectorConfig.Service.Pipelines["logs/test-1"].Receivers)
+		require.Equal(t, []string{"memory_limiter", "k8sattributes", "transform/drop-metadata-test-1", "batch"}, collectorConfig.Service.Pipelines["logs/test-1"].Processors)
+		require.Equal(t, []string{"otlp"}, collectorConfig.Service.Pipelines["logs/test-1"].Exporters)
+	})
+
+	t.Run("keep annotations and labels", func(t *testing.T) {
+		pipeline := testutils.NewLogPipelineBuilder().WithName("test").WithOtlpOutput("https://localhost").Build()
+		pipeline.Spec.Input.Application.KeepAnnotations = true
+
+		collectorConfig := MakeConfig([]v1alpha1.LogPipeline{pipeline}, opts)
+
+		require.Empty(t, collectorConfig.Processors.Dynamic)
+		require.Equal(t, []string{"memory_limiter", "k8sattributes", "batch"}, collectorConfig.Service.Pipelines["logs/test"].Processors)
+	})
+
+	t.Run("marshaling", func(t *testing.T) {
+		pipeline := testutils.NewLogPipelineBuilder().WithName("test").WithOtlpOutput("https://localhost").Build()
+		pipeline.Spec.Input.Application.DropLabels = true
+
+		collectorConfig := MakeConfig([]v1alpha1.LogPipeline{pipeline}, opts)
+
+		configYAML, err := yaml.Marshal(collectorConfig)
+		require.NoError(t, err, "failed to marshal config")
+
+		goldenFilePath := filepath.Join("testdata", "config.yaml")
+		goldenFile, err := os.ReadFile(goldenFilePath)
+		require.NoError(t, err, "failed to load golden file")
+
+		require.Equal(t, string(goldenFile), string(configYAML))
+	})
+}
@@ -0,0 +1,84 @@
+package agent
+
+import (
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+)
+
+func makeProcessorsConfig() Processors {
+	return Processors{
+		BaseProcessors: config.BaseProcessors{
+			Batch: &config.BatchProcessor{
+				SendBatchSize:    512,
+				Timeout:          "10s",
+				SendBatchMaxSize: 512,
+			},
+			MemoryLimiter: &config.MemoryLimiter{
+				CheckInterval:        "1s",
+				LimitPercentage:      75,
+				SpikeLimitPercentage: 15,
+			},
+		},
+		K8sAttributes: makeK8sAttributesProcessorConfig(),
+		Dynamic:       make(map[string]any),
+	}
+}
+
+// makeK8sAttributesProcessorConfig creates a processor that enriches the logs with the workload metadata, and all labels and annotations of the Pod.
+// The agent only watches the Pods of its own node. The Pods are identified by the UID that the filelog receiver takes from the log file path.
+func makeK8sAttributesProcessorConfig() *config.K8sAttributesProcessor {
+	return &config.K8sAttributesProcessor{
+		AuthType:    "serviceAccount",
+		Passthrough: false,
+		Extract: config.ExtractK8sMetadata{
+			Metadata: []string{
+				"k8s.node.name",
+				"k8s.deployment.name",
+				"k8s.statefulset.name",
+				"k8s.daemonset.name",
+				"k8s.cronjob.name",
+				"k8s.job.name",
+			},
+			Labels: []config.ExtractLabel{
+				{From: "pod", KeyRegex: "(.*)", TagName: "k8s.pod.labels.$$1"},
+			},
+			Annotations: []config.ExtractLabel{
+				{From: "pod", KeyRegex: "(.*)", TagName: "k8s.pod.annotations.$$1"},
+			},
+		},
+		PodAssociation: []config.PodAssociations{
+			{
+				Sources: []config.PodAssociation{{From: "resource_attribute", Name: "k8s.pod.uid"}},
+			},
+		},
+		Filter: &config.K8sAttributesFilter{
+			NodeFromEnvVar: config.EnvVarCurrentNodeName,
+		},
+	}
+}
+
+// makeDropMetadataConfig creates a processor that removes the Pod annotations and labels that the pipeline does not keep, like the Kubernetes metadata filters of Fluent Bit.
+// It returns nil if the pipeline keeps all metadata.
+func makeDropMetadataConfig(input telemetryv1alpha1.ApplicationInput) *TransformProcessor {
+	var statements []string
+	if !input.KeepAnnotations {
+		statements = append(statements, `delete_matching_keys(attributes, "k8s\\.pod\\.annotations\\..*")`)
+	}
+	if input.DropLabels {
+		statements = append(statements, `delete_matching_keys(attributes, "k8s\\.pod\\.labels\\..*")`)
+	}
+
+	if len(statements) == 0 {
+		return nil
+	}
+
+	return &TransformProcessor{
+		ErrorMode: "ignore",
+		LogStatements: []config.TransformProcessorStatements{
+			{
+				Context:    "resource",
+				Statements: statements,
+			},
+		},
+	}
+}
@@ -0,0 +1,102 @@
+package agent
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/namespaces"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/log"
+)
+
+// makeFileLogReceiverConfig creates a filelog receiver that tails the container logs selected by the application input of the pipeline.
+// The log line is stored in the "log" key of the body, and the body is marked with the pipeline name, which matches the records that Fluent Bit forwards to the log gateway.
+func makeFileLogReceiverConfig(pipeline *telemetryv1alpha1.LogPipeline, agentName types.NamespacedName) FileLogReceiver {
+	input := pipeline.Spec.Input.Application
+
+	return FileLogReceiver{
+		Include:         includePaths(input),
+		Exclude:         excludePaths(input, agentName),
+		IncludeFileName: false,
+		IncludeFilePath: true,
+		StartAt:         "beginning",
+		Storage:         checkpointStorageID,
+		RetryOnFailure: config.RetryOnFailure{
+			Enabled:         true,
+			InitialInterval: "5s",
+			MaxInterval:     "30s",
+			MaxElapsedTime:  "300s",
+		},
+		Operators: []Operator{
+			{
+				ID:   "parse-container-log",
+				Type: "container",
+			},
+			{
+				ID:   "move-body-to-log",
+				Type: "move",
+				From: "body",
+				To:   "body.log",
+			},
+			{
+				ID:    "add-pipeline-name",
+				Type:  "add",
+				Field: fmt.Sprintf(`body["%s"]`, log.PipelineNameAttribute),
+				Value: pipeline.Name,
+			},
+		},
+	}
+}
+
+// includePaths returns the log file patterns of the selected Namespaces and containers.
+// The container log files are stored in /var/log/pods/<namespace>_<pod>_<uid>/<container>/<restart count>.log.
+func includePaths(input telemetryv1alpha1.ApplicationInput) []string {
+	namespacePatterns := []string{"*"}
+	if len(input.Namespaces.Include) > 0 {
+		namespacePatterns = input.Namespaces.Include
+	}
+
+	containerPatterns := []string{"*"}
+	if len(input.Containers.Include) > 0 {
+		containerPatterns = input.Containers.Include
+	}
+
+	var paths []string
+	for _, namespace := range namespacePatterns {
+		for _, container := range containerPatterns {
+			paths = append(paths, logFilePattern(namespace, "*", container))
+		}
+	}
+	return paths
+}
+
+// excludePaths returns the log file patterns of the excluded Namespaces and containers, and of the agent itself.
+// Like in Fluent Bit, the system Namespaces are excluded unless the pipeline selects Namespaces explicitly or includes the system Namespaces.
+func excludePaths(input telemetryv1alpha1.ApplicationInput, agentName types.NamespacedName) []string {
+	paths := []string{logFilePattern(agentName.Namespace, agentName.Name+"-*", "*")}
+
+	var excludedNamespaces []string
+	switch {
+	case input.Namespaces.System || len(input.Namespaces.Include) > 0:
+	case len(input.Namespaces.Exclude) > 0:
+		excludedNamespaces = input.Namespaces.Exclude
+	default:
+		excludedNamespaces = namespaces.System()
+	}
+
+	for _, namespace := range excludedNamespaces {
+		paths = append(paths, logFilePattern(namespace, "*", "*"))
+	}
+
+	for _, container := range input.Containers.Exclude {
+		paths = append(paths, logFilePattern("*", "*", container))
+	}
+
+	return paths
+}
+
+func logFilePattern(namespace, pod, container string) string {
+	return fmt.Sprintf("%s/%s_%s_*/%s/*.log", LogsDirectory, namespace, pod, container)
+}
@@ -0,0 +1,81 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
+)
+
+func TestFileLogReceiver(t *testing.T) {
+	agentName := types.NamespacedName{Name: "telemetry-log-agent", Namespace: "kyma-system"}
+	agentExcludePath := "/var/log/pods/kyma-system_telemetry-log-agent-*_*/*/*.log"
+
+	tests := []struct {
+		name            string
+		input           v1alpha1.ApplicationInput
+		expectedInclude []string
+		expectedExclude []string
+	}{
+		{
+			name:            "default excludes system namespaces",
+			input:           v1alpha1.ApplicationInput{},
+			expectedInclude: []string{"/var/log/pods/*_*_*/*/*.log"},
+			expectedExclude: []string{
+				agentExcludePath,
+				"/var/log/pods/kyma-system_*_*/*/*.log",
+				"/var/log/pods/kube-system_*_*/*/*.log",
+				"/var/log/pods/istio-system_*_*/*/*.log",
+				"/var/log/pods/compass-system_*_*/*/*.log",
+			},
+		},
+		{
+			name:            "include system namespaces",
+			input:           v1alpha1.ApplicationInput{Namespaces: v1alpha1.InputNamespaces{System: true}},
+			expectedInclude: []string{"/var/log/pods/*_*_*/*/*.log"},
+			expectedExclude: []string{agentExcludePath},
+		},
+		{
+			name: "include namespaces and containers",
+			input: v1alpha1.ApplicationInput{
+				Namespaces: v1alpha1.InputNamespaces{Include: []string{"ns-1", "ns-2"}},
+				Containers: v1alpha1.InputContainers{Include: []string{"app"}},
+			},
+			expectedInclude: []string{"/var/log/pods/ns-1_*_*/app/*.log", "/var/log/pods/ns-2_*_*/app/*.log"},
+			expectedExclude: []string{agentExcludePath},
+		},
+		{
+			name: "exclude namespaces and containers",
+			input: v1alpha1.ApplicationInput{
+				Namespaces: v1alpha1.InputNamespaces{Exclude: []string{"ns-1"}},
+				Containers: v1alpha1.InputContainers{Exclude: []string{"istio-proxy"}},
+			},
+			expectedInclude: []string{"/var/log/pods/*_*_*/*/*.log"},
+			expectedExclude: []string{agentExcludePath, "/var/log/pods/ns-1_*_*/*/*.log", "/var/log/pods/*_*_*/istio-proxy/*.log"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline := testutils.NewLogPipelineBuilder().WithName("test").Build()
+			pipeline.Spec.Input.Application = tt.input
+
+			receiver := makeFileLogReceiverConfig(&pipeline, agentName)
+
+			require.Equal(t, tt.expectedInclude, receiver.Include)
+			require.ElementsMatch(t, tt.expectedExclude, receiver.Exclude)
+		})
+	}
+
+	t.Run("stores offsets and marks body with pipeline name", func(t *testing.T) {
+		pipeline := testutils.NewLogPipelineBuilder().WithName("test").Build()
+
+		receiver := makeFileLogReceiverConfig(&pipeline, agentName)
+
+		require.Equal(t, "file_storage", receiver.Storage)
+		require.Equal(t, Operator{ID: "add-pipeline-name", Type: "add", Field: `body["kyma.log_pipeline"]`, Value: "test"}, receiver.Operators[len(receiver.Operators)-1])
+	})
+}
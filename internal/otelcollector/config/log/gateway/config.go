@@ -0,0 +1,47 @@
+package gateway
+
+import (
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+)
+
+type Config struct {
+	config.Base `yaml:",inline"`
+
+	Receivers  Receivers  `yaml:"receivers"`
+	Processors Processors `yaml:"processors"`
+	Exporters  Exporters  `yaml:"exporters"`
+}
+
+type Receivers struct {
+	OTLP config.OTLPReceiver `yaml:"otlp"`
+}
+
+type Processors struct {
+	config.BaseProcessors `yaml:",inline"`
+
+	InsertClusterName *config.ResourceProcessor `yaml:"resource/insert-cluster-name,omitempty"`
+	DropPipelineName  *TransformProcessor       `yaml:"transform/drop-pipeline-name,omitempty"`
+
+	// OTel Collector components with dynamic IDs that are pipeline name based.
+	Dynamic map[string]any `yaml:",inline,omitempty"`
+}
+
+type FilterProcessor struct {
+	ErrorMode string `yaml:"error_mode"`
+	Logs      Logs   `yaml:"logs"`
+}
+
+type Logs struct {
+	LogRecord []string `yaml:"log_record"`
+}
+
+type TransformProcessor struct {
+	ErrorMode     string                                `yaml:"error_mode"`
+	LogStatements []config.TransformProcessorStatements `yaml:"log_statements"`
+}
+
+type Exporters map[string]Exporter
+
+type Exporter struct {
+	OTLP *config.OTLPExporter `yaml:",inline,omitempty"`
+}
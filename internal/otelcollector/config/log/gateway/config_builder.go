@@ -0,0 +1,172 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/otlpexporter"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
+)
+
+// BuildOptions contains the settings for the log gateway config that are not derived from the LogPipeline resources.
+type BuildOptions struct {
+	// Cluster defines the cluster name and the static attributes that are added to the logs.
+	Cluster *operatorv1alpha1.ClusterSpec
+}
+
+// MakeConfig creates the log gateway configuration for all given LogPipelines with at least one OTLP output. Pipelines without OTLP outputs are ignored.
+func MakeConfig(ctx context.Context, c client.Reader, pipelines []telemetryv1alpha1.LogPipeline, opts BuildOptions) (*Config, otlpexporter.EnvVars, error) {
+	cfg := &Config{
+		Base: config.Base{
+			Service:    makeServiceConfig(),
+			Extensions: makeExtensionsConfig(),
+		},
+		Receivers:  makeReceiversConfig(),
+		Processors: makeProcessorsConfig(opts),
+		Exporters:  make(Exporters),
+	}
+
+	envVars := make(otlpexporter.EnvVars)
+
+	var otlpPipelines []telemetryv1alpha1.LogPipeline
+	for i := range pipelines {
+		if pipelines[i].DeletionTimestamp == nil && pipelines[i].Spec.HasOtlpOutput() {
+			otlpPipelines = append(otlpPipelines, pipelines[i])
+		}
+	}
+
+	if len(otlpPipelines) == 0 {
+		return cfg, envVars, nil
+	}
+
+	queueSize := 256 / countOtlpOutputs(otlpPipelines)
+
+	for i := range otlpPipelines {
+		pipeline := otlpPipelines[i]
+		if err := addComponentsForLogPipeline(ctx, c, &pipeline, cfg, envVars, queueSize); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return cfg, envVars, nil
+}
+
+// countOtlpOutputs returns the number of OTLP outputs of all given pipelines, so that the sending queue capacity can be shared among all exporters.
+func countOtlpOutputs(pipelines []telemetryv1alpha1.LogPipeline) int {
+	count := 0
+	for i := range pipelines {
+		for _, output := range pipelines[i].Spec.AllOutputs() {
+			if output.IsOtlpDefined() {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func makeReceiversConfig() Receivers {
+	return Receivers{
+		OTLP: config.OTLPReceiver{
+			Protocols: config.ReceiverProtocols{
+				HTTP: config.Endpoint{
+					Endpoint: fmt.Sprintf("${%s}:%d", config.EnvVarCurrentPodIP, ports.OTLPHTTP),
+				},
+				GRPC: config.Endpoint{
+					Endpoint: fmt.Sprintf("${%s}:%d", config.EnvVarCurrentPodIP, ports.OTLPGRPC),
+				},
+			},
+		},
+	}
+}
+
+func makeExtensionsConfig() config.Extensions {
+	return config.Extensions{
+		HealthCheck: config.Endpoint{
+			Endpoint: fmt.Sprintf("${%s}:%d", config.EnvVarCurrentPodIP, ports.HealthCheck),
+		},
+		Pprof: config.Endpoint{
+			Endpoint: fmt.Sprintf("127.0.0.1:%d", ports.Pprof),
+		},
+		Dynamic: make(map[string]any),
+	}
+}
+
+func makeServiceConfig() config.Service {
+	return config.Service{
+		Pipelines: make(config.Pipelines),
+		Telemetry: config.Telemetry{
+			Metrics: config.Metrics{
+				Address: fmt.Sprintf("${%s}:%d", config.EnvVarCurrentPodIP, ports.Metrics),
+			},
+			Logs: config.Logs{
+				Level:    "info",
+				Encoding: "json",
+			},
+		},
+		Extensions: []string{"health_check", "pprof"},
+	}
+}
+
+// addComponentsForLogPipeline enriches a Config (exporters, processors, etc.) with components for a given telemetryv1alpha1.LogPipeline.
+// Every OTLP output of the pipeline results in an OTLP exporter, all of them are attached to the same collector pipeline.
+func addComponentsForLogPipeline(ctx context.Context, c client.Reader, pipeline *telemetryv1alpha1.LogPipeline, cfg *Config, envVars otlpexporter.EnvVars, queueSize int) error {
+	var otlpExporterIDs []string
+	for _, output := range pipeline.Spec.AllOutputs() {
+		if !output.IsOtlpDefined() {
+			continue
+		}
+
+		outputID := telemetryv1alpha1.OutputID(pipeline.Name, output.Name)
+		otlpExporterBuilder := otlpexporter.NewConfigBuilder(c, output.Otlp, outputID, queueSize)
+		otlpExporterConfig, otlpExporterEnvVars, err := otlpExporterBuilder.MakeConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to make otlp exporter config: %w", err)
+		}
+
+		maps.Copy(envVars, otlpExporterEnvVars)
+
+		otlpExporterID := otlpexporter.ExporterID(output.Otlp, outputID)
+		cfg.Exporters[otlpExporterID] = Exporter{OTLP: otlpExporterConfig}
+		otlpExporterIDs = append(otlpExporterIDs, otlpExporterID)
+
+		if authenticatorID, authenticatorConfig := otlpexporter.MakeAuthenticatorConfig(output.Otlp, outputID); authenticatorID != "" {
+			cfg.Extensions.Dynamic[authenticatorID] = authenticatorConfig
+			cfg.Service.Extensions = append(cfg.Service.Extensions, authenticatorID)
+		}
+
+		if output.Otlp.HasPersistentQueue() && cfg.Extensions.FileStorage == nil {
+			cfg.Extensions.FileStorage = &config.FileStorageExtension{Directory: config.PersistentQueueDirectory}
+			cfg.Service.Extensions = append(cfg.Service.Extensions, config.FileStorageExtensionID)
+		}
+	}
+
+	selectPipelineID := fmt.Sprintf("filter/select-%s", pipeline.Name)
+	cfg.Processors.Dynamic[selectPipelineID] = makeSelectPipelineConfig(pipeline.Name)
+
+	pipelineID := fmt.Sprintf("logs/%s", pipeline.Name)
+	cfg.Service.Pipelines[pipelineID] = makePipelineConfig(selectPipelineID, otlpExporterIDs...)
+
+	return nil
+}
+
+func makePipelineConfig(selectPipelineID string, exporterIDs ...string) config.Pipeline {
+	sort.Strings(exporterIDs)
+
+	return config.Pipeline{
+		Receivers: []string{"otlp"},
+		Processors: []string{"memory_limiter",
+			selectPipelineID,
+			"transform/drop-pipeline-name",
+			"resource/insert-cluster-name",
+			"batch",
+		},
+		Exporters: exporterIDs,
+	}
+}
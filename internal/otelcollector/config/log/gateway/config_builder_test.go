@@ -0,0 +1,104 @@
+package gateway
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
+)
+
+func TestMakeConfig(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewClientBuilder().Build()
+
+	t.Run("otlp exporter endpoint", func(t *testing.T) {
+		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []v1alpha1.LogPipeline{
+			testutils.NewLogPipelineBuilder().WithName("test").WithOtlpOutput("https://localhost").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+		require.Contains(t, collectorConfig.Exporters, "otlp/test")
+		require.Equal(t, "${OTLP_ENDPOINT_TEST}", collectorConfig.Exporters["otlp/test"].OTLP.Endpoint)
+		require.Equal(t, []byte("https://localhost"), envVars["OTLP_ENDPOINT_TEST"])
+	})
+
+	t.Run("pipelines without otlp output are ignored", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.LogPipeline{
+			testutils.NewLogPipelineBuilder().WithName("test-http").Build(),
+			testutils.NewLogPipelineBuilder().WithName("test-otlp").WithOtlpOutput("https://localhost").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+		require.Len(t, collectorConfig.Exporters, 1)
+		require.Contains(t, collectorConfig.Service.Pipelines, "logs/test-otlp")
+		require.NotContains(t, collectorConfig.Service.Pipelines, "logs/test-http")
+	})
+
+	t.Run("multi pipeline queue size", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.LogPipeline{
+			testutils.NewLogPipelineBuilder().WithName("test-1").WithOtlpOutput("https://localhost").Build(),
+			testutils.NewLogPipelineBuilder().WithName("test-2").WithOtlpOutput("https://localhost").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+		require.Equal(t, 128, collectorConfig.Exporters["otlp/test-1"].OTLP.SendingQueue.QueueSize, "Queue size should be divided by the number of pipelines")
+		require.Equal(t, 128, collectorConfig.Exporters["otlp/test-2"].OTLP.SendingQueue.QueueSize, "Queue size should be divided by the number of pipelines")
+	})
+
+	t.Run("multi pipeline topology", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.LogPipeline{
+			testutils.NewLogPipelineBuilder().WithName("test-1").WithOtlpOutput("https://localhost").Build(),
+			testutils.NewLogPipelineBuilder().WithName("test-2").WithOtlpOutput("https://localhost").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		for _, name := range []string{"test-1", "test-2"} {
+			pipelineID := "logs/" + name
+			require.Contains(t, collectorConfig.Service.Pipelines, pipelineID)
+			require.Equal(t, []string{"otlp"}, collectorConfig.Service.Pipelines[pipelineID].Receivers)
+			require.Equal(t, []string{
+				"memory_limiter",
+				"filter/select-" + name,
+				"transform/drop-pipeline-name",
+				"resource/insert-cluster-name",
+				"batch",
+			}, collectorConfig.Service.Pipelines[pipelineID].Processors)
+			require.Equal(t, []string{"otlp/" + name}, collectorConfig.Service.Pipelines[pipelineID].Exporters)
+			require.Contains(t, collectorConfig.Processors.Dynamic, "filter/select-"+name)
+		}
+	})
+
+	t.Run("additional outputs", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.LogPipeline{
+			testutils.NewLogPipelineBuilder().WithName("test").WithOtlpOutput("https://localhost").
+				WithAdditionalOutput("archive", v1alpha1.Output{Otlp: &v1alpha1.OtlpOutput{Endpoint: v1alpha1.ValueType{Value: "https://archive"}}}).
+				WithAdditionalOutput("http", v1alpha1.Output{HTTP: &v1alpha1.HTTPOutput{Host: v1alpha1.ValueType{Value: "localhost"}}}).
+				Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Len(t, collectorConfig.Exporters, 2)
+		require.Equal(t, 128, collectorConfig.Exporters["otlp/test_archive"].OTLP.SendingQueue.QueueSize, "Queue size should be divided by the number of OTLP outputs")
+		require.Equal(t, []string{"otlp/test", "otlp/test_archive"}, collectorConfig.Service.Pipelines["logs/test"].Exporters)
+	})
+
+	t.Run("marshaling", func(t *testing.T) {
+		config, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.LogPipeline{
+			testutils.NewLogPipelineBuilder().WithName("test").WithOtlpOutput("https://localhost").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		configYAML, err := yaml.Marshal(config)
+		require.NoError(t, err, "failed to marshal config")
+
+		goldenFilePath := filepath.Join("testdata", "config.yaml")
+		goldenFile, err := os.ReadFile(goldenFilePath)
+		require.NoError(t, err, "failed to load golden file")
+
+		require.Equal(t, string(goldenFile), string(configYAML))
+	})
+}
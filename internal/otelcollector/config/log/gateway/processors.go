@@ -0,0 +1,61 @@
+package gateway
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/gatewayprocs"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/log"
+)
+
+func makeProcessorsConfig(opts BuildOptions) Processors {
+	return Processors{
+		BaseProcessors: config.BaseProcessors{
+			Batch:         makeBatchProcessorConfig(),
+			MemoryLimiter: makeMemoryLimiterConfig(),
+		},
+		InsertClusterName: gatewayprocs.InsertClusterNameProcessorConfig(opts.Cluster),
+		DropPipelineName:  makeDropPipelineNameConfig(),
+		Dynamic:           make(map[string]any),
+	}
+}
+
+func makeBatchProcessorConfig() *config.BatchProcessor {
+	return &config.BatchProcessor{
+		SendBatchSize:    512,
+		Timeout:          "10s",
+		SendBatchMaxSize: 512,
+	}
+}
+
+func makeMemoryLimiterConfig() *config.MemoryLimiter {
+	return &config.MemoryLimiter{
+		CheckInterval:        "1s",
+		LimitPercentage:      75,
+		SpikeLimitPercentage: 15,
+	}
+}
+
+// makeSelectPipelineConfig creates a filter processor that drops all log records that Fluent Bit did not forward for the given pipeline.
+func makeSelectPipelineConfig(pipelineName string) *FilterProcessor {
+	return &FilterProcessor{
+		ErrorMode: "ignore",
+		Logs: Logs{
+			LogRecord: []string{
+				fmt.Sprintf(`body["%s"] != "%s"`, log.PipelineNameAttribute, pipelineName),
+			},
+		},
+	}
+}
+
+func makeDropPipelineNameConfig() *TransformProcessor {
+	return &TransformProcessor{
+		ErrorMode: "ignore",
+		LogStatements: []config.TransformProcessorStatements{
+			{
+				Context:    "log",
+				Statements: []string{fmt.Sprintf(`delete_key(body, "%s")`, log.PipelineNameAttribute)},
+			},
+		},
+	}
+}
@@ -0,0 +1,7 @@
+package log
+
+const (
+	// PipelineNameAttribute is the key of the log record field that Fluent Bit sets to the name of the LogPipeline.
+	// The log gateway uses it to dispatch every record to the collector pipeline of the matching LogPipeline.
+	PipelineNameAttribute = "kyma.log_pipeline"
+)
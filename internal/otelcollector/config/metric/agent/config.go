@@ -18,6 +18,7 @@ type Receivers struct {
 	KubeletStats          *KubeletStatsReceiver `yaml:"kubeletstats,omitempty"`
 	PrometheusAppPods     *PrometheusReceiver   `yaml:"prometheus/app-pods,omitempty"`
 	PrometheusAppServices *PrometheusReceiver   `yaml:"prometheus/app-services,omitempty"`
+	PrometheusAppTargets  *PrometheusReceiver   `yaml:"prometheus/app-targets,omitempty"`
 	PrometheusIstio       *PrometheusReceiver   `yaml:"prometheus/istio,omitempty"`
 }
 
@@ -34,6 +35,8 @@ type MetricGroupType string
 const (
 	MetricGroupTypeContainer MetricGroupType = "container"
 	MetricGroupTypePod       MetricGroupType = "pod"
+	MetricGroupTypeNode      MetricGroupType = "node"
+	MetricGroupTypeVolume    MetricGroupType = "volume"
 )
 
 type PrometheusReceiver struct {
@@ -105,6 +108,7 @@ type Processors struct {
 	InsertInputSourceRuntime    *config.ResourceProcessor `yaml:"resource/insert-input-source-runtime,omitempty"`
 	InsertInputSourcePrometheus *config.ResourceProcessor `yaml:"resource/insert-input-source-prometheus,omitempty"`
 	InsertInputSourceIstio      *config.ResourceProcessor `yaml:"resource/insert-input-source-istio,omitempty"`
+	InsertPrometheusTarget      *config.ResourceProcessor `yaml:"resource/insert-prometheus-target,omitempty"`
 }
 
 type Exporters struct {
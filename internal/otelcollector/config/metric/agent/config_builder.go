@@ -2,10 +2,12 @@ package agent
 
 import (
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/namespaces"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
 )
@@ -14,13 +16,56 @@ type inputSources struct {
 	runtime    bool
 	prometheus bool
 	istio      bool
+
+	// runtimeNode and runtimeVolume are true if at least one pipeline that enables the runtime input also enables the Node or volume metrics.
+	runtimeNode   bool
+	runtimeVolume bool
+
+	// prometheusNamespaces and istioNamespaces are the Namespaces selected by at least one pipeline that enables the input.
+	prometheusNamespaces namespaces.Selection
+	istioNamespaces      namespaces.Selection
+
+	// prometheusScrape are the settings of the jobs that scrape the annotated workloads for all pipelines together.
+	prometheusScrape scrapeSettings
+	// prometheusTargets are the additional targets of all pipelines that enable the Prometheus input.
+	prometheusTargets []scrapeTarget
+}
+
+type scrapeSettings struct {
+	interval    time.Duration
+	sampleLimit int
+}
+
+// scrapeTarget is an additional target of a pipeline, which is scraped by a dedicated job.
+type scrapeTarget struct {
+	jobName    string
+	target     v1alpha1.PrometheusScrapeTarget
+	settings   scrapeSettings
+	namespaces namespaces.Selection
 }
 
 func MakeConfig(gatewayServiceName types.NamespacedName, pipelines []v1alpha1.MetricPipeline, isIstioActive bool) *Config {
 	inputs := inputSources{
-		runtime:    enableRuntimeMetricScraping(pipelines),
+		runtime:    enableRuntimeMetricScraping(pipelines, nil),
 		prometheus: enablePrometheusMetricScraping(pipelines),
 		istio:      enableIstioMetricScraping(pipelines),
+
+		runtimeNode: enableRuntimeMetricScraping(pipelines, func(resources v1alpha1.MetricPipelineRuntimeResources) bool {
+			return resources.Node.Enabled
+		}),
+		runtimeVolume: enableRuntimeMetricScraping(pipelines, func(resources v1alpha1.MetricPipelineRuntimeResources) bool {
+			return resources.Volume.Enabled
+		}),
+
+		prometheusNamespaces: selectedNamespaces(pipelines, func(input v1alpha1.MetricPipelineApplicationInput) (bool, *v1alpha1.NamespaceSelector) {
+			return input.Prometheus.Enabled, input.Prometheus.Namespaces
+		}),
+		istioNamespaces: selectedNamespaces(pipelines, func(input v1alpha1.MetricPipelineApplicationInput) (bool, *v1alpha1.NamespaceSelector) {
+			return input.Istio.Enabled, input.Istio.Namespaces
+		}),
+
+		prometheusScrape:  prometheusScrapeSettings(pipelines),
+		prometheusTargets: prometheusTargets(pipelines),
 	}
 
 	return &Config{
@@ -44,10 +89,11 @@ func enablePrometheusMetricScraping(pipelines []v1alpha1.MetricPipeline) bool {
 	return false
 }
 
-func enableRuntimeMetricScraping(pipelines []v1alpha1.MetricPipeline) bool {
+// enableRuntimeMetricScraping returns true if at least one pipeline enables the runtime input. If resourceFn is given, the pipeline must also enable the resource returned by resourceFn.
+func enableRuntimeMetricScraping(pipelines []v1alpha1.MetricPipeline, resourceFn func(v1alpha1.MetricPipelineRuntimeResources) bool) bool {
 	for i := range pipelines {
 		input := pipelines[i].Spec.Input
-		if input.Application.Runtime.Enabled {
+		if input.Application.Runtime.Enabled && (resourceFn == nil || resourceFn(input.Application.Runtime.Resources)) {
 			return true
 		}
 	}
@@ -64,6 +110,72 @@ func enableIstioMetricScraping(pipelines []v1alpha1.MetricPipeline) bool {
 	return false
 }
 
+// selectedNamespaces returns the union of the Namespaces selected by all pipelines that enable the input returned by inputFn.
+func selectedNamespaces(pipelines []v1alpha1.MetricPipeline, inputFn func(v1alpha1.MetricPipelineApplicationInput) (bool, *v1alpha1.NamespaceSelector)) namespaces.Selection {
+	var selections []namespaces.Selection
+	for i := range pipelines {
+		enabled, selector := inputFn(pipelines[i].Spec.Input.Application)
+		if enabled {
+			selections = append(selections, namespaces.Resolve(selector))
+		}
+	}
+	return namespaces.Union(selections...)
+}
+
+// prometheusScrapeSettings returns the settings that satisfy all pipelines that enable the Prometheus input, which are the shortest interval and the largest sample limit.
+func prometheusScrapeSettings(pipelines []v1alpha1.MetricPipeline) scrapeSettings {
+	var merged scrapeSettings
+	for i := range pipelines {
+		input := pipelines[i].Spec.Input.Application.Prometheus
+		if !input.Enabled {
+			continue
+		}
+
+		settings := scrapeSettingsOf(input)
+		if merged.interval == 0 || settings.interval < merged.interval {
+			merged.interval = settings.interval
+		}
+		if settings.sampleLimit > merged.sampleLimit {
+			merged.sampleLimit = settings.sampleLimit
+		}
+	}
+	return merged
+}
+
+func scrapeSettingsOf(input v1alpha1.MetricPipelinePrometheusInput) scrapeSettings {
+	settings := scrapeSettings{
+		interval:    defaultScrapeInterval,
+		sampleLimit: defaultSampleLimit,
+	}
+	if input.Interval != nil {
+		settings.interval = input.Interval.Duration
+	}
+	if input.SampleLimit != nil {
+		settings.sampleLimit = *input.SampleLimit
+	}
+	return settings
+}
+
+func prometheusTargets(pipelines []v1alpha1.MetricPipeline) []scrapeTarget {
+	var targets []scrapeTarget
+	for i := range pipelines {
+		input := pipelines[i].Spec.Input.Application.Prometheus
+		if !input.Enabled {
+			continue
+		}
+
+		for _, target := range input.Targets {
+			targets = append(targets, scrapeTarget{
+				jobName:    fmt.Sprintf("%s/%s", pipelines[i].Name, target.Name),
+				target:     target,
+				settings:   scrapeSettingsOf(input),
+				namespaces: namespaces.Resolve(input.Namespaces),
+			})
+		}
+	}
+	return targets
+}
+
 func makeExportersConfig(gatewayServiceName types.NamespacedName) Exporters {
 	return Exporters{
 		OTLP: config.OTLPExporter{
@@ -126,6 +238,15 @@ func makePipelinesConfig(inputs inputSources) config.Pipelines {
 			Processors: []string{"memory_limiter", "resource/delete-service-name", "resource/insert-input-source-prometheus", "batch"},
 			Exporters:  []string{"otlp"},
 		}
+
+		// The metrics of additional targets are tagged with the job name, so that the gateway ships them only to the pipeline that declares the target
+		if len(inputs.prometheusTargets) > 0 {
+			pipelinesConfig["metrics/prometheus-targets"] = config.Pipeline{
+				Receivers:  []string{"prometheus/app-targets"},
+				Processors: []string{"memory_limiter", "resource/insert-prometheus-target", "resource/delete-service-name", "resource/insert-input-source-prometheus", "batch"},
+				Exporters:  []string{"otlp"},
+			}
+		}
 	}
 
 	if inputs.istio {
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/testutils"
@@ -91,6 +92,22 @@ func TestMakeAgentConfig(t *testing.T) {
 			require.Equal(t, []string{"otlp"}, collectorConfig.Service.Pipelines["metrics/prometheus"].Exporters)
 		})
 
+		t.Run("prometheus input with additional targets enabled", func(t *testing.T) {
+			collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []v1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithPrometheusInputOn(true).WithPrometheusInputTargets(v1alpha1.PrometheusScrapeTarget{
+					Name: "app",
+					Pods: &v1alpha1.ScrapeTargetSelector{MatchLabels: map[string]string{"app": "app"}, Port: intstr.FromInt(8080)},
+				}).Build(),
+			}, false)
+
+			require.NotNil(t, collectorConfig.Processors.InsertPrometheusTarget)
+			require.Equal(t, "service.name", collectorConfig.Processors.InsertPrometheusTarget.Attributes[0].FromAttribute)
+
+			require.Equal(t, []string{"prometheus/app-pods", "prometheus/app-services"}, collectorConfig.Service.Pipelines["metrics/prometheus"].Receivers)
+			require.Equal(t, []string{"prometheus/app-targets"}, collectorConfig.Service.Pipelines["metrics/prometheus-targets"].Receivers)
+			require.Equal(t, []string{"memory_limiter", "resource/insert-prometheus-target", "resource/delete-service-name", "resource/insert-input-source-prometheus", "batch"}, collectorConfig.Service.Pipelines["metrics/prometheus-targets"].Processors)
+		})
+
 		t.Run("istio input enabled", func(t *testing.T) {
 			collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []v1alpha1.MetricPipeline{
 				testutils.NewMetricPipelineBuilder().WithIstioInputOn(true).Build(),
@@ -22,6 +22,9 @@ func makeProcessorsConfig(inputs inputSources) Processors {
 
 		if inputs.prometheus {
 			processorsConfig.InsertInputSourcePrometheus = makeEmittedByConfig(metric.InputSourcePrometheus)
+			if len(inputs.prometheusTargets) > 0 {
+				processorsConfig.InsertPrometheusTarget = makeInsertPrometheusTargetConfig()
+			}
 		}
 
 		if inputs.istio {
@@ -59,6 +62,20 @@ func makeDeleteServiceNameConfig() *config.ResourceProcessor {
 	}
 }
 
+// makeInsertPrometheusTargetConfig copies the job name of the additional Prometheus targets, which the Prometheus receiver sets as service name,
+// so that the gateway can ship the metrics of a target only to the pipeline that declares it. It must run before the service name is deleted.
+func makeInsertPrometheusTargetConfig() *config.ResourceProcessor {
+	return &config.ResourceProcessor{
+		Attributes: []config.AttributeAction{
+			{
+				Action:        "insert",
+				Key:           metric.PrometheusTargetAttribute,
+				FromAttribute: "service.name",
+			},
+		},
+	}
+}
+
 func makeEmittedByConfig(inputSource metric.InputSourceType) *config.ResourceProcessor {
 	return &config.ResourceProcessor{
 		Attributes: []config.AttributeAction{
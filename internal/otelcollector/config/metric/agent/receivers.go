@@ -5,12 +5,14 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/namespaces"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
 )
 
-const scrapeInterval = 30 * time.Second
+const defaultScrapeInterval = 30 * time.Second
 const IstioCertPath = "/etc/istio-output-certs"
-const sampleLimit = 50000
+const defaultSampleLimit = 50000
 
 var (
 	istioCAFile   = filepath.Join(IstioCertPath, "root-cert.pem")
@@ -22,76 +24,116 @@ func makeReceiversConfig(inputs inputSources, isIstioActive bool) Receivers {
 	var receiversConfig Receivers
 
 	if inputs.prometheus {
-		receiversConfig.PrometheusAppPods = makePrometheusConfigForPods(isIstioActive)
-		receiversConfig.PrometheusAppServices = makePrometheusConfigForServices(isIstioActive)
+		receiversConfig.PrometheusAppPods = makePrometheusConfigForPods(isIstioActive, inputs.prometheusScrape, inputs.prometheusNamespaces)
+		receiversConfig.PrometheusAppServices = makePrometheusConfigForServices(isIstioActive, inputs.prometheusScrape, inputs.prometheusNamespaces)
+		if len(inputs.prometheusTargets) > 0 {
+			receiversConfig.PrometheusAppTargets = makePrometheusConfigForTargets(isIstioActive, inputs.prometheusTargets)
+		}
 	}
 
 	if inputs.runtime {
-		receiversConfig.KubeletStats = makeKubeletStatsConfig()
+		receiversConfig.KubeletStats = makeKubeletStatsConfig(inputs)
 	}
 
 	if inputs.istio {
-		receiversConfig.PrometheusIstio = makePrometheusIstioConfig()
+		receiversConfig.PrometheusIstio = makePrometheusIstioConfig(inputs.istioNamespaces)
 	}
 
 	return receiversConfig
 }
 
-func makeKubeletStatsConfig() *KubeletStatsReceiver {
+func makeKubeletStatsConfig(inputs inputSources) *KubeletStatsReceiver {
 	const collectionInterval = "30s"
 	const portKubelet = 10250
+
+	metricGroups := []MetricGroupType{MetricGroupTypeContainer, MetricGroupTypePod}
+	if inputs.runtimeNode {
+		metricGroups = append(metricGroups, MetricGroupTypeNode)
+	}
+	if inputs.runtimeVolume {
+		metricGroups = append(metricGroups, MetricGroupTypeVolume)
+	}
+
 	return &KubeletStatsReceiver{
 		CollectionInterval: collectionInterval,
 		AuthType:           "serviceAccount",
 		InsecureSkipVerify: true,
 		Endpoint:           fmt.Sprintf("https://${env:%s}:%d", config.EnvVarCurrentNodeName, portKubelet),
-		MetricGroups:       []MetricGroupType{MetricGroupTypeContainer, MetricGroupTypePod},
+		MetricGroups:       metricGroups,
 	}
 }
 
-func makePrometheusConfigForPods(isIstioActive bool) *PrometheusReceiver {
-	return makePrometheusConfig(isIstioActive, "app-pods", RolePod, makePrometheusPodsRelabelConfigs)
+func makePrometheusConfigForPods(isIstioActive bool, settings scrapeSettings, selection namespaces.Selection) *PrometheusReceiver {
+	return &PrometheusReceiver{
+		Config: PrometheusConfig{
+			ScrapeConfigs: makeScrapeConfigs(isIstioActive, settings, selection, "app-pods", RolePod, "", makePrometheusPodsRelabelConfigs),
+		},
+	}
 }
 
-func makePrometheusConfigForServices(isIstioActive bool) *PrometheusReceiver {
-	return makePrometheusConfig(isIstioActive, "app-services", RoleEndpoints, makePrometheusServicesRelabelConfigs)
+func makePrometheusConfigForServices(isIstioActive bool, settings scrapeSettings, selection namespaces.Selection) *PrometheusReceiver {
+	return &PrometheusReceiver{
+		Config: PrometheusConfig{
+			ScrapeConfigs: makeScrapeConfigs(isIstioActive, settings, selection, "app-services", RoleEndpoints, "", makePrometheusServicesRelabelConfigs),
+		},
+	}
 }
 
-func makePrometheusConfig(isIstioActive bool, jobNamePrefix string, role Role, relabelConfigFn func(keepSecure bool) []RelabelConfig) *PrometheusReceiver {
+// makePrometheusConfigForTargets creates one job (and its secure counterpart if Istio is active) for every additional target of the pipelines.
+// The jobs use the settings and the Namespace selection of the pipeline that declares the target.
+func makePrometheusConfigForTargets(isIstioActive bool, targets []scrapeTarget) *PrometheusReceiver {
 	var config PrometheusReceiver
 
+	for _, target := range targets {
+		role := RolePod
+		if target.target.Services != nil {
+			role = RoleEndpoints
+		}
+
+		scrapeConfigs := makeScrapeConfigs(isIstioActive, target.settings, target.namespaces, target.jobName, role, target.target.Path, makePrometheusTargetRelabelConfigs(target.target))
+		config.Config.ScrapeConfigs = append(config.Config.ScrapeConfigs, scrapeConfigs...)
+	}
+
+	return &config
+}
+
+func makeScrapeConfigs(isIstioActive bool, settings scrapeSettings, selection namespaces.Selection, jobNamePrefix string, role Role, metricsPath string, relabelConfigFn func(keepSecure bool, selection namespaces.Selection) []RelabelConfig) []ScrapeConfig {
 	baseScrapeConfig := ScrapeConfig{
-		ScrapeInterval:             scrapeInterval,
-		SampleLimit:                sampleLimit,
+		ScrapeInterval:             settings.interval,
+		SampleLimit:                settings.sampleLimit,
+		MetricsPath:                metricsPath,
 		KubernetesDiscoveryConfigs: []KubernetesDiscoveryConfig{{Role: role}},
 	}
 
 	httpScrapeConfig := baseScrapeConfig
 	httpScrapeConfig.JobName = jobNamePrefix
-	httpScrapeConfig.RelabelConfigs = relabelConfigFn(false)
-	config.Config.ScrapeConfigs = append(config.Config.ScrapeConfigs, httpScrapeConfig)
+	httpScrapeConfig.RelabelConfigs = relabelConfigFn(false, selection)
+	scrapeConfigs := []ScrapeConfig{httpScrapeConfig}
 
 	if isIstioActive {
 		httpsScrapeConfig := baseScrapeConfig
 		httpsScrapeConfig.JobName = jobNamePrefix + "-secure"
-		httpsScrapeConfig.RelabelConfigs = relabelConfigFn(true)
+		httpsScrapeConfig.RelabelConfigs = relabelConfigFn(true, selection)
 		httpsScrapeConfig.TLSConfig = makeTLSConfig()
-		config.Config.ScrapeConfigs = append(config.Config.ScrapeConfigs, httpsScrapeConfig)
+		scrapeConfigs = append(scrapeConfigs, httpsScrapeConfig)
 	}
 
-	return &config
+	return scrapeConfigs
 }
 
-func makePrometheusPodsRelabelConfigs(keepSecure bool) []RelabelConfig {
+func makePrometheusPodsRelabelConfigs(keepSecure bool, selection namespaces.Selection) []RelabelConfig {
 	relabelConfigs := []RelabelConfig{
 		keepIfRunningOnSameNode(NodeAffiliatedPod),
 		keepIfScrapingEnabled(AnnotatedPod),
+	}
+	relabelConfigs = append(relabelConfigs, namespaceRelabelConfigs(selection)...)
+	relabelConfigs = append(relabelConfigs,
 		dropIfPodNotRunning(),
 		dropIfInitContainer(),
 		dropIfIstioProxy(),
 		inferSchemeFromIstioInjectedLabel(),
 		inferSchemeFromAnnotation(AnnotatedPod),
-	}
+	)
 
 	if keepSecure {
 		relabelConfigs = append(relabelConfigs, dropIfSchemeHTTP())
@@ -104,16 +146,19 @@ func makePrometheusPodsRelabelConfigs(keepSecure bool) []RelabelConfig {
 		inferAddressFromAnnotation(AnnotatedPod))
 }
 
-func makePrometheusServicesRelabelConfigs(keepSecure bool) []RelabelConfig {
+func makePrometheusServicesRelabelConfigs(keepSecure bool, selection namespaces.Selection) []RelabelConfig {
 	relabelConfigs := []RelabelConfig{
 		keepIfRunningOnSameNode(NodeAffiliatedEndpoint),
 		keepIfScrapingEnabled(AnnotatedService),
+	}
+	relabelConfigs = append(relabelConfigs, namespaceRelabelConfigs(selection)...)
+	relabelConfigs = append(relabelConfigs,
 		dropIfPodNotRunning(),
 		dropIfInitContainer(),
 		dropIfIstioProxy(),
 		inferSchemeFromIstioInjectedLabel(),
 		inferSchemeFromAnnotation(AnnotatedService),
-	}
+	)
 
 	if keepSecure {
 		relabelConfigs = append(relabelConfigs, dropIfSchemeHTTP())
@@ -127,6 +172,48 @@ func makePrometheusServicesRelabelConfigs(keepSecure bool) []RelabelConfig {
 		inferServiceFromMetaLabel())
 }
 
+// makePrometheusTargetRelabelConfigs returns a function that creates the relabel configs of an additional target.
+// Instead of the prometheus.io annotations, the target is selected by its labels and port, and the scheme is inferred from the target definition.
+func makePrometheusTargetRelabelConfigs(target v1alpha1.PrometheusScrapeTarget) func(keepSecure bool, selection namespaces.Selection) []RelabelConfig {
+	return func(keepSecure bool, selection namespaces.Selection) []RelabelConfig {
+		selector := target.Pods
+		nodeAffiliated, labeled, portNameLabel := NodeAffiliatedPod, LabeledPod, "__meta_kubernetes_pod_container_port_name"
+		if target.Services != nil {
+			selector = target.Services
+			nodeAffiliated, labeled, portNameLabel = NodeAffiliatedEndpoint, LabeledService, "__meta_kubernetes_endpoint_port_name"
+		}
+
+		relabelConfigs := []RelabelConfig{
+			keepIfRunningOnSameNode(nodeAffiliated),
+		}
+		relabelConfigs = append(relabelConfigs, keepIfLabelsMatch(labeled, selector.MatchLabels)...)
+		relabelConfigs = append(relabelConfigs, namespaceRelabelConfigs(selection)...)
+		relabelConfigs = append(relabelConfigs,
+			keepIfPortMatches(portNameLabel, selector.Port),
+			dropIfPodNotRunning(),
+			dropIfInitContainer(),
+			dropIfIstioProxy(),
+			inferSchemeFromIstioInjectedLabel(),
+		)
+
+		if target.Scheme != "" {
+			relabelConfigs = append(relabelConfigs, setScheme(target.Scheme))
+		}
+
+		if keepSecure {
+			relabelConfigs = append(relabelConfigs, dropIfSchemeHTTP())
+		} else {
+			relabelConfigs = append(relabelConfigs, dropIfSchemeHTTPS())
+		}
+
+		if target.Services != nil {
+			relabelConfigs = append(relabelConfigs, inferServiceFromMetaLabel())
+		}
+
+		return relabelConfigs
+	}
+}
+
 func makeTLSConfig() *TLSConfig {
 	return &TLSConfig{
 		CAFile:             istioCAFile,
@@ -136,22 +223,27 @@ func makeTLSConfig() *TLSConfig {
 	}
 }
 
-func makePrometheusIstioConfig() *PrometheusReceiver {
+func makePrometheusIstioConfig(selection namespaces.Selection) *PrometheusReceiver {
+	relabelConfigs := []RelabelConfig{
+		keepIfRunningOnSameNode(NodeAffiliatedPod),
+	}
+	relabelConfigs = append(relabelConfigs, namespaceRelabelConfigs(selection)...)
+	relabelConfigs = append(relabelConfigs,
+		keepIfIstioProxy(),
+		keepIfContainerWithEnvoyPort(),
+		dropIfPodNotRunning(),
+	)
+
 	return &PrometheusReceiver{
 		Config: PrometheusConfig{
 			ScrapeConfigs: []ScrapeConfig{
 				{
 					JobName:                    "istio-proxy",
-					SampleLimit:                sampleLimit,
+					SampleLimit:                defaultSampleLimit,
 					MetricsPath:                "/stats/prometheus",
-					ScrapeInterval:             scrapeInterval,
+					ScrapeInterval:             defaultScrapeInterval,
 					KubernetesDiscoveryConfigs: []KubernetesDiscoveryConfig{{Role: RolePod}},
-					RelabelConfigs: []RelabelConfig{
-						keepIfRunningOnSameNode(NodeAffiliatedPod),
-						keepIfIstioProxy(),
-						keepIfContainerWithEnvoyPort(),
-						dropIfPodNotRunning(),
-					},
+					RelabelConfigs:             relabelConfigs,
 					MetricRelabelConfigs: []RelabelConfig{
 						{
 							SourceLabels: []string{"__name__"},
@@ -2,9 +2,11 @@ package agent
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/testutils"
@@ -30,11 +32,23 @@ func TestReceivers(t *testing.T) {
 		require.Equal(t, "serviceAccount", collectorConfig.Receivers.KubeletStats.AuthType)
 		require.Equal(t, true, collectorConfig.Receivers.KubeletStats.InsecureSkipVerify)
 		require.Equal(t, "https://${env:MY_NODE_NAME}:10250", collectorConfig.Receivers.KubeletStats.Endpoint)
+		require.Equal(t, []MetricGroupType{MetricGroupTypeContainer, MetricGroupTypePod}, collectorConfig.Receivers.KubeletStats.MetricGroups)
 
 		require.Nil(t, collectorConfig.Receivers.PrometheusAppPods)
 		require.Nil(t, collectorConfig.Receivers.PrometheusIstio)
 	})
 
+	t.Run("runtime input with node and volume metrics enabled", func(t *testing.T) {
+		collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []v1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithRuntimeInputOn(true).WithRuntimeInputNodeMetrics(true).Build(),
+			testutils.NewMetricPipelineBuilder().WithRuntimeInputOn(true).WithRuntimeInputVolumeMetrics(true).Build(),
+			testutils.NewMetricPipelineBuilder().WithRuntimeInputOn(false).WithRuntimeInputNodeMetrics(true).Build(),
+		}, false)
+
+		require.NotNil(t, collectorConfig.Receivers.KubeletStats)
+		require.Equal(t, []MetricGroupType{MetricGroupTypeContainer, MetricGroupTypePod, MetricGroupTypeNode, MetricGroupTypeVolume}, collectorConfig.Receivers.KubeletStats.MetricGroups)
+	})
+
 	t.Run("prometheus input enabled", func(t *testing.T) {
 		tests := []struct {
 			name                      string
@@ -102,4 +116,157 @@ func TestReceivers(t *testing.T) {
 		require.Len(t, collectorConfig.Receivers.PrometheusIstio.Config.ScrapeConfigs, 1)
 		require.Len(t, collectorConfig.Receivers.PrometheusIstio.Config.ScrapeConfigs[0].KubernetesDiscoveryConfigs, 1)
 	})
+
+	t.Run("namespace selection", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			pipelines []v1alpha1.MetricPipeline
+			expected  []RelabelConfig
+		}{
+			{
+				name: "no selector",
+				pipelines: []v1alpha1.MetricPipeline{
+					testutils.NewMetricPipelineBuilder().WithPrometheusInputOn(true).WithIstioInputOn(true).Build(),
+				},
+			},
+			{
+				name: "include",
+				pipelines: []v1alpha1.MetricPipeline{
+					testutils.NewMetricPipelineBuilder().WithPrometheusInputOn(true).WithIstioInputOn(true).
+						WithPrometheusInputNamespaces(v1alpha1.NamespaceSelector{Include: []string{"team-a"}}).
+						WithIstioInputNamespaces(v1alpha1.NamespaceSelector{Include: []string{"team-a"}}).Build(),
+					testutils.NewMetricPipelineBuilder().WithPrometheusInputOn(true).WithIstioInputOn(true).
+						WithPrometheusInputNamespaces(v1alpha1.NamespaceSelector{Include: []string{"team-b"}}).
+						WithIstioInputNamespaces(v1alpha1.NamespaceSelector{Include: []string{"team-b"}}).Build(),
+				},
+				expected: []RelabelConfig{{SourceLabels: []string{"__meta_kubernetes_namespace"}, Regex: "(team-a|team-b)", Action: Keep}},
+			},
+			{
+				name: "exclude",
+				pipelines: []v1alpha1.MetricPipeline{
+					testutils.NewMetricPipelineBuilder().WithPrometheusInputOn(true).WithIstioInputOn(true).
+						WithPrometheusInputNamespaces(v1alpha1.NamespaceSelector{Exclude: []string{"team-a"}, System: true}).
+						WithIstioInputNamespaces(v1alpha1.NamespaceSelector{Exclude: []string{"team-a"}, System: true}).Build(),
+				},
+				expected: []RelabelConfig{{SourceLabels: []string{"__meta_kubernetes_namespace"}, Regex: "(team-a)", Action: Drop}},
+			},
+			{
+				name: "one pipeline selects all namespaces",
+				pipelines: []v1alpha1.MetricPipeline{
+					testutils.NewMetricPipelineBuilder().WithPrometheusInputOn(true).WithIstioInputOn(true).
+						WithPrometheusInputNamespaces(v1alpha1.NamespaceSelector{Include: []string{"team-a"}}).
+						WithIstioInputNamespaces(v1alpha1.NamespaceSelector{Include: []string{"team-a"}}).Build(),
+					testutils.NewMetricPipelineBuilder().WithPrometheusInputOn(true).WithIstioInputOn(true).Build(),
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, tt.pipelines, true)
+
+				receivers := collectorConfig.Receivers
+				scrapeConfigs := append(receivers.PrometheusAppPods.Config.ScrapeConfigs, receivers.PrometheusAppServices.Config.ScrapeConfigs...)
+				scrapeConfigs = append(scrapeConfigs, receivers.PrometheusIstio.Config.ScrapeConfigs...)
+				for _, scrapeConfig := range scrapeConfigs {
+					var namespaceRelabelConfigs []RelabelConfig
+					for _, relabelConfig := range scrapeConfig.RelabelConfigs {
+						if len(relabelConfig.SourceLabels) == 1 && relabelConfig.SourceLabels[0] == "__meta_kubernetes_namespace" {
+							namespaceRelabelConfigs = append(namespaceRelabelConfigs, relabelConfig)
+						}
+					}
+					require.Equal(t, tt.expected, namespaceRelabelConfigs, "scrape job %s", scrapeConfig.JobName)
+				}
+			})
+		}
+	})
+	t.Run("scrape settings", func(t *testing.T) {
+		collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []v1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithPrometheusInputOn(true).WithIstioInputOn(true).
+				WithPrometheusInputInterval(time.Minute).WithPrometheusInputSampleLimit(100000).Build(),
+			testutils.NewMetricPipelineBuilder().WithPrometheusInputOn(true).
+				WithPrometheusInputInterval(15 * time.Second).WithPrometheusInputSampleLimit(1000).Build(),
+			testutils.NewMetricPipelineBuilder().WithPrometheusInputOn(false).
+				WithPrometheusInputInterval(5 * time.Second).Build(),
+		}, true)
+
+		receivers := collectorConfig.Receivers
+		scrapeConfigs := append(receivers.PrometheusAppPods.Config.ScrapeConfigs, receivers.PrometheusAppServices.Config.ScrapeConfigs...)
+		for _, scrapeConfig := range scrapeConfigs {
+			require.Equal(t, 15*time.Second, scrapeConfig.ScrapeInterval, "scrape job %s", scrapeConfig.JobName)
+			require.Equal(t, 100000, scrapeConfig.SampleLimit, "scrape job %s", scrapeConfig.JobName)
+		}
+
+		require.Equal(t, 30*time.Second, receivers.PrometheusIstio.Config.ScrapeConfigs[0].ScrapeInterval)
+		require.Equal(t, 50000, receivers.PrometheusIstio.Config.ScrapeConfigs[0].SampleLimit)
+	})
+
+	t.Run("additional targets", func(t *testing.T) {
+		podTarget := v1alpha1.PrometheusScrapeTarget{
+			Name:   "app",
+			Pods:   &v1alpha1.ScrapeTargetSelector{MatchLabels: map[string]string{"app.kubernetes.io/name": "app", "tier": "backend"}, Port: intstr.FromString("metrics")},
+			Path:   "/stats",
+			Scheme: "http",
+		}
+		serviceTarget := v1alpha1.PrometheusScrapeTarget{
+			Name:     "db",
+			Services: &v1alpha1.ScrapeTargetSelector{MatchLabels: map[string]string{"app": "db"}, Port: intstr.FromInt(9187)},
+		}
+
+		collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []v1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithName("test-1").WithPrometheusInputOn(true).
+				WithPrometheusInputInterval(10 * time.Second).
+				WithPrometheusInputNamespaces(v1alpha1.NamespaceSelector{Include: []string{"team-a"}}).
+				WithPrometheusInputTargets(podTarget).Build(),
+			testutils.NewMetricPipelineBuilder().WithName("test-2").WithPrometheusInputOn(true).
+				WithPrometheusInputTargets(serviceTarget).Build(),
+			testutils.NewMetricPipelineBuilder().WithName("test-3").WithPrometheusInputOn(false).
+				WithPrometheusInputTargets(serviceTarget).Build(),
+		}, true)
+
+		require.NotNil(t, collectorConfig.Receivers.PrometheusAppTargets)
+		scrapeConfigs := collectorConfig.Receivers.PrometheusAppTargets.Config.ScrapeConfigs
+		require.Len(t, scrapeConfigs, 4)
+
+		podJob := scrapeConfigs[0]
+		require.Equal(t, "test-1/app", podJob.JobName)
+		require.Equal(t, "test-1/app-secure", scrapeConfigs[1].JobName)
+		require.Equal(t, 10*time.Second, podJob.ScrapeInterval)
+		require.Equal(t, 50000, podJob.SampleLimit)
+		require.Equal(t, "/stats", podJob.MetricsPath)
+		require.Nil(t, podJob.TLSConfig)
+		require.NotNil(t, scrapeConfigs[1].TLSConfig)
+		require.Equal(t, []KubernetesDiscoveryConfig{{Role: RolePod}}, podJob.KubernetesDiscoveryConfigs)
+		require.Equal(t, []RelabelConfig{
+			{SourceLabels: []string{"__meta_kubernetes_pod_node_name"}, Regex: "$MY_NODE_NAME", Action: Keep},
+			{SourceLabels: []string{"__meta_kubernetes_pod_label_app_kubernetes_io_name"}, Regex: "app", Action: Keep},
+			{SourceLabels: []string{"__meta_kubernetes_pod_label_tier"}, Regex: "backend", Action: Keep},
+			{SourceLabels: []string{"__meta_kubernetes_namespace"}, Regex: "(team-a)", Action: Keep},
+			{SourceLabels: []string{"__meta_kubernetes_pod_container_port_name"}, Regex: "metrics", Action: Keep},
+			{SourceLabels: []string{"__meta_kubernetes_pod_phase"}, Regex: "Pending|Succeeded|Failed", Action: Drop},
+			{SourceLabels: []string{"__meta_kubernetes_pod_container_init"}, Regex: "(true)", Action: Drop},
+			{SourceLabels: []string{"__meta_kubernetes_pod_container_name"}, Regex: "(istio-proxy)", Action: Drop},
+			{SourceLabels: []string{"__meta_kubernetes_pod_label_security_istio_io_tlsMode"}, Regex: "(istio)", TargetLabel: "__scheme__", Replacement: "https", Action: Replace},
+			{TargetLabel: "__scheme__", Replacement: "http", Action: Replace},
+			{SourceLabels: []string{"__scheme__"}, Regex: "(https)", Action: Drop},
+		}, podJob.RelabelConfigs)
+
+		serviceJob := scrapeConfigs[2]
+		require.Equal(t, "test-2/db", serviceJob.JobName)
+		require.Equal(t, "test-2/db-secure", scrapeConfigs[3].JobName)
+		require.Equal(t, 30*time.Second, serviceJob.ScrapeInterval)
+		require.Empty(t, serviceJob.MetricsPath)
+		require.Equal(t, []KubernetesDiscoveryConfig{{Role: RoleEndpoints}}, serviceJob.KubernetesDiscoveryConfigs)
+		require.Equal(t, []RelabelConfig{
+			{SourceLabels: []string{"__meta_kubernetes_endpoint_node_name"}, Regex: "$MY_NODE_NAME", Action: Keep},
+			{SourceLabels: []string{"__meta_kubernetes_service_label_app"}, Regex: "db", Action: Keep},
+			{SourceLabels: []string{"__address__"}, Regex: ".+:9187", Action: Keep},
+			{SourceLabels: []string{"__meta_kubernetes_pod_phase"}, Regex: "Pending|Succeeded|Failed", Action: Drop},
+			{SourceLabels: []string{"__meta_kubernetes_pod_container_init"}, Regex: "(true)", Action: Drop},
+			{SourceLabels: []string{"__meta_kubernetes_pod_container_name"}, Regex: "(istio-proxy)", Action: Drop},
+			{SourceLabels: []string{"__meta_kubernetes_pod_label_security_istio_io_tlsMode"}, Regex: "(istio)", TargetLabel: "__scheme__", Replacement: "https", Action: Replace},
+			{SourceLabels: []string{"__scheme__"}, Regex: "(https)", Action: Drop},
+			{SourceLabels: []string{"__meta_kubernetes_service_name"}, TargetLabel: "service", Action: Replace},
+		}, serviceJob.RelabelConfigs)
+	})
 }
@@ -2,10 +2,18 @@ package agent
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kyma-project/telemetry-manager/internal/namespaces"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
 )
 
+var invalidLabelCharRegex = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
 type NodeAffiliatedResource string
 
 const (
@@ -20,6 +28,13 @@ const (
 	AnnotatedService AnnotatedResource = "service"
 )
 
+type LabeledResource string
+
+const (
+	LabeledPod     LabeledResource = "pod"
+	LabeledService LabeledResource = "service"
+)
+
 func keepIfRunningOnSameNode(nodeAffiliated NodeAffiliatedResource) RelabelConfig {
 	return RelabelConfig{
 		SourceLabels: []string{fmt.Sprintf("__meta_kubernetes_%s_node_name", nodeAffiliated)},
@@ -36,6 +51,76 @@ func keepIfScrapingEnabled(annotated AnnotatedResource) RelabelConfig {
 	}
 }
 
+// keepIfLabelsMatch keeps only the targets whose resource has all the given labels with the given values.
+func keepIfLabelsMatch(labeled LabeledResource, labels map[string]string) []RelabelConfig {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	relabelConfigs := make([]RelabelConfig, 0, len(keys))
+	for _, key := range keys {
+		relabelConfigs = append(relabelConfigs, RelabelConfig{
+			SourceLabels: []string{fmt.Sprintf("__meta_kubernetes_%s_label_%s", labeled, sanitizeLabelName(key))},
+			Regex:        regexp.QuoteMeta(labels[key]),
+			Action:       Keep,
+		})
+	}
+	return relabelConfigs
+}
+
+// sanitizeLabelName converts a Kubernetes label key to the name of the meta label that the Kubernetes service discovery creates for it.
+func sanitizeLabelName(key string) string {
+	return invalidLabelCharRegex.ReplaceAllString(key, "_")
+}
+
+// keepIfPortMatches keeps only the targets of the given port. A named port is matched by the given meta label, a numbered port by the target address.
+func keepIfPortMatches(portNameLabel string, port intstr.IntOrString) RelabelConfig {
+	if port.Type == intstr.Int {
+		return RelabelConfig{
+			SourceLabels: []string{"__address__"},
+			Regex:        fmt.Sprintf(".+:%d", port.IntVal),
+			Action:       Keep,
+		}
+	}
+
+	return RelabelConfig{
+		SourceLabels: []string{portNameLabel},
+		Regex:        regexp.QuoteMeta(port.StrVal),
+		Action:       Keep,
+	}
+}
+
+// namespaceRelabelConfigs keeps only the targets in the selected Namespaces. If all Namespaces are selected, no relabel config is needed.
+func namespaceRelabelConfigs(selection namespaces.Selection) []RelabelConfig {
+	if len(selection.Include) > 0 {
+		return []RelabelConfig{{
+			SourceLabels: []string{"__meta_kubernetes_namespace"},
+			Regex:        namespacesRegex(selection.Include),
+			Action:       Keep,
+		}}
+	}
+
+	if len(selection.Exclude) > 0 {
+		return []RelabelConfig{{
+			SourceLabels: []string{"__meta_kubernetes_namespace"},
+			Regex:        namespacesRegex(selection.Exclude),
+			Action:       Drop,
+		}}
+	}
+
+	return nil
+}
+
+func namespacesRegex(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = regexp.QuoteMeta(name)
+	}
+	return "(" + strings.Join(quoted, "|") + ")"
+}
+
 func keepIfIstioProxy() RelabelConfig {
 	return RelabelConfig{
 		SourceLabels: []string{"__meta_kubernetes_pod_container_name"},
@@ -70,6 +155,14 @@ func inferSchemeFromIstioInjectedLabel() RelabelConfig {
 	}
 }
 
+func setScheme(scheme string) RelabelConfig {
+	return RelabelConfig{
+		Action:      Replace,
+		TargetLabel: "__scheme__",
+		Replacement: scheme,
+	}
+}
+
 func inferSchemeFromAnnotation(annotated AnnotatedResource) RelabelConfig {
 	return RelabelConfig{
 		SourceLabels: []string{fmt.Sprintf("__meta_kubernetes_%s_annotation_prometheus_io_scheme", annotated)},
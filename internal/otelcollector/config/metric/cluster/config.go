@@ -0,0 +1,58 @@
+package cluster
+
+import (
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+)
+
+type Config struct {
+	config.Base `yaml:",inline"`
+
+	Receivers  Receivers  `yaml:"receivers"`
+	Processors Processors `yaml:"processors"`
+	Exporters  Exporters  `yaml:"exporters"`
+	Connectors Connectors `yaml:"connectors,omitempty"`
+}
+
+type Receivers struct {
+	K8sCluster *K8sClusterReceiver `yaml:"k8s_cluster,omitempty"`
+	K8sEvents  *K8sEventsReceiver  `yaml:"k8s_events,omitempty"`
+}
+
+type K8sClusterReceiver struct {
+	AuthType                 string   `yaml:"auth_type"`
+	CollectionInterval       string   `yaml:"collection_interval"`
+	NodeConditionsToReport   []string `yaml:"node_conditions_to_report"`
+	AllocatableTypesToReport []string `yaml:"allocatable_types_to_report"`
+}
+
+type K8sEventsReceiver struct {
+	AuthType string `yaml:"auth_type"`
+}
+
+type Processors struct {
+	config.BaseProcessors `yaml:",inline"`
+
+	InsertInputSourceCluster *config.ResourceProcessor `yaml:"resource/insert-input-source-cluster,omitempty"`
+}
+
+type Exporters struct {
+	OTLP config.OTLPExporter `yaml:"otlp"`
+}
+
+type Connectors struct {
+	CountK8sEvents *CountConnector `yaml:"count/k8s-events,omitempty"`
+}
+
+type CountConnector struct {
+	Logs map[string]CountConnectorMetric `yaml:"logs"`
+}
+
+type CountConnectorMetric struct {
+	Description string                    `yaml:"description"`
+	Attributes  []CountConnectorAttribute `yaml:"attributes,omitempty"`
+}
+
+type CountConnectorAttribute struct {
+	Key          string `yaml:"key"`
+	DefaultValue string `yaml:"default_value,omitempty"`
+}
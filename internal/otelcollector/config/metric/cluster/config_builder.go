@@ -0,0 +1,171 @@
+// Package cluster builds the configuration of the cluster collector, a single collector instance that collects the metrics
+// about the state of the Kubernetes resources of the whole cluster, and sends them to the metric gateway.
+package cluster
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/metric"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
+)
+
+func MakeConfig(gatewayServiceName types.NamespacedName, pipelines []v1alpha1.MetricPipeline) *Config {
+	events := enableEvents(pipelines)
+
+	cfg := &Config{
+		Base: config.Base{
+			Extensions: makeExtensionsConfig(),
+			Service:    makeServiceConfig(events),
+		},
+		Receivers:  makeReceiversConfig(events),
+		Processors: makeProcessorsConfig(),
+		Exporters:  makeExportersConfig(gatewayServiceName),
+	}
+
+	if events {
+		cfg.Connectors.CountK8sEvents = makeCountK8sEventsConfig()
+	}
+
+	return cfg
+}
+
+// enableEvents returns true if at least one pipeline that enables the cluster input also enables the event metrics.
+func enableEvents(pipelines []v1alpha1.MetricPipeline) bool {
+	for i := range pipelines {
+		input := pipelines[i].Spec.Input.Application.Cluster
+		if input.Enabled && input.Events.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+func makeReceiversConfig(events bool) Receivers {
+	receivers := Receivers{
+		K8sCluster: &K8sClusterReceiver{
+			AuthType:                 "serviceAccount",
+			CollectionInterval:       "30s",
+			NodeConditionsToReport:   []string{"Ready", "MemoryPressure", "DiskPressure", "PIDPressure"},
+			AllocatableTypesToReport: []string{"cpu", "memory", "ephemeral-storage"},
+		},
+	}
+
+	if events {
+		receivers.K8sEvents = &K8sEventsReceiver{
+			AuthType: "serviceAccount",
+		}
+	}
+
+	return receivers
+}
+
+// makeCountK8sEventsConfig returns the connector that turns the Kubernetes events, which the events receiver emits as logs, into a metric.
+func makeCountK8sEventsConfig() *CountConnector {
+	return &CountConnector{
+		Logs: map[string]CountConnectorMetric{
+			metric.EventCountMetricName: {
+				Description: "The number of Kubernetes events",
+				Attributes: []CountConnectorAttribute{
+					{Key: "k8s.event.reason", DefaultValue: "unknown"},
+				},
+			},
+		},
+	}
+}
+
+func makeProcessorsConfig() Processors {
+	return Processors{
+		BaseProcessors: config.BaseProcessors{
+			Batch: &config.BatchProcessor{
+				SendBatchSize:    1024,
+				Timeout:          "10s",
+				SendBatchMaxSize: 1024,
+			},
+			MemoryLimiter: &config.MemoryLimiter{
+				CheckInterval:        "0.1s",
+				LimitPercentage:      80,
+				SpikeLimitPercentage: 15,
+			},
+		},
+		InsertInputSourceCluster: &config.ResourceProcessor{
+			Attributes: []config.AttributeAction{
+				{
+					Action: "insert",
+					Key:    metric.InputSourceAttribute,
+					Value:  string(metric.InputSourceCluster),
+				},
+			},
+		},
+	}
+}
+
+func makeExportersConfig(gatewayServiceName types.NamespacedName) Exporters {
+	return Exporters{
+		OTLP: config.OTLPExporter{
+			Endpoint: fmt.Sprintf("%s.%s.svc.cluster.local:%d", gatewayServiceName.Name, gatewayServiceName.Namespace, ports.OTLPGRPC),
+			TLS: config.TLS{
+				Insecure: true,
+			},
+			SendingQueue: config.SendingQueue{
+				Enabled:   true,
+				QueueSize: 512,
+			},
+			RetryOnFailure: config.RetryOnFailure{
+				Enabled:         true,
+				InitialInterval: "5s",
+				MaxInterval:     "30s",
+				MaxElapsedTime:  "300s",
+			},
+		},
+	}
+}
+
+func makeExtensionsConfig() config.Extensions {
+	return config.Extensions{
+		HealthCheck: config.Endpoint{
+			Endpoint: fmt.Sprintf("${%s}:%d", config.EnvVarCurrentPodIP, ports.HealthCheck),
+		},
+	}
+}
+
+func makeServiceConfig(events bool) config.Service {
+	return config.Service{
+		Pipelines: makePipelinesConfig(events),
+		Telemetry: config.Telemetry{
+			Metrics: config.Metrics{
+				Address: fmt.Sprintf("${%s}:%d", config.EnvVarCurrentPodIP, ports.Metrics),
+			},
+			Logs: config.Logs{
+				Level:    "info",
+				Encoding: "json",
+			},
+		},
+		Extensions: []string{"health_check"},
+	}
+}
+
+func makePipelinesConfig(events bool) config.Pipelines {
+	pipelinesConfig := make(config.Pipelines)
+
+	receivers := []string{"k8s_cluster"}
+	if events {
+		pipelinesConfig["logs/k8s-events"] = config.Pipeline{
+			Receivers:  []string{"k8s_events"},
+			Processors: []string{"memory_limiter"},
+			Exporters:  []string{"count/k8s-events"},
+		}
+		receivers = append(receivers, "count/k8s-events")
+	}
+
+	pipelinesConfig["metrics/cluster"] = config.Pipeline{
+		Receivers:  receivers,
+		Processors: []string{"memory_limiter", "resource/insert-input-source-cluster", "batch"},
+		Exporters:  []string{"otlp"},
+	}
+
+	return pipelinesConfig
+}
@@ -0,0 +1,78 @@
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
+)
+
+func TestMakeConfig(t *testing.T) {
+	gatewayServiceName := types.NamespacedName{Name: "metrics", Namespace: "telemetry-system"}
+
+	t.Run("otlp exporter endpoint", func(t *testing.T) {
+		collectorConfig := MakeConfig(gatewayServiceName, []v1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithClusterInputOn(true).Build(),
+		})
+
+		require.Equal(t, "metrics.telemetry-system.svc.cluster.local:4317", collectorConfig.Exporters.OTLP.Endpoint)
+		require.True(t, collectorConfig.Exporters.OTLP.TLS.Insecure)
+	})
+
+	t.Run("events disabled", func(t *testing.T) {
+		collectorConfig := MakeConfig(gatewayServiceName, []v1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithClusterInputOn(true).Build(),
+			testutils.NewMetricPipelineBuilder().WithClusterInputOn(false).WithClusterInputEvents(true).Build(),
+		})
+
+		require.NotNil(t, collectorConfig.Receivers.K8sCluster)
+		require.Nil(t, collectorConfig.Receivers.K8sEvents)
+		require.Nil(t, collectorConfig.Connectors.CountK8sEvents)
+
+		require.Len(t, collectorConfig.Service.Pipelines, 1)
+		require.Equal(t, []string{"k8s_cluster"}, collectorConfig.Service.Pipelines["metrics/cluster"].Receivers)
+		require.Equal(t, []string{"memory_limiter", "resource/insert-input-source-cluster", "batch"}, collectorConfig.Service.Pipelines["metrics/cluster"].Processors)
+	})
+
+	t.Run("events enabled", func(t *testing.T) {
+		collectorConfig := MakeConfig(gatewayServiceName, []v1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithClusterInputOn(true).Build(),
+			testutils.NewMetricPipelineBuilder().WithClusterInputOn(true).WithClusterInputEvents(true).Build(),
+		})
+
+		require.NotNil(t, collectorConfig.Receivers.K8sEvents)
+		require.NotNil(t, collectorConfig.Connectors.CountK8sEvents)
+		require.Contains(t, collectorConfig.Connectors.CountK8sEvents.Logs, "k8s.event.count")
+
+		require.Len(t, collectorConfig.Service.Pipelines, 2)
+		require.Equal(t, []string{"k8s_events"}, collectorConfig.Service.Pipelines["logs/k8s-events"].Receivers)
+		require.Equal(t, []string{"count/k8s-events"}, collectorConfig.Service.Pipelines["logs/k8s-events"].Exporters)
+		require.Equal(t, []string{"k8s_cluster", "count/k8s-events"}, collectorConfig.Service.Pipelines["metrics/cluster"].Receivers)
+	})
+
+	t.Run("marshaling", func(t *testing.T) {
+		overwriteGoldenFile := false
+
+		config := MakeConfig(gatewayServiceName, []v1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithClusterInputOn(true).WithClusterInputEvents(true).Build(),
+		})
+		configYAML, err := yaml.Marshal(config)
+		require.NoError(t, err, "failed to marshal config")
+
+		goldenFilePath := filepath.Join("testdata", "config.yaml")
+		if overwriteGoldenFile {
+			err = os.WriteFile(goldenFilePath, configYAML, 0600)
+			require.NoError(t, err, "failed to overwrite golden file")
+		}
+
+		goldenFile, err := os.ReadFile(goldenFilePath)
+		require.NoError(t, err, "failed to load golden file")
+		require.Equal(t, string(goldenFile), string(configYAML))
+	})
+}
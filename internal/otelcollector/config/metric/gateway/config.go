@@ -24,16 +24,22 @@ type Processors struct {
 	DropIfInputSourceRuntime    *FilterProcessor               `yaml:"filter/drop-if-input-source-runtime,omitempty"`
 	DropIfInputSourcePrometheus *FilterProcessor               `yaml:"filter/drop-if-input-source-prometheus,omitempty"`
 	DropIfInputSourceIstio      *FilterProcessor               `yaml:"filter/drop-if-input-source-istio,omitempty"`
+	DropIfInputSourceCluster    *FilterProcessor               `yaml:"filter/drop-if-input-source-cluster,omitempty"`
 	ResolveServiceName          *TransformProcessor            `yaml:"transform/resolve-service-name,omitempty"`
 	DropKymaAttributes          *config.ResourceProcessor      `yaml:"resource/drop-kyma-attributes,omitempty"`
+
+	// OTel Collector components with dynamic IDs that are pipeline name based.
+	Dynamic map[string]any `yaml:",inline,omitempty"`
 }
 
 type FilterProcessor struct {
-	Metrics FilterProcessorMetric `yaml:"metrics"`
+	ErrorMode string                `yaml:"error_mode,omitempty"`
+	Metrics   FilterProcessorMetric `yaml:"metrics"`
 }
 
 type FilterProcessorMetric struct {
-	DataPoint []string `yaml:"datapoint"`
+	Metric    []string `yaml:"metric,omitempty"`
+	DataPoint []string `yaml:"datapoint,omitempty"`
 }
 
 type TransformProcessor struct {
@@ -44,5 +50,20 @@ type TransformProcessor struct {
 type Exporters map[string]Exporter
 
 type Exporter struct {
-	OTLP *config.OTLPExporter `yaml:",inline,omitempty"`
+	OTLP                  *config.OTLPExporter                  `yaml:",inline,omitempty"`
+	PrometheusRemoteWrite *config.PrometheusRemoteWriteExporter `yaml:"-"`
+	Prometheus            *config.PrometheusExporter            `yaml:"-"`
+}
+
+// MarshalYAML renders the config of the exporter that is set.
+// The exporter types share keys like endpoint, so they cannot be inlined into the same mapping.
+func (e Exporter) MarshalYAML() (any, error) {
+	switch {
+	case e.PrometheusRemoteWrite != nil:
+		return e.PrometheusRemoteWrite, nil
+	case e.Prometheus != nil:
+		return e.Prometheus, nil
+	default:
+		return e.OTLP, nil
+	}
 }
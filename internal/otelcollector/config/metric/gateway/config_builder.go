@@ -4,29 +4,41 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"slices"
 	"sort"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/otlpexporter"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/prometheusexporter"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
 )
 
-func MakeConfig(ctx context.Context, c client.Reader, pipelines []telemetryv1alpha1.MetricPipeline) (*Config, otlpexporter.EnvVars, error) {
+// BuildOptions contains the settings for the metric gateway config that are not derived from the MetricPipeline resources.
+type BuildOptions struct {
+	// Enrichments defines the Kubernetes metadata that is added to the metrics in addition to the default metadata.
+	Enrichments *operatorv1alpha1.EnrichmentSpec
+
+	// Cluster defines the cluster name and the static attributes that are added to the metrics.
+	Cluster *operatorv1alpha1.ClusterSpec
+}
+
+func MakeConfig(ctx context.Context, c client.Reader, pipelines []telemetryv1alpha1.MetricPipeline, opts BuildOptions) (*Config, otlpexporter.EnvVars, error) {
 	cfg := &Config{
 		Base: config.Base{
 			Service:    makeServiceConfig(),
 			Extensions: makeExtensionsConfig(),
 		},
 		Receivers:  makeReceiversConfig(),
-		Processors: makeProcessorsConfig(),
+		Processors: makeProcessorsConfig(opts),
 		Exporters:  make(Exporters),
 	}
 
 	envVars := make(otlpexporter.EnvVars)
-	queueSize := 256 / len(pipelines)
+	queueSize := 256 / max(countQueuedOutputs(pipelines), 1)
 
 	for i := range pipelines {
 		pipeline := pipelines[i]
@@ -34,8 +46,7 @@ func MakeConfig(ctx context.Context, c client.Reader, pipelines []telemetryv1alp
 			continue
 		}
 
-		otlpExporterBuilder := otlpexporter.NewConfigBuilder(c, pipeline.Spec.Output.Otlp, pipeline.Name, queueSize)
-		if err := addComponentsForMetricPipeline(ctx, otlpExporterBuilder, &pipeline, cfg, envVars); err != nil {
+		if err := addComponentsForMetricPipeline(ctx, c, &pipeline, cfg, envVars, queueSize); err != nil {
 			return nil, nil, err
 		}
 	}
@@ -43,6 +54,53 @@ func MakeConfig(ctx context.Context, c client.Reader, pipelines []telemetryv1alp
 	return cfg, envVars, nil
 }
 
+// MakeEnvVars returns the environment variables that the exporters of the given pipelines read from the gateway Secret.
+// It resolves the variables without rendering the pipelines, so that the Secret also contains the variables of the suspended pipelines, which can then be resumed without further changes.
+func MakeEnvVars(ctx context.Context, c client.Reader, pipelines []telemetryv1alpha1.MetricPipeline) (otlpexporter.EnvVars, error) {
+	envVars := make(otlpexporter.EnvVars)
+	for i := range pipelines {
+		if pipelines[i].DeletionTimestamp != nil {
+			continue
+		}
+
+		for _, output := range pipelines[i].Spec.AllOutputs() {
+			outputID := telemetryv1alpha1.OutputID(pipelines[i].Name, output.Name)
+
+			var outputEnvVars otlpexporter.EnvVars
+			var err error
+			switch {
+			case output.PrometheusRemoteWrite != nil:
+				_, outputEnvVars, err = prometheusexporter.NewRemoteWriteConfigBuilder(c, output.PrometheusRemoteWrite, outputID, 0).MakeConfig(ctx)
+			case output.Prometheus != nil:
+				continue
+			default:
+				_, outputEnvVars, err = otlpexporter.NewConfigBuilder(c, output.Otlp, outputID, 0).MakeConfig(ctx)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to make env vars of output %s: %w", outputID, err)
+			}
+
+			maps.Copy(envVars, outputEnvVars)
+		}
+	}
+
+	return envVars, nil
+}
+
+// countQueuedOutputs returns the number of outputs of all given pipelines that push the metrics to a backend, so that the sending queue capacity can be shared among their exporters.
+// Prometheus outputs are scraped and do not have a queue.
+func countQueuedOutputs(pipelines []telemetryv1alpha1.MetricPipeline) int {
+	count := 0
+	for i := range pipelines {
+		for _, output := range pipelines[i].Spec.AllOutputs() {
+			if output.Prometheus == nil {
+				count++
+			}
+		}
+	}
+	return count
+}
+
 func makeReceiversConfig() Receivers {
 	return Receivers{
 		OTLP: config.OTLPReceiver{
@@ -66,6 +124,7 @@ func makeExtensionsConfig() config.Extensions {
 		Pprof: config.Endpoint{
 			Endpoint: fmt.Sprintf("127.0.0.1:%d", ports.Pprof),
 		},
+		Dynamic: make(map[string]any),
 	}
 }
 
@@ -86,7 +145,7 @@ func makeServiceConfig() config.Service {
 }
 
 // addComponentsForMetricPipeline enriches a Config (exporters, processors, etc.) with components for a given telemetryv1alpha1.MetricPipeline.
-func addComponentsForMetricPipeline(ctx context.Context, otlpExporterBuilder *otlpexporter.ConfigBuilder, pipeline *telemetryv1alpha1.MetricPipeline, cfg *Config, envVars otlpexporter.EnvVars) error {
+func addComponentsForMetricPipeline(ctx context.Context, c client.Reader, pipeline *telemetryv1alpha1.MetricPipeline, cfg *Config, envVars otlpexporter.EnvVars, queueSize int) error {
 	if enableDropIfInputSourceRuntime(pipeline) {
 		cfg.Processors.DropIfInputSourceRuntime = makeDropIfInputSourceRuntimeConfig()
 	}
@@ -99,23 +158,116 @@ func addComponentsForMetricPipeline(ctx context.Context, otlpExporterBuilder *ot
 		cfg.Processors.DropIfInputSourceIstio = makeDropIfInputSourceIstioConfig()
 	}
 
-	otlpExporterConfig, otlpExporterEnvVars, err := otlpExporterBuilder.MakeConfig(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to make otlp exporter config: %w", err)
+	if enableDropIfInputSourceCluster(pipeline) {
+		cfg.Processors.DropIfInputSourceCluster = makeDropIfInputSourceClusterConfig()
 	}
 
-	maps.Copy(envVars, otlpExporterEnvVars)
+	var exporterIDs []string
+	for _, output := range pipeline.Spec.AllOutputs() {
+		exporterID, err := addComponentsForOutput(ctx, c, pipeline, output, cfg, envVars, queueSize)
+		if err != nil {
+			return err
+		}
+		exporterIDs = append(exporterIDs, exporterID)
+	}
 
-	otlpExporterID := otlpexporter.ExporterID(pipeline.Spec.Output.Otlp, pipeline.Name)
-	cfg.Exporters[otlpExporterID] = Exporter{OTLP: otlpExporterConfig}
+	var pipelineProcessorIDs []string
+	if filterProcessorID, filterProcessorConfig := makeFilterProcessorConfig(pipeline); filterProcessorID != "" {
+		cfg.Processors.Dynamic[filterProcessorID] = filterProcessorConfig
+		pipelineProcessorIDs = append(pipelineProcessorIDs, filterProcessorID)
+	}
+	if transformProcessorID, transformProcessorConfig := makeTransformProcessorConfig(pipeline); transformProcessorID != "" {
+		cfg.Processors.Dynamic[transformProcessorID] = transformProcessorConfig
+		pipelineProcessorIDs = append(pipelineProcessorIDs, transformProcessorID)
+	}
 
 	pipelineID := fmt.Sprintf("metrics/%s", pipeline.Name)
-	cfg.Service.Pipelines[pipelineID] = makePipelineConfig(pipeline, otlpExporterID)
+	cfg.Service.Pipelines[pipelineID] = makePipelineConfig(pipeline, pipelineProcessorIDs, exporterIDs...)
 
 	return nil
 }
 
-func makePipelineConfig(pipeline *telemetryv1alpha1.MetricPipeline, exporterIDs ...string) config.Pipeline {
+// addComponentsForOutput adds the exporter of the given output, and the extensions that the exporter requires, to the Config. It returns the ID of the exporter.
+func addComponentsForOutput(ctx context.Context, c client.Reader, pipeline *telemetryv1alpha1.MetricPipeline, output telemetryv1alpha1.NamedMetricPipelineOutput, cfg *Config, envVars otlpexporter.EnvVars, queueSize int) (string, error) {
+	outputID := telemetryv1alpha1.OutputID(pipeline.Name, output.Name)
+	exporterID := ExporterID(pipeline.Name, output)
+
+	switch {
+	case output.PrometheusRemoteWrite != nil:
+		exporterConfig, exporterEnvVars, err := prometheusexporter.NewRemoteWriteConfigBuilder(c, output.PrometheusRemoteWrite, outputID, queueSize).MakeConfig(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to make prometheus remote-write exporter config: %w", err)
+		}
+
+		maps.Copy(envVars, exporterEnvVars)
+		cfg.Exporters[exporterID] = Exporter{PrometheusRemoteWrite: exporterConfig}
+
+		if authenticatorID, authenticatorConfig := prometheusexporter.MakeRemoteWriteAuthenticatorConfig(output.PrometheusRemoteWrite, outputID); authenticatorID != "" {
+			cfg.Extensions.Dynamic[authenticatorID] = authenticatorConfig
+			cfg.Service.Extensions = append(cfg.Service.Extensions, authenticatorID)
+		}
+
+	case output.Prometheus != nil:
+		cfg.Exporters[exporterID] = Exporter{Prometheus: prometheusexporter.MakeConfig(output.Prometheus)}
+
+	default:
+		otlpExporterBuilder := otlpexporter.NewConfigBuilder(c, output.Otlp, outputID, queueSize)
+		otlpExporterConfig, otlpExporterEnvVars, err := otlpExporterBuilder.MakeConfig(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to make otlp exporter config: %w", err)
+		}
+
+		maps.Copy(envVars, otlpExporterEnvVars)
+		cfg.Exporters[exporterID] = Exporter{OTLP: otlpExporterConfig}
+
+		if authenticatorID, authenticatorConfig := otlpexporter.MakeAuthenticatorConfig(output.Otlp, outputID); authenticatorID != "" {
+			cfg.Extensions.Dynamic[authenticatorID] = authenticatorConfig
+			cfg.Service.Extensions = append(cfg.Service.Extensions, authenticatorID)
+		}
+
+		if output.Otlp.HasPersistentQueue() && cfg.Extensions.FileStorage == nil {
+			cfg.Extensions.FileStorage = &config.FileStorageExtension{Directory: config.PersistentQueueDirectory}
+			cfg.Service.Extensions = append(cfg.Service.Extensions, config.FileStorageExtensionID)
+		}
+	}
+
+	return exporterID, nil
+}
+
+// ExporterID returns the ID of the exporter that ships the metrics of the given pipeline to the given output.
+func ExporterID(pipelineName string, output telemetryv1alpha1.NamedMetricPipelineOutput) string {
+	outputID := telemetryv1alpha1.OutputID(pipelineName, output.Name)
+
+	switch {
+	case output.PrometheusRemoteWrite != nil:
+		return prometheusexporter.RemoteWriteExporterID(outputID)
+	case output.Prometheus != nil:
+		return prometheusexporter.ExporterID(outputID)
+	default:
+		return otlpexporter.ExporterID(output.Otlp, outputID)
+	}
+}
+
+// PrometheusPorts returns the ports on which the Prometheus outputs of the given pipelines expose the metrics.
+func PrometheusPorts(pipelines []telemetryv1alpha1.MetricPipeline) []int32 {
+	var prometheusPorts []int32
+	for i := range pipelines {
+		if pipelines[i].DeletionTimestamp != nil {
+			continue
+		}
+		for _, output := range pipelines[i].Spec.AllOutputs() {
+			if output.Prometheus != nil {
+				prometheusPorts = append(prometheusPorts, output.Prometheus.Port)
+			}
+		}
+	}
+	slices.Sort(prometheusPorts)
+	return prometheusPorts
+}
+
+// makePipelineConfig creates the pipeline of the given MetricPipeline. The pipeline processors are the filter and transform processors
+// that are specific to the MetricPipeline, they are applied after the drop-if-input-source filters and before the Kyma-internal attributes are removed.
+func makePipelineConfig(pipeline *telemetryv1alpha1.MetricPipeline, pipelineProcessorIDs []string, exporterIDs ...string) config.Pipeline {
 	sort.Strings(exporterIDs)
 
 	processors := []string{"memory_limiter", "k8sattributes", "resource/insert-cluster-name", "transform/resolve-service-name"}
@@ -132,6 +284,11 @@ func makePipelineConfig(pipeline *telemetryv1alpha1.MetricPipeline, exporterIDs
 		processors = append(processors, "filter/drop-if-input-source-istio")
 	}
 
+	if enableDropIfInputSourceCluster(pipeline) {
+		processors = append(processors, "filter/drop-if-input-source-cluster")
+	}
+
+	processors = append(processors, pipelineProcessorIDs...)
 	processors = append(processors, "resource/drop-kyma-attributes", "batch")
 
 	return config.Pipeline{
@@ -155,3 +312,8 @@ func enableDropIfInputSourceIstio(pipeline *telemetryv1alpha1.MetricPipeline) bo
 	appInput := pipeline.Spec.Input.Application
 	return !appInput.Istio.Enabled
 }
+
+func enableDropIfInputSourceCluster(pipeline *telemetryv1alpha1.MetricPipeline) bool {
+	appInput := pipeline.Spec.Input.Application
+	return !appInput.Cluster.Enabled
+}
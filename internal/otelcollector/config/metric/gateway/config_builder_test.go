@@ -20,7 +20,7 @@ func TestMakeConfig(t *testing.T) {
 	fakeClient := fake.NewClientBuilder().Build()
 
 	t.Run("otlp exporter endpoint", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().WithName("test").Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().WithName("test").Build()}, BuildOptions{})
 		require.NoError(t, err)
 		expectedEndpoint := fmt.Sprintf("${%s}", "OTLP_ENDPOINT_TEST")
 		require.Contains(t, collectorConfig.Exporters, "otlp/test")
@@ -30,7 +30,7 @@ func TestMakeConfig(t *testing.T) {
 	})
 
 	t.Run("secure", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().WithName("test").Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().WithName("test").Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Contains(t, collectorConfig.Exporters, "otlp/test")
@@ -40,7 +40,7 @@ func TestMakeConfig(t *testing.T) {
 
 	t.Run("insecure", func(t *testing.T) {
 		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{
-			testutils.NewMetricPipelineBuilder().WithName("test-insecure").WithEndpoint("http://localhost").Build()},
+			testutils.NewMetricPipelineBuilder().WithName("test-insecure").WithEndpoint("http://localhost").Build()}, BuildOptions{},
 		)
 		require.NoError(t, err)
 
@@ -52,7 +52,7 @@ func TestMakeConfig(t *testing.T) {
 	t.Run("basic auth", func(t *testing.T) {
 		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{
 			testutils.NewMetricPipelineBuilder().WithName("test-basic-auth").WithBasicAuth("user", "password").Build(),
-		})
+		}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Contains(t, collectorConfig.Exporters, "otlp/test-basic-auth")
@@ -65,7 +65,7 @@ func TestMakeConfig(t *testing.T) {
 	})
 
 	t.Run("extensions", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.NotEmpty(t, collectorConfig.Extensions.HealthCheck.Endpoint)
@@ -74,8 +74,26 @@ func TestMakeConfig(t *testing.T) {
 		require.Contains(t, collectorConfig.Service.Extensions, "pprof")
 	})
 
+	t.Run("persistent queue", func(t *testing.T) {
+		withoutQueue := testutils.NewMetricPipelineBuilder().WithName("without-queue").Build()
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{withoutQueue}, BuildOptions{})
+		require.NoError(t, err)
+		require.Nil(t, collectorConfig.Extensions.FileStorage)
+		require.NotContains(t, collectorConfig.Service.Extensions, "file_storage/queue")
+
+		withQueue := testutils.NewMetricPipelineBuilder().WithName("with-queue").Build()
+		withQueue.Spec.Output.Otlp.Queue = &v1alpha1.OtlpQueue{Persistent: true}
+		collectorConfig, _, err = MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{withoutQueue, withQueue}, BuildOptions{})
+		require.NoError(t, err)
+		require.NotNil(t, collectorConfig.Extensions.FileStorage)
+		require.Equal(t, "/var/lib/otelcol/queue", collectorConfig.Extensions.FileStorage.Directory)
+		require.Contains(t, collectorConfig.Service.Extensions, "file_storage/queue")
+		require.Equal(t, "file_storage/queue", collectorConfig.Exporters["otlp/with-queue"].OTLP.SendingQueue.Storage)
+		require.Empty(t, collectorConfig.Exporters["otlp/without-queue"].OTLP.SendingQueue.Storage)
+	})
+
 	t.Run("telemetry", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Equal(t, "info", collectorConfig.Service.Telemetry.Logs.Level)
@@ -84,7 +102,7 @@ func TestMakeConfig(t *testing.T) {
 	})
 
 	t.Run("single pipeline queue size", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().WithName("test").Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().WithName("test").Build()}, BuildOptions{})
 		require.NoError(t, err)
 		require.Equal(t, 256, collectorConfig.Exporters["otlp/test"].OTLP.SendingQueue.QueueSize, "Pipeline should have the full queue size")
 	})
@@ -93,7 +111,7 @@ func TestMakeConfig(t *testing.T) {
 		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{
 			testutils.NewMetricPipelineBuilder().WithName("test-1").Build(),
 			testutils.NewMetricPipelineBuilder().WithName("test-2").Build(),
-			testutils.NewMetricPipelineBuilder().WithName("test-3").Build()},
+			testutils.NewMetricPipelineBuilder().WithName("test-3").Build()}, BuildOptions{},
 		)
 		require.NoError(t, err)
 		require.Equal(t, 85, collectorConfig.Exporters["otlp/test-1"].OTLP.SendingQueue.QueueSize, "Queue size should be divided by the number of pipelines")
@@ -103,7 +121,7 @@ func TestMakeConfig(t *testing.T) {
 
 	t.Run("single pipeline topology", func(t *testing.T) {
 		t.Run("with no application inputs enabled", func(t *testing.T) {
-			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().WithName("test").Build()})
+			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().WithName("test").Build()}, BuildOptions{})
 			require.NoError(t, err)
 
 			require.Contains(t, collectorConfig.Exporters, "otlp/test")
@@ -118,6 +136,7 @@ func TestMakeConfig(t *testing.T) {
 				"filter/drop-if-input-source-runtime",
 				"filter/drop-if-input-source-prometheus",
 				"filter/drop-if-input-source-istio",
+				"filter/drop-if-input-source-cluster",
 				"resource/drop-kyma-attributes",
 				"batch",
 			})
@@ -125,7 +144,7 @@ func TestMakeConfig(t *testing.T) {
 
 		t.Run("with prometheus input enabled", func(t *testing.T) {
 			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{
-				testutils.NewMetricPipelineBuilder().WithName("test").WithPrometheusInputOn(true).Build()},
+				testutils.NewMetricPipelineBuilder().WithName("test").WithPrometheusInputOn(true).Build()}, BuildOptions{},
 			)
 			require.NoError(t, err)
 
@@ -140,6 +159,8 @@ func TestMakeConfig(t *testing.T) {
 				"transform/resolve-service-name",
 				"filter/drop-if-input-source-runtime",
 				"filter/drop-if-input-source-istio",
+				"filter/drop-if-input-source-cluster",
+				"filter/test",
 				"resource/drop-kyma-attributes",
 				"batch",
 			})
@@ -147,7 +168,7 @@ func TestMakeConfig(t *testing.T) {
 
 		t.Run("with runtime input enabled", func(t *testing.T) {
 			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{
-				testutils.NewMetricPipelineBuilder().WithName("test").WithRuntimeInputOn(true).Build()},
+				testutils.NewMetricPipelineBuilder().WithName("test").WithRuntimeInputOn(true).Build()}, BuildOptions{},
 			)
 			require.NoError(t, err)
 
@@ -162,6 +183,8 @@ func TestMakeConfig(t *testing.T) {
 				"transform/resolve-service-name",
 				"filter/drop-if-input-source-prometheus",
 				"filter/drop-if-input-source-istio",
+				"filter/drop-if-input-source-cluster",
+				"filter/test",
 				"resource/drop-kyma-attributes",
 				"batch",
 			})
@@ -169,7 +192,7 @@ func TestMakeConfig(t *testing.T) {
 
 		t.Run("with istio input enabled", func(t *testing.T) {
 			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{
-				testutils.NewMetricPipelineBuilder().WithName("test").WithIstioInputOn(true).Build()},
+				testutils.NewMetricPipelineBuilder().WithName("test").WithIstioInputOn(true).Build()}, BuildOptions{},
 			)
 			require.NoError(t, err)
 
@@ -184,6 +207,27 @@ func TestMakeConfig(t *testing.T) {
 				"transform/resolve-service-name",
 				"filter/drop-if-input-source-runtime",
 				"filter/drop-if-input-source-prometheus",
+				"filter/drop-if-input-source-cluster",
+				"resource/drop-kyma-attributes",
+				"batch",
+			})
+		})
+
+		t.Run("with cluster input enabled", func(t *testing.T) {
+			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithName("test").WithClusterInputOn(true).WithClusterInputEvents(true).Build()}, BuildOptions{},
+			)
+			require.NoError(t, err)
+
+			require.Contains(t, collectorConfig.Service.Pipelines, "metrics/test")
+			require.Nil(t, collectorConfig.Processors.DropIfInputSourceCluster)
+			require.Equal(t, collectorConfig.Service.Pipelines["metrics/test"].Processors, []string{"memory_limiter",
+				"k8sattributes",
+				"resource/insert-cluster-name",
+				"transform/resolve-service-name",
+				"filter/drop-if-input-source-runtime",
+				"filter/drop-if-input-source-prometheus",
+				"filter/drop-if-input-source-istio",
 				"resource/drop-kyma-attributes",
 				"batch",
 			})
@@ -194,7 +238,7 @@ func TestMakeConfig(t *testing.T) {
 		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{
 			testutils.NewMetricPipelineBuilder().WithName("test-1").WithRuntimeInputOn(true).Build(),
 			testutils.NewMetricPipelineBuilder().WithName("test-2").WithPrometheusInputOn(true).Build(),
-			testutils.NewMetricPipelineBuilder().WithName("test-3").WithIstioInputOn(true).Build()},
+			testutils.NewMetricPipelineBuilder().WithName("test-3").WithIstioInputOn(true).Build()}, BuildOptions{},
 		)
 		require.NoError(t, err)
 
@@ -211,6 +255,8 @@ func TestMakeConfig(t *testing.T) {
 			"transform/resolve-service-name",
 			"filter/drop-if-input-source-prometheus",
 			"filter/drop-if-input-source-istio",
+			"filter/drop-if-input-source-cluster",
+			"filter/test-1",
 			"resource/drop-kyma-attributes",
 			"batch",
 		})
@@ -224,6 +270,8 @@ func TestMakeConfig(t *testing.T) {
 			"transform/resolve-service-name",
 			"filter/drop-if-input-source-runtime",
 			"filter/drop-if-input-source-istio",
+			"filter/drop-if-input-source-cluster",
+			"filter/test-2",
 			"resource/drop-kyma-attributes",
 			"batch",
 		})
@@ -237,15 +285,100 @@ func TestMakeConfig(t *testing.T) {
 			"transform/resolve-service-name",
 			"filter/drop-if-input-source-runtime",
 			"filter/drop-if-input-source-prometheus",
+			"filter/drop-if-input-source-cluster",
 			"resource/drop-kyma-attributes",
 			"batch",
 		})
 	})
 
+	t.Run("additional outputs", func(t *testing.T) {
+		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithName("test").WithAdditionalOutput("archive", "https://archive:4317").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Contains(t, collectorConfig.Exporters, "otlp/test")
+		require.Contains(t, collectorConfig.Exporters, "otlp/test_archive")
+		require.Equal(t, 128, collectorConfig.Exporters["otlp/test_archive"].OTLP.SendingQueue.QueueSize, "Queue size should be divided by the number of outputs")
+		require.Equal(t, []byte("https://archive:4317"), envVars["OTLP_ENDPOINT_TEST_ARCHIVE"])
+
+		require.Len(t, collectorConfig.Service.Pipelines, 1)
+		require.Equal(t, []string{"otlp/test", "otlp/test_archive"}, collectorConfig.Service.Pipelines["metrics/test"].Exporters)
+	})
+
+	t.Run("prometheus outputs", func(t *testing.T) {
+		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithName("test").
+				WithAdditionalPrometheusRemoteWriteOutput("remote", "https://prometheus:9090/api/v1/write").
+				WithAdditionalPrometheusOutput("scrape", 8889).
+				Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Contains(t, collectorConfig.Exporters, "prometheusremotewrite/test_remote")
+		remoteWriteConfig := collectorConfig.Exporters["prometheusremotewrite/test_remote"].PrometheusRemoteWrite
+		require.NotNil(t, remoteWriteConfig)
+		require.Equal(t, "${OTLP_ENDPOINT_TEST_REMOTE}", remoteWriteConfig.Endpoint)
+		require.Equal(t, 128, remoteWriteConfig.RemoteWriteQueue.QueueSize, "Queue size should be divided by the number of outputs with a queue")
+		require.Equal(t, []byte("https://prometheus:9090/api/v1/write"), envVars["OTLP_ENDPOINT_TEST_REMOTE"])
+
+		require.Contains(t, collectorConfig.Exporters, "prometheus/test_scrape")
+		prometheusConfig := collectorConfig.Exporters["prometheus/test_scrape"].Prometheus
+		require.NotNil(t, prometheusConfig)
+		require.Equal(t, "${MY_POD_IP}:8889", prometheusConfig.Endpoint)
+
+		require.Equal(t, []string{"otlp/test", "prometheus/test_scrape", "prometheusremotewrite/test_remote"}, collectorConfig.Service.Pipelines["metrics/test"].Exporters)
+
+		configYAML, err := yaml.Marshal(collectorConfig)
+		require.NoError(t, err)
+		require.Contains(t, string(configYAML), "prometheus/test_scrape:\n        endpoint: ${MY_POD_IP}:8889\n")
+		require.Contains(t, string(configYAML), "prometheusremotewrite/test_remote:\n        endpoint: ${OTLP_ENDPOINT_TEST_REMOTE}\n")
+	})
+
+	t.Run("only prometheus outputs", func(t *testing.T) {
+		pipeline := testutils.NewMetricPipelineBuilder().WithName("test").Build()
+		pipeline.Spec.Output = v1alpha1.MetricPipelineOutput{Prometheus: &v1alpha1.PrometheusOutput{Port: 8889}}
+
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{pipeline}, BuildOptions{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"prometheus/test"}, collectorConfig.Service.Pipelines["metrics/test"].Exporters)
+		require.Equal(t, []int32{8889}, PrometheusPorts([]v1alpha1.MetricPipeline{pipeline}))
+	})
+
+	t.Run("filters and transforms", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithName("test").
+				WithFilters(v1alpha1.MetricPipelineFilters{DropMetricNames: []string{"go_gc_duration_seconds"}}).
+				WithTransforms(v1alpha1.MetricPipelineTransforms{DropAttributes: []string{"pod_uid"}}).
+				Build(),
+			testutils.NewMetricPipelineBuilder().WithName("test-2").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Contains(t, collectorConfig.Processors.Dynamic, "filter/test")
+		require.Contains(t, collectorConfig.Processors.Dynamic, "transform/test")
+		require.Len(t, collectorConfig.Processors.Dynamic, 2)
+
+		require.Equal(t, []string{"memory_limiter",
+			"k8sattributes",
+			"resource/insert-cluster-name",
+			"transform/resolve-service-name",
+			"filter/drop-if-input-source-runtime",
+			"filter/drop-if-input-source-prometheus",
+			"filter/drop-if-input-source-istio",
+			"filter/drop-if-input-source-cluster",
+			"filter/test",
+			"transform/test",
+			"resource/drop-kyma-attributes",
+			"batch",
+		}, collectorConfig.Service.Pipelines["metrics/test"].Processors)
+		require.NotContains(t, collectorConfig.Service.Pipelines["metrics/test-2"].Processors, "filter/test")
+	})
+
 	t.Run("marshaling", func(t *testing.T) {
 		config, _, err := MakeConfig(context.Background(), fakeClient, []v1alpha1.MetricPipeline{
 			testutils.NewMetricPipelineBuilder().WithName("test").Build(),
-		})
+		}, BuildOptions{})
 		require.NoError(t, err)
 
 		configYAML, err := yaml.Marshal(config)
@@ -259,3 +392,24 @@ func TestMakeConfig(t *testing.T) {
 		require.Equal(t, string(goldenFile), string(configYAML))
 	})
 }
+
+func TestMakeEnvVars(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewClientBuilder().Build()
+
+	pipelines := []v1alpha1.MetricPipeline{
+		testutils.NewMetricPipelineBuilder().WithName("test").WithBasicAuth("user", "password").Build(),
+		testutils.NewMetricPipelineBuilder().WithName("test-outputs").
+			WithAdditionalPrometheusRemoteWriteOutput("remote-write", "https://localhost/api/v1/write").
+			WithAdditionalPrometheusOutput("scrape", 9090).Build(),
+	}
+
+	envVars, err := MakeEnvVars(ctx, fakeClient, pipelines)
+	require.NoError(t, err)
+
+	_, expectedEnvVars, err := MakeConfig(ctx, fakeClient, pipelines, BuildOptions{})
+	require.NoError(t, err)
+	require.Equal(t, expectedEnvVars, envVars)
+	require.Contains(t, envVars, "OTLP_ENDPOINT_TEST")
+	require.Contains(t, envVars, "BASIC_AUTH_HEADER_TEST")
+}
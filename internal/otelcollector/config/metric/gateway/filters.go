@@ -0,0 +1,193 @@
+package gateway
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/namespaces"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/gatewayprocs"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/metric"
+)
+
+const namespaceAttribute = "k8s.namespace.name"
+
+// diagnosticMetricNames are the metrics that the Prometheus receiver emits for every scrape, in addition to the scraped metrics.
+var diagnosticMetricNames = []string{"up", "scrape_duration_seconds", "scrape_samples_scraped", "scrape_samples_post_metric_relabeling", "scrape_series_added"}
+
+// makeFilterProcessorConfig returns the ID and the configuration of the filter processor for the given pipeline.
+// The processor drops metrics of Namespaces that are not selected by the inputs, metrics that are disabled in the inputs, metrics of the additional Prometheus targets of other pipelines,
+// and metrics matching the filters of the pipeline.
+// If nothing needs to be dropped, an empty ID is returned.
+func makeFilterProcessorConfig(pipeline *telemetryv1alpha1.MetricPipeline) (string, *FilterProcessor) {
+	metricConditions := makeNamespaceConditions(pipeline)
+	metricConditions = append(metricConditions, makeDisabledMetricsConditions(pipeline)...)
+	if condition := makeForeignPrometheusTargetsCondition(pipeline); condition != "" {
+		metricConditions = append(metricConditions, condition)
+	}
+	var dataPointConditions []string
+
+	if filters := pipeline.Spec.Filters; filters != nil {
+		for _, name := range filters.DropMetricNames {
+			metricConditions = append(metricConditions, "name == "+strconv.Quote(name))
+		}
+		for _, attr := range filters.DropResourceAttributes {
+			metricConditions = append(metricConditions, resourceAttributeEquals(attr.Key, attr.Value))
+		}
+		if len(filters.KeepNamespaces) > 0 {
+			metricConditions = append(metricConditions, namespaceNotIn(filters.KeepNamespaces))
+		}
+		dataPointConditions = filters.Conditions
+	}
+
+	if len(metricConditions) == 0 && len(dataPointConditions) == 0 {
+		return "", nil
+	}
+
+	return fmt.Sprintf("filter/%s", pipeline.Name), &FilterProcessor{
+		ErrorMode: "ignore",
+		Metrics: FilterProcessorMetric{
+			Metric:    metricConditions,
+			DataPoint: dataPointConditions,
+		},
+	}
+}
+
+// makeNamespaceConditions returns the conditions that match the metrics of an input source that were emitted in a Namespace that is not selected by the input.
+// The metric agent already skips Namespaces that are not selected by any pipeline, but it is shared by all pipelines and the runtime and cluster inputs cannot be filtered by the collectors at all.
+// Metrics of cluster-scoped resources, which have no Namespace, are never dropped.
+func makeNamespaceConditions(pipeline *telemetryv1alpha1.MetricPipeline) []string {
+	appInput := pipeline.Spec.Input.Application
+	inputs := []struct {
+		enabled   bool
+		source    metric.InputSourceType
+		selection namespaces.Selection
+	}{
+		{appInput.Runtime.Enabled, metric.InputSourceRuntime, namespaces.Resolve(appInput.Runtime.Namespaces)},
+		{appInput.Prometheus.Enabled, metric.InputSourcePrometheus, namespaces.Resolve(appInput.Prometheus.Namespaces)},
+		{appInput.Istio.Enabled, metric.InputSourceIstio, namespaces.Resolve(appInput.Istio.Namespaces)},
+		{appInput.Cluster.Enabled, metric.InputSourceCluster, namespaces.Resolve(appInput.Cluster.Namespaces)},
+	}
+
+	var conditions []string
+	for _, input := range inputs {
+		if !input.enabled || input.selection.SelectsAll() {
+			continue
+		}
+		conditions = append(conditions, fmt.Sprintf("%s and %s != nil and %s",
+			resourceAttributeEquals(metric.InputSourceAttribute, string(input.source)),
+			resourceAttribute(namespaceAttribute),
+			gatewayprocs.NamespaceNotSelectedCondition(input.selection)))
+	}
+	return conditions
+}
+
+// makeDisabledMetricsConditions returns the conditions that match the optional metrics of the enabled inputs that are not enabled by the pipeline:
+// the diagnostic metrics of the Prometheus input, the Node and volume metrics of the runtime input, and the event metrics of the cluster input.
+// The collectors collect for all pipelines together, so these metrics can only be dropped per pipeline in the gateway.
+func makeDisabledMetricsConditions(pipeline *telemetryv1alpha1.MetricPipeline) []string {
+	appInput := pipeline.Spec.Input.Application
+
+	var conditions []string
+	if appInput.Prometheus.Enabled && !appInput.Prometheus.DiagnosticMetrics.Enabled {
+		names := make([]string, len(diagnosticMetricNames))
+		for i, name := range diagnosticMetricNames {
+			names[i] = "name == " + strconv.Quote(name)
+		}
+		conditions = append(conditions, fmt.Sprintf("%s and (%s)",
+			resourceAttributeEquals(metric.InputSourceAttribute, string(metric.InputSourcePrometheus)),
+			strings.Join(names, " or ")))
+	}
+
+	if appInput.Runtime.Enabled && !appInput.Runtime.Resources.Node.Enabled {
+		conditions = append(conditions, fmt.Sprintf("%s and IsMatch(name, %s)",
+			resourceAttributeEquals(metric.InputSourceAttribute, string(metric.InputSourceRuntime)),
+			strconv.Quote(`^k8s\.node\.`)))
+	}
+
+	if appInput.Runtime.Enabled && !appInput.Runtime.Resources.Volume.Enabled {
+		conditions = append(conditions, fmt.Sprintf("%s and IsMatch(name, %s)",
+			resourceAttributeEquals(metric.InputSourceAttribute, string(metric.InputSourceRuntime)),
+			strconv.Quote(`^k8s\.volume\.`)))
+	}
+
+	if appInput.Cluster.Enabled && !appInput.Cluster.Events.Enabled {
+		conditions = append(conditions, fmt.Sprintf("%s and name == %s",
+			resourceAttributeEquals(metric.InputSourceAttribute, string(metric.InputSourceCluster)),
+			strconv.Quote(metric.EventCountMetricName)))
+	}
+
+	return conditions
+}
+
+// makeForeignPrometheusTargetsCondition returns the condition that matches the metrics of the additional Prometheus targets declared by other pipelines.
+// The targets of all pipelines are scraped by the shared metric agent, which tags their metrics with the job name "<pipeline>/<target>".
+func makeForeignPrometheusTargetsCondition(pipeline *telemetryv1alpha1.MetricPipeline) string {
+	if !pipeline.Spec.Input.Application.Prometheus.Enabled {
+		return ""
+	}
+
+	target := resourceAttribute(metric.PrometheusTargetAttribute)
+	return fmt.Sprintf("%s != nil and not IsMatch(%s, %s)", target, target, strconv.Quote("^"+regexp.QuoteMeta(pipeline.Name)+"/"))
+}
+
+// makeTransformProcessorConfig returns the ID and the configuration of the transform processor for the given pipeline.
+// If no transforms are configured, an empty ID is returned.
+func makeTransformProcessorConfig(pipeline *telemetryv1alpha1.MetricPipeline) (string, *TransformProcessor) {
+	transforms := pipeline.Spec.Transforms
+	if transforms == nil {
+		return "", nil
+	}
+
+	var statements []string
+	for _, rename := range transforms.RenameAttributes {
+		from := attribute(rename.From)
+		statements = append(statements,
+			fmt.Sprintf("set(%s, %s) where %s != nil", attribute(rename.To), from, from),
+			fmt.Sprintf("delete_key(attributes, %s)", strconv.Quote(rename.From)),
+		)
+	}
+	for _, key := range transforms.DropAttributes {
+		statements = append(statements, fmt.Sprintf("delete_key(attributes, %s)", strconv.Quote(key)))
+	}
+	statements = append(statements, transforms.Statements...)
+
+	if len(statements) == 0 {
+		return "", nil
+	}
+
+	return fmt.Sprintf("transform/%s", pipeline.Name), &TransformProcessor{
+		ErrorMode: "ignore",
+		MetricStatements: []config.TransformProcessorStatements{
+			{
+				Context:    "datapoint",
+				Statements: statements,
+			},
+		},
+	}
+}
+
+// namespaceNotIn matches metrics of namespaced resources that do not belong to any of the given namespaces.
+func namespaceNotIn(namespaces []string) string {
+	namespace := resourceAttribute(namespaceAttribute)
+	parts := []string{namespace + " != nil"}
+	for _, ns := range namespaces {
+		parts = append(parts, namespace+" != "+strconv.Quote(ns))
+	}
+	return strings.Join(parts, " and ")
+}
+
+func resourceAttributeEquals(key, value string) string {
+	return resourceAttribute(key) + " == " + strconv.Quote(value)
+}
+
+func resourceAttribute(key string) string {
+	return "resource.attributes[" + strconv.Quote(key) + "]"
+}
+
+func attribute(key string) string {
+	return "attributes[" + strconv.Quote(key) + "]"
+}
@@ -0,0 +1,196 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/ottl"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
+)
+
+func TestFilterProcessor(t *testing.T) {
+	t.Run("no filters", func(t *testing.T) {
+		pipeline := testutils.NewMetricPipelineBuilder().WithName("test").Build()
+
+		id, filterConfig := makeFilterProcessorConfig(&pipeline)
+		require.Empty(t, id)
+		require.Nil(t, filterConfig)
+	})
+
+	t.Run("all filters", func(t *testing.T) {
+		pipeline := testutils.NewMetricPipelineBuilder().WithName("test").WithFilters(v1alpha1.MetricPipelineFilters{
+			DropMetricNames:        []string{"go_gc_duration_seconds"},
+			DropResourceAttributes: []v1alpha1.MetricPipelineResourceAttribute{{Key: "k8s.deployment.name", Value: "noisy"}},
+			KeepNamespaces:         []string{"default", "prod"},
+			Conditions:             []string{`attributes["code"] == "200"`},
+		}).Build()
+
+		id, filterConfig := makeFilterProcessorConfig(&pipeline)
+		require.Equal(t, "filter/test", id)
+		require.Equal(t, &FilterProcessor{
+			ErrorMode: "ignore",
+			Metrics: FilterProcessorMetric{
+				Metric: []string{
+					`name == "go_gc_duration_seconds"`,
+					`resource.attributes["k8s.deployment.name"] == "noisy"`,
+					`resource.attributes["k8s.namespace.name"] != nil and resource.attributes["k8s.namespace.name"] != "default" and resource.attributes["k8s.namespace.name"] != "prod"`,
+				},
+				DataPoint: []string{`attributes["code"] == "200"`},
+			},
+		}, filterConfig)
+
+		for _, condition := range filterConfig.Metrics.Metric {
+			require.NoError(t, ottl.ValidateCondition(ottl.ContextMetric, condition))
+		}
+	})
+
+	t.Run("namespace selection of inputs", func(t *testing.T) {
+		pipeline := testutils.NewMetricPipelineBuilder().WithName("test").
+			WithRuntimeInputOn(true).WithRuntimeInputNamespaces(v1alpha1.NamespaceSelector{Include: []string{"team-a"}}).
+			WithPrometheusInputOn(true).WithPrometheusInputNamespaces(v1alpha1.NamespaceSelector{Exclude: []string{"team-b"}, System: true}).WithPrometheusInputDiagnosticMetrics(true).
+			WithIstioInputOn(true).
+			WithRuntimeInputNodeMetrics(true).WithRuntimeInputVolumeMetrics(true).
+			WithClusterInputOn(true).WithClusterInputNamespaces(v1alpha1.NamespaceSelector{Include: []string{"team-c"}}).WithClusterInputEvents(true).
+			Build()
+
+		id, filterConfig := makeFilterProcessorConfig(&pipeline)
+		require.Equal(t, "filter/test", id)
+		require.Equal(t, []string{
+			`resource.attributes["kyma.source"] == "runtime" and resource.attributes["k8s.namespace.name"] != nil and resource.attributes["k8s.namespace.name"] != "team-a"`,
+			`resource.attributes["kyma.source"] == "prometheus" and resource.attributes["k8s.namespace.name"] != nil and (resource.attributes["k8s.namespace.name"] == "team-b")`,
+			`resource.attributes["kyma.source"] == "cluster" and resource.attributes["k8s.namespace.name"] != nil and resource.attributes["k8s.namespace.name"] != "team-c"`,
+			`resource.attributes["kyma.prometheus.target"] != nil and not IsMatch(resource.attributes["kyma.prometheus.target"], "^test/")`,
+		}, filterConfig.Metrics.Metric)
+		require.Empty(t, filterConfig.Metrics.DataPoint)
+
+		for _, condition := range filterConfig.Metrics.Metric {
+			require.NoError(t, ottl.ValidateCondition(ottl.ContextMetric, condition))
+		}
+	})
+
+	t.Run("diagnostic metrics", func(t *testing.T) {
+		disabled := testutils.NewMetricPipelineBuilder().WithName("test").WithPrometheusInputOn(true).Build()
+
+		id, filterConfig := makeFilterProcessorConfig(&disabled)
+		require.Equal(t, "filter/test", id)
+		require.Equal(t, []string{
+			`resource.attributes["kyma.source"] == "prometheus" and (name == "up" or name == "scrape_duration_seconds" or name == "scrape_samples_scraped" or name == "scrape_samples_post_metric_relabeling" or name == "scrape_series_added")`,
+			`resource.attributes["kyma.prometheus.target"] != nil and not IsMatch(resource.attributes["kyma.prometheus.target"], "^test/")`,
+		}, filterConfig.Metrics.Metric)
+		require.NoError(t, ottl.ValidateCondition(ottl.ContextMetric, filterConfig.Metrics.Metric[0]))
+
+		enabled := testutils.NewMetricPipelineBuilder().WithName("test").WithPrometheusInputOn(true).WithPrometheusInputDiagnosticMetrics(true).Build()
+
+		_, filterConfig = makeFilterProcessorConfig(&enabled)
+		require.Equal(t, []string{
+			`resource.attributes["kyma.prometheus.target"] != nil and not IsMatch(resource.attributes["kyma.prometheus.target"], "^test/")`,
+		}, filterConfig.Metrics.Metric)
+	})
+
+	t.Run("additional prometheus targets of other pipelines", func(t *testing.T) {
+		team := testutils.NewMetricPipelineBuilder().WithName("team.a").WithPrometheusInputOn(true).WithPrometheusInputDiagnosticMetrics(true).
+			WithPrometheusInputTargets(v1alpha1.PrometheusScrapeTarget{
+				Name: "app",
+				Pods: &v1alpha1.ScrapeTargetSelector{MatchLabels: map[string]string{"app": "app"}, Port: intstr.FromInt(8080)},
+			}).Build()
+		other := testutils.NewMetricPipelineBuilder().WithName("other").WithPrometheusInputOn(true).WithPrometheusInputDiagnosticMetrics(true).Build()
+
+		_, teamFilter := makeFilterProcessorConfig(&team)
+		require.Equal(t, []string{
+			`resource.attributes["kyma.prometheus.target"] != nil and not IsMatch(resource.attributes["kyma.prometheus.target"], "^team\\.a/")`,
+		}, teamFilter.Metrics.Metric)
+		require.NoError(t, ottl.ValidateCondition(ottl.ContextMetric, teamFilter.Metrics.Metric[0]))
+
+		_, otherFilter := makeFilterProcessorConfig(&other)
+		require.Equal(t, []string{
+			`resource.attributes["kyma.prometheus.target"] != nil and not IsMatch(resource.attributes["kyma.prometheus.target"], "^other/")`,
+		}, otherFilter.Metrics.Metric)
+	})
+
+	t.Run("node, volume and event metrics", func(t *testing.T) {
+		disabled := testutils.NewMetricPipelineBuilder().WithName("test").WithRuntimeInputOn(true).WithClusterInputOn(true).Build()
+
+		id, filterConfig := makeFilterProcessorConfig(&disabled)
+		require.Equal(t, "filter/test", id)
+		require.Equal(t, []string{
+			`resource.attributes["kyma.source"] == "runtime" and IsMatch(name, "^k8s\\.node\\.")`,
+			`resource.attributes["kyma.source"] == "runtime" and IsMatch(name, "^k8s\\.volume\\.")`,
+			`resource.attributes["kyma.source"] == "cluster" and name == "k8s.event.count"`,
+		}, filterConfig.Metrics.Metric)
+		for _, condition := range filterConfig.Metrics.Metric {
+			require.NoError(t, ottl.ValidateCondition(ottl.ContextMetric, condition))
+		}
+
+		enabled := testutils.NewMetricPipelineBuilder().WithName("test").
+			WithRuntimeInputOn(true).WithRuntimeInputNodeMetrics(true).WithRuntimeInputVolumeMetrics(true).
+			WithClusterInputOn(true).WithClusterInputEvents(true).
+			Build()
+
+		id, filterConfig = makeFilterProcessorConfig(&enabled)
+		require.Empty(t, id)
+		require.Nil(t, filterConfig)
+	})
+
+	t.Run("namespace selection of disabled input is ignored", func(t *testing.T) {
+		pipeline := testutils.NewMetricPipelineBuilder().WithName("test").
+			WithRuntimeInputNamespaces(v1alpha1.NamespaceSelector{Include: []string{"team-a"}}).
+			Build()
+
+		id, filterConfig := makeFilterProcessorConfig(&pipeline)
+		require.Empty(t, id)
+		require.Nil(t, filterConfig)
+	})
+
+	t.Run("values are escaped", func(t *testing.T) {
+		pipeline := testutils.NewMetricPipelineBuilder().WithName("test").WithFilters(v1alpha1.MetricPipelineFilters{
+			DropMetricNames: []string{`my"metric`},
+		}).Build()
+
+		_, filterConfig := makeFilterProcessorConfig(&pipeline)
+		require.Equal(t, []string{`name == "my\"metric"`}, filterConfig.Metrics.Metric)
+		require.NoError(t, ottl.ValidateCondition(ottl.ContextMetric, filterConfig.Metrics.Metric[0]))
+	})
+}
+
+func TestTransformProcessor(t *testing.T) {
+	t.Run("no transforms", func(t *testing.T) {
+		pipeline := testutils.NewMetricPipelineBuilder().WithName("test").Build()
+
+		id, transformConfig := makeTransformProcessorConfig(&pipeline)
+		require.Empty(t, id)
+		require.Nil(t, transformConfig)
+	})
+
+	t.Run("all transforms", func(t *testing.T) {
+		pipeline := testutils.NewMetricPipelineBuilder().WithName("test").WithTransforms(v1alpha1.MetricPipelineTransforms{
+			RenameAttributes: []v1alpha1.MetricPipelineAttributeRename{{From: "svc", To: "service"}},
+			DropAttributes:   []string{"pod_uid"},
+			Statements:       []string{`set(attributes["team"], "core")`},
+		}).Build()
+
+		id, transformConfig := makeTransformProcessorConfig(&pipeline)
+		require.Equal(t, "transform/test", id)
+		require.Equal(t, &TransformProcessor{
+			ErrorMode: "ignore",
+			MetricStatements: []config.TransformProcessorStatements{
+				{
+					Context: "datapoint",
+					Statements: []string{
+						`set(attributes["service"], attributes["svc"]) where attributes["svc"] != nil`,
+						`delete_key(attributes, "svc")`,
+						`delete_key(attributes, "pod_uid")`,
+						`set(attributes["team"], "core")`,
+					},
+				},
+			},
+		}, transformConfig)
+
+		for _, statement := range transformConfig.MetricStatements[0].Statements {
+			require.NoError(t, ottl.ValidateStatement(ottl.ContextDataPoint, statement))
+		}
+	})
+}
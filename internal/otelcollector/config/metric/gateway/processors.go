@@ -8,16 +8,17 @@ import (
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/metric"
 )
 
-func makeProcessorsConfig() Processors {
+func makeProcessorsConfig(opts BuildOptions) Processors {
 	return Processors{
 		BaseProcessors: config.BaseProcessors{
 			Batch:         makeBatchProcessorConfig(),
 			MemoryLimiter: makeMemoryLimiterConfig(),
 		},
-		K8sAttributes:      gatewayprocs.K8sAttributesProcessorConfig(),
-		InsertClusterName:  gatewayprocs.InsertClusterNameProcessorConfig(),
+		K8sAttributes:      gatewayprocs.K8sAttributesProcessorConfig(opts.Enrichments),
+		InsertClusterName:  gatewayprocs.InsertClusterNameProcessorConfig(opts.Cluster),
 		ResolveServiceName: makeResolveServiceNameConfig(),
 		DropKymaAttributes: gatewayprocs.DropKymaAttributesProcessorConfig(),
+		Dynamic:            make(map[string]any),
 	}
 }
 
@@ -67,6 +68,16 @@ func makeDropIfInputSourceIstioConfig() *FilterProcessor {
 	}
 }
 
+func makeDropIfInputSourceClusterConfig() *FilterProcessor {
+	return &FilterProcessor{
+		Metrics: FilterProcessorMetric{
+			DataPoint: []string{
+				fmt.Sprintf("resource.attributes[\"%s\"] == \"%s\"", metric.InputSourceAttribute, metric.InputSourceCluster),
+			},
+		},
+	}
+}
+
 func makeResolveServiceNameConfig() *TransformProcessor {
 	return &TransformProcessor{
 		ErrorMode:        "ignore",
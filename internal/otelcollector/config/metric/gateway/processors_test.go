@@ -16,7 +16,7 @@ func TestProcessors(t *testing.T) {
 	fakeClient := fake.NewClientBuilder().Build()
 
 	t.Run("insert cluster name processor", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Equal(t, 1, len(collectorConfig.Processors.InsertClusterName.Attributes))
@@ -26,7 +26,7 @@ func TestProcessors(t *testing.T) {
 	})
 
 	t.Run("memory limit processors", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Equal(t, "0.1s", collectorConfig.Processors.MemoryLimiter.CheckInterval)
@@ -35,7 +35,7 @@ func TestProcessors(t *testing.T) {
 	})
 
 	t.Run("batch processors", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Equal(t, 1024, collectorConfig.Processors.Batch.SendBatchSize)
@@ -44,7 +44,7 @@ func TestProcessors(t *testing.T) {
 	})
 
 	t.Run("k8s attributes processors", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Equal(t, "serviceAccount", collectorConfig.Processors.K8sAttributes.AuthType)
@@ -72,7 +72,7 @@ func TestProcessors(t *testing.T) {
 	})
 
 	t.Run("drop by input source filter", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.NotNil(t, collectorConfig.Processors.DropIfInputSourceRuntime)
@@ -2,6 +2,13 @@ package metric
 
 const (
 	InputSourceAttribute = "kyma.source"
+
+	// PrometheusTargetAttribute is the job name of an additional Prometheus target, which is "<pipeline>/<target>".
+	// The gateway uses it to ship the metrics of a target only to the pipeline that declares the target.
+	PrometheusTargetAttribute = "kyma.prometheus.target"
+
+	// EventCountMetricName is the metric that the cluster input derives from the Kubernetes events.
+	EventCountMetricName = "k8s.event.count"
 )
 
 type InputSourceType string
@@ -10,4 +17,5 @@ const (
 	InputSourceRuntime    InputSourceType = "runtime"
 	InputSourcePrometheus InputSourceType = "prometheus"
 	InputSourceIstio      InputSourceType = "istio"
+	InputSourceCluster    InputSourceType = "cluster"
 )
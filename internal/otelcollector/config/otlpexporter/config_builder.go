@@ -46,24 +46,98 @@ func makeExportersConfig(otlpOutput *telemetryv1alpha1.OtlpOutput, pipelineName
 	tlsConfig := makeTLSConfig(otlpOutput, otlpEndpointValue, pipelineName)
 
 	otlpExporterConfig := config.OTLPExporter{
-		Endpoint: fmt.Sprintf("${%s}", otlpEndpointVariable),
-		Headers:  headers,
-		TLS:      tlsConfig,
-		SendingQueue: config.SendingQueue{
-			Enabled:   true,
-			QueueSize: queueSize,
-		},
-		RetryOnFailure: config.RetryOnFailure{
-			Enabled:         true,
-			InitialInterval: "5s",
-			MaxInterval:     "30s",
-			MaxElapsedTime:  "300s",
-		},
+		Endpoint:       fmt.Sprintf("${%s}", otlpEndpointVariable),
+		Headers:        headers,
+		TLS:            tlsConfig,
+		Compression:    otlpOutput.Compression,
+		SendingQueue:   makeSendingQueueConfig(otlpOutput, queueSize),
+		RetryOnFailure: makeRetryOnFailureConfig(otlpOutput),
+	}
+
+	if otlpOutput.Timeout != nil {
+		otlpExporterConfig.Timeout = otlpOutput.Timeout.Duration.String()
+	}
+
+	if authenticatorID, _ := MakeAuthenticatorConfig(otlpOutput, pipelineName); authenticatorID != "" {
+		otlpExporterConfig.Auth = &config.Auth{Authenticator: authenticatorID}
 	}
 
 	return &otlpExporterConfig
 }
 
+// makeSendingQueueConfig creates the sending queue of the exporter. If the output does not define a queue size, the given default is used,
+// which is the queue capacity of the gateway shared among all outputs.
+func makeSendingQueueConfig(otlpOutput *telemetryv1alpha1.OtlpOutput, defaultQueueSize int) config.SendingQueue {
+	sendingQueue := config.SendingQueue{
+		Enabled:   true,
+		QueueSize: defaultQueueSize,
+	}
+
+	if otlpOutput.Queue == nil {
+		return sendingQueue
+	}
+
+	if otlpOutput.Queue.Size > 0 {
+		sendingQueue.QueueSize = otlpOutput.Queue.Size
+	}
+	if otlpOutput.Queue.Persistent {
+		sendingQueue.Storage = config.FileStorageExtensionID
+	}
+
+	return sendingQueue
+}
+
+func makeRetryOnFailureConfig(otlpOutput *telemetryv1alpha1.OtlpOutput) config.RetryOnFailure {
+	retryOnFailure := config.RetryOnFailure{
+		Enabled:         true,
+		InitialInterval: "5s",
+		MaxInterval:     "30s",
+		MaxElapsedTime:  "300s",
+	}
+
+	if otlpOutput.Retry == nil {
+		return retryOnFailure
+	}
+
+	if otlpOutput.Retry.InitialInterval != nil {
+		retryOnFailure.InitialInterval = otlpOutput.Retry.InitialInterval.Duration.String()
+	}
+	if otlpOutput.Retry.MaxInterval != nil {
+		retryOnFailure.MaxInterval = otlpOutput.Retry.MaxInterval.Duration.String()
+	}
+	if otlpOutput.Retry.MaxElapsedTime != nil {
+		retryOnFailure.MaxElapsedTime = otlpOutput.Retry.MaxElapsedTime.Duration.String()
+	}
+
+	return retryOnFailure
+}
+
+// MakeAuthenticatorConfig returns the ID and the config of the authenticator extension that is required by the exporter of the given output.
+// If the output does not use OAuth2 or bearer token authentication, the returned ID is empty.
+// The extension must be added to the extensions of the collector and enabled in the service.
+func MakeAuthenticatorConfig(otlpOutput *telemetryv1alpha1.OtlpOutput, pipelineName string) (string, any) {
+	if otlpOutput.Authentication == nil {
+		return "", nil
+	}
+
+	if otlpOutput.Authentication.OAuth2.IsDefined() {
+		return fmt.Sprintf("oauth2client/%s", pipelineName), config.OAuth2ClientExtension{
+			TokenURL:     fmt.Sprintf("${%s}", makeOAuth2TokenURLVariable(pipelineName)),
+			ClientID:     fmt.Sprintf("${%s}", makeOAuth2ClientIDVariable(pipelineName)),
+			ClientSecret: fmt.Sprintf("${%s}", makeOAuth2ClientSecretVariable(pipelineName)),
+			Scopes:       otlpOutput.Authentication.OAuth2.Scopes,
+		}
+	}
+
+	if otlpOutput.Authentication.BearerToken.IsDefined() {
+		return fmt.Sprintf("bearertokenauth/%s", pipelineName), config.BearerTokenAuthExtension{
+			Token: fmt.Sprintf("${%s}", makeBearerTokenVariable(pipelineName)),
+		}
+	}
+
+	return "", nil
+}
+
 func ExporterID(output *telemetryv1alpha1.OtlpOutput, pipelineName string) string {
 	var outputType string
 	if output.Protocol == "http" {
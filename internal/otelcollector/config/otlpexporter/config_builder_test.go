@@ -3,11 +3,15 @@ package otlpexporter
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
 )
 
 func TestExporterIDHTTP(t *testing.T) {
@@ -157,3 +161,119 @@ func TestMakeExporterConfigWithmTLS(t *testing.T) {
 	require.Equal(t, envVars["OTLP_TLS_KEY_PEM_TEST"], []byte("test client key pem"))
 
 }
+
+func TestMakeExporterConfigWithOAuth2(t *testing.T) {
+	output := &telemetryv1alpha1.OtlpOutput{
+		Endpoint: telemetryv1alpha1.ValueType{Value: "otlp-endpoint"},
+		Authentication: &telemetryv1alpha1.AuthenticationOptions{
+			OAuth2: &telemetryv1alpha1.OAuth2Options{
+				TokenURL:     telemetryv1alpha1.ValueType{Value: "https://auth.example.com/token"},
+				ClientID:     telemetryv1alpha1.ValueType{Value: "client-id"},
+				ClientSecret: telemetryv1alpha1.ValueType{Value: "client-secret"},
+				Scopes:       []string{"ingest", "read"},
+			},
+		},
+	}
+
+	cb := NewConfigBuilder(fake.NewClientBuilder().Build(), output, "test", 512)
+	otlpExporterConfig, envVars, err := cb.MakeConfig(context.Background())
+	require.NoError(t, err)
+
+	require.NotNil(t, otlpExporterConfig.Auth)
+	require.Equal(t, "oauth2client/test", otlpExporterConfig.Auth.Authenticator)
+	require.Empty(t, otlpExporterConfig.Headers)
+
+	require.Equal(t, []byte("https://auth.example.com/token"), envVars["OAUTH2_TOKEN_URL_TEST"])
+	require.Equal(t, []byte("client-id"), envVars["OAUTH2_CLIENT_ID_TEST"])
+	require.Equal(t, []byte("client-secret"), envVars["OAUTH2_CLIENT_SECRET_TEST"])
+
+	authenticatorID, authenticatorConfig := MakeAuthenticatorConfig(output, "test")
+	require.Equal(t, "oauth2client/test", authenticatorID)
+	require.Equal(t, config.OAuth2ClientExtension{
+		TokenURL:     "${OAUTH2_TOKEN_URL_TEST}",
+		ClientID:     "${OAUTH2_CLIENT_ID_TEST}",
+		ClientSecret: "${OAUTH2_CLIENT_SECRET_TEST}",
+		Scopes:       []string{"ingest", "read"},
+	}, authenticatorConfig)
+}
+
+func TestMakeExporterConfigWithBearerToken(t *testing.T) {
+	output := &telemetryv1alpha1.OtlpOutput{
+		Endpoint: telemetryv1alpha1.ValueType{Value: "otlp-endpoint"},
+		Authentication: &telemetryv1alpha1.AuthenticationOptions{
+			BearerToken: &telemetryv1alpha1.ValueType{
+				ValueFrom: &telemetryv1alpha1.ValueFromSource{
+					SecretKeyRef: &telemetryv1alpha1.SecretKeyRef{
+						Name:      "token",
+						Namespace: "default",
+						Key:       "token",
+					},
+				},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("my-token")},
+	}
+
+	cb := NewConfigBuilder(fake.NewClientBuilder().WithObjects(secret).Build(), output, "test", 512)
+	otlpExporterConfig, envVars, err := cb.MakeConfig(context.Background())
+	require.NoError(t, err)
+
+	require.NotNil(t, otlpExporterConfig.Auth)
+	require.Equal(t, "bearertokenauth/test", otlpExporterConfig.Auth.Authenticator)
+	require.Equal(t, []byte("my-token"), envVars["BEARER_TOKEN_TEST"])
+
+	authenticatorID, authenticatorConfig := MakeAuthenticatorConfig(output, "test")
+	require.Equal(t, "bearertokenauth/test", authenticatorID)
+	require.Equal(t, config.BearerTokenAuthExtension{Token: "${BEARER_TOKEN_TEST}"}, authenticatorConfig)
+}
+
+func TestMakeAuthenticatorConfigWithBasicAuth(t *testing.T) {
+	output := &telemetryv1alpha1.OtlpOutput{
+		Endpoint: telemetryv1alpha1.ValueType{Value: "otlp-endpoint"},
+		Authentication: &telemetryv1alpha1.AuthenticationOptions{
+			Basic: &telemetryv1alpha1.BasicAuthOptions{
+				User:     telemetryv1alpha1.ValueType{Value: "user"},
+				Password: telemetryv1alpha1.ValueType{Value: "password"},
+			},
+		},
+	}
+
+	authenticatorID, authenticatorConfig := MakeAuthenticatorConfig(output, "test")
+	require.Empty(t, authenticatorID)
+	require.Nil(t, authenticatorConfig)
+}
+
+func TestMakeExporterConfigWithDeliveryOptions(t *testing.T) {
+	output := &telemetryv1alpha1.OtlpOutput{
+		Endpoint:    telemetryv1alpha1.ValueType{Value: "otlp-endpoint"},
+		Compression: "zstd",
+		Timeout:     &metav1.Duration{Duration: 10 * time.Second},
+		Retry: &telemetryv1alpha1.OtlpRetry{
+			InitialInterval: &metav1.Duration{Duration: time.Second},
+			MaxElapsedTime:  &metav1.Duration{Duration: 10 * time.Minute},
+		},
+		Queue: &telemetryv1alpha1.OtlpQueue{
+			Size:       1000,
+			Persistent: true,
+		},
+	}
+
+	cb := NewConfigBuilder(fake.NewClientBuilder().Build(), output, "test", 512)
+	otlpExporterConfig, _, err := cb.MakeConfig(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, "zstd", otlpExporterConfig.Compression)
+	require.Equal(t, "10s", otlpExporterConfig.Timeout)
+
+	require.True(t, otlpExporterConfig.RetryOnFailure.Enabled)
+	require.Equal(t, "1s", otlpExporterConfig.RetryOnFailure.InitialInterval)
+	require.Equal(t, "30s", otlpExporterConfig.RetryOnFailure.MaxInterval)
+	require.Equal(t, "10m0s", otlpExporterConfig.RetryOnFailure.MaxElapsedTime)
+
+	require.True(t, otlpExporterConfig.SendingQueue.Enabled)
+	require.Equal(t, 1000, otlpExporterConfig.SendingQueue.QueueSize)
+	require.Equal(t, "file_storage/queue", otlpExporterConfig.SendingQueue.Storage)
+}
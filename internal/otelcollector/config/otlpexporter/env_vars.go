@@ -13,11 +13,15 @@ import (
 )
 
 const (
-	basicAuthHeaderVariablePrefix = "BASIC_AUTH_HEADER"
-	otlpEndpointVariablePrefix    = "OTLP_ENDPOINT"
-	tlsConfigCertVariablePrefix   = "OTLP_TLS_CERT_PEM"
-	tlsConfigKeyVariablePrefix    = "OTLP_TLS_KEY_PEM"
-	tlsConfigCaVariablePrefix     = "OTLP_TLS_CA_PEM"
+	basicAuthHeaderVariablePrefix    = "BASIC_AUTH_HEADER"
+	bearerTokenVariablePrefix        = "BEARER_TOKEN"
+	oauth2TokenURLVariablePrefix     = "OAUTH2_TOKEN_URL"
+	oauth2ClientIDVariablePrefix     = "OAUTH2_CLIENT_ID"
+	oauth2ClientSecretVariablePrefix = "OAUTH2_CLIENT_SECRET"
+	otlpEndpointVariablePrefix       = "OTLP_ENDPOINT"
+	tlsConfigCertVariablePrefix      = "OTLP_TLS_CERT_PEM"
+	tlsConfigKeyVariablePrefix       = "OTLP_TLS_KEY_PEM"
+	tlsConfigCaVariablePrefix        = "OTLP_TLS_CA_PEM"
 )
 
 func makeEnvVars(ctx context.Context, c client.Reader, output *telemetryv1alpha1.OtlpOutput, pipelineName string) (map[string][]byte, error) {
@@ -36,6 +40,29 @@ func makeEnvVars(ctx context.Context, c client.Reader, output *telemetryv1alpha1
 		secretData[basicAuthHeaderVariable] = []byte(basicAuthHeader)
 	}
 
+	if output.Authentication != nil && output.Authentication.OAuth2.IsDefined() {
+		oauth2 := output.Authentication.OAuth2
+		for variable, value := range map[string]telemetryv1alpha1.ValueType{
+			makeOAuth2TokenURLVariable(pipelineName):     oauth2.TokenURL,
+			makeOAuth2ClientIDVariable(pipelineName):     oauth2.ClientID,
+			makeOAuth2ClientSecretVariable(pipelineName): oauth2.ClientSecret,
+		} {
+			resolved, err := resolveValue(ctx, c, value)
+			if err != nil {
+				return nil, err
+			}
+			secretData[variable] = resolved
+		}
+	}
+
+	if output.Authentication != nil && output.Authentication.BearerToken.IsDefined() {
+		token, err := resolveValue(ctx, c, *output.Authentication.BearerToken)
+		if err != nil {
+			return nil, err
+		}
+		secretData[makeBearerTokenVariable(pipelineName)] = token
+	}
+
 	endpoint, err := resolveValue(ctx, c, output.Endpoint)
 	if err != nil {
 		return nil, err
@@ -105,6 +132,22 @@ func makeBasicAuthHeaderVariable(pipelineName string) string {
 	return fmt.Sprintf("%s_%s", basicAuthHeaderVariablePrefix, envvar.MakeEnvVarCompliant(pipelineName))
 }
 
+func makeBearerTokenVariable(pipelineName string) string {
+	return fmt.Sprintf("%s_%s", bearerTokenVariablePrefix, envvar.MakeEnvVarCompliant(pipelineName))
+}
+
+func makeOAuth2TokenURLVariable(pipelineName string) string {
+	return fmt.Sprintf("%s_%s", oauth2TokenURLVariablePrefix, envvar.MakeEnvVarCompliant(pipelineName))
+}
+
+func makeOAuth2ClientIDVariable(pipelineName string) string {
+	return fmt.Sprintf("%s_%s", oauth2ClientIDVariablePrefix, envvar.MakeEnvVarCompliant(pipelineName))
+}
+
+func makeOAuth2ClientSecretVariable(pipelineName string) string {
+	return fmt.Sprintf("%s_%s", oauth2ClientSecretVariablePrefix, envvar.MakeEnvVarCompliant(pipelineName))
+}
+
 func makeHeaderVariable(header telemetryv1alpha1.Header, pipelineName string) string {
 	return fmt.Sprintf("HEADER_%s_%s", envvar.MakeEnvVarCompliant(pipelineName), envvar.MakeEnvVarCompliant(header.Name))
 }
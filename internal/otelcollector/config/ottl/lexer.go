@@ -0,0 +1,131 @@
+package ottl
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenPunct
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+func (t token) String() string {
+	if t.kind == tokenEnd {
+		return "end of expression"
+	}
+	return fmt.Sprintf("'%s'", t.value)
+}
+
+func tokenize(expression string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expression)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"':
+			end, err := scanString(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, value: string(runes[i : end+1])})
+			i = end + 1
+		case unicode.IsDigit(r):
+			end := scanNumber(runes, i)
+			tokens = append(tokens, token{kind: tokenNumber, value: string(runes[i:end])})
+			i = end
+		case r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1]) && !endsOperand(tokens):
+			end := scanNumber(runes, i+1)
+			tokens = append(tokens, token{kind: tokenNumber, value: string(runes[i:end])})
+			i = end
+		case unicode.IsLetter(r) || r == '_':
+			end := i
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '_') {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, value: string(runes[i:end])})
+			i = end
+		default:
+			if op := scanOperator(runes, i); op != "" {
+				tokens = append(tokens, token{kind: tokenOperator, value: op})
+				i += len(op)
+				continue
+			}
+			if strings.ContainsRune("()[]{},.:=", r) {
+				tokens = append(tokens, token{kind: tokenPunct, value: string(r)})
+				i++
+				continue
+			}
+			return nil, fmt.Errorf("unexpected character '%c'", r)
+		}
+	}
+
+	return tokens, nil
+}
+
+// scanString returns the index of the closing quote of the string literal that starts at the given index.
+func scanString(runes []rune, start int) (int, error) {
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			i++
+		case '"':
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated string literal")
+}
+
+func scanNumber(runes []rune, start int) int {
+	end := start
+	for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.' || unicode.IsLetter(runes[end])) {
+		end++
+	}
+	return end
+}
+
+func scanOperator(runes []rune, start int) string {
+	if start+1 < len(runes) {
+		switch two := string(runes[start : start+2]); two {
+		case "==", "!=", "<=", ">=":
+			return two
+		}
+	}
+	switch runes[start] {
+	case '<', '>', '+', '-', '*', '/':
+		return string(runes[start])
+	}
+	return ""
+}
+
+// endsOperand reports whether the last token ends an operand, in which case a following minus is a subtraction and not the sign of a number.
+func endsOperand(tokens []token) bool {
+	if len(tokens) == 0 {
+		return false
+	}
+	last := tokens[len(tokens)-1]
+	switch last.kind {
+	case tokenIdent:
+		return !isKeyword(last.value) || last.value == "true" || last.value == "false" || last.value == "nil"
+	case tokenString, tokenNumber:
+		return true
+	case tokenPunct:
+		return last.value == ")" || last.value == "]" || last.value == "}"
+	}
+	return false
+}
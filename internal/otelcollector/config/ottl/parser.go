@@ -0,0 +1,419 @@
+// Package ottl checks the syntax of OTTL (OpenTelemetry Transformation Language) conditions and statements,
+// so that invalid expressions are rejected before they are rendered into the collector configuration.
+// Besides the grammar, the check rejects functions that are not known to the collector and paths that don't exist in the given context.
+// The number and types of function arguments, as well as enum names, are not checked; such errors are reported by the collector at runtime.
+package ottl
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+var errEmpty = errors.New("expression must not be empty")
+
+// ValidateCondition checks that a condition is a valid OTTL boolean expression in the given context.
+func ValidateCondition(context Context, condition string) error {
+	p, err := newParser(context, condition)
+	if err != nil {
+		return err
+	}
+
+	if err := p.parseBoolExpr(); err != nil {
+		return fmt.Errorf("invalid condition '%s': %w", condition, err)
+	}
+	if err := p.expectEnd(); err != nil {
+		return fmt.Errorf("invalid condition '%s': %w", condition, err)
+	}
+	return nil
+}
+
+// ValidateStatement checks that a statement is a valid OTTL statement in the given context, which is an editor invocation with an optional where clause.
+func ValidateStatement(context Context, statement string) error {
+	p, err := newParser(context, statement)
+	if err != nil {
+		return err
+	}
+
+	if err := p.parseStatement(); err != nil {
+		return fmt.Errorf("invalid statement '%s': %w", statement, err)
+	}
+	return nil
+}
+
+type parser struct {
+	context Context
+	tokens  []token
+	pos     int
+}
+
+func newParser(context Context, expression string) (*parser, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, errEmpty
+	}
+
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression '%s': %w", expression, err)
+	}
+	return &parser{context: context, tokens: tokens}, nil
+}
+
+func (p *parser) peek() token {
+	if p.pos >= len(p.tokens) {
+		return token{kind: tokenEnd}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return token{kind: tokenEnd}
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) accept(kind tokenKind, value string) bool {
+	t := p.peek()
+	if t.kind == kind && t.value == value {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) acceptKeyword(keyword string) bool {
+	return p.accept(tokenIdent, keyword)
+}
+
+func (p *parser) expect(kind tokenKind, value string) error {
+	if p.accept(kind, value) {
+		return nil
+	}
+	return fmt.Errorf("expected '%s', found %s", value, p.peek())
+}
+
+func (p *parser) expectEnd() error {
+	if t := p.peek(); t.kind != tokenEnd {
+		return fmt.Errorf("unexpected %s", t)
+	}
+	return nil
+}
+
+// parseStatement parses: editor '(' arguments ')' [ 'where' boolExpr ].
+func (p *parser) parseStatement() error {
+	editor := p.next()
+	if editor.kind != tokenIdent || !isLower(editor.value) || isKeyword(editor.value) {
+		return fmt.Errorf("expected an editor function, found %s", editor)
+	}
+	if !editors[editor.value] {
+		return fmt.Errorf("unknown editor '%s'", editor.value)
+	}
+	if err := p.parseArguments(); err != nil {
+		return err
+	}
+
+	if p.acceptKeyword("where") {
+		if err := p.parseBoolExpr(); err != nil {
+			return err
+		}
+	}
+	return p.expectEnd()
+}
+
+// parseArguments parses: '(' [ argument { ',' argument } ] ')'.
+func (p *parser) parseArguments() error {
+	if err := p.expect(tokenPunct, "("); err != nil {
+		return err
+	}
+	if p.accept(tokenPunct, ")") {
+		return nil
+	}
+
+	for {
+		// named argument
+		if p.peek().kind == tokenIdent && p.peekAt(1).kind == tokenPunct && p.peekAt(1).value == "=" {
+			p.pos += 2
+		}
+		if err := p.parseArgument(); err != nil {
+			return err
+		}
+		if p.accept(tokenPunct, ")") {
+			return nil
+		}
+		if err := p.expect(tokenPunct, ","); err != nil {
+			return err
+		}
+	}
+}
+
+// parseArgument parses a value or a boolean expression, because some converters accept conditions as arguments.
+func (p *parser) parseArgument() error {
+	start := p.pos
+	if err := p.parseBoolExpr(); err == nil {
+		return nil
+	}
+	p.pos = start
+	return p.parseMathExpr()
+}
+
+// parseBoolExpr parses: andExpr { 'or' andExpr }.
+func (p *parser) parseBoolExpr() error {
+	if err := p.parseAndExpr(); err != nil {
+		return err
+	}
+	for p.acceptKeyword("or") {
+		if err := p.parseAndExpr(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseAndExpr parses: notExpr { 'and' notExpr }.
+func (p *parser) parseAndExpr() error {
+	if err := p.parseNotExpr(); err != nil {
+		return err
+	}
+	for p.acceptKeyword("and") {
+		if err := p.parseNotExpr(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseNotExpr parses: 'not' notExpr | '(' boolExpr ')' | comparison | 'true' | 'false' | converter.
+func (p *parser) parseNotExpr() error {
+	if p.acceptKeyword("not") {
+		return p.parseNotExpr()
+	}
+
+	if p.peek().kind == tokenPunct && p.peek().value == "(" {
+		// A parenthesis either groups a boolean expression or starts the math expression of a comparison
+		start := p.pos
+		p.pos++
+		if err := p.parseBoolExpr(); err == nil && p.accept(tokenPunct, ")") {
+			if !isComparisonOperator(p.peek()) && !isMathOperator(p.peek()) {
+				return nil
+			}
+		}
+		p.pos = start
+	}
+
+	return p.parseComparison()
+}
+
+// parseComparison parses: mathExpr [ comparisonOperator mathExpr ]. Without an operator, the value must be boolean.
+func (p *parser) parseComparison() error {
+	start := p.peek()
+	if err := p.parseMathExpr(); err != nil {
+		return err
+	}
+
+	if isComparisonOperator(p.peek()) {
+		p.next()
+		return p.parseMathExpr()
+	}
+
+	if start.kind == tokenIdent && (start.value == "true" || start.value == "false" || isUpper(start.value)) {
+		return nil
+	}
+	return fmt.Errorf("expected a comparison or a boolean value, found %s", start)
+}
+
+// parseMathExpr parses: term { ('+' | '-') term }.
+func (p *parser) parseMathExpr() error {
+	if err := p.parseTerm(); err != nil {
+		return err
+	}
+	for p.accept(tokenOperator, "+") || p.accept(tokenOperator, "-") {
+		if err := p.parseTerm(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseTerm parses: factor { ('*' | '/') factor }.
+func (p *parser) parseTerm() error {
+	if err := p.parseFactor(); err != nil {
+		return err
+	}
+	for p.accept(tokenOperator, "*") || p.accept(tokenOperator, "/") {
+		if err := p.parseFactor(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseFactor parses: '(' mathExpr ')' | value.
+func (p *parser) parseFactor() error {
+	if p.accept(tokenPunct, "(") {
+		if err := p.parseMathExpr(); err != nil {
+			return err
+		}
+		return p.expect(tokenPunct, ")")
+	}
+	return p.parseValue()
+}
+
+// parseValue parses a literal, a list, a map, a path, an enum, or a converter invocation.
+func (p *parser) parseValue() error {
+	t := p.next()
+	switch t.kind {
+	case tokenString, tokenNumber:
+		return nil
+	case tokenPunct:
+		switch t.value {
+		case "[":
+			return p.parseList()
+		case "{":
+			return p.parseMap()
+		}
+	case tokenIdent:
+		switch {
+		case t.value == "true" || t.value == "false" || t.value == "nil":
+			return nil
+		case isKeyword(t.value):
+			return fmt.Errorf("expected a value, found %s", t)
+		case isUpper(t.value) && p.peek().kind == tokenPunct && p.peek().value == "(":
+			if !converters[t.value] {
+				return fmt.Errorf("unknown converter '%s'", t.value)
+			}
+			if err := p.parseArguments(); err != nil {
+				return err
+			}
+			return p.parseKeys()
+		case isUpper(t.value):
+			// enum
+			return nil
+		}
+		return p.parsePath(t.value)
+	}
+	return fmt.Errorf("expected a value, found %s", t)
+}
+
+// parsePath parses the remainder of a path that starts with the given segment: [ '.' identifier ] keys.
+// The path must exist in the context of the parser.
+func (p *parser) parsePath(first string) error {
+	nested, ok := paths[p.context][first]
+	if !ok {
+		return fmt.Errorf("unknown path '%s' in the %s context", first, p.context)
+	}
+
+	if p.accept(tokenPunct, ".") {
+		t := p.next()
+		if t.kind != tokenIdent {
+			return fmt.Errorf("expected a path segment, found %s", t)
+		}
+		if !slices.Contains(nested, t.value) {
+			return fmt.Errorf("unknown path '%s.%s' in the %s context", first, t.value, p.context)
+		}
+	}
+	return p.parseKeys()
+}
+
+// parseKeys parses: { '[' ( string | number ) ']' }.
+func (p *parser) parseKeys() error {
+	for p.accept(tokenPunct, "[") {
+		if t := p.next(); t.kind != tokenString && t.kind != tokenNumber {
+			return fmt.Errorf("expected a string or an integer key, found %s", t)
+		}
+		if err := p.expect(tokenPunct, "]"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseList parses the remainder of a list: [ value { ',' value } ] ']'.
+func (p *parser) parseList() error {
+	if p.accept(tokenPunct, "]") {
+		return nil
+	}
+	for {
+		if err := p.parseMathExpr(); err != nil {
+			return err
+		}
+		if p.accept(tokenPunct, "]") {
+			return nil
+		}
+		if err := p.expect(tokenPunct, ","); err != nil {
+			return err
+		}
+	}
+}
+
+// parseMap parses the remainder of a map: [ string ':' value { ',' string ':' value } ] '}'.
+func (p *parser) parseMap() error {
+	if p.accept(tokenPunct, "}") {
+		return nil
+	}
+	for {
+		if t := p.next(); t.kind != tokenString {
+			return fmt.Errorf("expected a string map key, found %s", t)
+		}
+		if err := p.expect(tokenPunct, ":"); err != nil {
+			return err
+		}
+		if err := p.parseMathExpr(); err != nil {
+			return err
+		}
+		if p.accept(tokenPunct, "}") {
+			return nil
+		}
+		if err := p.expect(tokenPunct, ","); err != nil {
+			return err
+		}
+	}
+}
+
+func isComparisonOperator(t token) bool {
+	if t.kind != tokenOperator {
+		return false
+	}
+	switch t.value {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+func isMathOperator(t token) bool {
+	if t.kind != tokenOperator {
+		return false
+	}
+	switch t.value {
+	case "+", "-", "*", "/":
+		return true
+	}
+	return false
+}
+
+func isKeyword(ident string) bool {
+	switch ident {
+	case "and", "or", "not", "where", "true", "false", "nil":
+		return true
+	}
+	return false
+}
+
+func isUpper(ident string) bool {
+	return ident != "" && unicode.IsUpper(rune(ident[0]))
+}
+
+func isLower(ident string) bool {
+	return ident != "" && unicode.IsLower(rune(ident[0]))
+}
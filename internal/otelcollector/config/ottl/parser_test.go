@@ -0,0 +1,109 @@
+package ottl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCondition(t *testing.T) {
+	tests := []struct {
+		context   Context
+		condition string
+		valid     bool
+	}{
+		{context: ContextMetric, condition: `name == "process.cpu.time"`, valid: true},
+		{condition: `resource.attributes["k8s.namespace.name"] == "default"`, valid: true},
+		{condition: `attributes["http.status_code"] >= 500 and attributes["http.method"] != "GET"`, valid: true},
+		{condition: `not (resource.attributes["k8s.namespace.name"] == "a" or resource.attributes["k8s.namespace.name"] == "b")`, valid: true},
+		{context: ContextMetric, condition: `IsMatch(name, "^go_.*")`, valid: true},
+		{context: ContextMetric, condition: `IsMatch(resource.attributes["k8s.pod.name"], "^istio-.*") and not IsMatch(name, "^istio_")`, valid: true},
+		{condition: `resource.attributes["k8s.namespace.name"] != nil`, valid: true},
+		{condition: `(value_int + 10) * 2 > -5`, valid: true},
+		{condition: `Len(attributes) > 10`, valid: true},
+		{condition: `metric.type == METRIC_DATA_TYPE_SUM`, valid: true},
+		{condition: `IsMatch(metric.name, "^go_.*") and instrumentation_scope.name == "otelcol"`, valid: true},
+		{context: ContextMetric, condition: `HasAttrOnDatapoint("code", "200")`, valid: true},
+		{condition: `true`, valid: true},
+		{condition: ``},
+		{condition: `name ==`},
+		{condition: `name = "foo"`},
+		{condition: `name == "foo`},
+		{condition: `resource.attributes["k8s.namespace.name"`},
+		{condition: `name == "a" and`},
+		{condition: `IsMatch(name, "^go_.*"`},
+		{condition: `name`},
+		{condition: `set(attributes["foo"], "bar")`},
+		{condition: `name == "a" where name == "b"`},
+		{condition: `name == "a" $`},
+		{condition: `name == "process.cpu.time"`},
+		{condition: `foo == "bar"`},
+		{condition: `metric.foo == "bar"`},
+		{condition: `resource.attributes.foo == "bar"`},
+		{condition: `attributes.name == "bar"`},
+		{context: ContextMetric, condition: `attributes["code"] == "200"`},
+		{condition: `IsFoo(attributes["code"])`},
+		{condition: `Len(foo) > 10`},
+		{condition: `attributes["code"] == Foo()`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			context := tt.context
+			if context == "" {
+				context = ContextDataPoint
+			}
+			err := ValidateCondition(context, tt.condition)
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateStatement(t *testing.T) {
+	tests := []struct {
+		context   Context
+		statement string
+		valid     bool
+	}{
+		{statement: `delete_key(attributes, "http.url")`, valid: true},
+		{statement: `set(attributes["service"], attributes["svc"]) where attributes["svc"] != nil`, valid: true},
+		{statement: `keep_keys(attributes, ["method", "status_code"])`, valid: true},
+		{statement: `set(attributes["team"], "core") where IsMatch(resource.attributes["k8s.namespace.name"], "^core-")`, valid: true},
+		{statement: `replace_pattern(attributes["path"], "/users/[0-9]+", "/users/{id}")`, valid: true},
+		{statement: `set(attributes["labels"], {"a": 1, "b": "c"})`, valid: true},
+		{statement: `truncate_all(attributes, limit = 100)`, valid: true},
+		{context: ContextMetric, statement: `set(description, Concat([name, "total"], "_"))`, valid: true},
+		{statement: `convert_sum_to_gauge() where metric.name == "k8s.pod.cpu.time"`, valid: true},
+		{statement: ``},
+		{statement: `delete_key(attributes, "http.url"`},
+		{statement: `Set(attributes["foo"], "bar")`},
+		{statement: `attributes["foo"] == "bar"`},
+		{statement: `set(attributes["foo"], "bar") where`},
+		{statement: `set(attributes["foo"], "bar") if name == "a"`},
+		{statement: `set(attributes["foo"] "bar")`},
+		{statement: `foo(attributes["foo"], "bar")`},
+		{statement: `set(foo["bar"], "baz")`},
+		{statement: `set(attributes["foo"], Foo(name))`},
+		{statement: `set(attributes["foo"], "bar") where foo == "baz"`},
+		{statement: `set(description, "foo")`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			context := tt.context
+			if context == "" {
+				context = ContextDataPoint
+			}
+			err := ValidateStatement(context, tt.statement)
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
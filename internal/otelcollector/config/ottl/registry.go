@@ -0,0 +1,123 @@
+package ottl
+
+// Context is the OTTL context in which a condition or statement is evaluated. It determines which paths are available.
+type Context string
+
+const (
+	ContextMetric    Context = "metric"
+	ContextDataPoint Context = "datapoint"
+)
+
+// editors are the standard OTTL editors and the editors that the transform processor adds for metrics.
+var editors = map[string]bool{
+	"append":                           true,
+	"delete_key":                       true,
+	"delete_matching_keys":             true,
+	"flatten":                          true,
+	"keep_keys":                        true,
+	"limit":                            true,
+	"merge_maps":                       true,
+	"replace_all_matches":              true,
+	"replace_all_patterns":             true,
+	"replace_match":                    true,
+	"replace_pattern":                  true,
+	"set":                              true,
+	"truncate_all":                     true,
+	"convert_gauge_to_sum":             true,
+	"convert_sum_to_gauge":             true,
+	"convert_summary_count_val_to_sum": true,
+	"convert_summary_sum_val_to_sum":   true,
+	"extract_count_metric":             true,
+	"extract_sum_metric":               true,
+}
+
+// converters are the standard OTTL converters and the converters that the filter processor adds for metrics.
+var converters = map[string]bool{
+	"Concat":                true,
+	"ConvertCase":           true,
+	"Double":                true,
+	"Duration":              true,
+	"ExtractPatterns":       true,
+	"FNV":                   true,
+	"Hours":                 true,
+	"Int":                   true,
+	"IsBool":                true,
+	"IsDouble":              true,
+	"IsInt":                 true,
+	"IsMap":                 true,
+	"IsMatch":               true,
+	"IsString":              true,
+	"Len":                   true,
+	"Log":                   true,
+	"Microseconds":          true,
+	"Milliseconds":          true,
+	"Minutes":               true,
+	"Nanoseconds":           true,
+	"Now":                   true,
+	"ParseJSON":             true,
+	"ParseKeyValue":         true,
+	"SHA1":                  true,
+	"SHA256":                true,
+	"Seconds":               true,
+	"SpanID":                true,
+	"Split":                 true,
+	"Substring":             true,
+	"Time":                  true,
+	"TraceID":               true,
+	"TruncateTime":          true,
+	"UUID":                  true,
+	"Unix":                  true,
+	"UnixMicro":             true,
+	"UnixMilli":             true,
+	"UnixNano":              true,
+	"UnixSeconds":           true,
+	"HasAttrKeyOnDatapoint": true,
+	"HasAttrOnDatapoint":    true,
+}
+
+var (
+	resourceFields             = []string{"attributes", "dropped_attributes_count"}
+	instrumentationScopeFields = []string{"name", "version", "attributes", "dropped_attributes_count"}
+	metricFields               = []string{"name", "description", "unit", "type", "aggregation_temporality", "is_monotonic", "data_points"}
+	bucketsFields              = []string{"offset", "bucket_counts"}
+)
+
+// paths maps the first segment of each path of a context to the segments that may follow it. Paths without nested segments map to nil.
+var paths = map[Context]map[string][]string{
+	ContextMetric: {
+		"cache":                   nil,
+		"resource":                resourceFields,
+		"instrumentation_scope":   instrumentationScopeFields,
+		"name":                    nil,
+		"description":             nil,
+		"unit":                    nil,
+		"type":                    nil,
+		"aggregation_temporality": nil,
+		"is_monotonic":            nil,
+		"data_points":             nil,
+	},
+	ContextDataPoint: {
+		"cache":                 nil,
+		"resource":              resourceFields,
+		"instrumentation_scope": instrumentationScopeFields,
+		"metric":                metricFields,
+		"attributes":            nil,
+		"start_time_unix_nano":  nil,
+		"time_unix_nano":        nil,
+		"start_time":            nil,
+		"time":                  nil,
+		"value_double":          nil,
+		"value_int":             nil,
+		"exemplars":             nil,
+		"flags":                 nil,
+		"count":                 nil,
+		"sum":                   nil,
+		"bucket_counts":         nil,
+		"explicit_bounds":       nil,
+		"scale":                 nil,
+		"zero_count":            nil,
+		"positive":              bucketsFields,
+		"negative":              bucketsFields,
+		"quantile_values":       nil,
+	},
+}
@@ -18,21 +18,29 @@ type MemoryLimiter struct {
 }
 
 type K8sAttributesProcessor struct {
-	AuthType       string             `yaml:"auth_type"`
-	Passthrough    bool               `yaml:"passthrough"`
-	Extract        ExtractK8sMetadata `yaml:"extract"`
-	PodAssociation []PodAssociations  `yaml:"pod_association"`
+	AuthType       string               `yaml:"auth_type"`
+	Passthrough    bool                 `yaml:"passthrough"`
+	Extract        ExtractK8sMetadata   `yaml:"extract"`
+	PodAssociation []PodAssociations    `yaml:"pod_association"`
+	Filter         *K8sAttributesFilter `yaml:"filter,omitempty"`
+}
+
+// K8sAttributesFilter restricts the Pods that the processor watches, for example, to the Pods of the node that an agent runs on.
+type K8sAttributesFilter struct {
+	NodeFromEnvVar string `yaml:"node_from_env_var,omitempty"`
 }
 
 type ExtractK8sMetadata struct {
-	Metadata []string       `yaml:"metadata"`
-	Labels   []ExtractLabel `yaml:"labels"`
+	Metadata    []string       `yaml:"metadata"`
+	Labels      []ExtractLabel `yaml:"labels"`
+	Annotations []ExtractLabel `yaml:"annotations,omitempty"`
 }
 
 type ExtractLabel struct {
-	From    string `yaml:"from"`
-	Key     string `yaml:"key"`
-	TagName string `yaml:"tag_name"`
+	From     string `yaml:"from"`
+	Key      string `yaml:"key,omitempty"`
+	KeyRegex string `yaml:"key_regex,omitempty"`
+	TagName  string `yaml:"tag_name"`
 }
 
 type PodAssociations struct {
@@ -49,10 +57,11 @@ type ResourceProcessor struct {
 }
 
 type AttributeAction struct {
-	Action       string `yaml:"action,omitempty"`
-	Key          string `yaml:"key,omitempty"`
-	Value        string `yaml:"value,omitempty"`
-	RegexPattern string `yaml:"pattern,omitempty"`
+	Action        string `yaml:"action,omitempty"`
+	Key           string `yaml:"key,omitempty"`
+	Value         string `yaml:"value,omitempty"`
+	RegexPattern  string `yaml:"pattern,omitempty"`
+	FromAttribute string `yaml:"from_attribute,omitempty"`
 }
 
 type TransformProcessorStatements struct {
@@ -0,0 +1,99 @@
+package prometheusexporter
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/otlpexporter"
+)
+
+type RemoteWriteConfigBuilder struct {
+	reader    client.Reader
+	output    *telemetryv1alpha1.PrometheusRemoteWriteOutput
+	outputID  string
+	queueSize int
+}
+
+func NewRemoteWriteConfigBuilder(reader client.Reader, output *telemetryv1alpha1.PrometheusRemoteWriteOutput, outputID string, queueSize int) *RemoteWriteConfigBuilder {
+	return &RemoteWriteConfigBuilder{
+		reader:    reader,
+		output:    output,
+		outputID:  outputID,
+		queueSize: queueSize,
+	}
+}
+
+// MakeConfig creates the config of the prometheusremotewrite exporter and the env vars that it references.
+// The connection settings (endpoint, authentication, headers, and TLS) are resolved the same way as the ones of an OTLP output.
+func (cb *RemoteWriteConfigBuilder) MakeConfig(ctx context.Context) (*config.PrometheusRemoteWriteExporter, otlpexporter.EnvVars, error) {
+	otlpConfig, envVars, err := otlpexporter.NewConfigBuilder(cb.reader, asOtlpOutput(cb.output), cb.outputID, cb.queueSize).MakeConfig(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &config.PrometheusRemoteWriteExporter{
+		Endpoint:       otlpConfig.Endpoint,
+		Headers:        otlpConfig.Headers,
+		TLS:            otlpConfig.TLS,
+		Timeout:        otlpConfig.Timeout,
+		ExternalLabels: cb.output.ExternalLabels,
+		ResourceToTelemetryConversion: config.ResourceToTelemetryConversion{
+			Enabled: cb.output.ResourceAttributesAsLabels,
+		},
+		RemoteWriteQueue: config.RemoteWriteQueue{
+			Enabled:   true,
+			QueueSize: otlpConfig.SendingQueue.QueueSize,
+		},
+		RetryOnFailure: otlpConfig.RetryOnFailure,
+		Auth:           otlpConfig.Auth,
+	}, envVars, nil
+}
+
+// MakeRemoteWriteAuthenticatorConfig returns the ID and the config of the authenticator extension that is required by the remote-write exporter of the given output.
+// If the output does not use OAuth2 or bearer token authentication, the returned ID is empty.
+func MakeRemoteWriteAuthenticatorConfig(output *telemetryv1alpha1.PrometheusRemoteWriteOutput, outputID string) (string, any) {
+	return otlpexporter.MakeAuthenticatorConfig(asOtlpOutput(output), outputID)
+}
+
+func RemoteWriteExporterID(outputID string) string {
+	return fmt.Sprintf("prometheusremotewrite/%s", outputID)
+}
+
+// MakeConfig creates the config of the prometheus exporter, which serves the metrics on the given port of every gateway replica.
+func MakeConfig(output *telemetryv1alpha1.PrometheusOutput) *config.PrometheusExporter {
+	exporterConfig := config.PrometheusExporter{
+		Endpoint:    fmt.Sprintf("${%s}:%d", config.EnvVarCurrentPodIP, output.Port),
+		Namespace:   output.MetricPrefix,
+		ConstLabels: output.ConstLabels,
+		ResourceToTelemetryConversion: config.ResourceToTelemetryConversion{
+			Enabled: output.ResourceAttributesAsLabels,
+		},
+	}
+
+	if output.MetricExpiration != nil {
+		exporterConfig.MetricExpiration = output.MetricExpiration.Duration.String()
+	}
+
+	return &exporterConfig
+}
+
+func ExporterID(outputID string) string {
+	return fmt.Sprintf("prometheus/%s", outputID)
+}
+
+// asOtlpOutput maps the connection settings of a remote-write output to an OTLP output, so that they can be built by the otlpexporter package.
+func asOtlpOutput(output *telemetryv1alpha1.PrometheusRemoteWriteOutput) *telemetryv1alpha1.OtlpOutput {
+	return &telemetryv1alpha1.OtlpOutput{
+		Protocol:       "http",
+		Endpoint:       output.Endpoint,
+		Authentication: output.Authentication,
+		Headers:        output.Headers,
+		TLS:            output.TLS,
+		Timeout:        output.Timeout,
+		Retry:          output.Retry,
+	}
+}
@@ -0,0 +1,89 @@
+package prometheusexporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+func TestExporterIDs(t *testing.T) {
+	require.Equal(t, "prometheusremotewrite/test", RemoteWriteExporterID("test"))
+	require.Equal(t, "prometheus/test", ExporterID("test"))
+}
+
+func TestMakeRemoteWriteConfig(t *testing.T) {
+	output := &telemetryv1alpha1.PrometheusRemoteWriteOutput{
+		Endpoint:                   telemetryv1alpha1.ValueType{Value: "https://prometheus:9090/api/v1/write"},
+		Headers:                    []telemetryv1alpha1.Header{{Name: "X-Scope-OrgID", ValueType: telemetryv1alpha1.ValueType{Value: "tenant"}}},
+		ExternalLabels:             map[string]string{"cluster": "prod"},
+		ResourceAttributesAsLabels: true,
+		Timeout:                    &metav1.Duration{Duration: 10 * time.Second},
+	}
+
+	cb := NewRemoteWriteConfigBuilder(fake.NewClientBuilder().Build(), output, "test", 128)
+	exporterConfig, envVars, err := cb.MakeConfig(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []byte("https://prometheus:9090/api/v1/write"), envVars["OTLP_ENDPOINT_TEST"])
+	require.Equal(t, []byte("tenant"), envVars["HEADER_TEST_X_SCOPE_ORGID"])
+	require.Equal(t, "${OTLP_ENDPOINT_TEST}", exporterConfig.Endpoint)
+	require.Equal(t, "${HEADER_TEST_X_SCOPE_ORGID}", exporterConfig.Headers["X-Scope-OrgID"])
+	require.Equal(t, map[string]string{"cluster": "prod"}, exporterConfig.ExternalLabels)
+	require.True(t, exporterConfig.ResourceToTelemetryConversion.Enabled)
+	require.Equal(t, "10s", exporterConfig.Timeout)
+	require.True(t, exporterConfig.RemoteWriteQueue.Enabled)
+	require.Equal(t, 128, exporterConfig.RemoteWriteQueue.QueueSize)
+	require.True(t, exporterConfig.RetryOnFailure.Enabled)
+	require.Nil(t, exporterConfig.Auth)
+}
+
+func TestMakeRemoteWriteConfigWithAuthentication(t *testing.T) {
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("my-token")},
+	}
+	output := &telemetryv1alpha1.PrometheusRemoteWriteOutput{
+		Endpoint: telemetryv1alpha1.ValueType{Value: "https://prometheus:9090/api/v1/write"},
+		Authentication: &telemetryv1alpha1.AuthenticationOptions{
+			BearerToken: &telemetryv1alpha1.ValueType{ValueFrom: &telemetryv1alpha1.ValueFromSource{
+				SecretKeyRef: &telemetryv1alpha1.SecretKeyRef{Name: "token", Namespace: "default", Key: "token"},
+			}},
+		},
+	}
+
+	cb := NewRemoteWriteConfigBuilder(fake.NewClientBuilder().WithObjects(tokenSecret).Build(), output, "test", 128)
+	exporterConfig, envVars, err := cb.MakeConfig(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []byte("my-token"), envVars["BEARER_TOKEN_TEST"])
+	require.NotNil(t, exporterConfig.Auth)
+	require.Equal(t, "bearertokenauth/test", exporterConfig.Auth.Authenticator)
+
+	authenticatorID, authenticatorConfig := MakeRemoteWriteAuthenticatorConfig(output, "test")
+	require.Equal(t, "bearertokenauth/test", authenticatorID)
+	require.NotNil(t, authenticatorConfig)
+}
+
+func TestMakeConfig(t *testing.T) {
+	output := &telemetryv1alpha1.PrometheusOutput{
+		Port:                       8889,
+		MetricPrefix:               "kyma",
+		ConstLabels:                map[string]string{"cluster": "prod"},
+		ResourceAttributesAsLabels: true,
+		MetricExpiration:           &metav1.Duration{Duration: 10 * time.Minute},
+	}
+
+	exporterConfig := MakeConfig(output)
+	require.Equal(t, "${MY_POD_IP}:8889", exporterConfig.Endpoint)
+	require.Equal(t, "kyma", exporterConfig.Namespace)
+	require.Equal(t, map[string]string{"cluster": "prod"}, exporterConfig.ConstLabels)
+	require.True(t, exporterConfig.ResourceToTelemetryConversion.Enabled)
+	require.Equal(t, "10m0s", exporterConfig.MetricExpiration)
+}
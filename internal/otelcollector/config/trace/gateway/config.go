@@ -10,11 +10,36 @@ type Config struct {
 	Receivers  Receivers  `yaml:"receivers"`
 	Processors Processors `yaml:"processors"`
 	Exporters  Exporters  `yaml:"exporters"`
+	Connectors Connectors `yaml:"connectors,omitempty"`
 }
 
 type Receivers struct {
-	OpenCensus config.Endpoint     `yaml:"opencensus"`
-	OTLP       config.OTLPReceiver `yaml:"otlp"`
+	OpenCensus       config.Endpoint      `yaml:"opencensus"`
+	OTLP             config.OTLPReceiver  `yaml:"otlp"`
+	OTLPLoadBalanced *config.OTLPReceiver `yaml:"otlp/loadbalanced,omitempty"`
+	Zipkin           *config.Endpoint     `yaml:"zipkin,omitempty"`
+	Jaeger           *JaegerReceiver      `yaml:"jaeger,omitempty"`
+}
+
+// workloadReceiverIDs returns the IDs of the receivers that accept spans from the workloads.
+func (r Receivers) workloadReceiverIDs() []string {
+	receiverIDs := []string{"opencensus", "otlp"}
+	if r.Zipkin != nil {
+		receiverIDs = append(receiverIDs, "zipkin")
+	}
+	if r.Jaeger != nil {
+		receiverIDs = append(receiverIDs, "jaeger")
+	}
+	return receiverIDs
+}
+
+type JaegerReceiver struct {
+	Protocols JaegerReceiverProtocols `yaml:"protocols"`
+}
+
+type JaegerReceiverProtocols struct {
+	GRPC       config.Endpoint `yaml:"grpc"`
+	ThriftHTTP config.Endpoint `yaml:"thrift_http"`
 }
 
 type Processors struct {
@@ -25,10 +50,14 @@ type Processors struct {
 	DropNoisySpans     FilterProcessor                `yaml:"filter/drop-noisy-spans"`
 	ResolveServiceName *TransformProcessor            `yaml:"transform/resolve-service-name,omitempty"`
 	DropKymaAttributes *config.ResourceProcessor      `yaml:"resource/drop-kyma-attributes,omitempty"`
+
+	// OTel Collector components with dynamic IDs that are pipeline name based.
+	Dynamic map[string]any `yaml:",inline,omitempty"`
 }
 
 type FilterProcessor struct {
-	Traces Traces `yaml:"traces"`
+	ErrorMode string `yaml:"error_mode,omitempty"`
+	Traces    Traces `yaml:"traces"`
 }
 
 type Traces struct {
@@ -40,8 +69,113 @@ type TransformProcessor struct {
 	TraceStatements []config.TransformProcessorStatements `yaml:"trace_statements"`
 }
 
+type ProbabilisticSamplerProcessor struct {
+	SamplingPercentage float64 `yaml:"sampling_percentage"`
+}
+
+type TailSamplingProcessor struct {
+	DecisionWait string               `yaml:"decision_wait"`
+	NumTraces    int                  `yaml:"num_traces"`
+	Policies     []TailSamplingPolicy `yaml:"policies"`
+}
+
+type TailSamplingPolicy struct {
+	Name            string                 `yaml:"name"`
+	Type            string                 `yaml:"type"`
+	StatusCode      *StatusCodePolicy      `yaml:"status_code,omitempty"`
+	Latency         *LatencyPolicy         `yaml:"latency,omitempty"`
+	StringAttribute *StringAttributePolicy `yaml:"string_attribute,omitempty"`
+	RateLimiting    *RateLimitingPolicy    `yaml:"rate_limiting,omitempty"`
+	Probabilistic   *ProbabilisticPolicy   `yaml:"probabilistic,omitempty"`
+}
+
+type StatusCodePolicy struct {
+	StatusCodes []string `yaml:"status_codes"`
+}
+
+type LatencyPolicy struct {
+	ThresholdMs int64 `yaml:"threshold_ms"`
+}
+
+type StringAttributePolicy struct {
+	Key    string   `yaml:"key"`
+	Values []string `yaml:"values"`
+}
+
+type RateLimitingPolicy struct {
+	SpansPerSecond int64 `yaml:"spans_per_second"`
+}
+
+type ProbabilisticPolicy struct {
+	SamplingPercentage float64 `yaml:"sampling_percentage"`
+}
+
 type Exporters map[string]Exporter
 
 type Exporter struct {
-	OTLP *config.OTLPExporter `yaml:",inline,omitempty"`
+	OTLP          *config.OTLPExporter   `yaml:",inline,omitempty"`
+	LoadBalancing *LoadBalancingExporter `yaml:",inline,omitempty"`
+	Zipkin        *config.ZipkinExporter `yaml:"-"`
+}
+
+// MarshalYAML renders the config of the exporter that is set.
+// The Zipkin exporter shares keys like endpoint with the OTLP exporter, so it cannot be inlined into the same mapping.
+func (e Exporter) MarshalYAML() (any, error) {
+	if e.Zipkin != nil {
+		return e.Zipkin, nil
+	}
+
+	type inlinedExporter Exporter
+	return inlinedExporter(e), nil
+}
+
+// LoadBalancingExporter routes all spans of a trace to the same gateway replica, which is required for tail sampling.
+type LoadBalancingExporter struct {
+	RoutingKey string                `yaml:"routing_key"`
+	Protocol   LoadBalancingProtocol `yaml:"protocol"`
+	Resolver   LoadBalancingResolver `yaml:"resolver"`
+}
+
+type LoadBalancingProtocol struct {
+	OTLP LoadBalancingOTLP `yaml:"otlp"`
+}
+
+type LoadBalancingOTLP struct {
+	TLS config.TLS `yaml:"tls"`
+}
+
+type LoadBalancingResolver struct {
+	DNS DNSResolver `yaml:"dns"`
+}
+
+type DNSResolver struct {
+	Hostname string `yaml:"hostname"`
+	Port     string `yaml:"port"`
+}
+
+// Connectors contains the connectors that derive metrics from spans. The IDs are pipeline name based.
+type Connectors map[string]any
+
+type SpanMetricsConnector struct {
+	Histogram            SpanMetricsHistogram   `yaml:"histogram"`
+	Dimensions           []SpanMetricsDimension `yaml:"dimensions,omitempty"`
+	MetricsFlushInterval string                 `yaml:"metrics_flush_interval"`
+}
+
+type SpanMetricsHistogram struct {
+	Unit string `yaml:"unit"`
+}
+
+type SpanMetricsDimension struct {
+	Name string `yaml:"name"`
+}
+
+type ServiceGraphConnector struct {
+	Store                ServiceGraphStore `yaml:"store"`
+	MetricsFlushInterval string            `yaml:"metrics_flush_interval"`
+}
+
+type ServiceGraphStore struct {
+	TTL      string `yaml:"ttl"`
+	MaxItems int    `yaml:"max_items"`
 }
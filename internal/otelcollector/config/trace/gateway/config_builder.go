@@ -8,25 +8,53 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/otlpexporter"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/zipkinexporter"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
 )
 
-func MakeConfig(ctx context.Context, c client.Reader, pipelines []telemetryv1alpha1.TracePipeline) (*Config, otlpexporter.EnvVars, error) {
+// BuildOptions contains the settings for the trace gateway config that are not derived from the TracePipeline resources.
+type BuildOptions struct {
+	// LoadBalancingHostname is the hostname of a headless Service that resolves to all gateway replicas.
+	// If it is set and a pipeline uses tail sampling, the spans are routed by trace ID, so that all spans of a trace reach the same replica.
+	LoadBalancingHostname string
+
+	// MetricGatewayEndpoint is the OTLP gRPC endpoint of the metric gateway, to which the metrics derived from spans are sent.
+	// If it is empty, no metrics are derived from spans.
+	MetricGatewayEndpoint string
+
+	// Enrichments defines the Kubernetes metadata that is added to the spans in addition to the default metadata.
+	Enrichments *operatorv1alpha1.EnrichmentSpec
+
+	// Cluster defines the cluster name and the static attributes that are added to the spans.
+	Cluster *operatorv1alpha1.ClusterSpec
+
+	// Receivers defines the receivers for legacy span formats that are enabled in addition to the OpenCensus and OTLP receivers.
+	Receivers *operatorv1alpha1.TraceGatewayReceivers
+}
+
+func MakeConfig(ctx context.Context, c client.Reader, pipelines []telemetryv1alpha1.TracePipeline, opts BuildOptions) (*Config, otlpexporter.EnvVars, error) {
 	cfg := &Config{
 		Base: config.Base{
 			Service:    makeServiceConfig(),
 			Extensions: makeExtensionsConfig(),
 		},
-		Receivers:  makeReceiversConfig(),
-		Processors: makeProcessorsConfig(),
+		Receivers:  makeReceiversConfig(opts.Receivers),
+		Processors: makeProcessorsConfig(opts),
 		Exporters:  make(Exporters),
+		Connectors: make(Connectors),
 	}
 
 	envVars := make(otlpexporter.EnvVars)
-	queueSize := 256 / len(pipelines)
+	queueSize := 256 / countOutputs(pipelines)
+
+	loadBalancingEnabled := opts.LoadBalancingHostname != "" && requiresTraceIDRouting(pipelines, opts.MetricGatewayEndpoint)
+	if loadBalancingEnabled {
+		addLoadBalancingComponents(cfg, opts.LoadBalancingHostname)
+	}
 
 	for i := range pipelines {
 		pipeline := pipelines[i]
@@ -34,8 +62,7 @@ func MakeConfig(ctx context.Context, c client.Reader, pipelines []telemetryv1alp
 			continue
 		}
 
-		otlpExporterBuilder := otlpexporter.NewConfigBuilder(c, pipeline.Spec.Output.Otlp, pipeline.Name, queueSize)
-		if err := addComponentsForTracePipeline(ctx, otlpExporterBuilder, &pipeline, cfg, envVars); err != nil {
+		if err := addComponentsForTracePipeline(ctx, c, &pipeline, cfg, envVars, queueSize, loadBalancingEnabled, opts.MetricGatewayEndpoint); err != nil {
 			return nil, nil, err
 		}
 	}
@@ -43,8 +70,47 @@ func MakeConfig(ctx context.Context, c client.Reader, pipelines []telemetryv1alp
 	return cfg, envVars, nil
 }
 
-func makeReceiversConfig() Receivers {
-	return Receivers{
+// MakeEnvVars returns the environment variables that the exporters of the given pipelines read from the gateway Secret.
+// It resolves the variables without rendering the pipelines, so that the Secret also contains the variables of the suspended pipelines, which can then be resumed without further changes.
+func MakeEnvVars(ctx context.Context, c client.Reader, pipelines []telemetryv1alpha1.TracePipeline) (otlpexporter.EnvVars, error) {
+	envVars := make(otlpexporter.EnvVars)
+	for i := range pipelines {
+		if pipelines[i].DeletionTimestamp != nil {
+			continue
+		}
+
+		for _, output := range pipelines[i].Spec.AllOutputs() {
+			outputID := telemetryv1alpha1.OutputID(pipelines[i].Name, output.Name)
+
+			var outputEnvVars otlpexporter.EnvVars
+			var err error
+			if output.Zipkin != nil {
+				_, outputEnvVars, err = zipkinexporter.NewConfigBuilder(c, output.Zipkin, outputID, 0).MakeConfig(ctx)
+			} else {
+				_, outputEnvVars, err = otlpexporter.NewConfigBuilder(c, output.Otlp, outputID, 0).MakeConfig(ctx)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to make env vars of output %s: %w", outputID, err)
+			}
+
+			maps.Copy(envVars, outputEnvVars)
+		}
+	}
+
+	return envVars, nil
+}
+
+// countOutputs returns the number of outputs of all given pipelines, so that the sending queue capacity can be shared among all exporters.
+func countOutputs(pipelines []telemetryv1alpha1.TracePipeline) int {
+	count := 0
+	for i := range pipelines {
+		count += len(pipelines[i].Spec.AllOutputs())
+	}
+	return count
+}
+
+func makeReceiversConfig(receivers *operatorv1alpha1.TraceGatewayReceivers) Receivers {
+	receiversConfig := Receivers{
 		OpenCensus: config.Endpoint{
 			Endpoint: fmt.Sprintf("${%s}:%d", config.EnvVarCurrentPodIP, ports.OpenCensus),
 		},
@@ -59,6 +125,31 @@ func makeReceiversConfig() Receivers {
 			},
 		},
 	}
+
+	if receivers == nil {
+		return receiversConfig
+	}
+
+	if receivers.Zipkin.Enabled {
+		receiversConfig.Zipkin = &config.Endpoint{
+			Endpoint: fmt.Sprintf("${%s}:%d", config.EnvVarCurrentPodIP, ports.Zipkin),
+		}
+	}
+
+	if receivers.Jaeger.Enabled {
+		receiversConfig.Jaeger = &JaegerReceiver{
+			Protocols: JaegerReceiverProtocols{
+				GRPC: config.Endpoint{
+					Endpoint: fmt.Sprintf("${%s}:%d", config.EnvVarCurrentPodIP, ports.JaegerGRPC),
+				},
+				ThriftHTTP: config.Endpoint{
+					Endpoint: fmt.Sprintf("${%s}:%d", config.EnvVarCurrentPodIP, ports.JaegerHTTP),
+				},
+			},
+		}
+	}
+
+	return receiversConfig
 }
 
 func makeExtensionsConfig() config.Extensions {
@@ -69,6 +160,7 @@ func makeExtensionsConfig() config.Extensions {
 		Pprof: config.Endpoint{
 			Endpoint: fmt.Sprintf("127.0.0.1:%d", ports.Pprof),
 		},
+		Dynamic: make(map[string]any),
 	}
 }
 
@@ -89,36 +181,152 @@ func makeServiceConfig() config.Service {
 }
 
 // addComponentsForTracePipeline enriches a Config (exporters, processors, etc.) with components for a given telemetryv1alpha1.TracePipeline.
-func addComponentsForTracePipeline(ctx context.Context, otlpExporterBuilder *otlpexporter.ConfigBuilder, pipeline *telemetryv1alpha1.TracePipeline, cfg *Config, envVars otlpexporter.EnvVars) error {
-	otlpExporterConfig, otlpExporterEnvVars, err := otlpExporterBuilder.MakeConfig(ctx)
+// Every output of the pipeline results in an exporter, all of them are attached to the same collector pipeline.
+func addComponentsForTracePipeline(ctx context.Context, c client.Reader, pipeline *telemetryv1alpha1.TracePipeline, cfg *Config, envVars otlpexporter.EnvVars, queueSize int, loadBalancingEnabled bool, metricGatewayEndpoint string) error {
+	var exporterIDs []string
+	for _, output := range pipeline.Spec.AllOutputs() {
+		exporterID, err := addComponentsForOutput(ctx, c, pipeline, output, cfg, envVars, queueSize)
+		if err != nil {
+			return err
+		}
+
+		exporterIDs = append(exporterIDs, exporterID)
+
+		if output.HasPersistentQueue() && cfg.Extensions.FileStorage == nil {
+			cfg.Extensions.FileStorage = &config.FileStorageExtension{Directory: config.PersistentQueueDirectory}
+			cfg.Service.Extensions = append(cfg.Service.Extensions, config.FileStorageExtensionID)
+		}
+	}
+
+	var pipelineProcessorIDs []string
+	filterProcessorID, filterProcessorConfig, err := makeFilterProcessorConfig(pipeline)
 	if err != nil {
-		return fmt.Errorf("failed to make otlp exporter config: %w", err)
+		return fmt.Errorf("failed to make filter processor config for pipeline %s: %w", pipeline.Name, err)
+	}
+	if filterProcessorID != "" {
+		cfg.Processors.Dynamic[filterProcessorID] = filterProcessorConfig
+		pipelineProcessorIDs = append(pipelineProcessorIDs, filterProcessorID)
 	}
 
-	maps.Copy(envVars, otlpExporterEnvVars)
+	if connectorIDs := addSpanMetricsComponents(cfg, pipeline, metricGatewayEndpoint); len(connectorIDs) > 0 {
+		// The service graph pairs the client and server spans of a request, so all spans of a trace must be processed by the same replica
+		spanMetricsPipelineID := spanMetricsPipelineID("traces", pipeline.Name)
+		if loadBalancingEnabled && (isTailSamplingEnabled(pipeline) || isServiceGraphEnabled(pipeline)) {
+			cfg.Service.Pipelines[spanMetricsPipelineID] = makeLoadBalancedPipelineConfig(pipeline, pipelineProcessorIDs, connectorIDs...)
+		} else {
+			cfg.Service.Pipelines[spanMetricsPipelineID] = makePipelineConfig(pipeline, cfg.Receivers.workloadReceiverIDs(), pipelineProcessorIDs, connectorIDs...)
+		}
+	}
 
-	otlpExporterID := otlpexporter.ExporterID(pipeline.Spec.Output.Otlp, pipeline.Name)
-	cfg.Exporters[otlpExporterID] = Exporter{OTLP: otlpExporterConfig}
+	samplingProcessorID, samplingProcessorConfig := makeSamplingProcessorConfig(pipeline)
+	if samplingProcessorID != "" {
+		cfg.Processors.Dynamic[samplingProcessorID] = samplingProcessorConfig
+		pipelineProcessorIDs = append(pipelineProcessorIDs, samplingProcessorID)
+	}
 
 	pipelineID := fmt.Sprintf("traces/%s", pipeline.Name)
-	cfg.Service.Pipelines[pipelineID] = makePipelineConfig(otlpExporterID)
+	if loadBalancingEnabled && isTailSamplingEnabled(pipeline) {
+		cfg.Service.Pipelines[pipelineID] = makeLoadBalancedPipelineConfig(pipeline, pipelineProcessorIDs, exporterIDs...)
+	} else {
+		cfg.Service.Pipelines[pipelineID] = makePipelineConfig(pipeline, cfg.Receivers.workloadReceiverIDs(), pipelineProcessorIDs, exporterIDs...)
+	}
 
 	return nil
 }
 
-func makePipelineConfig(exporterIDs ...string) config.Pipeline {
+// addComponentsForOutput adds the exporter and the authenticator extension of the given output and returns the exporter ID.
+func addComponentsForOutput(ctx context.Context, c client.Reader, pipeline *telemetryv1alpha1.TracePipeline, output telemetryv1alpha1.NamedTracePipelineOutput, cfg *Config, envVars otlpexporter.EnvVars, queueSize int) (string, error) {
+	outputID := telemetryv1alpha1.OutputID(pipeline.Name, output.Name)
+	exporterID := ExporterID(pipeline.Name, output)
+
+	if output.Zipkin != nil {
+		exporterConfig, exporterEnvVars, err := zipkinexporter.NewConfigBuilder(c, output.Zipkin, outputID, queueSize).MakeConfig(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to make zipkin exporter config: %w", err)
+		}
+
+		maps.Copy(envVars, exporterEnvVars)
+		cfg.Exporters[exporterID] = Exporter{Zipkin: exporterConfig}
+
+		if authenticatorID, authenticatorConfig := zipkinexporter.MakeAuthenticatorConfig(output.Zipkin, outputID); authenticatorID != "" {
+			cfg.Extensions.Dynamic[authenticatorID] = authenticatorConfig
+			cfg.Service.Extensions = append(cfg.Service.Extensions, authenticatorID)
+		}
+
+		return exporterID, nil
+	}
+
+	otlpExporterBuilder := otlpexporter.NewConfigBuilder(c, output.Otlp, outputID, queueSize)
+	otlpExporterConfig, otlpExporterEnvVars, err := otlpExporterBuilder.MakeConfig(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to make otlp exporter config: %w", err)
+	}
+
+	maps.Copy(envVars, otlpExporterEnvVars)
+	cfg.Exporters[exporterID] = Exporter{OTLP: otlpExporterConfig}
+
+	if authenticatorID, authenticatorConfig := otlpexporter.MakeAuthenticatorConfig(output.Otlp, outputID); authenticatorID != "" {
+		cfg.Extensions.Dynamic[authenticatorID] = authenticatorConfig
+		cfg.Service.Extensions = append(cfg.Service.Extensions, authenticatorID)
+	}
+
+	return exporterID, nil
+}
+
+// ExporterID returns the ID of the exporter that ships the spans of the given pipeline to the given output.
+func ExporterID(pipelineName string, output telemetryv1alpha1.NamedTracePipelineOutput) string {
+	outputID := telemetryv1alpha1.OutputID(pipelineName, output.Name)
+	if output.Zipkin != nil {
+		return zipkinexporter.ExporterID(outputID)
+	}
+	return otlpexporter.ExporterID(output.Otlp, outputID)
+}
+
+// makePipelineConfig creates the pipeline of the given TracePipeline. The pipeline processors are the filter and sampling processors
+// that are specific to the TracePipeline, they are applied after the shared processors.
+func makePipelineConfig(pipeline *telemetryv1alpha1.TracePipeline, receiverIDs []string, pipelineProcessorIDs []string, exporterIDs ...string) config.Pipeline {
 	sort.Strings(exporterIDs)
 
+	processors := []string{"memory_limiter", "k8sattributes"}
+	processors = append(processors, makeSharedProcessorIDs(pipeline)...)
+	processors = append(processors, pipelineProcessorIDs...)
+	processors = append(processors, "batch")
+
 	return config.Pipeline{
-		Receivers: []string{"opencensus", "otlp"},
-		Processors: []string{"memory_limiter",
-			"k8sattributes",
-			"filter/drop-noisy-spans",
-			"resource/insert-cluster-name",
-			"transform/resolve-service-name",
-			"resource/drop-kyma-attributes",
-			"batch",
-		},
-		Exporters: exporterIDs,
+		Receivers:  receiverIDs,
+		Processors: processors,
+		Exporters:  exporterIDs,
 	}
 }
+
+// makeLoadBalancedPipelineConfig creates a pipeline that receives spans that were already routed by trace ID.
+// The k8sattributes processor is not part of the pipeline, since the spans were enriched before being routed and the connection no longer originates from the workload.
+func makeLoadBalancedPipelineConfig(pipeline *telemetryv1alpha1.TracePipeline, pipelineProcessorIDs []string, exporterIDs ...string) config.Pipeline {
+	sort.Strings(exporterIDs)
+
+	processors := []string{"memory_limiter"}
+	processors = append(processors, makeSharedProcessorIDs(pipeline)...)
+	processors = append(processors, pipelineProcessorIDs...)
+	processors = append(processors, "batch")
+
+	return config.Pipeline{
+		Receivers:  []string{"otlp/loadbalanced"},
+		Processors: processors,
+		Exporters:  exporterIDs,
+	}
+}
+
+// makeSharedProcessorIDs returns the processors that are shared by all pipelines and applied after the spans are enriched.
+// The filter for spans of Kyma-internal traffic is skipped if the pipeline disables it.
+func makeSharedProcessorIDs(pipeline *telemetryv1alpha1.TracePipeline) []string {
+	var processors []string
+	if pipeline.Spec.Filters == nil || !pipeline.Spec.Filters.DisableDropNoisySpans {
+		processors = append(processors, "filter/drop-noisy-spans")
+	}
+
+	return append(processors,
+		"resource/insert-cluster-name",
+		"transform/resolve-service-name",
+		"resource/drop-kyma-attributes",
+	)
+}
@@ -11,6 +11,7 @@ import (
 	"gopkg.in/yaml.v3"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/testutils"
 )
@@ -20,7 +21,7 @@ func TestMakeConfig(t *testing.T) {
 	fakeClient := fake.NewClientBuilder().Build()
 
 	t.Run("otlp exporter endpoint", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().WithName("test").Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().WithName("test").Build()}, BuildOptions{})
 		require.NoError(t, err)
 		expectedEndpoint := fmt.Sprintf("${%s}", "OTLP_ENDPOINT_TEST")
 		require.Contains(t, collectorConfig.Exporters, "otlp/test")
@@ -29,7 +30,7 @@ func TestMakeConfig(t *testing.T) {
 	})
 
 	t.Run("secure", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().WithName("test").Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().WithName("test").Build()}, BuildOptions{})
 		require.NoError(t, err)
 		require.Contains(t, collectorConfig.Exporters, "otlp/test")
 		otlpExporterConfig := collectorConfig.Exporters["otlp/test"]
@@ -39,7 +40,7 @@ func TestMakeConfig(t *testing.T) {
 	t.Run("insecure", func(t *testing.T) {
 		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{
 			testutils.NewTracePipelineBuilder().WithName("test-insecure").WithEndpoint("http://localhost").Build()},
-		)
+			BuildOptions{})
 		require.NoError(t, err)
 
 		require.Contains(t, collectorConfig.Exporters, "otlp/test-insecure")
@@ -50,7 +51,7 @@ func TestMakeConfig(t *testing.T) {
 	t.Run("basic auth", func(t *testing.T) {
 		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{
 			testutils.NewTracePipelineBuilder().WithName("test-basic-auth").WithBasicAuth("user", "password").Build(),
-		})
+		}, BuildOptions{})
 		require.NoError(t, err)
 		require.Contains(t, collectorConfig.Exporters, "otlp/test-basic-auth")
 		otlpExporterConfig := collectorConfig.Exporters["otlp/test-basic-auth"]
@@ -61,8 +62,32 @@ func TestMakeConfig(t *testing.T) {
 		require.Equal(t, "${BASIC_AUTH_HEADER_TEST_BASIC_AUTH}", authHeader)
 	})
 
+	t.Run("oauth2 authentication", func(t *testing.T) {
+		pipeline := testutils.NewTracePipelineBuilder().WithName("test-oauth2").Build()
+		pipeline.Spec.Output.Otlp.Authentication = &v1alpha1.AuthenticationOptions{
+			OAuth2: &v1alpha1.OAuth2Options{
+				TokenURL:     v1alpha1.ValueType{Value: "https://auth.example.com/token"},
+				ClientID:     v1alpha1.ValueType{Value: "client-id"},
+				ClientSecret: v1alpha1.ValueType{Value: "client-secret"},
+				Scopes:       []string{"ingest"},
+			},
+		}
+
+		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{pipeline}, BuildOptions{})
+		require.NoError(t, err)
+
+		otlpExporterConfig := collectorConfig.Exporters["otlp/test-oauth2"]
+		require.NotNil(t, otlpExporterConfig.OTLP.Auth)
+		require.Equal(t, "oauth2client/test-oauth2", otlpExporterConfig.OTLP.Auth.Authenticator)
+		require.NotContains(t, otlpExporterConfig.OTLP.Headers, "Authorization")
+
+		require.Contains(t, collectorConfig.Extensions.Dynamic, "oauth2client/test-oauth2")
+		require.Contains(t, collectorConfig.Service.Extensions, "oauth2client/test-oauth2")
+		require.Equal(t, []byte("client-secret"), envVars["OAUTH2_CLIENT_SECRET_TEST_OAUTH2"])
+	})
+
 	t.Run("extensions", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.NotEmpty(t, collectorConfig.Extensions.HealthCheck.Endpoint)
@@ -72,7 +97,7 @@ func TestMakeConfig(t *testing.T) {
 	})
 
 	t.Run("telemetry", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Equal(t, "info", collectorConfig.Service.Telemetry.Logs.Level)
@@ -81,7 +106,7 @@ func TestMakeConfig(t *testing.T) {
 	})
 
 	t.Run("single pipeline queue size", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().WithName("test").Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().WithName("test").Build()}, BuildOptions{})
 		require.NoError(t, err)
 		require.Equal(t, 256, collectorConfig.Exporters["otlp/test"].OTLP.SendingQueue.QueueSize, "Pipeline should have the full queue size")
 	})
@@ -91,7 +116,7 @@ func TestMakeConfig(t *testing.T) {
 			testutils.NewTracePipelineBuilder().WithName("test-1").Build(),
 			testutils.NewTracePipelineBuilder().WithName("test-2").Build(),
 			testutils.NewTracePipelineBuilder().WithName("test-3").Build()},
-		)
+			BuildOptions{})
 		require.NoError(t, err)
 		require.Equal(t, 85, collectorConfig.Exporters["otlp/test-1"].OTLP.SendingQueue.QueueSize, "Queue size should be divided by the number of pipelines")
 		require.Equal(t, 85, collectorConfig.Exporters["otlp/test-2"].OTLP.SendingQueue.QueueSize, "Queue size should be divided by the number of pipelines")
@@ -99,7 +124,7 @@ func TestMakeConfig(t *testing.T) {
 	})
 
 	t.Run("single pipeline topology", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().WithName("test").Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().WithName("test").Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Contains(t, collectorConfig.Service.Pipelines, "traces/test")
@@ -121,7 +146,7 @@ func TestMakeConfig(t *testing.T) {
 		collectorConfig, _, err := MakeConfig(context.Background(), fakeClient, []v1alpha1.TracePipeline{
 			testutils.NewTracePipelineBuilder().WithName("test-1").Build(),
 			testutils.NewTracePipelineBuilder().WithName("test-2").Build()},
-		)
+			BuildOptions{})
 		require.NoError(t, err)
 
 		require.Contains(t, collectorConfig.Exporters, "otlp/test-1")
@@ -152,10 +177,80 @@ func TestMakeConfig(t *testing.T) {
 		require.Equal(t, collectorConfig.Service.Pipelines["traces/test-2"].Processors[6], "batch")
 	})
 
+	t.Run("additional outputs", func(t *testing.T) {
+		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithAdditionalOutput("archive", "https://archive:4317").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Contains(t, collectorConfig.Exporters, "otlp/test")
+		require.Contains(t, collectorConfig.Exporters, "otlp/test_archive")
+		require.Equal(t, 128, collectorConfig.Exporters["otlp/test_archive"].OTLP.SendingQueue.QueueSize, "Queue size should be divided by the number of outputs")
+		require.Equal(t, "${OTLP_ENDPOINT_TEST_ARCHIVE}", collectorConfig.Exporters["otlp/test_archive"].OTLP.Endpoint)
+		require.Equal(t, []byte("https://archive:4317"), envVars["OTLP_ENDPOINT_TEST_ARCHIVE"])
+
+		require.Len(t, collectorConfig.Service.Pipelines, 1)
+		require.Equal(t, []string{"otlp/test", "otlp/test_archive"}, collectorConfig.Service.Pipelines["traces/test"].Exporters)
+	})
+
+	t.Run("zipkin output", func(t *testing.T) {
+		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithAdditionalZipkinOutput("legacy", "http://zipkin:9411/api/v2/spans").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Contains(t, collectorConfig.Exporters, "zipkin/test_legacy")
+		zipkinExporterConfig := collectorConfig.Exporters["zipkin/test_legacy"].Zipkin
+		require.NotNil(t, zipkinExporterConfig)
+		require.Equal(t, "${OTLP_ENDPOINT_TEST_LEGACY}", zipkinExporterConfig.Endpoint)
+		require.Equal(t, "json", zipkinExporterConfig.Format)
+		require.Equal(t, 128, zipkinExporterConfig.SendingQueue.QueueSize)
+		require.Equal(t, []byte("http://zipkin:9411/api/v2/spans"), envVars["OTLP_ENDPOINT_TEST_LEGACY"])
+		require.Equal(t, []string{"otlp/test", "zipkin/test_legacy"}, collectorConfig.Service.Pipelines["traces/test"].Exporters)
+
+		configYAML, err := yaml.Marshal(collectorConfig)
+		require.NoError(t, err)
+		require.Contains(t, string(configYAML), "zipkin/test_legacy:\n        endpoint: ${OTLP_ENDPOINT_TEST_LEGACY}\n        format: json\n")
+	})
+
+	t.Run("legacy receivers", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").Build(),
+		}, BuildOptions{
+			Receivers: &operatorv1alpha1.TraceGatewayReceivers{
+				Zipkin: operatorv1alpha1.ReceiverSpec{Enabled: true},
+				Jaeger: operatorv1alpha1.ReceiverSpec{Enabled: true},
+			},
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, collectorConfig.Receivers.Zipkin)
+		require.Equal(t, "${MY_POD_IP}:9411", collectorConfig.Receivers.Zipkin.Endpoint)
+		require.NotNil(t, collectorConfig.Receivers.Jaeger)
+		require.Equal(t, "${MY_POD_IP}:14250", collectorConfig.Receivers.Jaeger.Protocols.GRPC.Endpoint)
+		require.Equal(t, "${MY_POD_IP}:14268", collectorConfig.Receivers.Jaeger.Protocols.ThriftHTTP.Endpoint)
+		require.Equal(t, []string{"opencensus", "otlp", "zipkin", "jaeger"}, collectorConfig.Service.Pipelines["traces/test"].Receivers)
+	})
+
+	t.Run("legacy receivers disabled", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").Build(),
+		}, BuildOptions{
+			Receivers: &operatorv1alpha1.TraceGatewayReceivers{
+				Jaeger: operatorv1alpha1.ReceiverSpec{Enabled: true},
+			},
+		})
+		require.NoError(t, err)
+
+		require.Nil(t, collectorConfig.Receivers.Zipkin)
+		require.NotNil(t, collectorConfig.Receivers.Jaeger)
+		require.Equal(t, []string{"opencensus", "otlp", "jaeger"}, collectorConfig.Service.Pipelines["traces/test"].Receivers)
+	})
+
 	t.Run("marshaling", func(t *testing.T) {
 		config, _, err := MakeConfig(context.Background(), fakeClient, []v1alpha1.TracePipeline{
 			testutils.NewTracePipelineBuilder().WithName("test").Build(),
-		})
+		}, BuildOptions{})
 		require.NoError(t, err)
 
 		configYAML, err := yaml.Marshal(config)
@@ -169,3 +264,22 @@ func TestMakeConfig(t *testing.T) {
 		require.Equal(t, string(goldenFile), string(configYAML))
 	})
 }
+
+func TestMakeEnvVars(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewClientBuilder().Build()
+
+	pipelines := []v1alpha1.TracePipeline{
+		testutils.NewTracePipelineBuilder().WithName("test").Build(),
+		testutils.NewTracePipelineBuilder().WithName("test-basic-auth").WithBasicAuth("user", "password").Build(),
+	}
+
+	envVars, err := MakeEnvVars(ctx, fakeClient, pipelines)
+	require.NoError(t, err)
+
+	_, expectedEnvVars, err := MakeConfig(ctx, fakeClient, pipelines, BuildOptions{})
+	require.NoError(t, err)
+	require.Equal(t, expectedEnvVars, envVars)
+	require.Contains(t, envVars, "OTLP_ENDPOINT_TEST")
+	require.Contains(t, envVars, "BASIC_AUTH_HEADER_TEST_BASIC_AUTH")
+}
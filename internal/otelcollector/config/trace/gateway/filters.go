@@ -0,0 +1,113 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/namespaces"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/gatewayprocs"
+)
+
+// makeFilterProcessorConfig returns the ID and the configuration of the filter processor for the given pipeline.
+// The processor drops spans of Namespaces that are not selected by the pipeline, spans that do not match any keep rule, and spans that match a drop rule.
+// If all spans are kept, an empty ID is returned.
+func makeFilterProcessorConfig(pipeline *telemetryv1alpha1.TracePipeline) (string, *FilterProcessor, error) {
+	var spanConditions []string
+	if condition := gatewayprocs.NamespaceNotSelectedCondition(namespaces.Resolve(pipeline.Spec.Input.Namespaces)); condition != "" {
+		spanConditions = append(spanConditions, condition)
+	}
+
+	if filters := pipeline.Spec.Filters; filters != nil {
+		if len(filters.Keep) > 0 {
+			var keepConditions []string
+			for _, rule := range filters.Keep {
+				condition, err := makeSpanFilterRuleCondition(rule)
+				if err != nil {
+					return "", nil, fmt.Errorf("invalid keep rule: %w", err)
+				}
+				keepConditions = append(keepConditions, "("+condition+")")
+			}
+			spanConditions = append(spanConditions, "not "+joinWithOr(keepConditions...))
+		}
+
+		for _, rule := range filters.Drop {
+			condition, err := makeSpanFilterRuleCondition(rule)
+			if err != nil {
+				return "", nil, fmt.Errorf("invalid drop rule: %w", err)
+			}
+			spanConditions = append(spanConditions, condition)
+		}
+	}
+
+	if len(spanConditions) == 0 {
+		return "", nil, nil
+	}
+
+	return fmt.Sprintf("filter/%s", pipeline.Name), &FilterProcessor{
+		ErrorMode: "ignore",
+		Traces: Traces{
+			Span: spanConditions,
+		},
+	}, nil
+}
+
+// makeSpanFilterRuleCondition compiles a rule into an OTTL condition that is true for all spans matching the rule.
+// All values are provided by the user, so they are escaped before they are embedded into OTTL strings.
+// A rule without any criteria would result in an invalid condition, so an error is returned for it.
+func makeSpanFilterRuleCondition(rule telemetryv1alpha1.SpanFilterRule) (string, error) {
+	var parts []string
+
+	if len(rule.Namespaces) > 0 {
+		var namespaceConditions []string
+		for _, namespace := range rule.Namespaces {
+			namespaceConditions = append(namespaceConditions, namespaceEquals(escapeOTTLString(namespace)))
+		}
+		parts = append(parts, joinWithOr(namespaceConditions...))
+	}
+
+	if len(rule.ServiceNames) > 0 {
+		var serviceNameConditions []string
+		for _, serviceName := range rule.ServiceNames {
+			serviceNameConditions = append(serviceNameConditions, resourceAttributeEquals("service.name", escapeOTTLString(serviceName)))
+		}
+		parts = append(parts, joinWithOr(serviceNameConditions...))
+	}
+
+	if rule.URLPattern != "" {
+		parts = append(parts, urlMatches(escapeOTTLString(rule.URLPattern)))
+	}
+
+	if rule.UserAgentPattern != "" {
+		parts = append(parts, userAgentMatches(escapeOTTLString(rule.UserAgentPattern)))
+	}
+
+	for _, attribute := range rule.Attributes {
+		if attribute.Pattern != "" {
+			parts = append(parts, spanAttributeMatches(escapeOTTLString(attribute.Key), escapeOTTLString(attribute.Pattern)))
+		} else {
+			parts = append(parts, spanAttributeEquals(escapeOTTLString(attribute.Key), escapeOTTLString(attribute.Value)))
+		}
+	}
+
+	for _, attribute := range rule.ResourceAttributes {
+		if attribute.Pattern != "" {
+			parts = append(parts, attributeMatches("resource.attributes[\""+escapeOTTLString(attribute.Key)+"\"]", escapeOTTLString(attribute.Pattern)))
+		} else {
+			parts = append(parts, resourceAttributeEquals(escapeOTTLString(attribute.Key), escapeOTTLString(attribute.Value)))
+		}
+	}
+
+	if len(parts) == 0 {
+		return "", errors.New("rule does not define any criteria")
+	}
+
+	return joinWithAnd(parts...), nil
+}
+
+// escapeOTTLString escapes a value, so that it can be embedded into a double-quoted OTTL string.
+func escapeOTTLString(value string) string {
+	quoted := strconv.Quote(value)
+	return quoted[1 : len(quoted)-1]
+}
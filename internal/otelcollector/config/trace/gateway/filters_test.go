@@ -0,0 +1,148 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
+)
+
+func TestNamespaceFilter(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewClientBuilder().Build()
+
+	t.Run("no namespace selector", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.NotContains(t, collectorConfig.Processors.Dynamic, "filter/test")
+		require.NotContains(t, collectorConfig.Service.Pipelines["traces/test"].Processors, "filter/test")
+	})
+
+	t.Run("include", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithNamespaces(v1alpha1.NamespaceSelector{Include: []string{"team-a", "team-b"}}).Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Equal(t, &FilterProcessor{
+			ErrorMode: "ignore",
+			Traces: Traces{
+				Span: []string{`resource.attributes["k8s.namespace.name"] != "team-a" and resource.attributes["k8s.namespace.name"] != "team-b"`},
+			},
+		}, collectorConfig.Processors.Dynamic["filter/test"])
+
+		processors := collectorConfig.Service.Pipelines["traces/test"].Processors
+		require.Equal(t, "filter/test", processors[len(processors)-2])
+	})
+
+	t.Run("empty selector excludes system namespaces", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithNamespaces(v1alpha1.NamespaceSelector{}).Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Contains(t, collectorConfig.Processors.Dynamic, "filter/test")
+		require.Contains(t, collectorConfig.Processors.Dynamic["filter/test"].(*FilterProcessor).Traces.Span[0], `resource.attributes["k8s.namespace.name"] == "kyma-system"`)
+	})
+
+	t.Run("filter is applied before sampling", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").
+				WithNamespaces(v1alpha1.NamespaceSelector{Exclude: []string{"team-a"}}).
+				WithSampling(v1alpha1.TracePipelineSampling{Probabilistic: &v1alpha1.ProbabilisticSampling{Percentage: 10}}).
+				Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		processors := collectorConfig.Service.Pipelines["traces/test"].Processors
+		require.Equal(t, []string{"filter/test", "probabilistic_sampler/test", "batch"}, processors[len(processors)-3:])
+	})
+}
+
+func TestSpanFilters(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewClientBuilder().Build()
+
+	t.Run("drop rules", func(t *testing.T) {
+		pipeline := testutils.NewTracePipelineBuilder().WithName("test").Build()
+		pipeline.Spec.Filters = &v1alpha1.TracePipelineFilters{
+			Drop: []v1alpha1.SpanFilterRule{
+				{
+					Namespaces:       []string{"team-a", "team-b"},
+					UserAgentPattern: "kube-probe/.*",
+				},
+				{
+					ServiceNames: []string{"auth-sidecar"},
+					URLPattern:   `.*/health"z`,
+				},
+			},
+		}
+
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{pipeline}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Equal(t, &FilterProcessor{
+			ErrorMode: "ignore",
+			Traces: Traces{
+				Span: []string{
+					`(resource.attributes["k8s.namespace.name"] == "team-a" or resource.attributes["k8s.namespace.name"] == "team-b") and IsMatch(attributes["user_agent"], "kube-probe/.*") == true`,
+					`(resource.attributes["service.name"] == "auth-sidecar") and IsMatch(attributes["http.url"], ".*/health\"z") == true`,
+				},
+			},
+		}, collectorConfig.Processors.Dynamic["filter/test"])
+	})
+
+	t.Run("keep rules", func(t *testing.T) {
+		pipeline := testutils.NewTracePipelineBuilder().WithName("test").Build()
+		pipeline.Spec.Filters = &v1alpha1.TracePipelineFilters{
+			Keep: []v1alpha1.SpanFilterRule{
+				{Attributes: []v1alpha1.AttributeMatcher{{Key: "http.method", Value: "POST"}}},
+				{ResourceAttributes: []v1alpha1.AttributeMatcher{{Key: "k8s.deployment.name", Pattern: "^checkout-.*"}}},
+			},
+		}
+
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{pipeline}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{
+			`not ((attributes["http.method"] == "POST") or (IsMatch(resource.attributes["k8s.deployment.name"], "^checkout-.*") == true))`,
+		}, collectorConfig.Processors.Dynamic["filter/test"].(*FilterProcessor).Traces.Span)
+	})
+
+	t.Run("empty rules", func(t *testing.T) {
+		for name, filters := range map[string]*v1alpha1.TracePipelineFilters{
+			"drop rule with empty namespaces":    {Drop: []v1alpha1.SpanFilterRule{{Namespaces: []string{}}}},
+			"drop rule with empty url pattern":   {Drop: []v1alpha1.SpanFilterRule{{URLPattern: ""}}},
+			"keep rule with empty service names": {Keep: []v1alpha1.SpanFilterRule{{ServiceNames: []string{}}}},
+			"keep rule with empty attributes":    {Keep: []v1alpha1.SpanFilterRule{{Attributes: []v1alpha1.AttributeMatcher{}}}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				pipeline := testutils.NewTracePipelineBuilder().WithName("test").Build()
+				pipeline.Spec.Filters = filters
+
+				_, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{pipeline}, BuildOptions{})
+				require.ErrorContains(t, err, "rule does not define any criteria")
+			})
+		}
+	})
+
+	t.Run("disable drop noisy spans", func(t *testing.T) {
+		disabled := testutils.NewTracePipelineBuilder().WithName("disabled").Build()
+		disabled.Spec.Filters = &v1alpha1.TracePipelineFilters{DisableDropNoisySpans: true}
+		enabled := testutils.NewTracePipelineBuilder().WithName("enabled").Build()
+
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{disabled, enabled}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.NotContains(t, collectorConfig.Processors.Dynamic, "filter/disabled")
+		require.NotContains(t, collectorConfig.Service.Pipelines["traces/disabled"].Processors, "filter/drop-noisy-spans")
+		require.Contains(t, collectorConfig.Service.Pipelines["traces/enabled"].Processors, "filter/drop-noisy-spans")
+	})
+}
@@ -5,17 +5,18 @@ import (
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/gatewayprocs"
 )
 
-func makeProcessorsConfig() Processors {
+func makeProcessorsConfig(opts BuildOptions) Processors {
 	return Processors{
 		BaseProcessors: config.BaseProcessors{
 			Batch:         makeBatchProcessorConfig(),
 			MemoryLimiter: makeMemoryLimiterConfig(),
 		},
-		K8sAttributes:      gatewayprocs.K8sAttributesProcessorConfig(),
-		InsertClusterName:  gatewayprocs.InsertClusterNameProcessorConfig(),
+		K8sAttributes:      gatewayprocs.K8sAttributesProcessorConfig(opts.Enrichments),
+		InsertClusterName:  gatewayprocs.InsertClusterNameProcessorConfig(opts.Cluster),
 		DropNoisySpans:     makeDropNoisySpansConfig(),
 		ResolveServiceName: makeResolveServiceNameConfig(),
 		DropKymaAttributes: gatewayprocs.DropKymaAttributesProcessorConfig(),
+		Dynamic:            make(map[string]any),
 	}
 }
 
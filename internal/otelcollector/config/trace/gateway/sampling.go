@@ -0,0 +1,150 @@
+package gateway
+
+import (
+	"fmt"
+	"strconv"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
+)
+
+const (
+	tailSamplingDecisionWait = "10s"
+	tailSamplingNumTraces    = 50000
+)
+
+// makeSamplingProcessorConfig returns the ID and the configuration of the sampling processor for the given pipeline.
+// If tail sampling policies are defined, the probabilistic sampling is added as an additional tail sampling policy, so that traces kept by the other policies are never dropped by head sampling.
+// If no sampling is configured, an empty ID is returned.
+func makeSamplingProcessorConfig(pipeline *telemetryv1alpha1.TracePipeline) (string, any) {
+	sampling := pipeline.Spec.Sampling
+	if sampling == nil {
+		return "", nil
+	}
+
+	if isTailSamplingEnabled(pipeline) {
+		return fmt.Sprintf("tail_sampling/%s", pipeline.Name), &TailSamplingProcessor{
+			DecisionWait: tailSamplingDecisionWait,
+			NumTraces:    tailSamplingNumTraces,
+			Policies:     makeTailSamplingPolicies(sampling),
+		}
+	}
+
+	if sampling.Probabilistic != nil {
+		return fmt.Sprintf("probabilistic_sampler/%s", pipeline.Name), &ProbabilisticSamplerProcessor{
+			SamplingPercentage: float64(sampling.Probabilistic.Percentage),
+		}
+	}
+
+	return "", nil
+}
+
+func isTailSamplingEnabled(pipeline *telemetryv1alpha1.TracePipeline) bool {
+	sampling := pipeline.Spec.Sampling
+	if sampling == nil || sampling.Tail == nil {
+		return false
+	}
+
+	tail := sampling.Tail
+	return tail.KeepErrors || tail.Latency != nil || len(tail.Attributes) > 0 || tail.RateLimit != nil
+}
+
+func makeTailSamplingPolicies(sampling *telemetryv1alpha1.TracePipelineSampling) []TailSamplingPolicy {
+	var policies []TailSamplingPolicy
+
+	tail := sampling.Tail
+	if tail.KeepErrors {
+		policies = append(policies, TailSamplingPolicy{
+			Name:       "keep-errors",
+			Type:       "status_code",
+			StatusCode: &StatusCodePolicy{StatusCodes: []string{"ERROR"}},
+		})
+	}
+
+	if tail.Latency != nil {
+		policies = append(policies, TailSamplingPolicy{
+			Name:    "keep-latency",
+			Type:    "latency",
+			Latency: &LatencyPolicy{ThresholdMs: tail.Latency.ThresholdMs},
+		})
+	}
+
+	for i, attribute := range tail.Attributes {
+		policies = append(policies, TailSamplingPolicy{
+			Name: fmt.Sprintf("keep-attribute-%d", i),
+			Type: "string_attribute",
+			StringAttribute: &StringAttributePolicy{
+				Key:    attribute.Key,
+				Values: attribute.Values,
+			},
+		})
+	}
+
+	if tail.RateLimit != nil {
+		policies = append(policies, TailSamplingPolicy{
+			Name:         "rate-limit",
+			Type:         "rate_limiting",
+			RateLimiting: &RateLimitingPolicy{SpansPerSecond: tail.RateLimit.SpansPerSecond},
+		})
+	}
+
+	if sampling.Probabilistic != nil {
+		policies = append(policies, TailSamplingPolicy{
+			Name:          "probabilistic",
+			Type:          "probabilistic",
+			Probabilistic: &ProbabilisticPolicy{SamplingPercentage: float64(sampling.Probabilistic.Percentage)},
+		})
+	}
+
+	return policies
+}
+
+// requiresTraceIDRouting returns true if a pipeline needs all spans of a trace on the same replica, which is the case for tail sampling and the service graph.
+// The service graph is only rendered if the metric gateway endpoint is set, so it does not require routing otherwise.
+func requiresTraceIDRouting(pipelines []telemetryv1alpha1.TracePipeline, metricGatewayEndpoint string) bool {
+	for i := range pipelines {
+		if pipelines[i].DeletionTimestamp != nil {
+			continue
+		}
+		if isTailSamplingEnabled(&pipelines[i]) || (metricGatewayEndpoint != "" && isServiceGraphEnabled(&pipelines[i])) {
+			return true
+		}
+	}
+	return false
+}
+
+// addLoadBalancingComponents adds a pipeline that enriches incoming spans and routes them by trace ID to a dedicated receiver on one of the gateway replicas.
+// The pipeline ID has no name suffix, so it cannot collide with the pipeline IDs derived from TracePipeline names.
+func addLoadBalancingComponents(cfg *Config, hostname string) {
+	cfg.Receivers.OTLPLoadBalanced = &config.OTLPReceiver{
+		Protocols: config.ReceiverProtocols{
+			GRPC: config.Endpoint{
+				Endpoint: fmt.Sprintf("${%s}:%d", config.EnvVarCurrentPodIP, ports.LoadBalancing),
+			},
+		},
+	}
+
+	cfg.Exporters["loadbalancing"] = Exporter{
+		LoadBalancing: &LoadBalancingExporter{
+			RoutingKey: "traceID",
+			Protocol: LoadBalancingProtocol{
+				OTLP: LoadBalancingOTLP{
+					TLS: config.TLS{Insecure: true},
+				},
+			},
+			Resolver: LoadBalancingResolver{
+				DNS: DNSResolver{
+					Hostname: hostname,
+					Port:     strconv.Itoa(ports.LoadBalancing),
+				},
+			},
+		},
+	}
+
+	cfg.Service.Pipelines["traces"] = config.Pipeline{
+		Receivers:  cfg.Receivers.workloadReceiverIDs(),
+		Processors: []string{"memory_limiter", "k8sattributes"},
+		Exporters:  []string{"loadbalancing"},
+	}
+}
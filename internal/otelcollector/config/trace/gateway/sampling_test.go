@@ -0,0 +1,120 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
+)
+
+func TestSampling(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewClientBuilder().Build()
+
+	t.Run("no sampling", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Empty(t, collectorConfig.Processors.Dynamic)
+		require.NotContains(t, collectorConfig.Service.Pipelines["traces/test"].Processors, "probabilistic_sampler/test")
+	})
+
+	t.Run("probabilistic sampling", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithSampling(v1alpha1.TracePipelineSampling{
+				Probabilistic: &v1alpha1.ProbabilisticSampling{Percentage: 25},
+			}).Build(),
+		}, BuildOptions{LoadBalancingHostname: "loadbalancing.kyma-system.svc.cluster.local"})
+		require.NoError(t, err)
+
+		require.Equal(t, &ProbabilisticSamplerProcessor{SamplingPercentage: 25}, collectorConfig.Processors.Dynamic["probabilistic_sampler/test"])
+
+		processors := collectorConfig.Service.Pipelines["traces/test"].Processors
+		require.Equal(t, "probabilistic_sampler/test", processors[len(processors)-2])
+		require.Equal(t, "batch", processors[len(processors)-1])
+
+		require.NotContains(t, collectorConfig.Exporters, "loadbalancing", "Probabilistic sampling does not require trace ID routing")
+	})
+
+	t.Run("tail sampling", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithSampling(v1alpha1.TracePipelineSampling{
+				Probabilistic: &v1alpha1.ProbabilisticSampling{Percentage: 10},
+				Tail: &v1alpha1.TailSampling{
+					KeepErrors: true,
+					Latency:    &v1alpha1.LatencySamplingPolicy{ThresholdMs: 500},
+					Attributes: []v1alpha1.AttributeSamplingPolicy{{Key: "http.route", Values: []string{"/checkout"}}},
+					RateLimit:  &v1alpha1.RateLimitSamplingPolicy{SpansPerSecond: 100},
+				},
+			}).Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Contains(t, collectorConfig.Processors.Dynamic, "tail_sampling/test")
+		require.NotContains(t, collectorConfig.Processors.Dynamic, "probabilistic_sampler/test")
+
+		tailSampling := collectorConfig.Processors.Dynamic["tail_sampling/test"].(*TailSamplingProcessor)
+		require.Equal(t, "10s", tailSampling.DecisionWait)
+		require.Equal(t, []TailSamplingPolicy{
+			{Name: "keep-errors", Type: "status_code", StatusCode: &StatusCodePolicy{StatusCodes: []string{"ERROR"}}},
+			{Name: "keep-latency", Type: "latency", Latency: &LatencyPolicy{ThresholdMs: 500}},
+			{Name: "keep-attribute-0", Type: "string_attribute", StringAttribute: &StringAttributePolicy{Key: "http.route", Values: []string{"/checkout"}}},
+			{Name: "rate-limit", Type: "rate_limiting", RateLimiting: &RateLimitingPolicy{SpansPerSecond: 100}},
+			{Name: "probabilistic", Type: "probabilistic", Probabilistic: &ProbabilisticPolicy{SamplingPercentage: 10}},
+		}, tailSampling.Policies)
+
+		require.Contains(t, collectorConfig.Service.Pipelines["traces/test"].Processors, "tail_sampling/test")
+		require.Equal(t, []string{"opencensus", "otlp"}, collectorConfig.Service.Pipelines["traces/test"].Receivers)
+		require.NotContains(t, collectorConfig.Exporters, "loadbalancing", "Trace ID routing requires a load balancing hostname")
+	})
+
+	t.Run("tail sampling without policies", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithSampling(v1alpha1.TracePipelineSampling{
+				Tail: &v1alpha1.TailSampling{},
+			}).Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Empty(t, collectorConfig.Processors.Dynamic)
+	})
+
+	t.Run("tail sampling with trace ID routing", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test-sampled").WithSampling(v1alpha1.TracePipelineSampling{
+				Tail: &v1alpha1.TailSampling{KeepErrors: true},
+			}).Build(),
+			testutils.NewTracePipelineBuilder().WithName("test-unsampled").Build(),
+		}, BuildOptions{LoadBalancingHostname: "loadbalancing.kyma-system.svc.cluster.local"})
+		require.NoError(t, err)
+
+		require.NotNil(t, collectorConfig.Receivers.OTLPLoadBalanced)
+		require.Equal(t, "${MY_POD_IP}:4319", collectorConfig.Receivers.OTLPLoadBalanced.Protocols.GRPC.Endpoint)
+
+		require.Contains(t, collectorConfig.Exporters, "loadbalancing")
+		loadBalancingExporter := collectorConfig.Exporters["loadbalancing"].LoadBalancing
+		require.Equal(t, "traceID", loadBalancingExporter.RoutingKey)
+		require.Equal(t, "loadbalancing.kyma-system.svc.cluster.local", loadBalancingExporter.Resolver.DNS.Hostname)
+		require.Equal(t, "4319", loadBalancingExporter.Resolver.DNS.Port)
+
+		require.Contains(t, collectorConfig.Service.Pipelines, "traces")
+		require.Equal(t, []string{"opencensus", "otlp"}, collectorConfig.Service.Pipelines["traces"].Receivers)
+		require.Equal(t, []string{"memory_limiter", "k8sattributes"}, collectorConfig.Service.Pipelines["traces"].Processors)
+		require.Equal(t, []string{"loadbalancing"}, collectorConfig.Service.Pipelines["traces"].Exporters)
+
+		sampledPipeline := collectorConfig.Service.Pipelines["traces/test-sampled"]
+		require.Equal(t, []string{"otlp/loadbalanced"}, sampledPipeline.Receivers)
+		require.NotContains(t, sampledPipeline.Processors, "k8sattributes")
+		require.Contains(t, sampledPipeline.Processors, "tail_sampling/test-sampled")
+
+		unsampledPipeline := collectorConfig.Service.Pipelines["traces/test-unsampled"]
+		require.Equal(t, []string{"opencensus", "otlp"}, unsampledPipeline.Receivers)
+		require.Contains(t, unsampledPipeline.Processors, "k8sattributes")
+	})
+}
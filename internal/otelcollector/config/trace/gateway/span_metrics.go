@@ -0,0 +1,108 @@
+package gateway
+
+import (
+	"fmt"
+	"sort"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+)
+
+const (
+	metricGatewayExporterID   = "otlp/metric-gateway"
+	spanMetricsFlushInterval  = "15s"
+	serviceGraphStoreTTL      = "10s"
+	serviceGraphStoreMaxItems = 10000
+)
+
+func isSpanMetricsEnabled(pipeline *telemetryv1alpha1.TracePipeline) bool {
+	return pipeline.Spec.Metrics != nil && pipeline.Spec.Metrics.SpanMetrics.Enabled
+}
+
+func isServiceGraphEnabled(pipeline *telemetryv1alpha1.TracePipeline) bool {
+	return pipeline.Spec.Metrics != nil && pipeline.Spec.Metrics.ServiceGraph.Enabled
+}
+
+// addSpanMetricsComponents adds the connectors that derive metrics from the spans of the given pipeline, and a metrics pipeline that sends the derived metrics to the metric gateway.
+// The connectors are fed by a separate traces pipeline that only applies the filter processors of the pipeline, so that the metrics are not distorted by sampling.
+// It returns the IDs of the connectors, or nil if no metrics are derived.
+func addSpanMetricsComponents(cfg *Config, pipeline *telemetryv1alpha1.TracePipeline, metricGatewayEndpoint string) []string {
+	if metricGatewayEndpoint == "" {
+		return nil
+	}
+
+	var connectorIDs []string
+	if isSpanMetricsEnabled(pipeline) {
+		connectorID := fmt.Sprintf("spanmetrics/%s", pipeline.Name)
+		cfg.Connectors[connectorID] = makeSpanMetricsConnectorConfig(pipeline.Spec.Metrics.SpanMetrics)
+		connectorIDs = append(connectorIDs, connectorID)
+	}
+
+	if isServiceGraphEnabled(pipeline) {
+		connectorID := fmt.Sprintf("servicegraph/%s", pipeline.Name)
+		cfg.Connectors[connectorID] = &ServiceGraphConnector{
+			Store: ServiceGraphStore{
+				TTL:      serviceGraphStoreTTL,
+				MaxItems: serviceGraphStoreMaxItems,
+			},
+			MetricsFlushInterval: spanMetricsFlushInterval,
+		}
+		connectorIDs = append(connectorIDs, connectorID)
+	}
+
+	if len(connectorIDs) == 0 {
+		return nil
+	}
+
+	if _, exists := cfg.Exporters[metricGatewayExporterID]; !exists {
+		cfg.Exporters[metricGatewayExporterID] = Exporter{OTLP: makeMetricGatewayExporterConfig(metricGatewayEndpoint)}
+	}
+
+	sort.Strings(connectorIDs)
+	cfg.Service.Pipelines[spanMetricsPipelineID("metrics", pipeline.Name)] = config.Pipeline{
+		Receivers:  connectorIDs,
+		Processors: []string{"memory_limiter", "batch"},
+		Exporters:  []string{metricGatewayExporterID},
+	}
+
+	return connectorIDs
+}
+
+// spanMetricsPipelineID returns the ID of the collector pipeline of the given signal type that derives metrics from the spans of a TracePipeline.
+// The underscore separates the suffix, because it is not allowed in pipeline names, so the ID cannot collide with the ID of another TracePipeline.
+func spanMetricsPipelineID(signalType, pipelineName string) string {
+	return fmt.Sprintf("%s/%s_span-metrics", signalType, pipelineName)
+}
+
+func makeSpanMetricsConnectorConfig(spanMetrics telemetryv1alpha1.SpanMetrics) *SpanMetricsConnector {
+	var dimensions []SpanMetricsDimension
+	for _, dimension := range spanMetrics.Dimensions {
+		dimensions = append(dimensions, SpanMetricsDimension{Name: dimension})
+	}
+
+	return &SpanMetricsConnector{
+		Histogram:            SpanMetricsHistogram{Unit: "ms"},
+		Dimensions:           dimensions,
+		MetricsFlushInterval: spanMetricsFlushInterval,
+	}
+}
+
+// makeMetricGatewayExporterConfig creates the exporter that sends the derived metrics to the metric gateway within the cluster.
+func makeMetricGatewayExporterConfig(endpoint string) *config.OTLPExporter {
+	return &config.OTLPExporter{
+		Endpoint: endpoint,
+		TLS: config.TLS{
+			Insecure: true,
+		},
+		SendingQueue: config.SendingQueue{
+			Enabled:   true,
+			QueueSize: 256,
+		},
+		RetryOnFailure: config.RetryOnFailure{
+			Enabled:         true,
+			InitialInterval: "5s",
+			MaxInterval:     "30s",
+			MaxElapsedTime:  "300s",
+		},
+	}
+}
@@ -0,0 +1,127 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
+)
+
+func TestSpanMetrics(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewClientBuilder().Build()
+	opts := BuildOptions{MetricGatewayEndpoint: "telemetry-otlp-metrics.kyma-system.svc.cluster.local:4317"}
+
+	t.Run("no span metrics", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").Build(),
+		}, opts)
+		require.NoError(t, err)
+
+		require.Empty(t, collectorConfig.Connectors)
+		require.NotContains(t, collectorConfig.Exporters, "otlp/metric-gateway")
+		require.Len(t, collectorConfig.Service.Pipelines, 1)
+	})
+
+	t.Run("span metrics and service graph", func(t *testing.T) {
+		pipeline := testutils.NewTracePipelineBuilder().WithName("test").WithSampling(v1alpha1.TracePipelineSampling{
+			Probabilistic: &v1alpha1.ProbabilisticSampling{Percentage: 10},
+		}).Build()
+		pipeline.Spec.Metrics = &v1alpha1.TracePipelineMetrics{
+			SpanMetrics:  v1alpha1.SpanMetrics{Enabled: true, Dimensions: []string{"http.method"}},
+			ServiceGraph: v1alpha1.ServiceGraph{Enabled: true},
+		}
+
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{pipeline}, opts)
+		require.NoError(t, err)
+
+		require.Equal(t, &SpanMetricsConnector{
+			Histogram:            SpanMetricsHistogram{Unit: "ms"},
+			Dimensions:           []SpanMetricsDimension{{Name: "http.method"}},
+			MetricsFlushInterval: "15s",
+		}, collectorConfig.Connectors["spanmetrics/test"])
+		require.Contains(t, collectorConfig.Connectors, "servicegraph/test")
+
+		require.Contains(t, collectorConfig.Exporters, "otlp/metric-gateway")
+		metricGatewayExporter := collectorConfig.Exporters["otlp/metric-gateway"].OTLP
+		require.Equal(t, "telemetry-otlp-metrics.kyma-system.svc.cluster.local:4317", metricGatewayExporter.Endpoint)
+		require.True(t, metricGatewayExporter.TLS.Insecure)
+
+		spanMetricsPipeline := collectorConfig.Service.Pipelines["traces/test_span-metrics"]
+		require.Equal(t, []string{"opencensus", "otlp"}, spanMetricsPipeline.Receivers)
+		require.NotContains(t, spanMetricsPipeline.Processors, "probabilistic_sampler/test", "Metrics must be derived before sampling")
+		require.Equal(t, []string{"servicegraph/test", "spanmetrics/test"}, spanMetricsPipeline.Exporters)
+
+		metricsPipeline := collectorConfig.Service.Pipelines["metrics/test_span-metrics"]
+		require.Equal(t, []string{"servicegraph/test", "spanmetrics/test"}, metricsPipeline.Receivers)
+		require.Equal(t, []string{"otlp/metric-gateway"}, metricsPipeline.Exporters)
+
+		require.Contains(t, collectorConfig.Service.Pipelines["traces/test"].Processors, "probabilistic_sampler/test")
+		require.Equal(t, []string{"otlp/test"}, collectorConfig.Service.Pipelines["traces/test"].Exporters)
+	})
+
+	t.Run("span metrics pipelines do not collide with other pipelines", func(t *testing.T) {
+		pipeline := testutils.NewTracePipelineBuilder().WithName("test").Build()
+		pipeline.Spec.Metrics = &v1alpha1.TracePipelineMetrics{
+			SpanMetrics: v1alpha1.SpanMetrics{Enabled: true},
+		}
+		otherPipeline := testutils.NewTracePipelineBuilder().WithName("test-span-metrics").Build()
+
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{pipeline, otherPipeline}, opts)
+		require.NoError(t, err)
+
+		require.Len(t, collectorConfig.Service.Pipelines, 4)
+		require.Equal(t, []string{"spanmetrics/test"}, collectorConfig.Service.Pipelines["traces/test_span-metrics"].Exporters)
+		require.Equal(t, []string{"otlp/test-span-metrics"}, collectorConfig.Service.Pipelines["traces/test-span-metrics"].Exporters)
+	})
+
+	t.Run("service graph with load balancing", func(t *testing.T) {
+		pipeline := testutils.NewTracePipelineBuilder().WithName("test").Build()
+		pipeline.Spec.Metrics = &v1alpha1.TracePipelineMetrics{
+			ServiceGraph: v1alpha1.ServiceGraph{Enabled: true},
+		}
+		loadBalancingOpts := opts
+		loadBalancingOpts.LoadBalancingHostname = "telemetry-trace-collector-loadbalancing.kyma-system.svc.cluster.local"
+
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{pipeline}, loadBalancingOpts)
+		require.NoError(t, err)
+
+		require.Contains(t, collectorConfig.Exporters, "loadbalancing")
+		require.Equal(t, []string{"otlp/loadbalanced"}, collectorConfig.Service.Pipelines["traces/test_span-metrics"].Receivers)
+		require.Equal(t, []string{"opencensus", "otlp"}, collectorConfig.Service.Pipelines["traces/test"].Receivers)
+	})
+
+	t.Run("service graph with load balancing but without metric gateway", func(t *testing.T) {
+		pipeline := testutils.NewTracePipelineBuilder().WithName("test").Build()
+		pipeline.Spec.Metrics = &v1alpha1.TracePipelineMetrics{
+			ServiceGraph: v1alpha1.ServiceGraph{Enabled: true},
+		}
+
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{pipeline}, BuildOptions{
+			LoadBalancingHostname: "telemetry-trace-collector-loadbalancing.kyma-system.svc.cluster.local",
+		})
+		require.NoError(t, err)
+
+		require.NotContains(t, collectorConfig.Exporters, "loadbalancing")
+		require.Nil(t, collectorConfig.Receivers.OTLPLoadBalanced)
+		require.Len(t, collectorConfig.Service.Pipelines, 1)
+		require.Equal(t, []string{"opencensus", "otlp"}, collectorConfig.Service.Pipelines["traces/test"].Receivers)
+	})
+
+	t.Run("without metric gateway", func(t *testing.T) {
+		pipeline := testutils.NewTracePipelineBuilder().WithName("test").Build()
+		pipeline.Spec.Metrics = &v1alpha1.TracePipelineMetrics{
+			SpanMetrics: v1alpha1.SpanMetrics{Enabled: true},
+		}
+
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []v1alpha1.TracePipeline{pipeline}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Empty(t, collectorConfig.Connectors)
+		require.Len(t, collectorConfig.Service.Pipelines, 1)
+	})
+}
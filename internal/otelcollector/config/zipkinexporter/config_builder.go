@@ -0,0 +1,72 @@
+package zipkinexporter
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/otlpexporter"
+)
+
+type ConfigBuilder struct {
+	reader    client.Reader
+	output    *telemetryv1alpha1.ZipkinOutput
+	outputID  string
+	queueSize int
+}
+
+func NewConfigBuilder(reader client.Reader, output *telemetryv1alpha1.ZipkinOutput, outputID string, queueSize int) *ConfigBuilder {
+	return &ConfigBuilder{
+		reader:    reader,
+		output:    output,
+		outputID:  outputID,
+		queueSize: queueSize,
+	}
+}
+
+// MakeConfig creates the config of the zipkin exporter and the env vars that it references.
+// The connection settings (endpoint, authentication, headers, and TLS) are resolved the same way as the ones of an OTLP output.
+func (cb *ConfigBuilder) MakeConfig(ctx context.Context) (*config.ZipkinExporter, otlpexporter.EnvVars, error) {
+	otlpConfig, envVars, err := otlpexporter.NewConfigBuilder(cb.reader, asOtlpOutput(cb.output), cb.outputID, cb.queueSize).MakeConfig(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &config.ZipkinExporter{
+		Endpoint:       otlpConfig.Endpoint,
+		Format:         cb.output.Format,
+		Headers:        otlpConfig.Headers,
+		TLS:            otlpConfig.TLS,
+		Timeout:        otlpConfig.Timeout,
+		SendingQueue:   otlpConfig.SendingQueue,
+		RetryOnFailure: otlpConfig.RetryOnFailure,
+		Auth:           otlpConfig.Auth,
+	}, envVars, nil
+}
+
+// MakeAuthenticatorConfig returns the ID and the config of the authenticator extension that is required by the zipkin exporter of the given output.
+// If the output does not use OAuth2 or bearer token authentication, the returned ID is empty.
+func MakeAuthenticatorConfig(output *telemetryv1alpha1.ZipkinOutput, outputID string) (string, any) {
+	return otlpexporter.MakeAuthenticatorConfig(asOtlpOutput(output), outputID)
+}
+
+func ExporterID(outputID string) string {
+	return fmt.Sprintf("zipkin/%s", outputID)
+}
+
+// asOtlpOutput maps the connection settings of a Zipkin output to an OTLP output, so that they can be built by the otlpexporter package.
+func asOtlpOutput(output *telemetryv1alpha1.ZipkinOutput) *telemetryv1alpha1.OtlpOutput {
+	return &telemetryv1alpha1.OtlpOutput{
+		Protocol:       "http",
+		Endpoint:       output.Endpoint,
+		Authentication: output.Authentication,
+		Headers:        output.Headers,
+		TLS:            output.TLS,
+		Timeout:        output.Timeout,
+		Retry:          output.Retry,
+		Queue:          output.Queue,
+	}
+}
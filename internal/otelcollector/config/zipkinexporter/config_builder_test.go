@@ -0,0 +1,84 @@
+package zipkinexporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+)
+
+func TestExporterID(t *testing.T) {
+	require.Equal(t, "zipkin/test", ExporterID("test"))
+}
+
+func TestMakeConfig(t *testing.T) {
+	output := &telemetryv1alpha1.ZipkinOutput{
+		Endpoint: telemetryv1alpha1.ValueType{Value: "https://zipkin:9411/api/v2/spans"},
+		Format:   "proto",
+		Headers:  []telemetryv1alpha1.Header{{Name: "X-Tenant", ValueType: telemetryv1alpha1.ValueType{Value: "tenant"}}},
+		Timeout:  &metav1.Duration{Duration: 10 * time.Second},
+	}
+
+	cb := NewConfigBuilder(fake.NewClientBuilder().Build(), output, "test", 128)
+	exporterConfig, envVars, err := cb.MakeConfig(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []byte("https://zipkin:9411/api/v2/spans"), envVars["OTLP_ENDPOINT_TEST"])
+	require.Equal(t, []byte("tenant"), envVars["HEADER_TEST_X_TENANT"])
+	require.Equal(t, "${OTLP_ENDPOINT_TEST}", exporterConfig.Endpoint)
+	require.Equal(t, "proto", exporterConfig.Format)
+	require.Equal(t, "${HEADER_TEST_X_TENANT}", exporterConfig.Headers["X-Tenant"])
+	require.Equal(t, "10s", exporterConfig.Timeout)
+	require.True(t, exporterConfig.SendingQueue.Enabled)
+	require.Equal(t, 128, exporterConfig.SendingQueue.QueueSize)
+	require.True(t, exporterConfig.RetryOnFailure.Enabled)
+	require.Nil(t, exporterConfig.Auth)
+}
+
+func TestMakeConfigWithPersistentQueue(t *testing.T) {
+	output := &telemetryv1alpha1.ZipkinOutput{
+		Endpoint: telemetryv1alpha1.ValueType{Value: "https://zipkin:9411/api/v2/spans"},
+		Queue:    &telemetryv1alpha1.OtlpQueue{Size: 1000, Persistent: true},
+	}
+
+	cb := NewConfigBuilder(fake.NewClientBuilder().Build(), output, "test", 128)
+	exporterConfig, _, err := cb.MakeConfig(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 1000, exporterConfig.SendingQueue.QueueSize)
+	require.Equal(t, config.FileStorageExtensionID, exporterConfig.SendingQueue.Storage)
+}
+
+func TestMakeConfigWithAuthentication(t *testing.T) {
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("my-token")},
+	}
+	output := &telemetryv1alpha1.ZipkinOutput{
+		Endpoint: telemetryv1alpha1.ValueType{Value: "https://zipkin:9411/api/v2/spans"},
+		Authentication: &telemetryv1alpha1.AuthenticationOptions{
+			BearerToken: &telemetryv1alpha1.ValueType{ValueFrom: &telemetryv1alpha1.ValueFromSource{
+				SecretKeyRef: &telemetryv1alpha1.SecretKeyRef{Name: "token", Namespace: "default", Key: "token"},
+			}},
+		},
+	}
+
+	cb := NewConfigBuilder(fake.NewClientBuilder().WithObjects(tokenSecret).Build(), output, "test", 128)
+	exporterConfig, envVars, err := cb.MakeConfig(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []byte("my-token"), envVars["BEARER_TOKEN_TEST"])
+	require.NotNil(t, exporterConfig.Auth)
+	require.Equal(t, "bearertokenauth/test", exporterConfig.Auth.Authenticator)
+
+	authenticatorID, authenticatorConfig := MakeAuthenticatorConfig(output, "test")
+	require.Equal(t, "bearertokenauth/test", authenticatorID)
+	require.NotNil(t, authenticatorConfig)
+}
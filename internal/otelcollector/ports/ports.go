@@ -1,10 +1,14 @@
 package ports
 
 const (
-	OTLPHTTP    = 4318
-	OTLPGRPC    = 4317
-	OpenCensus  = 55678
-	Metrics     = 8888
-	HealthCheck = 13133
-	Pprof       = 1777
+	OTLPHTTP      = 4318
+	OTLPGRPC      = 4317
+	OpenCensus    = 55678
+	Metrics       = 8888
+	HealthCheck   = 13133
+	Pprof         = 1777
+	LoadBalancing = 4319
+	Zipkin        = 9411
+	JaegerGRPC    = 14250
+	JaegerHTTP    = 14268
 )
@@ -0,0 +1,32 @@
+// Package persistentqueue decides on which volume the gateways store the persistent sending queues of their outputs.
+package persistentqueue
+
+import (
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
+)
+
+// MakeConfig returns the volume config for the persistent sending queues, or nil if no output uses a persistent queue.
+// The volume is backed by the configured PersistentVolumeClaim if the gateway can use it, or by an emptyDir volume otherwise.
+func MakeConfig(required bool, persistentQueue *operatorv1alpha1.PersistentQueue, scaling operatorv1alpha1.Scaling) *otelcollector.PersistentQueueConfig {
+	if !required {
+		return nil
+	}
+
+	config := &otelcollector.PersistentQueueConfig{}
+	if persistentQueue != nil && CanUsePersistentVolumeClaim(scaling) {
+		config.PersistentVolumeClaimName = persistentQueue.PersistentVolumeClaimName
+	}
+	return config
+}
+
+// CanUsePersistentVolumeClaim returns true if the gateway runs with the Static scaling strategy and one replica.
+// The queue files cannot be shared between replicas, so a PersistentVolumeClaim cannot be used by a gateway that runs or can run with more replicas.
+func CanUsePersistentVolumeClaim(scaling operatorv1alpha1.Scaling) bool {
+	return scaling.Type == operatorv1alpha1.StaticScalingStrategyType && scaling.Static != nil && scaling.Static.Replicas == 1
+}
+
+// IsPersistentVolumeClaimIgnored returns true if a PersistentVolumeClaim is configured, but cannot be used with the given scaling.
+func IsPersistentVolumeClaimIgnored(persistentQueue *operatorv1alpha1.PersistentQueue, scaling operatorv1alpha1.Scaling) bool {
+	return persistentQueue != nil && persistentQueue.PersistentVolumeClaimName != "" && !CanUsePersistentVolumeClaim(scaling)
+}
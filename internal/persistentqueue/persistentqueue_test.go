@@ -0,0 +1,82 @@
+package persistentqueue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
+)
+
+func TestMakeConfig(t *testing.T) {
+	persistentQueue := &operatorv1alpha1.PersistentQueue{PersistentVolumeClaimName: "queue"}
+	singleReplica := operatorv1alpha1.Scaling{
+		Type:   operatorv1alpha1.StaticScalingStrategyType,
+		Static: &operatorv1alpha1.StaticScaling{Replicas: 1},
+	}
+
+	tests := []struct {
+		name            string
+		required        bool
+		persistentQueue *operatorv1alpha1.PersistentQueue
+		scaling         operatorv1alpha1.Scaling
+		expected        *otelcollector.PersistentQueueConfig
+		expectedIgnored bool
+	}{
+		{
+			name:            "not required",
+			persistentQueue: persistentQueue,
+			scaling:         singleReplica,
+		},
+		{
+			name:     "emptyDir volume without persistent volume claim",
+			required: true,
+			scaling:  singleReplica,
+			expected: &otelcollector.PersistentQueueConfig{},
+		},
+		{
+			name:            "persistent volume claim with one static replica",
+			required:        true,
+			persistentQueue: persistentQueue,
+			scaling:         singleReplica,
+			expected:        &otelcollector.PersistentQueueConfig{PersistentVolumeClaimName: "queue"},
+		},
+		{
+			name:            "persistent volume claim ignored with default scaling",
+			required:        true,
+			persistentQueue: persistentQueue,
+			expected:        &otelcollector.PersistentQueueConfig{},
+			expectedIgnored: true,
+		},
+		{
+			name:            "persistent volume claim ignored with more static replicas",
+			required:        true,
+			persistentQueue: persistentQueue,
+			scaling: operatorv1alpha1.Scaling{
+				Type:   operatorv1alpha1.StaticScalingStrategyType,
+				Static: &operatorv1alpha1.StaticScaling{Replicas: 3},
+			},
+			expected:        &otelcollector.PersistentQueueConfig{},
+			expectedIgnored: true,
+		},
+		{
+			name:            "persistent volume claim ignored with autoscaling",
+			required:        true,
+			persistentQueue: persistentQueue,
+			scaling: operatorv1alpha1.Scaling{
+				Type:        operatorv1alpha1.AutoscalingStrategyType,
+				Autoscaling: &operatorv1alpha1.AutoscalingScaling{MinReplicas: 1, MaxReplicas: 1},
+			},
+			expected:        &otelcollector.PersistentQueueConfig{},
+			expectedIgnored: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, MakeConfig(tt.required, tt.persistentQueue, tt.scaling))
+			require.Equal(t, tt.expectedIgnored, IsPersistentVolumeClaimIgnored(tt.persistentQueue, tt.scaling))
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package pipelinelimit
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Pipeline is a pipeline that competes for one of the limited pipeline slots.
+type Pipeline struct {
+	Name string
+	// Namespace is the Namespace of a namespaced pipeline, and empty for a cluster-scoped pipeline.
+	Namespace         string
+	Priority          int32
+	CreationTimestamp metav1.Time
+}
+
+// Select returns the names of the pipelines that are deployed if at most maxPipelines pipelines can be deployed.
+// Pipelines with a higher priority are selected first. Pipelines with the same priority are selected in the order of their creation, and by name if they were created at the same time.
+// The selection only depends on the given pipelines, so all reconcilers select the same pipelines. If maxPipelines is 0, all pipelines are selected.
+func Select(pipelines []Pipeline, maxPipelines int) []string {
+	ranked := rank(pipelines)
+
+	if maxPipelines > 0 && len(ranked) > maxPipelines {
+		ranked = ranked[:maxPipelines]
+	}
+
+	selected := make([]string, 0, len(ranked))
+	for _, p := range ranked {
+		selected = append(selected, p.Name)
+	}
+	return selected
+}
+
+// SelectPerNamespace returns the pipelines that are within the limit of their Namespace, in the same order as Select would select them.
+// Cluster-scoped pipelines are not limited. If maxPipelinesPerNamespace is 0, all pipelines are returned.
+// Apply Select to the result to enforce the global limit as well.
+func SelectPerNamespace(pipelines []Pipeline, maxPipelinesPerNamespace int) []Pipeline {
+	countPerNamespace := make(map[string]int)
+
+	var selected []Pipeline
+	for _, p := range rank(pipelines) {
+		if p.Namespace != "" && maxPipelinesPerNamespace > 0 {
+			if countPerNamespace[p.Namespace] >= maxPipelinesPerNamespace {
+				continue
+			}
+			countPerNamespace[p.Namespace]++
+		}
+		selected = append(selected, p)
+	}
+	return selected
+}
+
+func rank(pipelines []Pipeline) []Pipeline {
+	ranked := make([]Pipeline, len(pipelines))
+	copy(ranked, pipelines)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Priority != ranked[j].Priority {
+			return ranked[i].Priority > ranked[j].Priority
+		}
+		if !ranked[i].CreationTimestamp.Equal(&ranked[j].CreationTimestamp) {
+			return ranked[i].CreationTimestamp.Before(&ranked[j].CreationTimestamp)
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+	return ranked
+}
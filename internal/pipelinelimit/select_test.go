@@ -0,0 +1,109 @@
+package pipelinelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSelect(t *testing.T) {
+	now := time.Now()
+	older := metav1.NewTime(now.Add(-time.Hour))
+	newer := metav1.NewTime(now)
+
+	tests := []struct {
+		name         string
+		pipelines    []Pipeline
+		maxPipelines int
+		expected     []string
+	}{
+		{
+			name:         "no pipelines",
+			maxPipelines: 3,
+			expected:     []string{},
+		},
+		{
+			name: "below the limit",
+			pipelines: []Pipeline{
+				{Name: "b", CreationTimestamp: newer},
+				{Name: "a", CreationTimestamp: older},
+			},
+			maxPipelines: 3,
+			expected:     []string{"a", "b"},
+		},
+		{
+			name: "higher priority wins over older pipeline",
+			pipelines: []Pipeline{
+				{Name: "throwaway", CreationTimestamp: older},
+				{Name: "production", Priority: 10, CreationTimestamp: newer},
+			},
+			maxPipelines: 1,
+			expected:     []string{"production"},
+		},
+		{
+			name: "older pipeline wins with same priority",
+			pipelines: []Pipeline{
+				{Name: "a", CreationTimestamp: newer},
+				{Name: "b", CreationTimestamp: older},
+			},
+			maxPipelines: 1,
+			expected:     []string{"b"},
+		},
+		{
+			name: "name decides if created at the same time",
+			pipelines: []Pipeline{
+				{Name: "b", CreationTimestamp: older},
+				{Name: "a", CreationTimestamp: older},
+				{Name: "c", CreationTimestamp: older},
+			},
+			maxPipelines: 2,
+			expected:     []string{"a", "b"},
+		},
+		{
+			name: "no limit",
+			pipelines: []Pipeline{
+				{Name: "a", CreationTimestamp: older},
+				{Name: "b", Priority: -1, CreationTimestamp: older},
+			},
+			maxPipelines: 0,
+			expected:     []string{"a", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, Select(tt.pipelines, tt.maxPipelines))
+		})
+	}
+}
+
+func TestSelectPerNamespace(t *testing.T) {
+	now := time.Now()
+	older := metav1.NewTime(now.Add(-time.Hour))
+	newer := metav1.NewTime(now)
+
+	pipelines := []Pipeline{
+		{Name: "cluster-a", CreationTimestamp: newer},
+		{Name: "cluster-b", CreationTimestamp: newer},
+		{Name: "team-a__new", Namespace: "team-a", CreationTimestamp: newer},
+		{Name: "team-a__old", Namespace: "team-a", CreationTimestamp: older},
+		{Name: "team-a__prio", Namespace: "team-a", Priority: 1, CreationTimestamp: newer},
+		{Name: "team-b__new", Namespace: "team-b", CreationTimestamp: newer},
+	}
+
+	t.Run("limits only namespaced pipelines", func(t *testing.T) {
+		selected := SelectPerNamespace(pipelines, 2)
+		require.Equal(t, []string{"team-a__prio", "team-a__old", "cluster-a", "cluster-b", "team-b__new"}, Select(selected, 0))
+	})
+
+	t.Run("global limit applies to the selected pipelines", func(t *testing.T) {
+		selected := SelectPerNamespace(pipelines, 1)
+		require.Equal(t, []string{"team-a__prio", "cluster-a"}, Select(selected, 2))
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		require.Len(t, SelectPerNamespace(pipelines, 0), len(pipelines))
+	})
+}
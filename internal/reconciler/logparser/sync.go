@@ -32,18 +32,12 @@ func (s *syncer) syncFluentBitConfig(ctx context.Context) error {
 		return fmt.Errorf("unable to list parsers: %w", err)
 	}
 	fluentBitParsersConfig := builder.BuildFluentBitParsersConfig(&logParsers)
-	if fluentBitParsersConfig == "" {
-		data := make(map[string]string)
-		data[parsersConfigMapKey] = ""
-		cm.Data = data
-	} else if cm.Data == nil {
-		data := make(map[string]string)
-		data[parsersConfigMapKey] = fluentBitParsersConfig
-		cm.Data = data
-	} else {
-		if oldConfig, hasKey := cm.Data[parsersConfigMapKey]; !hasKey || oldConfig != fluentBitParsersConfig {
-			cm.Data[parsersConfigMapKey] = fluentBitParsersConfig
-		}
+	// The ConfigMap also holds the parsers of LogPipelines, so only the own key is touched
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	if oldConfig, hasKey := cm.Data[parsersConfigMapKey]; !hasKey || oldConfig != fluentBitParsersConfig {
+		cm.Data[parsersConfigMapKey] = fluentBitParsersConfig
 	}
 
 	for i := range logParsers.Items {
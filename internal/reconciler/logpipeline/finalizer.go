@@ -3,6 +3,7 @@ package logpipeline
 import (
 	"context"
 
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -34,7 +35,7 @@ func ensureFinalizers(ctx context.Context, client client.Client, pipeline *telem
 		return nil
 	}
 
-	return client.Update(ctx, pipeline)
+	return updateFinalizers(ctx, client, pipeline)
 }
 
 func cleanupFinalizersIfNeeded(ctx context.Context, client client.Client, pipeline *telemetryv1alpha1.LogPipeline) error {
@@ -57,5 +58,25 @@ func cleanupFinalizersIfNeeded(ctx context.Context, client client.Client, pipeli
 		return nil
 	}
 
-	return client.Update(ctx, pipeline)
+	return updateFinalizers(ctx, client, pipeline)
+}
+
+// updateFinalizers persists the finalizers of the pipeline. The finalizers of a converted NamespacedLogPipeline are written to the NamespacedLogPipeline.
+func updateFinalizers(ctx context.Context, client client.Client, pipeline *telemetryv1alpha1.LogPipeline) error {
+	namespace, name, ok := telemetryv1alpha1.SplitNamespacedPipelineName(pipeline.Name)
+	if !ok {
+		return client.Update(ctx, pipeline)
+	}
+
+	var namespacedPipeline telemetryv1alpha1.NamespacedLogPipeline
+	if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &namespacedPipeline); err != nil {
+		return err
+	}
+	namespacedPipeline.ResourceVersion = pipeline.ResourceVersion
+	namespacedPipeline.Finalizers = pipeline.Finalizers
+	if err := client.Update(ctx, &namespacedPipeline); err != nil {
+		return err
+	}
+	pipeline.ResourceVersion = namespacedPipeline.ResourceVersion
+	return nil
 }
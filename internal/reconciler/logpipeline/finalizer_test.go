@@ -57,6 +57,23 @@ func TestEnsureFinalizers(t *testing.T) {
 		require.True(t, controllerutil.ContainsFinalizer(&updatedPipeline, sectionsFinalizer))
 		require.True(t, controllerutil.ContainsFinalizer(&updatedPipeline, filesFinalizer))
 	})
+
+	t.Run("namespaced pipeline", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		_ = telemetryv1alpha1.AddToScheme(scheme)
+		namespacedPipeline := &telemetryv1alpha1.NamespacedLogPipeline{ObjectMeta: metav1.ObjectMeta{Name: "pipeline", Namespace: "team-a"}}
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespacedPipeline).Build()
+
+		pipeline := namespacedPipeline.ToLogPipeline()
+		err := ensureFinalizers(context.Background(), client, &pipeline)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.NamespacedLogPipeline
+		_ = client.Get(context.TODO(), types.NamespacedName{Name: "pipeline", Namespace: "team-a"}, &updatedPipeline)
+
+		require.True(t, controllerutil.ContainsFinalizer(&updatedPipeline, sectionsFinalizer))
+		require.Equal(t, updatedPipeline.ResourceVersion, pipeline.ResourceVersion)
+	})
 }
 
 func TestCleanupFinalizers(t *testing.T) {
@@ -19,25 +19,36 @@ package logpipeline
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v3"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/configchecksum"
 	configbuilder "github.com/kyma-project/telemetry-manager/internal/fluentbit/config/builder"
 	"github.com/kyma-project/telemetry-manager/internal/kubernetes"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/log/agent"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/log/gateway"
 	"github.com/kyma-project/telemetry-manager/internal/overrides"
+	"github.com/kyma-project/telemetry-manager/internal/pipelinelimit"
 	commonresources "github.com/kyma-project/telemetry-manager/internal/resources/common"
 	resources "github.com/kyma-project/telemetry-manager/internal/resources/fluentbit"
+	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
 	"github.com/kyma-project/telemetry-manager/internal/secretref"
 )
 
+const defaultGatewayReplicaCount int32 = 2
+
 type Config struct {
 	DaemonSet             types.NamespacedName
 	SectionsConfigMap     types.NamespacedName
@@ -50,6 +61,12 @@ type Config struct {
 	PipelineDefaults      configbuilder.PipelineDefaults
 	Overrides             overrides.Config
 	DaemonSetConfig       resources.DaemonSetConfig
+	Gateway               otelcollector.GatewayConfig
+	Agent                 otelcollector.AgentConfig
+	// MaxPipelines is the maximum number of log pipelines. The webhook enforces it for LogPipelines, and NamespacedLogPipelines only get the slots that are left. If 0, no limit is applied.
+	MaxPipelines int
+	// MaxPipelinesPerNamespace limits the number of NamespacedLogPipelines per Namespace. If 0, no limit is applied.
+	MaxPipelinesPerNamespace int
 }
 
 //go:generate mockery --name DaemonSetProber --filename daemon_set_prober.go
@@ -57,6 +74,11 @@ type DaemonSetProber interface {
 	IsReady(ctx context.Context, name types.NamespacedName) (bool, error)
 }
 
+//go:generate mockery --name DeploymentProber --filename deployment_prober.go
+type DeploymentProber interface {
+	IsReady(ctx context.Context, name types.NamespacedName) (bool, error)
+}
+
 //go:generate mockery --name DaemonSetAnnotator --filename daemon_set_annotator.go
 type DaemonSetAnnotator interface {
 	SetAnnotation(ctx context.Context, name types.NamespacedName, key, value string) error
@@ -66,17 +88,19 @@ type Reconciler struct {
 	client.Client
 	config                  Config
 	prober                  DaemonSetProber
+	gatewayProber           DeploymentProber
 	allLogPipelines         prometheus.Gauge
 	unsupportedLogPipelines prometheus.Gauge
 	syncer                  syncer
 	overridesHandler        *overrides.Handler
 }
 
-func NewReconciler(client client.Client, config Config, prober DaemonSetProber, overridesHandler *overrides.Handler) *Reconciler {
+func NewReconciler(client client.Client, config Config, prober DaemonSetProber, gatewayProber DeploymentProber, overridesHandler *overrides.Handler) *Reconciler {
 	var r Reconciler
 	r.Client = client
 	r.config = config
 	r.prober = prober
+	r.gatewayProber = gatewayProber
 	r.allLogPipelines = prometheus.NewGauge(prometheus.GaugeOpts{Name: "telemetry_all_logpipelines", Help: "Number of log pipelines."})
 	r.unsupportedLogPipelines = prometheus.NewGauge(prometheus.GaugeOpts{Name: "telemetry_unsupported_logpipelines", Help: "Number of log pipelines with custom filters or outputs."})
 	metrics.Registry.MustRegister(r.allLogPipelines, r.unsupportedLogPipelines)
@@ -108,12 +132,17 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		log.Error(err, "Failed to get all LogPipelines while updating metrics")
 	}
 
-	var pipeline telemetryv1alpha1.LogPipeline
-	if err := r.Get(ctx, req.NamespacedName, &pipeline); err != nil {
+	pipelineName := req.Name
+	if req.Namespace != "" {
+		pipelineName = telemetryv1alpha1.NamespacedPipelineName(req.Namespace, req.Name)
+	}
+
+	pipeline, err := r.getPipeline(ctx, pipelineName)
+	if err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	return ctrl.Result{}, r.doReconcile(ctx, &pipeline)
+	return ctrl.Result{}, r.doReconcile(ctx, pipeline)
 }
 
 func (r *Reconciler) doReconcile(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline) (err error) {
@@ -128,8 +157,8 @@ func (r *Reconciler) doReconcile(ctx context.Context, pipeline *telemetryv1alpha
 		}
 	}()
 
-	var allPipelines telemetryv1alpha1.LogPipelineList
-	if err := r.List(ctx, &allPipelines); err != nil {
+	allPipelines, err := r.listPipelines(ctx)
+	if err != nil {
 		return fmt.Errorf("failed to get all log pipelines while syncing Fluent Bit ConfigMaps: %w", err)
 	}
 
@@ -137,15 +166,28 @@ func (r *Reconciler) doReconcile(ctx context.Context, pipeline *telemetryv1alpha
 		return err
 	}
 
-	deployableLogPipelines := getDeployableLogPipelines(ctx, allPipelines.Items, r.Client)
-	if err = r.syncer.syncFluentBitConfig(ctx, pipeline, deployableLogPipelines); err != nil {
+	deployableLogPipelines := getDeployableLogPipelines(ctx, r.withinLimit(allPipelines), r.Client)
+	fluentBitPipelines, otelAgentPipelines := SplitPipelinesByAgent(deployableLogPipelines, getLogAgentFromTelemetry(ctx, r.Client))
+	if err = r.syncer.syncFluentBitConfig(ctx, pipeline, fluentBitPipelines); err != nil {
 		return err
 	}
 
-	if err = r.reconcileFluentBit(ctx, pipeline, deployableLogPipelines); err != nil {
+	if err = r.reconcileFluentBit(ctx, pipeline, withoutSuspended(fluentBitPipelines)); err != nil {
 		return err
 	}
 
+	if activeOTelAgentPipelines := withoutSuspended(otelAgentPipelines); len(activeOTelAgentPipelines) > 0 {
+		if err = r.reconcileLogAgent(ctx, pipeline, activeOTelAgentPipelines); err != nil {
+			return fmt.Errorf("failed to reconcile log agent: %w", err)
+		}
+	} else if err = r.deleteLogAgent(ctx); err != nil {
+		return fmt.Errorf("failed to delete log agent: %w", err)
+	}
+
+	if err = r.reconcileLogGateway(ctx, pipeline, deployableLogPipelines); err != nil {
+		return fmt.Errorf("failed to reconcile log gateway: %w", err)
+	}
+
 	if err = cleanupFinalizersIfNeeded(ctx, r.Client, pipeline); err != nil {
 		return err
 	}
@@ -153,8 +195,101 @@ func (r *Reconciler) doReconcile(ctx context.Context, pipeline *telemetryv1alpha
 	return err
 }
 
+// getPipeline returns the LogPipeline with the given name. If the name is the name of a converted NamespacedLogPipeline, the NamespacedLogPipeline is converted.
+func (r *Reconciler) getPipeline(ctx context.Context, pipelineName string) (*telemetryv1alpha1.LogPipeline, error) {
+	if namespace, name, ok := telemetryv1alpha1.SplitNamespacedPipelineName(pipelineName); ok {
+		var namespacedPipeline telemetryv1alpha1.NamespacedLogPipeline
+		if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &namespacedPipeline); err != nil {
+			return nil, err
+		}
+		pipeline := namespacedPipeline.ToLogPipeline()
+		return &pipeline, nil
+	}
+
+	var pipeline telemetryv1alpha1.LogPipeline
+	if err := r.Get(ctx, types.NamespacedName{Name: pipelineName}, &pipeline); err != nil {
+		return nil, err
+	}
+	return &pipeline, nil
+}
+
+// listPipelines returns all LogPipelines and all converted NamespacedLogPipelines, which are rendered together into the Fluent Bit and log gateway configuration.
+func (r *Reconciler) listPipelines(ctx context.Context) ([]telemetryv1alpha1.LogPipeline, error) {
+	var pipelines telemetryv1alpha1.LogPipelineList
+	if err := r.List(ctx, &pipelines); err != nil {
+		return nil, err
+	}
+
+	var namespacedPipelines telemetryv1alpha1.NamespacedLogPipelineList
+	if err := r.List(ctx, &namespacedPipelines); err != nil {
+		return nil, err
+	}
+
+	allPipelines := pipelines.Items
+	for i := range namespacedPipelines.Items {
+		allPipelines = append(allPipelines, namespacedPipelines.Items[i].ToLogPipeline())
+	}
+	return allPipelines, nil
+}
+
+// withinLimit returns the pipelines that are within the pipeline limit. See SelectPipelines.
+func (r *Reconciler) withinLimit(allPipelines []telemetryv1alpha1.LogPipeline) []telemetryv1alpha1.LogPipeline {
+	selectedPipelines := SelectPipelines(allPipelines, r.config.MaxPipelines, r.config.MaxPipelinesPerNamespace)
+
+	var pipelines []telemetryv1alpha1.LogPipeline
+	for i := range allPipelines {
+		if slices.Contains(selectedPipelines, allPipelines[i].Name) {
+			pipelines = append(pipelines, allPipelines[i])
+		}
+	}
+	return pipelines
+}
+
+// SelectPipelines returns the names of the pipelines that are within the pipeline limit. The limit of LogPipelines is enforced by the webhook, so they are always selected.
+// Converted NamespacedLogPipelines are selected in the order of their creation, if they are within the limit of their Namespace and there are slots left.
+// Pipelines that are being deleted do not count towards the limit.
+func SelectPipelines(allPipelines []telemetryv1alpha1.LogPipeline, maxPipelines, maxPipelinesPerNamespace int) []string {
+	var selected []string
+	var namespacedCandidates []pipelinelimit.Pipeline
+	for i := range allPipelines {
+		if !allPipelines[i].GetDeletionTimestamp().IsZero() {
+			continue
+		}
+
+		if allPipelines[i].Namespace == "" {
+			selected = append(selected, allPipelines[i].Name)
+			continue
+		}
+
+		namespacedCandidates = append(namespacedCandidates, pipelinelimit.Pipeline{
+			Name:              allPipelines[i].Name,
+			Namespace:         allPipelines[i].Namespace,
+			CreationTimestamp: allPipelines[i].CreationTimestamp,
+		})
+	}
+
+	var remainingSlots int
+	if maxPipelines > 0 {
+		remainingSlots = maxPipelines - len(selected)
+		if remainingSlots <= 0 {
+			return selected
+		}
+	}
+
+	return append(selected, pipelinelimit.Select(pipelinelimit.SelectPerNamespace(namespacedCandidates, maxPipelinesPerNamespace), remainingSlots)...)
+}
+
+// ownerReferenceSetter returns a client that sets the pipeline as owner of the created resources.
+// A NamespacedLogPipeline cannot own the resources in the Fluent Bit and gateway Namespace, so they are created without an owner reference.
+func (r *Reconciler) ownerReferenceSetter(pipeline *telemetryv1alpha1.LogPipeline) client.Client {
+	if pipeline.Namespace != "" {
+		return r.Client
+	}
+	return kubernetes.NewOwnerReferenceSetter(r.Client, pipeline)
+}
+
 func (r *Reconciler) reconcileFluentBit(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline, pipelines []telemetryv1alpha1.LogPipeline) error {
-	ownerRefSetter := kubernetes.NewOwnerReferenceSetter(r.Client, pipeline)
+	ownerRefSetter := r.ownerReferenceSetter(pipeline)
 
 	serviceAccount := commonresources.MakeServiceAccount(r.config.DaemonSet)
 	if err := kubernetes.CreateOrUpdateServiceAccount(ctx, ownerRefSetter, serviceAccount); err != nil {
@@ -214,6 +349,167 @@ func (r *Reconciler) reconcileFluentBit(ctx context.Context, pipeline *telemetry
 	return nil
 }
 
+func (r *Reconciler) reconcileLogGateway(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline, pipelines []telemetryv1alpha1.LogPipeline) error {
+	var allOtlpPipelines []telemetryv1alpha1.LogPipeline
+	for i := range pipelines {
+		if pipelines[i].Spec.HasOtlpOutput() {
+			allOtlpPipelines = append(allOtlpPipelines, pipelines[i])
+		}
+	}
+	if len(allOtlpPipelines) == 0 {
+		logf.FromContext(ctx).V(1).Info("Deleting log gateway resources: no log pipeline with OTLP output left to deploy")
+		return otelcollector.DeleteGatewayResources(ctx, r.Client, &r.config.Gateway)
+	}
+
+	otlpPipelines := withoutSuspended(allOtlpPipelines)
+	if len(otlpPipelines) == 0 {
+		logf.FromContext(ctx).V(1).Info("Deleting log gateway deployment: all log pipelines with OTLP output are suspended")
+		return r.deleteLogGatewayDeployment(ctx)
+	}
+
+	scaling := otelcollector.GatewayScalingConfig{
+		Replicas:                       defaultGatewayReplicaCount,
+		ResourceRequirementsMultiplier: len(otlpPipelines),
+	}
+
+	collectorConfig, collectorEnvVars, err := gateway.MakeConfig(ctx, r.Client, otlpPipelines, gateway.BuildOptions{
+		Cluster: getClusterFromTelemetry(ctx, r.Client),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create collector config: %w", err)
+	}
+
+	collectorConfigYAML, err := yaml.Marshal(collectorConfig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal collector config: %w", err)
+	}
+
+	if err := otelcollector.ApplyGatewayResources(ctx,
+		r.ownerReferenceSetter(pipeline),
+		r.config.Gateway.WithScaling(scaling).
+			WithPersistentQueue(makePersistentQueue(collectorConfig.Extensions.FileStorage != nil)).
+			WithCollectorConfig(string(collectorConfigYAML), collectorEnvVars)); err != nil {
+		return fmt.Errorf("failed to apply gateway resources: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) reconcileLogAgent(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline, pipelines []telemetryv1alpha1.LogPipeline) error {
+	agentConfig := agent.MakeConfig(pipelines, agent.BuildOptions{
+		GatewayServiceName: types.NamespacedName{Name: r.config.Gateway.OTLPServiceName, Namespace: r.config.Gateway.Namespace},
+		AgentName:          types.NamespacedName{Name: r.config.Agent.BaseName, Namespace: r.config.Agent.Namespace},
+	})
+
+	agentConfigYAML, err := yaml.Marshal(agentConfig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal collector config: %w", err)
+	}
+
+	if err := otelcollector.ApplyLogAgentResources(ctx,
+		r.ownerReferenceSetter(pipeline),
+		r.config.Agent.WithCollectorConfig(string(agentConfigYAML))); err != nil {
+		return fmt.Errorf("failed to apply agent resources: %w", err)
+	}
+
+	return nil
+}
+
+// deleteLogAgent removes the log agent DaemonSet if no pipeline is served by it anymore, for example, after switching back to Fluent Bit.
+// Otherwise, the logs would be collected twice.
+func (r *Reconciler) deleteLogAgent(ctx context.Context) error {
+	daemonSet := appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: r.config.Agent.BaseName, Namespace: r.config.Agent.Namespace}}
+	return client.IgnoreNotFound(r.Delete(ctx, &daemonSet))
+}
+
+// deleteLogGatewayDeployment deletes the log gateway Deployment, so that no logs are shipped if all remaining pipelines with OTLP output are suspended.
+// The other gateway resources are kept, so that the gateway is restored with the next reconciliation once a pipeline is resumed.
+func (r *Reconciler) deleteLogGatewayDeployment(ctx context.Context) error {
+	deployment := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: r.config.Gateway.BaseName, Namespace: r.config.Gateway.Namespace}}
+	return client.IgnoreNotFound(r.Delete(ctx, &deployment))
+}
+
+// withoutSuspended returns the pipelines that are not suspended. Suspended pipelines are not rendered into any configuration,
+// but their Secrets are still synced into the Fluent Bit Secrets, so that they can be resumed without further changes.
+func withoutSuspended(pipelines []telemetryv1alpha1.LogPipeline) []telemetryv1alpha1.LogPipeline {
+	var active []telemetryv1alpha1.LogPipeline
+	for i := range pipelines {
+		if !pipelines[i].Spec.Suspend {
+			active = append(active, pipelines[i])
+		}
+	}
+	return active
+}
+
+// SplitPipelinesByAgent returns the pipelines that are served by Fluent Bit and the pipelines that are served by the OpenTelemetry log agent.
+func SplitPipelinesByAgent(pipelines []telemetryv1alpha1.LogPipeline, agentType operatorv1alpha1.LogAgentType) (fluentBitPipelines, otelAgentPipelines []telemetryv1alpha1.LogPipeline) {
+	for i := range pipelines {
+		if isServedByOTelAgent(&pipelines[i], agentType) {
+			otelAgentPipelines = append(otelAgentPipelines, pipelines[i])
+		} else {
+			fluentBitPipelines = append(fluentBitPipelines, pipelines[i])
+		}
+	}
+	return fluentBitPipelines, otelAgentPipelines
+}
+
+// isServedByOTelAgent returns true if the OpenTelemetry log agent is selected and the pipeline can be served without Fluent Bit,
+// which requires that all outputs are OTLP outputs and that neither custom filters nor the multiline and parse options are defined.
+func isServedByOTelAgent(pipeline *telemetryv1alpha1.LogPipeline, agentType operatorv1alpha1.LogAgentType) bool {
+	return agentType == operatorv1alpha1.OpenTelemetryLogAgentType &&
+		pipeline.Spec.HasOnlyOtlpOutputs() &&
+		len(pipeline.Spec.Filters) == 0 &&
+		pipeline.Spec.Input.Application.Multiline == nil &&
+		pipeline.Spec.Input.Application.Parse == nil
+}
+
+// makePersistentQueue returns the volume config for the persistent sending queues, or nil if no output uses a persistent queue.
+// The log gateway cannot be configured in the Telemetry resource, so the queues are always stored in an emptyDir volume.
+func makePersistentQueue(required bool) *otelcollector.PersistentQueueConfig {
+	if !required {
+		return nil
+	}
+	return &otelcollector.PersistentQueueConfig{}
+}
+
+// getClusterFromTelemetry returns the cluster name and attributes configured in the Telemetry resource, or nil if none are configured.
+func getClusterFromTelemetry(ctx context.Context, c client.Reader) *operatorv1alpha1.ClusterSpec {
+	var telemetries operatorv1alpha1.TelemetryList
+	if err := c.List(ctx, &telemetries); err != nil {
+		logf.FromContext(ctx).V(1).Error(err, "Failed to list telemetry: using default cluster name")
+		return nil
+	}
+	for i := range telemetries.Items {
+		if cluster := telemetries.Items[i].Spec.Cluster; cluster != nil {
+			return cluster
+		}
+	}
+	return nil
+}
+
+// getLogAgentFromTelemetry returns the log agent type configured in the Telemetry resource. It defaults to Fluent Bit.
+func getLogAgentFromTelemetry(ctx context.Context, c client.Reader) operatorv1alpha1.LogAgentType {
+	var telemetries operatorv1alpha1.TelemetryList
+	if err := c.List(ctx, &telemetries); err != nil {
+		logf.FromContext(ctx).V(1).Error(err, "Failed to list telemetry: using Fluent Bit log agent")
+		return operatorv1alpha1.FluentBitLogAgentType
+	}
+	for i := range telemetries.Items {
+		if agentType := LogAgent(&telemetries.Items[i]); agentType != operatorv1alpha1.FluentBitLogAgentType {
+			return agentType
+		}
+	}
+	return operatorv1alpha1.FluentBitLogAgentType
+}
+
+// LogAgent returns the log agent selected in the Telemetry resource. If the Telemetry resource is nil or does not select an agent, Fluent Bit is used.
+func LogAgent(telemetry *operatorv1alpha1.Telemetry) operatorv1alpha1.LogAgentType {
+	if telemetry != nil && telemetry.Spec.Log != nil && telemetry.Spec.Log.Agent != "" {
+		return telemetry.Spec.Log.Agent
+	}
+	return operatorv1alpha1.FluentBitLogAgentType
+}
+
 func (r *Reconciler) updateMetrics(ctx context.Context) error {
 	var allPipelines telemetryv1alpha1.LogPipelineList
 	if err := r.List(ctx, &allPipelines); err != nil {
@@ -3,14 +3,22 @@ package logpipeline
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
 )
 
 func TestGetDeployableLogPipelines(t *testing.T) {
@@ -125,3 +133,142 @@ func TestGetDeployableLogPipelines(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectPipelines(t *testing.T) {
+	newNamespacedPipeline := func(namespace, name string, age time.Duration) telemetryv1alpha1.LogPipeline {
+		namespacedPipeline := telemetryv1alpha1.NamespacedLogPipeline{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, CreationTimestamp: metav1.NewTime(time.Now().Add(-age))},
+		}
+		return namespacedPipeline.ToLogPipeline()
+	}
+	clusterPipeline1 := testutils.NewLogPipelineBuilder().WithName("cluster-1").Build()
+	clusterPipeline2 := testutils.NewLogPipelineBuilder().WithName("cluster-2").Build()
+	oldTenantPipeline := newNamespacedPipeline("team-a", "old", 2*time.Hour)
+	newTenantPipeline := newNamespacedPipeline("team-a", "new", time.Hour)
+	otherTenantPipeline := newNamespacedPipeline("team-b", "pipeline", time.Minute)
+	pipelines := []telemetryv1alpha1.LogPipeline{clusterPipeline1, clusterPipeline2, newTenantPipeline, oldTenantPipeline, otherTenantPipeline}
+
+	t.Run("selects all pipelines without limits", func(t *testing.T) {
+		require.ElementsMatch(t, []string{"cluster-1", "cluster-2", "team-a__old", "team-a__new", "team-b__pipeline"}, SelectPipelines(pipelines, 0, 0))
+	})
+
+	t.Run("selects the oldest namespaced pipelines within the limit of their namespace", func(t *testing.T) {
+		require.ElementsMatch(t, []string{"cluster-1", "cluster-2", "team-a__old", "team-b__pipeline"}, SelectPipelines(pipelines, 0, 1))
+	})
+
+	t.Run("fills only the slots left by cluster pipelines with namespaced pipelines", func(t *testing.T) {
+		require.ElementsMatch(t, []string{"cluster-1", "cluster-2", "team-a__old"}, SelectPipelines(pipelines, 3, 0))
+	})
+
+	t.Run("selects no namespaced pipeline if cluster pipelines use all slots", func(t *testing.T) {
+		require.ElementsMatch(t, []string{"cluster-1", "cluster-2"}, SelectPipelines(pipelines, 2, 0))
+	})
+}
+
+func TestSplitPipelinesByAgent(t *testing.T) {
+	otlpPipeline := testutils.NewLogPipelineBuilder().WithName("otlp").WithOtlpOutput("https://localhost").Build()
+	mixedPipeline := testutils.NewLogPipelineBuilder().WithName("mixed").WithOtlpOutput("https://localhost").
+		WithAdditionalOutput("stdout", telemetryv1alpha1.Output{Custom: "Name	stdout\n"}).Build()
+	customFilterPipeline := testutils.NewLogPipelineBuilder().WithName("custom-filter").WithOtlpOutput("https://localhost").Build()
+	customFilterPipeline.Spec.Filters = []telemetryv1alpha1.Filter{{Custom: "Name grep\n"}}
+	multilinePipeline := testutils.NewLogPipelineBuilder().WithName("multiline").WithOtlpOutput("https://localhost").Build()
+	multilinePipeline.Spec.Input.Application.Multiline = &telemetryv1alpha1.MultilineInput{
+		Presets: []telemetryv1alpha1.MultilinePreset{telemetryv1alpha1.MultilinePresetJava},
+	}
+	httpPipeline := telemetryv1alpha1.LogPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "http"},
+		Spec: telemetryv1alpha1.LogPipelineSpec{
+			Output: telemetryv1alpha1.Output{HTTP: &telemetryv1alpha1.HTTPOutput{Host: telemetryv1alpha1.ValueType{Value: "localhost"}}},
+		},
+	}
+	pipelines := []telemetryv1alpha1.LogPipeline{otlpPipeline, mixedPipeline, customFilterPipeline, multilinePipeline, httpPipeline}
+
+	t.Run("fluent bit serves all pipelines", func(t *testing.T) {
+		fluentBitPipelines, otelAgentPipelines := SplitPipelinesByAgent(pipelines, operatorv1alpha1.FluentBitLogAgentType)
+		require.Equal(t, pipelines, fluentBitPipelines)
+		require.Empty(t, otelAgentPipelines)
+	})
+
+	t.Run("otel agent serves pipelines with only otlp outputs and no custom filters or multiline and parse options", func(t *testing.T) {
+		fluentBitPipelines, otelAgentPipelines := SplitPipelinesByAgent(pipelines, operatorv1alpha1.OpenTelemetryLogAgentType)
+		require.Equal(t, []telemetryv1alpha1.LogPipeline{mixedPipeline, customFilterPipeline, multilinePipeline, httpPipeline}, fluentBitPipelines)
+		require.Equal(t, []telemetryv1alpha1.LogPipeline{otlpPipeline}, otelAgentPipelines)
+	})
+}
+
+func TestGetLogAgentFromTelemetry(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = operatorv1alpha1.AddToScheme(scheme)
+
+	t.Run("defaults to fluent bit", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		require.Equal(t, operatorv1alpha1.FluentBitLogAgentType, getLogAgentFromTelemetry(ctx, fakeClient))
+	})
+
+	t.Run("uses agent of telemetry resource", func(t *testing.T) {
+		telemetry := &operatorv1alpha1.Telemetry{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "kyma-system"},
+			Spec: operatorv1alpha1.TelemetrySpec{
+				Log: &operatorv1alpha1.LogSpec{Agent: operatorv1alpha1.OpenTelemetryLogAgentType},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(telemetry).Build()
+		require.Equal(t, operatorv1alpha1.OpenTelemetryLogAgentType, getLogAgentFromTelemetry(ctx, fakeClient))
+	})
+}
+
+func TestReconcileLogGateway(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = operatorv1alpha1.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+
+	otlpPipeline := testutils.NewLogPipelineBuilder().WithName("otlp").WithOtlpOutput("https://backend:4317").Build()
+	httpPipeline := testutils.NewLogPipelineBuilder().WithName("http").Build()
+	gatewayName := types.NamespacedName{Name: "telemetry-log-gateway", Namespace: "kyma-system"}
+
+	newReconciler := func() *Reconciler {
+		return &Reconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+			config: Config{
+				Gateway: otelcollector.GatewayConfig{
+					Config:          otelcollector.Config{BaseName: gatewayName.Name, Namespace: gatewayName.Namespace},
+					OTLPServiceName: "telemetry-otlp-logs",
+				},
+			},
+		}
+	}
+
+	t.Run("deploys the gateway for pipelines with OTLP output", func(t *testing.T) {
+		sut := newReconciler()
+		require.NoError(t, sut.reconcileLogGateway(ctx, &httpPipeline, []telemetryv1alpha1.LogPipeline{httpPipeline, otlpPipeline}))
+
+		require.NoError(t, sut.Get(ctx, gatewayName, &appsv1.Deployment{}))
+	})
+
+	t.Run("deletes the gateway resources if no pipeline with OTLP output is left", func(t *testing.T) {
+		sut := newReconciler()
+		require.NoError(t, sut.reconcileLogGateway(ctx, &otlpPipeline, []telemetryv1alpha1.LogPipeline{otlpPipeline}))
+
+		require.NoError(t, sut.reconcileLogGateway(ctx, &httpPipeline, []telemetryv1alpha1.LogPipeline{httpPipeline}))
+
+		require.True(t, apierrors.IsNotFound(sut.Get(ctx, gatewayName, &appsv1.Deployment{})))
+		require.True(t, apierrors.IsNotFound(sut.Get(ctx, gatewayName, &corev1.Secret{})))
+		require.True(t, apierrors.IsNotFound(sut.Get(ctx, types.NamespacedName{Name: "telemetry-otlp-logs", Namespace: gatewayName.Namespace}, &corev1.Service{})))
+	})
+
+	t.Run("deletes only the gateway deployment if all pipelines with OTLP output are suspended", func(t *testing.T) {
+		sut := newReconciler()
+		require.NoError(t, sut.reconcileLogGateway(ctx, &otlpPipeline, []telemetryv1alpha1.LogPipeline{otlpPipeline}))
+
+		suspendedPipeline := otlpPipeline.DeepCopy()
+		suspendedPipeline.Spec.Suspend = true
+		require.NoError(t, sut.reconcileLogGateway(ctx, suspendedPipeline, []telemetryv1alpha1.LogPipeline{*suspendedPipeline}))
+
+		require.True(t, apierrors.IsNotFound(sut.Get(ctx, gatewayName, &appsv1.Deployment{})))
+		require.NoError(t, sut.Get(ctx, gatewayName, &corev1.Secret{}))
+	})
+}
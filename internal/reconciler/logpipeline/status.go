@@ -3,10 +3,11 @@ package logpipeline
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"slices"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
@@ -18,13 +19,16 @@ func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string) erro
 	if err := r.updateStatusUnsupportedMode(ctx, pipelineName); err != nil {
 		return err
 	}
+	if err := r.updateStatusOutputs(ctx, pipelineName); err != nil {
+		return err
+	}
 	return r.updateStatusConditions(ctx, pipelineName)
 
 }
 
 func (r *Reconciler) updateStatusUnsupportedMode(ctx context.Context, pipelineName string) error {
-	var pipeline telemetryv1alpha1.LogPipeline
-	if err := r.Get(ctx, types.NamespacedName{Name: pipelineName}, &pipeline); err != nil {
+	pipeline, err := r.getPipeline(ctx, pipelineName)
+	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil
 		}
@@ -35,7 +39,7 @@ func (r *Reconciler) updateStatusUnsupportedMode(ctx context.Context, pipelineNa
 	desiredUnsupportedMode := pipeline.ContainsCustomPlugin()
 	if pipeline.Status.UnsupportedMode != desiredUnsupportedMode {
 		pipeline.Status.UnsupportedMode = desiredUnsupportedMode
-		if err := r.Status().Update(ctx, &pipeline); err != nil {
+		if err := r.updatePipelineStatus(ctx, pipeline); err != nil {
 			return fmt.Errorf("failed to update LogPipeline unsupported mode status: %v", err)
 		}
 	}
@@ -43,9 +47,42 @@ func (r *Reconciler) updateStatusUnsupportedMode(ctx context.Context, pipelineNa
 	return nil
 }
 
+func (r *Reconciler) updateStatusOutputs(ctx context.Context, pipelineName string) error {
+	pipeline, err := r.getPipeline(ctx, pipelineName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get LogPipeline: %v", err)
+	}
+
+	if pipeline.DeletionTimestamp != nil {
+		return nil
+	}
+
+	var desiredOutputs []telemetryv1alpha1.OutputStatus
+	for _, output := range pipeline.Spec.AllOutputs() {
+		reason := conditions.ReasonOutputReady
+		if secretref.ReferencesNonExistentSecret(ctx, r.Client, &output.Output) {
+			reason = conditions.ReasonReferencedSecretMissing
+		}
+		desiredOutputs = append(desiredOutputs, telemetryv1alpha1.OutputStatus{Name: output.Name, Reason: reason})
+	}
+
+	if !reflect.DeepEqual(pipeline.Status.Outputs, desiredOutputs) {
+		pipeline.Status.Outputs = desiredOutputs
+		if err := r.updatePipelineStatus(ctx, pipeline); err != nil {
+			return fmt.Errorf("failed to update LogPipeline outputs status: %v", err)
+		}
+	}
+
+	return nil
+}
+
 func (r *Reconciler) updateStatusConditions(ctx context.Context, pipelineName string) error {
-	var pipeline telemetryv1alpha1.LogPipeline
-	if err := r.Get(ctx, types.NamespacedName{Name: pipelineName}, &pipeline); err != nil {
+	pipeline, err := r.getPipeline(ctx, pipelineName)
+	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil
 		}
@@ -67,10 +104,45 @@ func (r *Reconciler) updateStatusConditions(ctx context.Context, pipelineName st
 			pipeline.Status.Conditions = []telemetryv1alpha1.LogPipelineCondition{}
 		}
 
-		return setCondition(ctx, r.Client, &pipeline, pending)
+		return r.setCondition(ctx, pipeline, pending)
+	}
+
+	if pipeline.Namespace != "" {
+		withinLimit, err := r.isWithinLimit(ctx, pipeline)
+		if err != nil {
+			return err
+		}
+
+		if !withinLimit {
+			pending := telemetryv1alpha1.NewLogPipelineCondition(conditions.ReasonMaxPipelinesExceeded, telemetryv1alpha1.LogPipelinePending)
+
+			if pipeline.Status.HasCondition(telemetryv1alpha1.LogPipelineRunning) {
+				log.V(1).Info(fmt.Sprintf("Updating the status of %s to %s. Resetting previous conditions", pipeline.Name, pending.Type))
+				pipeline.Status.Conditions = []telemetryv1alpha1.LogPipelineCondition{}
+			}
+
+			return r.setCondition(ctx, pipeline, pending)
+		}
+	}
+
+	if pipeline.Spec.Suspend {
+		if isSuspended(pipeline) {
+			return nil
+		}
+
+		suspended := telemetryv1alpha1.NewLogPipelineCondition(conditions.ReasonPipelineSuspended, telemetryv1alpha1.LogPipelineSuspended)
+		log.V(1).Info(fmt.Sprintf("Updating the status of %s to %s. Resetting previous conditions", pipeline.Name, suspended.Type))
+		pipeline.Status.Conditions = []telemetryv1alpha1.LogPipelineCondition{}
+
+		return r.setCondition(ctx, pipeline, suspended)
+	}
+
+	if pipeline.Status.HasCondition(telemetryv1alpha1.LogPipelineSuspended) {
+		log.V(1).Info(fmt.Sprintf("Resuming %s. Resetting previous conditions", pipeline.Name))
+		pipeline.Status.Conditions = []telemetryv1alpha1.LogPipelineCondition{}
 	}
 
-	referencesNonExistentSecret := secretref.ReferencesNonExistentSecret(ctx, r.Client, &pipeline)
+	referencesNonExistentSecret := secretref.ReferencesNonExistentSecret(ctx, r.Client, pipeline)
 	if referencesNonExistentSecret {
 		pending := telemetryv1alpha1.NewLogPipelineCondition(conditions.ReasonReferencedSecretMissing, telemetryv1alpha1.LogPipelinePending)
 
@@ -79,7 +151,11 @@ func (r *Reconciler) updateStatusConditions(ctx context.Context, pipelineName st
 			pipeline.Status.Conditions = []telemetryv1alpha1.LogPipelineCondition{}
 		}
 
-		return setCondition(ctx, r.Client, &pipeline, pending)
+		return r.setCondition(ctx, pipeline, pending)
+	}
+
+	if isServedByOTelAgent(pipeline, getLogAgentFromTelemetry(ctx, r.Client)) {
+		return r.updateLogAgentConditions(ctx, pipeline)
 	}
 
 	fluentBitReady, err := r.prober.IsReady(ctx, r.config.DaemonSet)
@@ -87,13 +163,17 @@ func (r *Reconciler) updateStatusConditions(ctx context.Context, pipelineName st
 		return err
 	}
 
+	if fluentBitReady && pipeline.Spec.HasOtlpOutput() {
+		return r.updateLogGatewayConditions(ctx, pipeline)
+	}
+
 	if fluentBitReady {
 		if pipeline.Status.HasCondition(telemetryv1alpha1.LogPipelineRunning) {
 			return nil
 		}
 
 		running := telemetryv1alpha1.NewLogPipelineCondition(conditions.ReasonFluentBitDSReady, telemetryv1alpha1.LogPipelineRunning)
-		return setCondition(ctx, r.Client, &pipeline, running)
+		return r.setCondition(ctx, pipeline, running)
 	}
 
 	pending := telemetryv1alpha1.NewLogPipelineCondition(conditions.ReasonFluentBitDSNotReady, telemetryv1alpha1.LogPipelinePending)
@@ -103,18 +183,91 @@ func (r *Reconciler) updateStatusConditions(ctx context.Context, pipelineName st
 		pipeline.Status.Conditions = []telemetryv1alpha1.LogPipelineCondition{}
 	}
 
-	return setCondition(ctx, r.Client, &pipeline, pending)
+	return r.setCondition(ctx, pipeline, pending)
+}
+
+// updateLogAgentConditions sets the conditions of a pipeline that is served by the OpenTelemetry log agent, which depends on the log agent and the log gateway.
+func (r *Reconciler) updateLogAgentConditions(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline) error {
+	agentReady, err := r.prober.IsReady(ctx, types.NamespacedName{Name: r.config.Agent.BaseName, Namespace: r.config.Agent.Namespace})
+	if err != nil {
+		return err
+	}
+
+	if agentReady {
+		return r.updateLogGatewayConditions(ctx, pipeline)
+	}
+
+	pending := telemetryv1alpha1.NewLogPipelineCondition(conditions.ReasonLogAgentDaemonSetNotReady, telemetryv1alpha1.LogPipelinePending)
+
+	if pipeline.Status.HasCondition(telemetryv1alpha1.LogPipelineRunning) {
+		logf.FromContext(ctx).V(1).Info(fmt.Sprintf("Updating the status of %s to %s. Resetting previous conditions", pipeline.Name, pending.Type))
+		pipeline.Status.Conditions = []telemetryv1alpha1.LogPipelineCondition{}
+	}
+
+	return r.setCondition(ctx, pipeline, pending)
+}
+
+// updateLogGatewayConditions sets the conditions of a pipeline with an OTLP output, which additionally depends on the log gateway.
+func (r *Reconciler) updateLogGatewayConditions(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline) error {
+	gatewayReady, err := r.gatewayProber.IsReady(ctx, types.NamespacedName{Name: r.config.Gateway.BaseName, Namespace: r.config.Gateway.Namespace})
+	if err != nil {
+		return err
+	}
+
+	if gatewayReady {
+		running := telemetryv1alpha1.NewLogPipelineCondition(conditions.ReasonLogGatewayDeploymentReady, telemetryv1alpha1.LogPipelineRunning)
+		return r.setCondition(ctx, pipeline, running)
+	}
+
+	pending := telemetryv1alpha1.NewLogPipelineCondition(conditions.ReasonLogGatewayDeploymentNotReady, telemetryv1alpha1.LogPipelinePending)
+
+	if pipeline.Status.HasCondition(telemetryv1alpha1.LogPipelineRunning) {
+		logf.FromContext(ctx).V(1).Info(fmt.Sprintf("Updating the status of %s to %s. Resetting previous conditions", pipeline.Name, pending.Type))
+		pipeline.Status.Conditions = []telemetryv1alpha1.LogPipelineCondition{}
+	}
+
+	return r.setCondition(ctx, pipeline, pending)
+}
+
+func isSuspended(pipeline *telemetryv1alpha1.LogPipeline) bool {
+	pipelineConditions := pipeline.Status.Conditions
+	return len(pipelineConditions) > 0 && pipelineConditions[len(pipelineConditions)-1].Type == telemetryv1alpha1.LogPipelineSuspended
 }
 
-func setCondition(ctx context.Context, client client.Client, pipeline *telemetryv1alpha1.LogPipeline, condition *telemetryv1alpha1.LogPipelineCondition) error {
+func (r *Reconciler) setCondition(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline, condition *telemetryv1alpha1.LogPipelineCondition) error {
 	log := logf.FromContext(ctx)
 
 	log.V(1).Info(fmt.Sprintf("Updating the status of %s to %s", pipeline.Name, condition.Type))
 
 	pipeline.Status.SetCondition(*condition)
 
-	if err := client.Status().Update(ctx, pipeline); err != nil {
+	if err := r.updatePipelineStatus(ctx, pipeline); err != nil {
 		return fmt.Errorf("failed to update LogPipeline status to %s: %v", condition.Type, err)
 	}
 	return nil
 }
+
+// isWithinLimit returns true if the pipeline is within the pipeline limit. See SelectPipelines.
+func (r *Reconciler) isWithinLimit(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline) (bool, error) {
+	allPipelines, err := r.listPipelines(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to list LogPipelines: %v", err)
+	}
+	return slices.Contains(SelectPipelines(allPipelines, r.config.MaxPipelines, r.config.MaxPipelinesPerNamespace), pipeline.Name), nil
+}
+
+// updatePipelineStatus persists the status of the pipeline. The status of a converted NamespacedLogPipeline is written to the NamespacedLogPipeline.
+func (r *Reconciler) updatePipelineStatus(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline) error {
+	namespace, name, ok := telemetryv1alpha1.SplitNamespacedPipelineName(pipeline.Name)
+	if !ok {
+		return r.Status().Update(ctx, pipeline)
+	}
+
+	var namespacedPipeline telemetryv1alpha1.NamespacedLogPipeline
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &namespacedPipeline); err != nil {
+		return err
+	}
+	namespacedPipeline.ResourceVersion = pipeline.ResourceVersion
+	namespacedPipeline.Status = pipeline.Status
+	return r.Status().Update(ctx, &namespacedPipeline)
+}
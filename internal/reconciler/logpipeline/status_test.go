@@ -3,6 +3,7 @@ package logpipeline
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -13,15 +14,18 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/conditions"
 	"github.com/kyma-project/telemetry-manager/internal/reconciler/logpipeline/mocks"
+	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
 )
 
 func TestUpdateStatus(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = telemetryv1alpha1.AddToScheme(scheme)
+	_ = operatorv1alpha1.AddToScheme(scheme)
 
 	t.Run("should add pending condition if some referenced secret does not exist", func(t *testing.T) {
 		pipelineName := "pipeline"
@@ -341,6 +345,45 @@ func TestUpdateStatus(t *testing.T) {
 		require.Equal(t, updatedPipeline.Status.Conditions[0].Reason, conditions.ReasonUnsupportedLokiOutput)
 	})
 
+	t.Run("should reset conditions and add suspended condition if pipeline is suspended", func(t *testing.T) {
+		pipelineName := "pipeline"
+		pipeline := &telemetryv1alpha1.LogPipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: pipelineName,
+			},
+			Status: telemetryv1alpha1.LogPipelineStatus{
+				Conditions: []telemetryv1alpha1.LogPipelineCondition{
+					{Reason: conditions.ReasonFluentBitDSNotReady, Type: telemetryv1alpha1.LogPipelinePending},
+					{Reason: conditions.ReasonFluentBitDSReady, Type: telemetryv1alpha1.LogPipelineRunning},
+				},
+			},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Suspend: true,
+				Output: telemetryv1alpha1.Output{
+					Custom: "Name	stdout\n",
+				}},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).WithStatusSubresource(pipeline).Build()
+
+		proberStub := &mocks.DaemonSetProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{DaemonSet: types.NamespacedName{Name: "fluent-bit"}},
+			prober: proberStub,
+		}
+
+		err := sut.updateStatus(context.Background(), pipeline.Name)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.LogPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipelineName}, &updatedPipeline)
+		require.Len(t, updatedPipeline.Status.Conditions, 1)
+		require.Equal(t, updatedPipeline.Status.Conditions[0].Type, telemetryv1alpha1.LogPipelineSuspended)
+		require.Equal(t, updatedPipeline.Status.Conditions[0].Reason, conditions.ReasonPipelineSuspended)
+	})
+
 	t.Run("should set status UnsupportedMode true if contains custom plugin", func(t *testing.T) {
 		pipelineName := "pipeline"
 		pipeline := &telemetryv1alpha1.LogPipeline{
@@ -405,4 +448,220 @@ func TestUpdateStatus(t *testing.T) {
 
 		require.False(t, updatedPipeline.Status.UnsupportedMode)
 	})
+
+	t.Run("should report the status of every output", func(t *testing.T) {
+		pipelineName := "pipeline"
+		pipeline := &telemetryv1alpha1.LogPipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: pipelineName,
+			},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Output: telemetryv1alpha1.Output{
+					HTTP: &telemetryv1alpha1.HTTPOutput{
+						Host: telemetryv1alpha1.ValueType{Value: "localhost"},
+					},
+				},
+				AdditionalOutputs: []telemetryv1alpha1.NamedOutput{
+					{
+						Name: "archive",
+						Output: telemetryv1alpha1.Output{
+							HTTP: &telemetryv1alpha1.HTTPOutput{
+								Host: telemetryv1alpha1.ValueType{
+									ValueFrom: &telemetryv1alpha1.ValueFromSource{
+										SecretKeyRef: &telemetryv1alpha1.SecretKeyRef{
+											Name:      "some-secret",
+											Namespace: "some-namespace",
+											Key:       "host",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).WithStatusSubresource(pipeline).Build()
+		proberStub := &mocks.DaemonSetProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{DaemonSet: types.NamespacedName{Name: "fluent-bit"}},
+			prober: proberStub,
+		}
+
+		err := sut.updateStatus(context.Background(), pipeline.Name)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.LogPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipelineName}, &updatedPipeline)
+
+		require.Equal(t, []telemetryv1alpha1.OutputStatus{
+			{Name: "default", Reason: conditions.ReasonOutputReady},
+			{Name: "archive", Reason: conditions.ReasonReferencedSecretMissing},
+		}, updatedPipeline.Status.Outputs)
+	})
+
+	t.Run("should add pending condition if otlp output is defined and log gateway is not ready", func(t *testing.T) {
+		pipelineName := "pipeline"
+		pipeline := &telemetryv1alpha1.LogPipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: pipelineName,
+			},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Output: telemetryv1alpha1.Output{
+					Otlp: &telemetryv1alpha1.OtlpOutput{
+						Endpoint: telemetryv1alpha1.ValueType{Value: "localhost"},
+					},
+				}},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).WithStatusSubresource(pipeline).Build()
+
+		proberStub := &mocks.DaemonSetProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		gatewayProberStub := &mocks.DeploymentProber{}
+		gatewayProberStub.On("IsReady", mock.Anything, mock.Anything).Return(false, nil)
+
+		sut := Reconciler{
+			Client:        fakeClient,
+			config:        Config{DaemonSet: types.NamespacedName{Name: "fluent-bit"}},
+			prober:        proberStub,
+			gatewayProber: gatewayProberStub,
+		}
+
+		err := sut.updateStatus(context.Background(), pipeline.Name)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.LogPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipelineName}, &updatedPipeline)
+		require.Len(t, updatedPipeline.Status.Conditions, 1)
+		require.Equal(t, updatedPipeline.Status.Conditions[0].Type, telemetryv1alpha1.LogPipelinePending)
+		require.Equal(t, updatedPipeline.Status.Conditions[0].Reason, conditions.ReasonLogGatewayDeploymentNotReady)
+	})
+
+	t.Run("should add running condition if otlp output is defined and log gateway is ready", func(t *testing.T) {
+		pipelineName := "pipeline"
+		pipeline := &telemetryv1alpha1.LogPipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: pipelineName,
+			},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Output: telemetryv1alpha1.Output{
+					Otlp: &telemetryv1alpha1.OtlpOutput{
+						Endpoint: telemetryv1alpha1.ValueType{Value: "localhost"},
+					},
+				}},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).WithStatusSubresource(pipeline).Build()
+
+		proberStub := &mocks.DaemonSetProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		gatewayProberStub := &mocks.DeploymentProber{}
+		gatewayProberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		sut := Reconciler{
+			Client:        fakeClient,
+			config:        Config{DaemonSet: types.NamespacedName{Name: "fluent-bit"}},
+			prober:        proberStub,
+			gatewayProber: gatewayProberStub,
+		}
+
+		err := sut.updateStatus(context.Background(), pipeline.Name)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.LogPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipelineName}, &updatedPipeline)
+		require.Len(t, updatedPipeline.Status.Conditions, 1)
+		require.Equal(t, updatedPipeline.Status.Conditions[0].Type, telemetryv1alpha1.LogPipelineRunning)
+		require.Equal(t, updatedPipeline.Status.Conditions[0].Reason, conditions.ReasonLogGatewayDeploymentReady)
+	})
+
+	t.Run("should add pending condition if pipeline is served by log agent and log agent is not ready", func(t *testing.T) {
+		pipelineName := "pipeline"
+		pipeline := &telemetryv1alpha1.LogPipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: pipelineName,
+			},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Output: telemetryv1alpha1.Output{
+					Otlp: &telemetryv1alpha1.OtlpOutput{
+						Endpoint: telemetryv1alpha1.ValueType{Value: "localhost"},
+					},
+				}},
+		}
+		telemetry := &operatorv1alpha1.Telemetry{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "kyma-system"},
+			Spec: operatorv1alpha1.TelemetrySpec{
+				Log: &operatorv1alpha1.LogSpec{Agent: operatorv1alpha1.OpenTelemetryLogAgentType},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline, telemetry).WithStatusSubresource(pipeline).Build()
+
+		proberStub := &mocks.DaemonSetProber{}
+		proberStub.On("IsReady", mock.Anything, types.NamespacedName{Name: "fluent-bit"}).Return(true, nil)
+		proberStub.On("IsReady", mock.Anything, types.NamespacedName{Name: "log-agent"}).Return(false, nil)
+
+		gatewayProberStub := &mocks.DeploymentProber{}
+		gatewayProberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{
+				DaemonSet: types.NamespacedName{Name: "fluent-bit"},
+				Agent:     otelcollector.AgentConfig{Config: otelcollector.Config{BaseName: "log-agent"}},
+			},
+			prober:        proberStub,
+			gatewayProber: gatewayProberStub,
+		}
+
+		err := sut.updateStatus(context.Background(), pipeline.Name)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.LogPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipelineName}, &updatedPipeline)
+		require.Len(t, updatedPipeline.Status.Conditions, 1)
+		require.Equal(t, updatedPipeline.Status.Conditions[0].Type, telemetryv1alpha1.LogPipelinePending)
+		require.Equal(t, updatedPipeline.Status.Conditions[0].Reason, conditions.ReasonLogAgentDaemonSetNotReady)
+	})
+
+	t.Run("should add pending condition to a namespaced pipeline if maximum pipeline count per namespace is exceeded", func(t *testing.T) {
+		newPipeline := func(name string, age time.Duration) *telemetryv1alpha1.NamespacedLogPipeline {
+			return &telemetryv1alpha1.NamespacedLogPipeline{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					Namespace:         "team-a",
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+				},
+				Spec: telemetryv1alpha1.NamespacedLogPipelineSpec{
+					Output: telemetryv1alpha1.NamespacedLogPipelineOutput{
+						Otlp: &telemetryv1alpha1.OtlpOutput{
+							Endpoint: telemetryv1alpha1.ValueType{Value: "localhost"},
+						},
+					}},
+			}
+		}
+		olderPipeline := newPipeline("older", time.Hour)
+		pipeline := newPipeline("pipeline", time.Minute)
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(olderPipeline, pipeline).WithStatusSubresource(olderPipeline, pipeline).Build()
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{
+				DaemonSet:                types.NamespacedName{Name: "fluent-bit"},
+				MaxPipelinesPerNamespace: 1,
+			},
+			prober: &mocks.DaemonSetProber{},
+		}
+
+		err := sut.updateStatus(context.Background(), telemetryv1alpha1.NamespacedPipelineName("team-a", "pipeline"))
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.NamespacedLogPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: "pipeline", Namespace: "team-a"}, &updatedPipeline)
+		require.Len(t, updatedPipeline.Status.Conditions, 1)
+		require.Equal(t, telemetryv1alpha1.LogPipelinePending, updatedPipeline.Status.Conditions[0].Type)
+		require.Equal(t, conditions.ReasonMaxPipelinesExceeded, updatedPipeline.Status.Conditions[0].Reason)
+	})
 }
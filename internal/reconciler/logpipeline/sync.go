@@ -6,6 +6,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -13,6 +14,7 @@ import (
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/fluentbit/config/builder"
 	utils "github.com/kyma-project/telemetry-manager/internal/kubernetes"
+	resources "github.com/kyma-project/telemetry-manager/internal/resources/fluentbit"
 	"github.com/kyma-project/telemetry-manager/internal/utils/envvar"
 )
 
@@ -21,13 +23,19 @@ type syncer struct {
 	config Config
 }
 
+// syncFluentBitConfig renders the sections and parsers of the deployable pipelines that are not suspended, and syncs the Secrets of all deployable pipelines.
 func (s *syncer) syncFluentBitConfig(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline, deployableLogPipelines []telemetryv1alpha1.LogPipeline) error {
 	log := logf.FromContext(ctx)
 
-	if err := s.syncSectionsConfigMap(ctx, pipeline, deployableLogPipelines); err != nil {
+	activeLogPipelines := withoutSuspended(deployableLogPipelines)
+	if err := s.syncSectionsConfigMap(ctx, pipeline, activeLogPipelines); err != nil {
 		return fmt.Errorf("failed to sync sections: %v", err)
 	}
 
+	if err := s.syncPipelineParsers(ctx, activeLogPipelines); err != nil {
+		return fmt.Errorf("failed to sync pipeline parsers: %v", err)
+	}
+
 	if err := s.syncFilesConfigMap(ctx, pipeline); err != nil {
 		return fmt.Errorf("failed to sync mounted files: %v", err)
 	}
@@ -62,7 +70,13 @@ func (s *syncer) syncSectionsConfigMap(ctx context.Context, pipeline *telemetryv
 	if !isLogPipelineDeployable(deployablePipelines, pipeline) {
 		delete(cm.Data, cmKey)
 	} else {
-		newConfig, err := builder.BuildFluentBitConfig(pipeline, s.config.PipelineDefaults)
+		defaults := s.config.PipelineDefaults
+		if cluster := getClusterFromTelemetry(ctx, s); cluster != nil {
+			defaults.ClusterName = cluster.Name
+			defaults.ClusterAttributes = cluster.Attributes
+		}
+
+		newConfig, err := builder.BuildFluentBitConfig(pipeline, defaults)
 		if err != nil {
 			return fmt.Errorf("unable to build section: %w", err)
 		}
@@ -72,7 +86,7 @@ func (s *syncer) syncSectionsConfigMap(ctx context.Context, pipeline *telemetryv
 			cm.Data[cmKey] = newConfig
 		}
 
-		if err = controllerutil.SetOwnerReference(pipeline, &cm, s.Scheme()); err != nil {
+		if err = s.setOwnerReference(pipeline, &cm); err != nil {
 			return fmt.Errorf("unable to set owner reference for section configmap: %w", err)
 		}
 	}
@@ -83,6 +97,30 @@ func (s *syncer) syncSectionsConfigMap(ctx context.Context, pipeline *telemetryv
 	return nil
 }
 
+// syncPipelineParsers renders the parsers for the multiline and parse options of all deployable pipelines into the parsers ConfigMap.
+// The ConfigMap is shared with the LogParser reconciler, so only the pipeline parsers key is touched.
+func (s *syncer) syncPipelineParsers(ctx context.Context, deployablePipelines []telemetryv1alpha1.LogPipeline) error {
+	cm, err := utils.GetOrCreateConfigMap(ctx, s, s.config.ParsersConfigMap)
+	if err != nil {
+		return fmt.Errorf("unable to get parsers configmap: %w", err)
+	}
+
+	newConfig := builder.BuildFluentBitPipelineParsersConfig(deployablePipelines)
+	if oldConfig, hasKey := cm.Data[resources.PipelineParsersConfigMapKey]; hasKey && oldConfig == newConfig {
+		return nil
+	}
+
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[resources.PipelineParsersConfigMapKey] = newConfig
+
+	if err = s.Update(ctx, &cm); err != nil {
+		return fmt.Errorf("unable to update parsers configmap: %w", err)
+	}
+	return nil
+}
+
 func (s *syncer) syncFilesConfigMap(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline) error {
 	cm, err := utils.GetOrCreateConfigMap(ctx, s, s.config.FilesConfigMap)
 	if err != nil {
@@ -102,7 +140,7 @@ func (s *syncer) syncFilesConfigMap(ctx context.Context, pipeline *telemetryv1al
 	}
 
 	if pipeline.DeletionTimestamp.IsZero() {
-		if err = controllerutil.SetOwnerReference(pipeline, &cm, s.Scheme()); err != nil {
+		if err = s.setOwnerReference(pipeline, &cm); err != nil {
 			return fmt.Errorf("unable to set owner reference for files configmap: %w", err)
 		}
 	}
@@ -144,7 +182,7 @@ func (s *syncer) syncEnvSecret(ctx context.Context, logPipelines []telemetryv1al
 			}
 		}
 
-		if err = controllerutil.SetOwnerReference(&logPipelines[i], &newSecret, s.Scheme()); err != nil {
+		if err = s.setOwnerReference(&logPipelines[i], &newSecret); err != nil {
 			return fmt.Errorf("unable to set owner reference for env secret: %w", err)
 		}
 	}
@@ -169,32 +207,40 @@ func (s *syncer) syncTLSConfigSecret(ctx context.Context, logPipelines []telemet
 			continue
 		}
 
-		output := logPipelines[i].Spec.Output
-		if !output.IsHTTPDefined() {
-			continue
-		}
+		hasTLSOutput := false
+		for _, output := range logPipelines[i].Spec.AllOutputs() {
+			tls := output.GetTLSConfig()
+			if tls == nil {
+				continue
+			}
+			hasTLSOutput = true
 
-		tls := output.HTTP.TLSConfig
-		if tls.CA.IsDefined() {
-			targetKey := fmt.Sprintf("%s-ca.crt", logPipelines[i].Name)
-			if err := s.copyFromValueOrSecret(ctx, *tls.CA, targetKey, newSecret.Data); err != nil {
-				return err
+			outputID := telemetryv1alpha1.OutputID(logPipelines[i].Name, output.Name)
+			if tls.CA.IsDefined() {
+				targetKey := fmt.Sprintf("%s-ca.crt", outputID)
+				if err := s.copyFromValueOrSecret(ctx, *tls.CA, targetKey, newSecret.Data); err != nil {
+					return err
+				}
 			}
-		}
-		if tls.Cert.IsDefined() {
-			targetKey := fmt.Sprintf("%s-cert.crt", logPipelines[i].Name)
-			if err := s.copyFromValueOrSecret(ctx, *tls.Cert, targetKey, newSecret.Data); err != nil {
-				return err
+			if tls.Cert.IsDefined() {
+				targetKey := fmt.Sprintf("%s-cert.crt", outputID)
+				if err := s.copyFromValueOrSecret(ctx, *tls.Cert, targetKey, newSecret.Data); err != nil {
+					return err
+				}
 			}
-		}
-		if tls.Key.IsDefined() {
-			targetKey := fmt.Sprintf("%s-key.key", logPipelines[i].Name)
-			if err := s.copyFromValueOrSecret(ctx, *tls.Key, targetKey, newSecret.Data); err != nil {
-				return err
+			if tls.Key.IsDefined() {
+				targetKey := fmt.Sprintf("%s-key.key", outputID)
+				if err := s.copyFromValueOrSecret(ctx, *tls.Key, targetKey, newSecret.Data); err != nil {
+					return err
+				}
 			}
 		}
 
-		if err = controllerutil.SetOwnerReference(&logPipelines[i], &newSecret, s.Scheme()); err != nil {
+		if !hasTLSOutput {
+			continue
+		}
+
+		if err = s.setOwnerReference(&logPipelines[i], &newSecret); err != nil {
 			return fmt.Errorf("unable to set owner reference for tls config secret: %w", err)
 		}
 	}
@@ -235,6 +281,15 @@ func (s *syncer) copySecretData(ctx context.Context, sourceRef telemetryv1alpha1
 		sourceRef.Namespace)
 }
 
+// setOwnerReference sets the pipeline as owner of the object.
+// A NamespacedLogPipeline cannot own objects in another Namespace, so no owner reference is set for a converted NamespacedLogPipeline.
+func (s *syncer) setOwnerReference(pipeline *telemetryv1alpha1.LogPipeline, object metav1.Object) error {
+	if pipeline.Namespace != "" {
+		return nil
+	}
+	return controllerutil.SetOwnerReference(pipeline, object, s.Scheme())
+}
+
 // isLogPipelineDeployable checks if logpipeline is ready to be rendered into the fluentbit configuration. A pipeline is deployable if it is not being deleted, all secret references exist, and is not above the pipeline limit.
 func isLogPipelineDeployable(allPipelines []telemetryv1alpha1.LogPipeline, logPipeline *telemetryv1alpha1.LogPipeline) bool {
 	for i := range allPipelines {
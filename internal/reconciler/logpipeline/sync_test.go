@@ -155,6 +155,70 @@ alias foo`,
 		require.NotContains(t, sectionsCm.Data, "noop.conf")
 	})
 
+	t.Run("should remove section of suspended pipeline", func(t *testing.T) {
+		config := testConfig
+		config.SectionsConfigMap = sectionsCmName
+		config.ParsersConfigMap = types.NamespacedName{Name: "parsers", Namespace: "telemetry-system"}
+		sut := syncer{fakeClient, config}
+
+		pipeline := &telemetryv1alpha1.LogPipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "suspended",
+			},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Output: telemetryv1alpha1.Output{
+					Custom: `
+name  null
+alias foo`,
+				},
+			},
+		}
+
+		err := sut.syncFluentBitConfig(context.Background(), pipeline, []telemetryv1alpha1.LogPipeline{*pipeline})
+		require.NoError(t, err)
+
+		var sectionsCm corev1.ConfigMap
+		err = fakeClient.Get(context.Background(), sectionsCmName, &sectionsCm)
+		require.NoError(t, err)
+		require.Contains(t, sectionsCm.Data, "suspended.conf")
+
+		pipeline.Spec.Suspend = true
+		err = sut.syncFluentBitConfig(context.Background(), pipeline, []telemetryv1alpha1.LogPipeline{*pipeline})
+		require.NoError(t, err)
+
+		err = fakeClient.Get(context.Background(), sectionsCmName, &sectionsCm)
+		require.NoError(t, err)
+		require.NotContains(t, sectionsCm.Data, "suspended.conf")
+	})
+
+	t.Run("should add section of namespaced pipeline without owner reference", func(t *testing.T) {
+		sut := syncer{fakeClient, Config{SectionsConfigMap: sectionsCmName}}
+
+		namespacedPipeline := &telemetryv1alpha1.NamespacedLogPipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tenant",
+				Namespace: "team-a",
+			},
+			Spec: telemetryv1alpha1.NamespacedLogPipelineSpec{
+				Output: telemetryv1alpha1.NamespacedLogPipelineOutput{
+					Otlp: &telemetryv1alpha1.OtlpOutput{Endpoint: telemetryv1alpha1.ValueType{Value: "https://localhost"}},
+				},
+			},
+		}
+		pipeline := namespacedPipeline.ToLogPipeline()
+		err := sut.syncSectionsConfigMap(context.Background(), &pipeline, []telemetryv1alpha1.LogPipeline{pipeline})
+		require.NoError(t, err)
+
+		var sectionsCm corev1.ConfigMap
+		err = fakeClient.Get(context.Background(), sectionsCmName, &sectionsCm)
+		require.NoError(t, err)
+		require.Contains(t, sectionsCm.Data, "team-a__tenant.conf")
+		require.Contains(t, sectionsCm.Data["team-a__tenant.conf"], "team-a")
+		for _, ownerReference := range sectionsCm.OwnerReferences {
+			require.NotEqual(t, "NamespacedLogPipeline", ownerReference.Kind)
+		}
+	})
+
 	t.Run("should fail if client fails", func(t *testing.T) {
 		badReqClient := &mocks.Client{}
 		badReqErr := errors.NewBadRequest("")
@@ -169,6 +233,53 @@ alias foo`,
 	})
 }
 
+func TestSyncPipelineParsers(t *testing.T) {
+	parsersCmName := types.NamespacedName{Name: "parsers", Namespace: "telemetry-system"}
+	fakeClient := fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      parsersCmName.Name,
+				Namespace: parsersCmName.Namespace,
+			},
+			Data: map[string]string{"parsers.conf": "[PARSER]\n    Name foo\n    Format json\n\n"},
+		}).Build()
+	sut := syncer{fakeClient, Config{ParsersConfigMap: parsersCmName}}
+
+	pipeline := telemetryv1alpha1.LogPipeline{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "json",
+		},
+		Spec: telemetryv1alpha1.LogPipelineSpec{
+			Input: telemetryv1alpha1.Input{Application: telemetryv1alpha1.ApplicationInput{
+				Parse: &telemetryv1alpha1.ParseInput{Format: telemetryv1alpha1.ParseFormatJSON},
+			}},
+		},
+	}
+
+	t.Run("should add pipeline parsers and keep log parsers", func(t *testing.T) {
+		err := sut.syncPipelineParsers(context.Background(), []telemetryv1alpha1.LogPipeline{pipeline})
+		require.NoError(t, err)
+
+		var parsersCm corev1.ConfigMap
+		err = fakeClient.Get(context.Background(), parsersCmName, &parsersCm)
+		require.NoError(t, err)
+		require.Equal(t, "[PARSER]\n    Name json-parse\n    Format json\n\n", parsersCm.Data[resources.PipelineParsersConfigMapKey])
+		require.Contains(t, parsersCm.Data["parsers.conf"], "Name foo")
+	})
+
+	t.Run("should clear pipeline parsers if no pipeline is deployable", func(t *testing.T) {
+		err := sut.syncPipelineParsers(context.Background(), nil)
+		require.NoError(t, err)
+
+		var parsersCm corev1.ConfigMap
+		err = fakeClient.Get(context.Background(), parsersCmName, &parsersCm)
+		require.NoError(t, err)
+		require.Contains(t, parsersCm.Data, resources.PipelineParsersConfigMapKey)
+		require.Empty(t, parsersCm.Data[resources.PipelineParsersConfigMapKey])
+		require.Contains(t, parsersCm.Data["parsers.conf"], "Name foo")
+	})
+}
+
 func TestSyncFilesConfigMap(t *testing.T) {
 	filesCmName := types.NamespacedName{Name: "files", Namespace: "telemetry-system"}
 	fakeClient := fake.NewClientBuilder().WithObjects(
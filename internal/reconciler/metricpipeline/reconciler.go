@@ -3,8 +3,11 @@ package metricpipeline
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"gopkg.in/yaml.v3"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -12,10 +15,14 @@ import (
 
 	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/flowhealth"
 	"github.com/kyma-project/telemetry-manager/internal/kubernetes"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/metric/agent"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/metric/cluster"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/metric/gateway"
 	"github.com/kyma-project/telemetry-manager/internal/overrides"
+	"github.com/kyma-project/telemetry-manager/internal/persistentqueue"
+	"github.com/kyma-project/telemetry-manager/internal/pipelinelimit"
 	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
 	"github.com/kyma-project/telemetry-manager/internal/secretref"
 )
@@ -24,6 +31,7 @@ const defaultReplicaCount int32 = 2
 
 type Config struct {
 	Agent                  otelcollector.AgentConfig
+	ClusterCollector       otelcollector.ClusterCollectorConfig
 	Gateway                otelcollector.GatewayConfig
 	OverridesConfigMapName types.NamespacedName
 	MaxPipelines           int
@@ -34,19 +42,26 @@ type DeploymentProber interface {
 	IsReady(ctx context.Context, name types.NamespacedName) (bool, error)
 }
 
+//go:generate mockery --name FlowHealthProber --filename flow_health_prober.go
+type FlowHealthProber interface {
+	Probe(ctx context.Context, exporterIDs []string) (flowhealth.ProbeResult, error)
+}
+
 type Reconciler struct {
 	client.Client
 	config             Config
 	prober             DeploymentProber
+	flowHealthProber   FlowHealthProber
 	overridesHandler   overrides.GlobalConfigHandler
 	istioStatusChecker istioStatusChecker
 }
 
-func NewReconciler(client client.Client, config Config, prober DeploymentProber, overridesHandler overrides.GlobalConfigHandler) *Reconciler {
+func NewReconciler(client client.Client, config Config, prober DeploymentProber, flowHealthProber FlowHealthProber, overridesHandler overrides.GlobalConfigHandler) *Reconciler {
 	return &Reconciler{
 		Client:             client,
 		config:             config,
 		prober:             prober,
+		flowHealthProber:   flowHealthProber,
 		overridesHandler:   overridesHandler,
 		istioStatusChecker: istioStatusChecker{client: client},
 	}
@@ -78,10 +93,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 func (r *Reconciler) doReconcile(ctx context.Context, pipeline *telemetryv1alpha1.MetricPipeline) error {
 	var err error
-	lockAcquired := true
+	withinLimit := true
 
 	defer func() {
-		if statusErr := r.updateStatus(ctx, pipeline.Name, lockAcquired); statusErr != nil {
+		if statusErr := r.updateStatus(ctx, pipeline.Name, withinLimit); statusErr != nil {
 			if err != nil {
 				err = fmt.Errorf("failed while updating status: %v: %v", statusErr, err)
 			} else {
@@ -90,46 +105,58 @@ func (r *Reconciler) doReconcile(ctx context.Context, pipeline *telemetryv1alpha
 		}
 	}()
 
-	lock := kubernetes.NewResourceCountLock(r.Client, types.NamespacedName{
-		Name:      "telemetry-metricpipeline-lock",
-		Namespace: r.config.Gateway.Namespace,
-	}, r.config.MaxPipelines)
-	if err = lock.TryAcquireLock(ctx, pipeline); err != nil {
-		lockAcquired = false
-		return err
-	}
-
 	var allPipelinesList telemetryv1alpha1.MetricPipelineList
 	if err = r.List(ctx, &allPipelinesList); err != nil {
 		return fmt.Errorf("failed to list metric pipelines: %w", err)
 	}
-	deployablePipelines, err := getDeployableMetricPipelines(ctx, allPipelinesList.Items, r, lock)
-	if err != nil {
-		return fmt.Errorf("failed to fetch deployable metric pipelines: %w", err)
+
+	if pipeline.DeletionTimestamp.IsZero() && !slices.Contains(SelectPipelines(allPipelinesList.Items, r.config.MaxPipelines), pipeline.Name) {
+		withinLimit = false
+		logf.FromContext(ctx).V(1).Info("Skipping reconciliation: maximum pipeline count limit exceeded")
+		return nil
 	}
+
+	deployablePipelines := getDeployableMetricPipelines(ctx, allPipelinesList.Items, r, r.config.MaxPipelines)
 	if len(deployablePipelines) == 0 {
 		logf.FromContext(ctx).V(1).Info("Skipping reconciliation: no metric pipeline ready for deployment")
 		return nil
 	}
 
+	if len(withoutSuspended(deployablePipelines)) == 0 {
+		logf.FromContext(ctx).V(1).Info("Deleting metric gateway deployment: all metric pipelines are suspended")
+		if err = r.deleteGatewayDeployment(ctx); err != nil {
+			return fmt.Errorf("failed to delete metric gateway deployment: %w", err)
+		}
+		return nil
+	}
+
 	if err = r.reconcileMetricGateway(ctx, pipeline, deployablePipelines); err != nil {
 		return fmt.Errorf("failed to reconcile metric gateway: %w", err)
 	}
 
-	if isMetricAgentRequired(pipeline) {
+	if IsMetricAgentRequired(pipeline) {
 		if err = r.reconcileMetricAgents(ctx, pipeline, allPipelinesList.Items); err != nil {
 			return fmt.Errorf("failed to reconcile metric agents: %w", err)
 		}
 	}
 
+	if isMetricClusterCollectorRequired(pipeline) {
+		if err = r.reconcileMetricClusterCollector(ctx, pipeline, allPipelinesList.Items); err != nil {
+			return fmt.Errorf("failed to reconcile metric cluster collector: %w", err)
+		}
+	}
+
 	return nil
 }
 
-// getDeployableMetricPipelines returns the list of metric pipelines that are ready to be rendered into the otel collector configuration. A pipeline is deployable if it is not being deleted, all secret references exist, and is not above the pipeline limit.
-func getDeployableMetricPipelines(ctx context.Context, allPipelines []telemetryv1alpha1.MetricPipeline, client client.Client, lock *kubernetes.ResourceCountLock) ([]telemetryv1alpha1.MetricPipeline, error) {
+// getDeployableMetricPipelines returns the list of metric pipelines that are ready to be rendered into the otel collector configuration. A pipeline is deployable if it is not being deleted, all secret references exist, its Prometheus outputs do not conflict with other pipelines, and is not above the pipeline limit.
+// A suspended pipeline is deployable, so that it keeps its slot within the pipeline limit and the ports of its Prometheus outputs, and its Secrets are synced, but it is not rendered.
+func getDeployableMetricPipelines(ctx context.Context, allPipelines []telemetryv1alpha1.MetricPipeline, client client.Client, maxPipelines int) []telemetryv1alpha1.MetricPipeline {
+	selectedPipelines := SelectPipelines(allPipelines, maxPipelines)
+
 	var deployablePipelines []telemetryv1alpha1.MetricPipeline
 	for i := range allPipelines {
-		if !allPipelines[i].GetDeletionTimestamp().IsZero() {
+		if !slices.Contains(selectedPipelines, allPipelines[i].Name) {
 			continue
 		}
 
@@ -137,33 +164,108 @@ func getDeployableMetricPipelines(ctx context.Context, allPipelines []telemetryv
 			continue
 		}
 
-		hasLock, err := lock.IsLockHolder(ctx, &allPipelines[i])
-		if err != nil {
-			return nil, err
+		if len(conflictingPrometheusOutputs(&allPipelines[i], allPipelines)) > 0 {
+			continue
+		}
+
+		deployablePipelines = append(deployablePipelines, allPipelines[i])
+	}
+	return deployablePipelines
+}
+
+// SelectPipelines returns the names of the pipelines that are within the pipeline limit, ranked by their priority and age.
+// Pipelines that are being deleted do not count towards the limit.
+func SelectPipelines(allPipelines []telemetryv1alpha1.MetricPipeline, maxPipelines int) []string {
+	var candidates []pipelinelimit.Pipeline
+	for i := range allPipelines {
+		if !allPipelines[i].GetDeletionTimestamp().IsZero() {
+			continue
+		}
+
+		candidates = append(candidates, pipelinelimit.Pipeline{
+			Name:              allPipelines[i].Name,
+			Priority:          allPipelines[i].Spec.Priority,
+			CreationTimestamp: allPipelines[i].CreationTimestamp,
+		})
+	}
+	return pipelinelimit.Select(candidates, maxPipelines)
+}
+
+// conflictingPrometheusOutputs returns the names of the Prometheus outputs of the pipeline that use a port that is already used by an older pipeline.
+// The older pipeline keeps the port, so that a new pipeline cannot take over the port of a running one.
+func conflictingPrometheusOutputs(pipeline *telemetryv1alpha1.MetricPipeline, allPipelines []telemetryv1alpha1.MetricPipeline) []string {
+	var olderPipelines []telemetryv1alpha1.MetricPipeline
+	for i := range allPipelines {
+		if allPipelines[i].Name != pipeline.Name && isOlder(&allPipelines[i], pipeline) {
+			olderPipelines = append(olderPipelines, allPipelines[i])
+		}
+	}
+
+	usedPorts := gateway.PrometheusPorts(olderPipelines)
+
+	var conflicting []string
+	for _, output := range pipeline.Spec.AllOutputs() {
+		if output.Prometheus != nil && slices.Contains(usedPorts, output.Prometheus.Port) {
+			conflicting = append(conflicting, output.Name)
 		}
+	}
+	return conflicting
+}
+
+func isOlder(pipeline, other *telemetryv1alpha1.MetricPipeline) bool {
+	if !pipeline.CreationTimestamp.Equal(&other.CreationTimestamp) {
+		return pipeline.CreationTimestamp.Before(&other.CreationTimestamp)
+	}
+	return pipeline.Name < other.Name
+}
 
-		if hasLock {
-			deployablePipelines = append(deployablePipelines, allPipelines[i])
+// deleteGatewayDeployment deletes the gateway Deployment, so that no metrics are shipped if all remaining pipelines are suspended.
+// The other gateway resources are kept, so that the gateway is restored with the next reconciliation once a pipeline is resumed.
+func (r *Reconciler) deleteGatewayDeployment(ctx context.Context) error {
+	deployment := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: r.config.Gateway.BaseName, Namespace: r.config.Gateway.Namespace}}
+	return client.IgnoreNotFound(r.Delete(ctx, &deployment))
+}
+
+// withoutSuspended returns the pipelines that are not suspended. Suspended pipelines are not rendered into the gateway configuration,
+// but their Secrets are still synced into the gateway Secret, so that they can be resumed without further changes.
+func withoutSuspended(pipelines []telemetryv1alpha1.MetricPipeline) []telemetryv1alpha1.MetricPipeline {
+	var active []telemetryv1alpha1.MetricPipeline
+	for i := range pipelines {
+		if !pipelines[i].Spec.Suspend {
+			active = append(active, pipelines[i])
 		}
 	}
-	return deployablePipelines, nil
+	return active
 }
 
-func isMetricAgentRequired(pipeline *telemetryv1alpha1.MetricPipeline) bool {
+func IsMetricAgentRequired(pipeline *telemetryv1alpha1.MetricPipeline) bool {
 	return pipeline.Spec.Input.Application.Runtime.Enabled || pipeline.Spec.Input.Application.Prometheus.Enabled || pipeline.Spec.Input.Application.Istio.Enabled
 }
 
+func isMetricClusterCollectorRequired(pipeline *telemetryv1alpha1.MetricPipeline) bool {
+	return pipeline.Spec.Input.Application.Cluster.Enabled
+}
+
+// reconcileMetricGateway renders the pipelines that are not suspended into the gateway configuration, and syncs the Secrets of all given pipelines into the gateway Secret.
 func (r *Reconciler) reconcileMetricGateway(ctx context.Context, pipeline *telemetryv1alpha1.MetricPipeline, allPipelines []telemetryv1alpha1.MetricPipeline) error {
-	scaling := otelcollector.GatewayScalingConfig{
-		Replicas:                       r.getReplicaCountFromTelemetry(ctx),
-		ResourceRequirementsMultiplier: len(allPipelines),
-	}
+	activePipelines := withoutSuspended(allPipelines)
+
+	scaling := r.getScalingFromTelemetry(ctx)
+	scaling.ResourceRequirementsMultiplier = len(activePipelines)
 
-	collectorConfig, collectorEnvVars, err := gateway.MakeConfig(ctx, r.Client, allPipelines)
+	collectorConfig, _, err := gateway.MakeConfig(ctx, r.Client, activePipelines, gateway.BuildOptions{
+		Enrichments: r.getEnrichmentsFromTelemetry(ctx),
+		Cluster:     r.getClusterFromTelemetry(ctx),
+	})
 	if err != nil {
 		return fmt.Errorf("failed to create collector config: %w", err)
 	}
 
+	collectorEnvVars, err := gateway.MakeEnvVars(ctx, r.Client, allPipelines)
+	if err != nil {
+		return fmt.Errorf("failed to create collector env vars: %w", err)
+	}
+
 	collectorConfigYAML, err := yaml.Marshal(collectorConfig)
 	if err != nil {
 		return fmt.Errorf("failed to marshal collector config: %w", err)
@@ -171,7 +273,10 @@ func (r *Reconciler) reconcileMetricGateway(ctx context.Context, pipeline *telem
 
 	if err := otelcollector.ApplyGatewayResources(ctx,
 		kubernetes.NewOwnerReferenceSetter(r.Client, pipeline),
-		r.config.Gateway.WithScaling(scaling).WithCollectorConfig(string(collectorConfigYAML), collectorEnvVars)); err != nil {
+		r.config.Gateway.WithScaling(scaling).
+			WithPersistentQueue(r.getPersistentQueue(ctx, collectorConfig.Extensions.FileStorage != nil)).
+			WithPrometheusPorts(gateway.PrometheusPorts(activePipelines)).
+			WithCollectorConfig(string(collectorConfigYAML), collectorEnvVars)); err != nil {
 		return fmt.Errorf("failed to apply gateway resources: %w", err)
 	}
 
@@ -199,11 +304,33 @@ func (r *Reconciler) reconcileMetricAgents(ctx context.Context, pipeline *teleme
 	return nil
 }
 
-func (r *Reconciler) getReplicaCountFromTelemetry(ctx context.Context) int32 {
+func (r *Reconciler) reconcileMetricClusterCollector(ctx context.Context, pipeline *telemetryv1alpha1.MetricPipeline, allPipelines []telemetryv1alpha1.MetricPipeline) error {
+	clusterCollectorConfig := cluster.MakeConfig(types.NamespacedName{
+		Namespace: r.config.Gateway.Namespace,
+		Name:      r.config.Gateway.OTLPServiceName,
+	}, allPipelines)
+
+	clusterCollectorConfigYAML, err := yaml.Marshal(clusterCollectorConfig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal collector config: %w", err)
+	}
+
+	if err := otelcollector.ApplyClusterCollectorResources(ctx,
+		kubernetes.NewOwnerReferenceSetter(r.Client, pipeline),
+		r.config.ClusterCollector.WithCollectorConfig(string(clusterCollectorConfigYAML))); err != nil {
+		return fmt.Errorf("failed to apply cluster collector resources: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) getScalingFromTelemetry(ctx context.Context) otelcollector.GatewayScalingConfig {
+	defaultScaling := otelcollector.GatewayScalingConfig{Replicas: defaultReplicaCount}
+
 	var telemetries operatorv1alpha1.TelemetryList
 	if err := r.List(ctx, &telemetries); err != nil {
 		logf.FromContext(ctx).V(1).Error(err, "Failed to list telemetry: using default scaling")
-		return defaultReplicaCount
+		return defaultScaling
 	}
 	for i := range telemetries.Items {
 		telemetrySpec := telemetries.Items[i].Spec
@@ -212,14 +339,68 @@ func (r *Reconciler) getReplicaCountFromTelemetry(ctx context.Context) int32 {
 		}
 
 		scaling := telemetrySpec.Metric.Gateway.Scaling
-		if scaling.Type != operatorv1alpha1.StaticScalingStrategyType {
-			continue
+		switch scaling.Type {
+		case operatorv1alpha1.StaticScalingStrategyType:
+			static := scaling.Static
+			if static != nil && static.Replicas > 0 {
+				return otelcollector.GatewayScalingConfig{Replicas: static.Replicas}
+			}
+		case operatorv1alpha1.AutoscalingStrategyType:
+			var autoscaling operatorv1alpha1.AutoscalingScaling
+			if scaling.Autoscaling != nil {
+				autoscaling = *scaling.Autoscaling
+			}
+			return otelcollector.GatewayScalingConfig{
+				Autoscaling: otelcollector.NewGatewayAutoscalingConfig(autoscaling.MinReplicas, autoscaling.MaxReplicas,
+					autoscaling.TargetCPUUtilizationPercentage, autoscaling.TargetMemoryUtilizationPercentage),
+			}
 		}
+	}
+	return defaultScaling
+}
 
-		static := scaling.Static
-		if static != nil && static.Replicas > 0 {
-			return static.Replicas
+// getPersistentQueue returns the volume config for the persistent sending queues, or nil if no output uses a persistent queue.
+// The PersistentVolumeClaim configured in the Telemetry resource is only used if the gateway runs with one static replica.
+func (r *Reconciler) getPersistentQueue(ctx context.Context, required bool) *otelcollector.PersistentQueueConfig {
+	var telemetries operatorv1alpha1.TelemetryList
+	if err := r.List(ctx, &telemetries); err != nil {
+		logf.FromContext(ctx).V(1).Error(err, "Failed to list telemetry: using emptyDir volume for persistent queue")
+		return persistentqueue.MakeConfig(required, nil, operatorv1alpha1.Scaling{})
+	}
+	for i := range telemetries.Items {
+		if metricSpec := telemetries.Items[i].Spec.Metric; metricSpec != nil && metricSpec.Gateway.PersistentQueue != nil {
+			return persistentqueue.MakeConfig(required, metricSpec.Gateway.PersistentQueue, metricSpec.Gateway.Scaling)
+		}
+	}
+	return persistentqueue.MakeConfig(required, nil, operatorv1alpha1.Scaling{})
+}
+
+// getEnrichmentsFromTelemetry returns the additional Kubernetes metadata configured in the Telemetry resource, or nil if none is configured.
+func (r *Reconciler) getEnrichmentsFromTelemetry(ctx context.Context) *operatorv1alpha1.EnrichmentSpec {
+	var telemetries operatorv1alpha1.TelemetryList
+	if err := r.List(ctx, &telemetries); err != nil {
+		logf.FromContext(ctx).V(1).Error(err, "Failed to list telemetry: using default enrichments")
+		return nil
+	}
+	for i := range telemetries.Items {
+		if enrichments := telemetries.Items[i].Spec.Enrichments; enrichments != nil {
+			return enrichments
 		}
 	}
-	return defaultReplicaCount
+	return nil
+}
+
+// getClusterFromTelemetry returns the cluster name and attributes configured in the Telemetry resource, or nil if none are configured.
+func (r *Reconciler) getClusterFromTelemetry(ctx context.Context) *operatorv1alpha1.ClusterSpec {
+	var telemetries operatorv1alpha1.TelemetryList
+	if err := r.List(ctx, &telemetries); err != nil {
+		logf.FromContext(ctx).V(1).Error(err, "Failed to list telemetry: using default cluster name")
+		return nil
+	}
+	for i := range telemetries.Items {
+		if cluster := telemetries.Items[i].Spec.Cluster; cluster != nil {
+			return cluster
+		}
+	}
+	return nil
 }
@@ -3,24 +3,18 @@ package metricpipeline
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
-	"github.com/kyma-project/telemetry-manager/internal/kubernetes"
 )
 
 var (
-	lockName = types.NamespacedName{
-		Name:      "lock",
-		Namespace: "default",
-	}
-
 	pipeline1 = telemetryv1alpha1.MetricPipeline{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "pipeline-1",
@@ -79,14 +73,9 @@ func TestGetDeployableMetricPipelines(t *testing.T) {
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = telemetryv1alpha1.AddToScheme(scheme)
 	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
-	l := kubernetes.NewResourceCountLock(fakeClient, lockName, 2)
-
-	err := l.TryAcquireLock(ctx, &pipeline1)
-	require.NoError(t, err)
 
 	pipelines := []telemetryv1alpha1.MetricPipeline{pipeline1}
-	deployablePipelines, err := getDeployableMetricPipelines(ctx, pipelines, fakeClient, l)
-	require.NoError(t, err)
+	deployablePipelines := getDeployableMetricPipelines(ctx, pipelines, fakeClient, 2)
 	require.Contains(t, deployablePipelines, pipeline1)
 }
 
@@ -96,69 +85,130 @@ func TestMultipleGetDeployableMetricPipelines(t *testing.T) {
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = telemetryv1alpha1.AddToScheme(scheme)
 	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
-	l := kubernetes.NewResourceCountLock(fakeClient, lockName, 2)
-
-	err := l.TryAcquireLock(ctx, &pipeline1)
-	require.NoError(t, err)
-
-	err = l.TryAcquireLock(ctx, &pipeline2)
-	require.NoError(t, err)
 
 	pipelines := []telemetryv1alpha1.MetricPipeline{pipeline1, pipeline2}
-	deployablePipelines, err := getDeployableMetricPipelines(ctx, pipelines, fakeClient, l)
-	require.NoError(t, err)
+	deployablePipelines := getDeployableMetricPipelines(ctx, pipelines, fakeClient, 2)
 	require.Contains(t, deployablePipelines, pipeline1)
 	require.Contains(t, deployablePipelines, pipeline2)
 }
 
-func TestMultipleGetDeployableMetricPipelinesWithoutLock(t *testing.T) {
+func TestMultipleGetDeployableMetricPipelinesAboveLimit(t *testing.T) {
 	ctx := context.Background()
 	scheme := runtime.NewScheme()
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = telemetryv1alpha1.AddToScheme(scheme)
 	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
-	l := kubernetes.NewResourceCountLock(fakeClient, lockName, 2)
-
-	err := l.TryAcquireLock(ctx, &pipeline1)
-	require.NoError(t, err)
 
 	pipelines := []telemetryv1alpha1.MetricPipeline{pipeline1, pipeline2}
-	deployablePipelines, err := getDeployableMetricPipelines(ctx, pipelines, fakeClient, l)
-	require.NoError(t, err)
+	deployablePipelines := getDeployableMetricPipelines(ctx, pipelines, fakeClient, 1)
 	require.Contains(t, deployablePipelines, pipeline1)
 	require.NotContains(t, deployablePipelines, pipeline2)
 }
 
-func TestGetDeployableMetricPipelinesWithMissingSecretReference(t *testing.T) {
+func TestGetDeployableMetricPipelinesPrefersHigherPriority(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	highPriorityPipeline := pipeline2.DeepCopy()
+	highPriorityPipeline.Spec.Priority = 10
+
+	pipelines := []telemetryv1alpha1.MetricPipeline{pipeline1, *highPriorityPipeline}
+	deployablePipelines := getDeployableMetricPipelines(ctx, pipelines, fakeClient, 1)
+	require.NotContains(t, deployablePipelines, pipeline1)
+	require.Contains(t, deployablePipelines, *highPriorityPipeline)
+}
+
+func TestGetDeployableMetricPipelinesIgnoresDeletedPipelines(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	deletedPipeline := pipeline1.DeepCopy()
+	deletedPipeline.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	pipelines := []telemetryv1alpha1.MetricPipeline{*deletedPipeline, pipeline2}
+	deployablePipelines := getDeployableMetricPipelines(ctx, pipelines, fakeClient, 1)
+	require.NotContains(t, deployablePipelines, *deletedPipeline)
+	require.Contains(t, deployablePipelines, pipeline2)
+}
+
+func TestGetDeployableMetricPipelinesIncludesSuspendedPipelines(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	suspendedPipeline := pipeline1.DeepCopy()
+	suspendedPipeline.Spec.Suspend = true
+
+	pipelines := []telemetryv1alpha1.MetricPipeline{*suspendedPipeline, pipeline2}
+	deployablePipelines := getDeployableMetricPipelines(ctx, pipelines, fakeClient, 2)
+	require.Contains(t, deployablePipelines, *suspendedPipeline)
+	require.Contains(t, deployablePipelines, pipeline2)
+	require.Equal(t, []telemetryv1alpha1.MetricPipeline{pipeline2}, withoutSuspended(deployablePipelines))
+}
+
+func TestGetDeployableMetricPipelinesSuspendedPipelineKeepsSlot(t *testing.T) {
 	ctx := context.Background()
 	scheme := runtime.NewScheme()
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = telemetryv1alpha1.AddToScheme(scheme)
 	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
-	l := kubernetes.NewResourceCountLock(fakeClient, lockName, 2)
 
-	err := l.TryAcquireLock(ctx, &pipelineWithSecretRef)
-	require.NoError(t, err)
+	suspendedPipeline := pipeline1.DeepCopy()
+	suspendedPipeline.Spec.Suspend = true
+
+	pipelines := []telemetryv1alpha1.MetricPipeline{*suspendedPipeline, pipeline2}
+	deployablePipelines := getDeployableMetricPipelines(ctx, pipelines, fakeClient, 1)
+	require.Equal(t, []telemetryv1alpha1.MetricPipeline{*suspendedPipeline}, deployablePipelines)
+}
+
+func TestGetDeployableMetricPipelinesWithMissingSecretReference(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
 
 	pipelines := []telemetryv1alpha1.MetricPipeline{pipelineWithSecretRef}
-	deployablePipelines, err := getDeployableMetricPipelines(ctx, pipelines, fakeClient, l)
-	require.NoError(t, err)
+	deployablePipelines := getDeployableMetricPipelines(ctx, pipelines, fakeClient, 2)
 	require.NotContains(t, deployablePipelines, pipelineWithSecretRef)
 }
 
-func TestGetDeployableMetricPipelinesWithoutLock(t *testing.T) {
+func TestGetDeployableMetricPipelinesWithPrometheusPortConflict(t *testing.T) {
 	ctx := context.Background()
 	scheme := runtime.NewScheme()
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = telemetryv1alpha1.AddToScheme(scheme)
 	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
-	l := kubernetes.NewResourceCountLock(fakeClient, lockName, 2)
 
-	err := l.TryAcquireLock(ctx, &pipelineWithSecretRef)
-	require.NoError(t, err)
+	older := newPipelineWithPrometheusOutput("older", 8889, time.Now())
+	newer := newPipelineWithPrometheusOutput("newer", 8889, time.Now().Add(time.Minute))
+	other := newPipelineWithPrometheusOutput("other", 8890, time.Now().Add(time.Minute))
 
-	pipelines := []telemetryv1alpha1.MetricPipeline{pipeline1}
-	deployablePipelines, err := getDeployableMetricPipelines(ctx, pipelines, fakeClient, l)
-	require.NoError(t, err)
-	require.NotContains(t, deployablePipelines, pipeline1)
+	deployablePipelines := getDeployableMetricPipelines(ctx, []telemetryv1alpha1.MetricPipeline{newer, older, other}, fakeClient, 3)
+	require.ElementsMatch(t, []telemetryv1alpha1.MetricPipeline{older, other}, deployablePipelines)
+
+	require.Empty(t, conflictingPrometheusOutputs(&older, []telemetryv1alpha1.MetricPipeline{newer, older, other}))
+	require.Equal(t, []string{"default"}, conflictingPrometheusOutputs(&newer, []telemetryv1alpha1.MetricPipeline{newer, older, other}))
+}
+
+func newPipelineWithPrometheusOutput(name string, port int32, creationTimestamp time.Time) telemetryv1alpha1.MetricPipeline {
+	return telemetryv1alpha1.MetricPipeline{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(creationTimestamp),
+		},
+		Spec: telemetryv1alpha1.MetricPipelineSpec{
+			Output: telemetryv1alpha1.MetricPipelineOutput{
+				Prometheus: &telemetryv1alpha1.PrometheusOutput{Port: port},
+			},
+		},
+	}
 }
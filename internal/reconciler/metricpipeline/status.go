@@ -3,18 +3,75 @@ package metricpipeline
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"slices"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/conditions"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/metric/gateway"
 	"github.com/kyma-project/telemetry-manager/internal/secretref"
 )
 
-func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, lockAcquired bool) error {
+func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, withinLimit bool) error {
+	if err := r.updateStatusOutputs(ctx, pipelineName); err != nil {
+		return err
+	}
+	if err := r.updateStatusConditions(ctx, pipelineName, withinLimit); err != nil {
+		return err
+	}
+	return r.updateStatusFlowHealth(ctx, pipelineName)
+}
+
+func (r *Reconciler) updateStatusOutputs(ctx context.Context, pipelineName string) error {
+	var pipeline telemetryv1alpha1.MetricPipeline
+	if err := r.Get(ctx, types.NamespacedName{Name: pipelineName}, &pipeline); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get MetricPipeline: %v", err)
+	}
+
+	if pipeline.DeletionTimestamp != nil {
+		return nil
+	}
+
+	var allPipelines telemetryv1alpha1.MetricPipelineList
+	if err := r.List(ctx, &allPipelines); err != nil {
+		return fmt.Errorf("failed to list MetricPipelines: %v", err)
+	}
+	conflictingOutputs := conflictingPrometheusOutputs(&pipeline, allPipelines.Items)
+
+	var desiredOutputs []telemetryv1alpha1.OutputStatus
+	for _, output := range pipeline.Spec.AllOutputs() {
+		reason := conditions.ReasonOutputReady
+		if secretref.ReferencesNonExistentSecret(ctx, r.Client, &output) {
+			reason = conditions.ReasonReferencedSecretMissing
+		}
+		if slices.Contains(conflictingOutputs, output.Name) {
+			reason = conditions.ReasonPrometheusPortConflict
+		}
+		desiredOutputs = append(desiredOutputs, telemetryv1alpha1.OutputStatus{Name: output.Name, Reason: reason})
+	}
+
+	if !reflect.DeepEqual(pipeline.Status.Outputs, desiredOutputs) {
+		pipeline.Status.Outputs = desiredOutputs
+		if err := r.Status().Update(ctx, &pipeline); err != nil {
+			return fmt.Errorf("failed to update MetricPipeline outputs status: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) updateStatusConditions(ctx context.Context, pipelineName string, withinLimit bool) error {
 	log := logf.FromContext(ctx)
 
 	var pipeline telemetryv1alpha1.MetricPipeline
@@ -30,8 +87,14 @@ func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, lock
 		return nil
 	}
 
-	if !lockAcquired {
-		pending := telemetryv1alpha1.NewMetricPipelineCondition(conditions.ReasonWaitingForLock, telemetryv1alpha1.MetricPipelinePending)
+	if !withinLimit {
+		var allPipelines telemetryv1alpha1.MetricPipelineList
+		if err := r.List(ctx, &allPipelines); err != nil {
+			return fmt.Errorf("failed to list MetricPipelines: %v", err)
+		}
+
+		pending := telemetryv1alpha1.NewMetricPipelineCondition(conditions.ReasonMaxPipelinesExceeded, telemetryv1alpha1.MetricPipelinePending)
+		pending.Message = conditions.MessageForMaxPipelinesExceeded(SelectPipelines(allPipelines.Items, r.config.MaxPipelines))
 
 		if pipeline.Status.HasCondition(telemetryv1alpha1.MetricPipelineRunning) {
 			log.V(1).Info(fmt.Sprintf("Updating the status of %s to %s. Resetting previous conditions", pipeline.Name, pending.Type))
@@ -41,6 +104,23 @@ func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, lock
 		return setCondition(ctx, r.Client, &pipeline, pending)
 	}
 
+	if pipeline.Spec.Suspend {
+		if isSuspended(&pipeline) {
+			return nil
+		}
+
+		suspended := telemetryv1alpha1.NewMetricPipelineCondition(conditions.ReasonPipelineSuspended, telemetryv1alpha1.MetricPipelineSuspended)
+		log.V(1).Info(fmt.Sprintf("Updating the status of %s to %s. Resetting previous conditions", pipeline.Name, suspended.Type))
+		pipeline.Status.Conditions = []telemetryv1alpha1.MetricPipelineCondition{}
+
+		return setCondition(ctx, r.Client, &pipeline, suspended)
+	}
+
+	if pipeline.Status.HasCondition(telemetryv1alpha1.MetricPipelineSuspended) {
+		log.V(1).Info(fmt.Sprintf("Resuming %s. Resetting previous conditions", pipeline.Name))
+		pipeline.Status.Conditions = []telemetryv1alpha1.MetricPipelineCondition{}
+	}
+
 	referencesNonExistentSecret := secretref.ReferencesNonExistentSecret(ctx, r.Client, &pipeline)
 	if referencesNonExistentSecret {
 		pending := telemetryv1alpha1.NewMetricPipelineCondition(conditions.ReasonReferencedSecretMissing, telemetryv1alpha1.MetricPipelinePending)
@@ -53,6 +133,22 @@ func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, lock
 		return setCondition(ctx, r.Client, &pipeline, pending)
 	}
 
+	var allPipelines telemetryv1alpha1.MetricPipelineList
+	if err := r.List(ctx, &allPipelines); err != nil {
+		return fmt.Errorf("failed to list MetricPipelines: %v", err)
+	}
+
+	if len(conflictingPrometheusOutputs(&pipeline, allPipelines.Items)) > 0 {
+		pending := telemetryv1alpha1.NewMetricPipelineCondition(conditions.ReasonPrometheusPortConflict, telemetryv1alpha1.MetricPipelinePending)
+
+		if pipeline.Status.HasCondition(telemetryv1alpha1.MetricPipelineRunning) {
+			log.V(1).Info(fmt.Sprintf("Updating the status of %s to %s. Resetting previous conditions", pipeline.Name, pending.Type))
+			pipeline.Status.Conditions = []telemetryv1alpha1.MetricPipelineCondition{}
+		}
+
+		return setCondition(ctx, r.Client, &pipeline, pending)
+	}
+
 	gatewayReady, err := r.prober.IsReady(ctx, types.NamespacedName{Name: r.config.Gateway.BaseName, Namespace: r.config.Gateway.Namespace})
 	if err != nil {
 		return err
@@ -77,6 +173,82 @@ func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, lock
 	return setCondition(ctx, r.Client, &pipeline, pending)
 }
 
+// updateStatusFlowHealth evaluates the data flow of a running pipeline. A failed evaluation does not fail the reconciliation, but is reported with an Unknown condition.
+func (r *Reconciler) updateStatusFlowHealth(ctx context.Context, pipelineName string) error {
+	var pipeline telemetryv1alpha1.MetricPipeline
+	if err := r.Get(ctx, types.NamespacedName{Name: pipelineName}, &pipeline); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get MetricPipeline: %v", err)
+	}
+
+	if pipeline.DeletionTimestamp != nil {
+		return nil
+	}
+
+	existing := pipeline.Status.DeepCopy().HealthConditions
+	if isRunning(&pipeline) {
+		meta.SetStatusCondition(&pipeline.Status.HealthConditions, r.evaluateFlowHealth(ctx, &pipeline))
+	} else {
+		meta.RemoveStatusCondition(&pipeline.Status.HealthConditions, conditions.TypeFlowHealthy)
+	}
+
+	if reflect.DeepEqual(existing, pipeline.Status.HealthConditions) {
+		return nil
+	}
+
+	if err := r.Status().Update(ctx, &pipeline); err != nil {
+		return fmt.Errorf("failed to update MetricPipeline flow health status: %v", err)
+	}
+	return nil
+}
+
+func (r *Reconciler) evaluateFlowHealth(ctx context.Context, pipeline *telemetryv1alpha1.MetricPipeline) metav1.Condition {
+	var exporterIDs []string
+	for _, output := range pipeline.Spec.AllOutputs() {
+		exporterIDs = append(exporterIDs, gateway.ExporterID(pipeline.Name, output))
+	}
+
+	status := metav1.ConditionFalse
+	var reason string
+	result, err := r.flowHealthProber.Probe(ctx, exporterIDs)
+	switch {
+	case err != nil:
+		logf.FromContext(ctx).V(1).Info(fmt.Sprintf("Failed to probe the flow health of %s: %v", pipeline.Name, err))
+		status = metav1.ConditionUnknown
+		reason = conditions.ReasonFlowHealthProbingFailed
+	case result.AllDataDropped:
+		reason = conditions.ReasonAllDataDropped
+	case result.SomeDataDropped:
+		reason = conditions.ReasonSomeDataDropped
+	case result.BufferFillingUp:
+		reason = conditions.ReasonBufferFillingUp
+	default:
+		status = metav1.ConditionTrue
+		reason = conditions.ReasonFlowHealthy
+	}
+
+	return metav1.Condition{
+		Type:               conditions.TypeFlowHealthy,
+		Status:             status,
+		Reason:             reason,
+		Message:            conditions.CommonMessageFor(reason),
+		ObservedGeneration: pipeline.Generation,
+	}
+}
+
+func isRunning(pipeline *telemetryv1alpha1.MetricPipeline) bool {
+	pipelineConditions := pipeline.Status.Conditions
+	return len(pipelineConditions) > 0 && pipelineConditions[len(pipelineConditions)-1].Type == telemetryv1alpha1.MetricPipelineRunning
+}
+
+func isSuspended(pipeline *telemetryv1alpha1.MetricPipeline) bool {
+	pipelineConditions := pipeline.Status.Conditions
+	return len(pipelineConditions) > 0 && pipelineConditions[len(pipelineConditions)-1].Type == telemetryv1alpha1.MetricPipelineSuspended
+}
+
 func setCondition(ctx context.Context, client client.Client, pipeline *telemetryv1alpha1.MetricPipeline, condition *telemetryv1alpha1.MetricPipelineCondition) error {
 	log := logf.FromContext(ctx)
 
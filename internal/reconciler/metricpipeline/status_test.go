@@ -2,11 +2,14 @@ package metricpipeline
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -15,10 +18,18 @@ import (
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/conditions"
+	"github.com/kyma-project/telemetry-manager/internal/flowhealth"
 	"github.com/kyma-project/telemetry-manager/internal/reconciler/metricpipeline/mocks"
 	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
 )
 
+func healthyFlowHealthProber() *mocks.FlowHealthProber {
+	flowHealthProberStub := &mocks.FlowHealthProber{}
+	flowHealthProberStub.On("Probe", mock.Anything, mock.Anything).Return(flowhealth.ProbeResult{}, nil)
+	return flowHealthProberStub
+}
+
 func TestUpdateStatus(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = clientgoscheme.AddToScheme(scheme)
@@ -47,7 +58,8 @@ func TestUpdateStatus(t *testing.T) {
 			config: Config{Gateway: otelcollector.GatewayConfig{
 				Config: otelcollector.Config{BaseName: "metric-gateway"},
 			}},
-			prober: proberStub,
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
 		}
 		err := sut.updateStatus(context.Background(), pipeline.Name, true)
 		require.NoError(t, err)
@@ -82,7 +94,8 @@ func TestUpdateStatus(t *testing.T) {
 			config: Config{Gateway: otelcollector.GatewayConfig{
 				Config: otelcollector.Config{BaseName: "metric-gateway"},
 			}},
-			prober: proberStub,
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
 		}
 		err := sut.updateStatus(context.Background(), pipeline.Name, true)
 		require.NoError(t, err)
@@ -123,7 +136,8 @@ func TestUpdateStatus(t *testing.T) {
 			config: Config{Gateway: otelcollector.GatewayConfig{
 				Config: otelcollector.Config{BaseName: "metric-gateway"},
 			}},
-			prober: proberStub,
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
 		}
 		err := sut.updateStatus(context.Background(), pipeline.Name, true)
 		require.NoError(t, err)
@@ -172,7 +186,8 @@ func TestUpdateStatus(t *testing.T) {
 			config: Config{Gateway: otelcollector.GatewayConfig{
 				Config: otelcollector.Config{BaseName: "metric-gateway"},
 			}},
-			prober: proberStub,
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
 		}
 
 		err := sut.updateStatus(context.Background(), pipeline.Name, true)
@@ -224,7 +239,8 @@ func TestUpdateStatus(t *testing.T) {
 			config: Config{Gateway: otelcollector.GatewayConfig{
 				Config: otelcollector.Config{BaseName: "metric-gateway"},
 			}},
-			prober: proberStub,
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
 		}
 
 		err := sut.updateStatus(context.Background(), pipeline.Name, true)
@@ -237,7 +253,7 @@ func TestUpdateStatus(t *testing.T) {
 		require.Equal(t, updatedPipeline.Status.Conditions[0].Reason, conditions.ReasonMetricGatewayDeploymentReady)
 	})
 
-	t.Run("should add pending condition if waiting for lock", func(t *testing.T) {
+	t.Run("should add pending condition if maximum pipeline count limit exceeded", func(t *testing.T) {
 		pipelineName := "pipeline"
 		pipeline := &telemetryv1alpha1.MetricPipeline{
 			ObjectMeta: metav1.ObjectMeta{
@@ -250,7 +266,10 @@ func TestUpdateStatus(t *testing.T) {
 					},
 				}},
 		}
-		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).WithStatusSubresource(pipeline).Build()
+		winnerPipeline := pipeline.DeepCopy()
+		winnerPipeline.Name = "winner"
+		winnerPipeline.Spec.Priority = 10
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline, winnerPipeline).WithStatusSubresource(pipeline, winnerPipeline).Build()
 
 		proberStub := &mocks.DeploymentProber{}
 		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(false, nil)
@@ -259,8 +278,11 @@ func TestUpdateStatus(t *testing.T) {
 			Client: fakeClient,
 			config: Config{Gateway: otelcollector.GatewayConfig{
 				Config: otelcollector.Config{BaseName: "metric-gateway"},
-			}},
-			prober: proberStub,
+			},
+				MaxPipelines: 1,
+			},
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
 		}
 		err := sut.updateStatus(context.Background(), pipeline.Name, false)
 		require.NoError(t, err)
@@ -269,7 +291,8 @@ func TestUpdateStatus(t *testing.T) {
 		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipelineName}, &updatedPipeline)
 		require.Len(t, updatedPipeline.Status.Conditions, 1)
 		require.Equal(t, updatedPipeline.Status.Conditions[0].Type, telemetryv1alpha1.MetricPipelinePending)
-		require.Equal(t, updatedPipeline.Status.Conditions[0].Reason, conditions.ReasonWaitingForLock)
+		require.Equal(t, updatedPipeline.Status.Conditions[0].Reason, conditions.ReasonMaxPipelinesExceeded)
+		require.Equal(t, "Maximum pipeline count limit exceeded. Deployed pipelines: winner", updatedPipeline.Status.Conditions[0].Message)
 	})
 
 	t.Run("should add pending condition if acquired lock but metric gateway is not ready", func(t *testing.T) {
@@ -295,7 +318,8 @@ func TestUpdateStatus(t *testing.T) {
 			config: Config{Gateway: otelcollector.GatewayConfig{
 				Config: otelcollector.Config{BaseName: "metric-gateway"},
 			}},
-			prober: proberStub,
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
 		}
 		err := sut.updateStatus(context.Background(), pipeline.Name, false)
 		require.NoError(t, err)
@@ -308,4 +332,299 @@ func TestUpdateStatus(t *testing.T) {
 		require.Equal(t, updatedPipeline.Status.Conditions[0].Type, telemetryv1alpha1.MetricPipelinePending)
 		require.Equal(t, updatedPipeline.Status.Conditions[0].Reason, conditions.ReasonMetricGatewayDeploymentNotReady)
 	})
+
+	t.Run("should reset conditions and add suspended condition if pipeline is suspended", func(t *testing.T) {
+		pipelineName := "pipeline"
+		pipeline := &telemetryv1alpha1.MetricPipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: pipelineName,
+			},
+			Spec: telemetryv1alpha1.MetricPipelineSpec{
+				Suspend: true,
+				Output: telemetryv1alpha1.MetricPipelineOutput{
+					Otlp: &telemetryv1alpha1.OtlpOutput{
+						Endpoint: telemetryv1alpha1.ValueType{Value: "localhost"},
+					},
+				}},
+			Status: telemetryv1alpha1.MetricPipelineStatus{
+				Conditions: []telemetryv1alpha1.MetricPipelineCondition{
+					{Reason: conditions.ReasonMetricGatewayDeploymentNotReady, Type: telemetryv1alpha1.MetricPipelinePending},
+					{Reason: conditions.ReasonMetricGatewayDeploymentReady, Type: telemetryv1alpha1.MetricPipelineRunning},
+				},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).WithStatusSubresource(pipeline).Build()
+
+		proberStub := &mocks.DeploymentProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{Gateway: otelcollector.GatewayConfig{
+				Config: otelcollector.Config{BaseName: "metric-gateway"},
+			}},
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
+		}
+		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.MetricPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipelineName}, &updatedPipeline)
+		require.Len(t, updatedPipeline.Status.Conditions, 1)
+		require.Equal(t, updatedPipeline.Status.Conditions[0].Type, telemetryv1alpha1.MetricPipelineSuspended)
+		require.Equal(t, updatedPipeline.Status.Conditions[0].Reason, conditions.ReasonPipelineSuspended)
+	})
+
+	t.Run("should reset conditions and add pending condition if pipeline is resumed", func(t *testing.T) {
+		pipelineName := "pipeline"
+		pipeline := &telemetryv1alpha1.MetricPipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: pipelineName,
+			},
+			Spec: telemetryv1alpha1.MetricPipelineSpec{
+				Output: telemetryv1alpha1.MetricPipelineOutput{
+					Otlp: &telemetryv1alpha1.OtlpOutput{
+						Endpoint: telemetryv1alpha1.ValueType{Value: "localhost"},
+					},
+				}},
+			Status: telemetryv1alpha1.MetricPipelineStatus{
+				Conditions: []telemetryv1alpha1.MetricPipelineCondition{
+					{Reason: conditions.ReasonPipelineSuspended, Type: telemetryv1alpha1.MetricPipelineSuspended},
+				},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).WithStatusSubresource(pipeline).Build()
+
+		proberStub := &mocks.DeploymentProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(false, nil)
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{Gateway: otelcollector.GatewayConfig{
+				Config: otelcollector.Config{BaseName: "metric-gateway"},
+			}},
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
+		}
+		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.MetricPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipelineName}, &updatedPipeline)
+		require.Len(t, updatedPipeline.Status.Conditions, 1)
+		require.Equal(t, updatedPipeline.Status.Conditions[0].Type, telemetryv1alpha1.MetricPipelinePending)
+		require.Equal(t, updatedPipeline.Status.Conditions[0].Reason, conditions.ReasonMetricGatewayDeploymentNotReady)
+	})
+
+	t.Run("should report the status of every output", func(t *testing.T) {
+		pipelineName := "pipeline"
+		pipeline := &telemetryv1alpha1.MetricPipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: pipelineName,
+			},
+			Spec: telemetryv1alpha1.MetricPipelineSpec{
+				Output: telemetryv1alpha1.MetricPipelineOutput{
+					Otlp: &telemetryv1alpha1.OtlpOutput{
+						Endpoint: telemetryv1alpha1.ValueType{Value: "localhost"},
+					},
+				},
+				AdditionalOutputs: []telemetryv1alpha1.NamedMetricPipelineOutput{
+					{
+						Name: "archive",
+						MetricPipelineOutput: telemetryv1alpha1.MetricPipelineOutput{
+							Otlp: &telemetryv1alpha1.OtlpOutput{
+								Endpoint: telemetryv1alpha1.ValueType{
+									ValueFrom: &telemetryv1alpha1.ValueFromSource{
+										SecretKeyRef: &telemetryv1alpha1.SecretKeyRef{
+											Name:      "some-secret",
+											Namespace: "some-namespace",
+											Key:       "host",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).WithStatusSubresource(pipeline).Build()
+
+		proberStub := &mocks.DeploymentProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{Gateway: otelcollector.GatewayConfig{
+				Config: otelcollector.Config{BaseName: "metric-gateway"},
+			}},
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
+		}
+		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.MetricPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipelineName}, &updatedPipeline)
+		require.Equal(t, []telemetryv1alpha1.OutputStatus{
+			{Name: "default", Reason: conditions.ReasonOutputReady},
+			{Name: "archive", Reason: conditions.ReasonReferencedSecretMissing},
+		}, updatedPipeline.Status.Outputs)
+	})
+
+	t.Run("should add pending condition if the port of a prometheus output is used by an older pipeline", func(t *testing.T) {
+		olderPipeline := &telemetryv1alpha1.MetricPipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "older",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+			Spec: telemetryv1alpha1.MetricPipelineSpec{
+				Output: telemetryv1alpha1.MetricPipelineOutput{
+					Prometheus: &telemetryv1alpha1.PrometheusOutput{Port: 8889},
+				},
+			},
+		}
+		pipeline := &telemetryv1alpha1.MetricPipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "pipeline",
+				CreationTimestamp: metav1.Now(),
+			},
+			Spec: telemetryv1alpha1.MetricPipelineSpec{
+				Output: telemetryv1alpha1.MetricPipelineOutput{
+					Otlp: &telemetryv1alpha1.OtlpOutput{
+						Endpoint: telemetryv1alpha1.ValueType{Value: "localhost"},
+					},
+				},
+				AdditionalOutputs: []telemetryv1alpha1.NamedMetricPipelineOutput{
+					{
+						Name: "scrape",
+						MetricPipelineOutput: telemetryv1alpha1.MetricPipelineOutput{
+							Prometheus: &telemetryv1alpha1.PrometheusOutput{Port: 8889},
+						},
+					},
+				},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(olderPipeline, pipeline).WithStatusSubresource(olderPipeline, pipeline).Build()
+
+		proberStub := &mocks.DeploymentProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{Gateway: otelcollector.GatewayConfig{
+				Config: otelcollector.Config{BaseName: "metric-gateway"},
+			}},
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
+		}
+		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.MetricPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+		require.Len(t, updatedPipeline.Status.Conditions, 1)
+		require.Equal(t, telemetryv1alpha1.MetricPipelinePending, updatedPipeline.Status.Conditions[0].Type)
+		require.Equal(t, conditions.ReasonPrometheusPortConflict, updatedPipeline.Status.Conditions[0].Reason)
+		require.Equal(t, []telemetryv1alpha1.OutputStatus{
+			{Name: "default", Reason: conditions.ReasonOutputReady},
+			{Name: "scrape", Reason: conditions.ReasonPrometheusPortConflict},
+		}, updatedPipeline.Status.Outputs)
+	})
+
+	t.Run("should set flow health condition of a running pipeline", func(t *testing.T) {
+		tests := []struct {
+			name           string
+			probeResult    flowhealth.ProbeResult
+			probeErr       error
+			expectedStatus metav1.ConditionStatus
+			expectedReason string
+		}{
+			{
+				name:           "healthy",
+				expectedStatus: metav1.ConditionTrue,
+				expectedReason: conditions.ReasonFlowHealthy,
+			},
+			{
+				name:           "buffer filling up",
+				probeResult:    flowhealth.ProbeResult{BufferFillingUp: true},
+				expectedStatus: metav1.ConditionFalse,
+				expectedReason: conditions.ReasonBufferFillingUp,
+			},
+			{
+				name:           "some data dropped",
+				probeResult:    flowhealth.ProbeResult{SomeDataDropped: true, BufferFillingUp: true},
+				expectedStatus: metav1.ConditionFalse,
+				expectedReason: conditions.ReasonSomeDataDropped,
+			},
+			{
+				name:           "all data dropped",
+				probeResult:    flowhealth.ProbeResult{AllDataDropped: true, SomeDataDropped: true},
+				expectedStatus: metav1.ConditionFalse,
+				expectedReason: conditions.ReasonAllDataDropped,
+			},
+			{
+				name:           "probing failed",
+				probeErr:       errors.New("connection refused"),
+				expectedStatus: metav1.ConditionUnknown,
+				expectedReason: conditions.ReasonFlowHealthProbingFailed,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				pipeline := testutils.NewMetricPipelineBuilder().WithName("pipeline").Build()
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline).WithStatusSubresource(&pipeline).Build()
+
+				proberStub := &mocks.DeploymentProber{}
+				proberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+				flowHealthProberStub := &mocks.FlowHealthProber{}
+				flowHealthProberStub.On("Probe", mock.Anything, []string{"otlp/pipeline"}).Return(tt.probeResult, tt.probeErr)
+
+				sut := Reconciler{
+					Client: fakeClient,
+					config: Config{Gateway: otelcollector.GatewayConfig{
+						Config: otelcollector.Config{BaseName: "metric-gateway"},
+					}},
+					prober:           proberStub,
+					flowHealthProber: flowHealthProberStub,
+				}
+				err := sut.updateStatus(context.Background(), pipeline.Name, true)
+				require.NoError(t, err)
+
+				var updatedPipeline telemetryv1alpha1.MetricPipeline
+				_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+				cond := meta.FindStatusCondition(updatedPipeline.Status.HealthConditions, conditions.TypeFlowHealthy)
+				require.NotNil(t, cond)
+				require.Equal(t, tt.expectedStatus, cond.Status)
+				require.Equal(t, tt.expectedReason, cond.Reason)
+			})
+		}
+	})
+
+	t.Run("should not set flow health condition of a pending pipeline", func(t *testing.T) {
+		pipeline := testutils.NewMetricPipelineBuilder().WithName("pipeline").Build()
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline).WithStatusSubresource(&pipeline).Build()
+
+		proberStub := &mocks.DeploymentProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(false, nil)
+		flowHealthProberStub := &mocks.FlowHealthProber{}
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{Gateway: otelcollector.GatewayConfig{
+				Config: otelcollector.Config{BaseName: "metric-gateway"},
+			}},
+			prober:           proberStub,
+			flowHealthProber: flowHealthProberStub,
+		}
+		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.MetricPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+		require.Empty(t, updatedPipeline.Status.HealthConditions)
+		flowHealthProberStub.AssertNotCalled(t, "Probe", mock.Anything, mock.Anything)
+	})
 }
@@ -50,6 +50,12 @@ func (l *logComponentsChecker) determineReason(pipelines []v1alpha1.LogPipeline,
 		return conditions.ReasonFluentBitDSNotReady
 	}
 
+	if found := slices.ContainsFunc(pipelines, func(p v1alpha1.LogPipeline) bool {
+		return l.isPendingWithReason(p, conditions.ReasonLogGatewayDeploymentNotReady)
+	}); found {
+		return conditions.ReasonLogGatewayDeploymentNotReady
+	}
+
 	if found := slices.ContainsFunc(pipelines, func(p v1alpha1.LogPipeline) bool {
 		return l.isPendingWithReason(p, conditions.ReasonUnsupportedLokiOutput)
 	}); found {
@@ -62,6 +68,12 @@ func (l *logComponentsChecker) determineReason(pipelines []v1alpha1.LogPipeline,
 		return conditions.ReasonReferencedSecretMissing
 	}
 
+	if found := slices.ContainsFunc(pipelines, func(p v1alpha1.LogPipeline) bool {
+		return l.isRunningWithReason(p, conditions.ReasonLogGatewayDeploymentReady)
+	}); found {
+		return conditions.ReasonLogGatewayDeploymentReady
+	}
+
 	return conditions.ReasonFluentBitDSReady
 }
 
@@ -74,6 +86,15 @@ func (l *logComponentsChecker) isPendingWithReason(p v1alpha1.LogPipeline, reaso
 	return lastCondition.Type == v1alpha1.LogPipelinePending && lastCondition.Reason == reason
 }
 
+func (l *logComponentsChecker) isRunningWithReason(p v1alpha1.LogPipeline, reason string) bool {
+	if len(p.Status.Conditions) == 0 {
+		return false
+	}
+
+	lastCondition := p.Status.Conditions[len(p.Status.Conditions)-1]
+	return lastCondition.Type == v1alpha1.LogPipelineRunning && lastCondition.Reason == reason
+}
+
 func (l *logComponentsChecker) createMessageForReason(pipelines []v1alpha1.LogPipeline, parsers []v1alpha1.LogParser, reason string) string {
 	if reason != conditions.ReasonResourceBlocksDeletion {
 		return conditions.CommonMessageFor(reason)
@@ -94,7 +115,7 @@ func (l *logComponentsChecker) createMessageForReason(pipelines []v1alpha1.LogPi
 
 func (l *logComponentsChecker) createConditionFromReason(reason, message string) *metav1.Condition {
 	conditionType := "LogComponentsHealthy"
-	if reason == conditions.ReasonFluentBitDSReady || reason == conditions.ReasonNoPipelineDeployed {
+	if reason == conditions.ReasonFluentBitDSReady || reason == conditions.ReasonLogGatewayDeploymentReady || reason == conditions.ReasonNoPipelineDeployed {
 		return &metav1.Condition{
 			Type:    conditionType,
 			Status:  metav1.ConditionTrue,
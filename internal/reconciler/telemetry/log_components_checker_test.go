@@ -49,6 +49,23 @@ func TestLogComponentsCheck(t *testing.T) {
 				Message: "Fluent Bit DaemonSet is ready",
 			},
 		},
+		{
+			name: "should be healthy with log gateway reason if a pipeline with OTLP output is running",
+			pipelines: []telemetryv1alpha1.LogPipeline{
+				testutils.NewLogPipelineBuilder().WithStatusConditions(
+					testutils.LogPendingCondition(conditions.ReasonFluentBitDSNotReady), testutils.LogRunningCondition()).Build(),
+				testutils.NewLogPipelineBuilder().WithOtlpOutput("https://localhost").WithStatusConditions(
+					testutils.LogPendingCondition(conditions.ReasonLogGatewayDeploymentNotReady),
+					telemetryv1alpha1.LogPipelineCondition{Type: telemetryv1alpha1.LogPipelineRunning, Reason: conditions.ReasonLogGatewayDeploymentReady}).Build(),
+			},
+			telemetryInDeletion: false,
+			expectedCondition: &metav1.Condition{
+				Type:    "LogComponentsHealthy",
+				Status:  "True",
+				Reason:  "LogGatewayDeploymentReady",
+				Message: "Log gateway Deployment is ready",
+			},
+		},
 		{
 			name: "should not be healthy if one pipeline refs missing secret",
 			pipelines: []telemetryv1alpha1.LogPipeline{
@@ -81,6 +98,22 @@ func TestLogComponentsCheck(t *testing.T) {
 				Message: "Fluent Bit DaemonSet is not ready",
 			},
 		},
+		{
+			name: "should not be healthy if one pipeline waiting for log gateway",
+			pipelines: []telemetryv1alpha1.LogPipeline{
+				testutils.NewLogPipelineBuilder().WithStatusConditions(
+					testutils.LogPendingCondition(conditions.ReasonFluentBitDSNotReady), testutils.LogRunningCondition()).Build(),
+				testutils.NewLogPipelineBuilder().WithOtlpOutput("https://localhost").WithStatusConditions(
+					testutils.LogPendingCondition(conditions.ReasonLogGatewayDeploymentNotReady)).Build(),
+			},
+			telemetryInDeletion: false,
+			expectedCondition: &metav1.Condition{
+				Type:    "LogComponentsHealthy",
+				Status:  "False",
+				Reason:  "LogGatewayDeploymentNotReady",
+				Message: "Log gateway Deployment is not ready",
+			},
+		},
 		{
 			name: "should not be healthy if one pipeline has Loki output defined",
 			pipelines: []telemetryv1alpha1.LogPipeline{
@@ -103,7 +136,7 @@ func TestLogComponentsCheck(t *testing.T) {
 				testutils.NewLogPipelineBuilder().WithStatusConditions(
 					testutils.LogPendingCondition(conditions.ReasonFluentBitDSNotReady), testutils.LogRunningCondition()).Build(),
 				testutils.NewLogPipelineBuilder().WithStatusConditions(
-					testutils.LogPendingCondition(conditions.ReasonWaitingForLock)).Build(),
+					testutils.LogPendingCondition(conditions.ReasonMaxPipelinesExceeded)).Build(),
 			},
 			telemetryInDeletion: false,
 			expectedCondition: &metav1.Condition{
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"slices"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -50,6 +51,15 @@ func (m *metricComponentsChecker) determineReason(pipelines []v1alpha1.MetricPip
 		return conditions.ReasonReferencedSecretMissing
 	}
 
+	// The data flow is only evaluated for running pipelines, so data loss is reported after all configuration problems are solved
+	for _, reason := range []string{conditions.ReasonAllDataDropped, conditions.ReasonSomeDataDropped, conditions.ReasonBufferFillingUp} {
+		if found := slices.ContainsFunc(pipelines, func(p v1alpha1.MetricPipeline) bool {
+			return m.hasFlowHealthReason(p, reason)
+		}); found {
+			return reason
+		}
+	}
+
 	return conditions.ReasonMetricGatewayDeploymentReady
 }
 
@@ -62,6 +72,11 @@ func (m *metricComponentsChecker) isPendingWithReason(p v1alpha1.MetricPipeline,
 	return lastCondition.Type == v1alpha1.MetricPipelinePending && lastCondition.Reason == reason
 }
 
+func (m *metricComponentsChecker) hasFlowHealthReason(p v1alpha1.MetricPipeline, reason string) bool {
+	cond := meta.FindStatusCondition(p.Status.HealthConditions, conditions.TypeFlowHealthy)
+	return cond != nil && cond.Reason == reason
+}
+
 func (m *metricComponentsChecker) createMessageForReason(pipelines []v1alpha1.MetricPipeline, reason string) string {
 	if reason != conditions.ReasonResourceBlocksDeletion {
 		return conditions.CommonMessageFor(reason)
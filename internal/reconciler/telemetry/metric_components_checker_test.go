@@ -48,6 +48,54 @@ func TestMetricComponentsCheck(t *testing.T) {
 				Message: "Metric gateway Deployment is ready",
 			},
 		},
+		{
+			name: "should not be healthy if one pipeline drops all data",
+			pipelines: []telemetryv1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithStatusConditions(testutils.MetricRunningCondition()).
+					WithHealthConditions(testutils.FlowHealthCondition(conditions.ReasonSomeDataDropped)).Build(),
+				testutils.NewMetricPipelineBuilder().WithStatusConditions(testutils.MetricRunningCondition()).
+					WithHealthConditions(testutils.FlowHealthCondition(conditions.ReasonAllDataDropped)).Build(),
+			},
+			telemetryInDeletion: false,
+			expectedCondition: &metav1.Condition{
+				Type:    "MetricComponentsHealthy",
+				Status:  "False",
+				Reason:  "AllDataDropped",
+				Message: "All data dropped: the backend is unreachable or rejects all data",
+			},
+		},
+		{
+			name: "should not be healthy if one pipeline buffer is filling up",
+			pipelines: []telemetryv1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithStatusConditions(testutils.MetricRunningCondition()).
+					WithHealthConditions(testutils.FlowHealthCondition(conditions.ReasonFlowHealthy)).Build(),
+				testutils.NewMetricPipelineBuilder().WithStatusConditions(testutils.MetricRunningCondition()).
+					WithHealthConditions(testutils.FlowHealthCondition(conditions.ReasonBufferFillingUp)).Build(),
+			},
+			telemetryInDeletion: false,
+			expectedCondition: &metav1.Condition{
+				Type:    "MetricComponentsHealthy",
+				Status:  "False",
+				Reason:  "BufferFillingUp",
+				Message: "Buffer nearing capacity: the incoming data rate exceeds the export rate",
+			},
+		},
+		{
+			name: "should prioritize configuration problems over data loss",
+			pipelines: []telemetryv1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithStatusConditions(testutils.MetricRunningCondition()).
+					WithHealthConditions(testutils.FlowHealthCondition(conditions.ReasonAllDataDropped)).Build(),
+				testutils.NewMetricPipelineBuilder().WithStatusConditions(
+					testutils.MetricPendingCondition(conditions.ReasonReferencedSecretMissing)).Build(),
+			},
+			telemetryInDeletion: false,
+			expectedCondition: &metav1.Condition{
+				Type:    "MetricComponentsHealthy",
+				Status:  "False",
+				Reason:  "ReferencedSecretMissing",
+				Message: "One or more referenced Secrets are missing",
+			},
+		},
 		{
 			name: "should not be healthy if one pipeline refs missing secret",
 			pipelines: []telemetryv1alpha1.MetricPipeline{
@@ -86,7 +134,7 @@ func TestMetricComponentsCheck(t *testing.T) {
 				testutils.NewMetricPipelineBuilder().WithStatusConditions(
 					testutils.MetricPendingCondition(conditions.ReasonMetricGatewayDeploymentNotReady), testutils.MetricRunningCondition()).Build(),
 				testutils.NewMetricPipelineBuilder().WithStatusConditions(
-					testutils.MetricPendingCondition(conditions.ReasonWaitingForLock)).Build(),
+					testutils.MetricPendingCondition(conditions.ReasonMaxPipelinesExceeded)).Build(),
 			},
 			telemetryInDeletion: false,
 			expectedCondition: &metav1.Condition{
@@ -0,0 +1,122 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/conditions"
+	"github.com/kyma-project/telemetry-manager/internal/extslices"
+)
+
+// pipelineState is the name of a pipeline with the type and reason of its latest condition.
+// The condition types of all pipeline kinds are Pending, Running, or Suspended.
+type pipelineState struct {
+	name          string
+	conditionType string
+	reason        string
+}
+
+func (r *Reconciler) updatePipelineSummaries(ctx context.Context, telemetry *operatorv1alpha1.Telemetry) error {
+	var logPipelines telemetryv1alpha1.LogPipelineList
+	if err := r.List(ctx, &logPipelines); err != nil {
+		return fmt.Errorf("failed to list log pipelines: %w", err)
+	}
+
+	var namespacedLogPipelines telemetryv1alpha1.NamespacedLogPipelineList
+	if err := r.List(ctx, &namespacedLogPipelines); err != nil {
+		return fmt.Errorf("failed to list namespaced log pipelines: %w", err)
+	}
+
+	var tracePipelines telemetryv1alpha1.TracePipelineList
+	if err := r.List(ctx, &tracePipelines); err != nil {
+		return fmt.Errorf("failed to list trace pipelines: %w", err)
+	}
+
+	var namespacedTracePipelines telemetryv1alpha1.NamespacedTracePipelineList
+	if err := r.List(ctx, &namespacedTracePipelines); err != nil {
+		return fmt.Errorf("failed to list namespaced trace pipelines: %w", err)
+	}
+
+	logStates := extslices.TransformFunc(logPipelines.Items, func(p telemetryv1alpha1.LogPipeline) pipelineState {
+		return latestPipelineState(p.Name, p.Status.Conditions)
+	})
+	logStates = append(logStates, extslices.TransformFunc(namespacedLogPipelines.Items, func(p telemetryv1alpha1.NamespacedLogPipeline) pipelineState {
+		return latestPipelineState(namespacedPipelineSummaryName(p.Namespace, p.Name), p.Status.Conditions)
+	})...)
+
+	traceStates := extslices.TransformFunc(tracePipelines.Items, func(p telemetryv1alpha1.TracePipeline) pipelineState {
+		return latestPipelineState(p.Name, p.Status.Conditions)
+	})
+	traceStates = append(traceStates, extslices.TransformFunc(namespacedTracePipelines.Items, func(p telemetryv1alpha1.NamespacedTracePipeline) pipelineState {
+		return latestPipelineState(namespacedPipelineSummaryName(p.Namespace, p.Name), p.Status.Conditions)
+	})...)
+
+	summaries := operatorv1alpha1.PipelineSummaries{
+		Logs:   summarizePipelines(logStates),
+		Traces: summarizePipelines(traceStates),
+	}
+
+	if r.config.Metrics.Enabled {
+		var metricPipelines telemetryv1alpha1.MetricPipelineList
+		if err := r.List(ctx, &metricPipelines); err != nil {
+			return fmt.Errorf("failed to list metric pipelines: %w", err)
+		}
+
+		summaries.Metrics = summarizePipelines(extslices.TransformFunc(metricPipelines.Items, func(p telemetryv1alpha1.MetricPipeline) pipelineState {
+			return latestPipelineState(p.Name, p.Status.Conditions)
+		}))
+	}
+
+	telemetry.Status.Pipelines = summaries
+
+	return nil
+}
+
+// namespacedPipelineSummaryName returns the name under which a namespaced pipeline is listed in a summary.
+func namespacedPipelineSummaryName(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// pipelineCondition is the condition type of any pipeline kind. The latest condition is the current state of the pipeline.
+type pipelineCondition interface {
+	telemetryv1alpha1.LogPipelineCondition | telemetryv1alpha1.TracePipelineCondition | telemetryv1alpha1.MetricPipelineCondition
+}
+
+// latestPipelineState returns the state of a pipeline with the given name and conditions, based on its latest condition.
+func latestPipelineState[C pipelineCondition](name string, pipelineConditions []C) pipelineState {
+	state := pipelineState{name: name}
+	if len(pipelineConditions) == 0 {
+		return state
+	}
+
+	switch latest := any(pipelineConditions[len(pipelineConditions)-1]).(type) {
+	case telemetryv1alpha1.LogPipelineCondition:
+		state.conditionType, state.reason = string(latest.Type), latest.Reason
+	case telemetryv1alpha1.TracePipelineCondition:
+		state.conditionType, state.reason = string(latest.Type), latest.Reason
+	case telemetryv1alpha1.MetricPipelineCondition:
+		state.conditionType, state.reason = string(latest.Type), latest.Reason
+	}
+	return state
+}
+
+// summarizePipelines counts the pipelines by their state. Pipelines without any condition are not yet reconciled, so they are only counted in the total.
+func summarizePipelines(pipelines []pipelineState) *operatorv1alpha1.PipelineSummary {
+	summary := &operatorv1alpha1.PipelineSummary{Total: len(pipelines)}
+	for _, p := range pipelines {
+		switch p.conditionType {
+		case string(telemetryv1alpha1.TracePipelineRunning):
+			summary.Running++
+		case string(telemetryv1alpha1.TracePipelinePending):
+			summary.Pending++
+			if p.reason == conditions.ReasonMaxPipelinesExceeded {
+				summary.Blocked = append(summary.Blocked, p.name)
+			}
+		case string(telemetryv1alpha1.TracePipelineSuspended):
+			summary.Suspended++
+		}
+	}
+	return summary
+}
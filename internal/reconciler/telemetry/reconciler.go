@@ -25,11 +25,17 @@ const (
 )
 
 type Config struct {
+	Logs    LogsConfig
 	Traces  TracesConfig
 	Metrics MetricsConfig
 	Webhook WebhookConfig
 }
 
+type LogsConfig struct {
+	OTLPServiceName string
+	Namespace       string
+}
+
 type TracesConfig struct {
 	OTLPServiceName string
 	Namespace       string
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -13,6 +14,7 @@ import (
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/conditions"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
+	"github.com/kyma-project/telemetry-manager/internal/persistentqueue"
 )
 
 //go:generate mockery --name ComponentHealthChecker --filename component_health_checker.go
@@ -29,12 +31,18 @@ func (r *Reconciler) updateStatus(ctx context.Context, telemetry *operatorv1alph
 		}
 	}
 
+	updatePersistentQueueCondition(telemetry)
+
 	r.updateOverallState(ctx, telemetry, telemetryInDeletion)
 
 	if err := r.updateGatewayEndpoints(ctx, telemetry, telemetryInDeletion); err != nil {
 		return fmt.Errorf("failed to update gateway endpoints: %w", err)
 	}
 
+	if err := r.updatePipelineSummaries(ctx, telemetry); err != nil {
+		return fmt.Errorf("failed to update pipeline summaries: %w", err)
+	}
+
 	if err := r.Status().Update(ctx, telemetry); err != nil {
 		return fmt.Errorf("failed to update status: %w", err)
 	}
@@ -61,6 +69,47 @@ func (r *Reconciler) updateComponentCondition(ctx context.Context, checker Compo
 	return nil
 }
 
+// updatePersistentQueueCondition reports whether the gateways use the PersistentVolumeClaims configured for their persistent queues.
+// A gateway ignores its PersistentVolumeClaim unless it runs with one static replica. The condition is removed if no PersistentVolumeClaim is configured.
+func updatePersistentQueueCondition(telemetry *operatorv1alpha1.Telemetry) {
+	var configured, ignored []string
+	checkGateway := func(gateway string, persistentQueue *operatorv1alpha1.PersistentQueue, scaling operatorv1alpha1.Scaling) {
+		if persistentQueue == nil || persistentQueue.PersistentVolumeClaimName == "" {
+			return
+		}
+		configured = append(configured, gateway)
+		if persistentqueue.IsPersistentVolumeClaimIgnored(persistentQueue, scaling) {
+			ignored = append(ignored, gateway)
+		}
+	}
+	if trace := telemetry.Spec.Trace; trace != nil {
+		checkGateway("trace", trace.Gateway.PersistentQueue, trace.Gateway.Scaling)
+	}
+	if metric := telemetry.Spec.Metric; metric != nil {
+		checkGateway("metric", metric.Gateway.PersistentQueue, metric.Gateway.Scaling)
+	}
+
+	if len(configured) == 0 {
+		meta.RemoveStatusCondition(&telemetry.Status.Conditions, conditions.TypePersistentQueueHealthy)
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               conditions.TypePersistentQueueHealthy,
+		Status:             metav1.ConditionTrue,
+		Reason:             conditions.ReasonPersistentVolumeClaimUsed,
+		Message:            conditions.CommonMessageFor(conditions.ReasonPersistentVolumeClaimUsed),
+		ObservedGeneration: telemetry.GetGeneration(),
+	}
+	if len(ignored) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = conditions.ReasonPersistentVolumeClaimIgnored
+		condition.Message = fmt.Sprintf("The PersistentVolumeClaim of the %s gateway is ignored and an emptyDir volume is used instead, because a PersistentVolumeClaim can only be used with the Static scaling strategy and one replica",
+			strings.Join(ignored, " and "))
+	}
+	meta.SetStatusCondition(&telemetry.Status.Conditions, condition)
+}
+
 func (r *Reconciler) updateOverallState(ctx context.Context, telemetry *operatorv1alpha1.Telemetry, telemetryInDeletion bool) {
 	if telemetryInDeletion {
 		// If the provided Telemetry CR is being deleted and dependent Telemetry CRs (LogPipeline, LogParser, MetricPipeline, TracePipeline) are found, the state is set to "Warning" until they are removed from the cluster.
@@ -85,30 +134,79 @@ func (r *Reconciler) updateOverallState(ctx context.Context, telemetry *operator
 }
 
 func (r *Reconciler) updateGatewayEndpoints(ctx context.Context, telemetry *operatorv1alpha1.Telemetry, telemetryInDeletion bool) error {
+	logEndpoints, err := r.logEndpoints(ctx, r.config, telemetryInDeletion)
+	if err != nil {
+		return fmt.Errorf("failed to get log endpoints: %w", err)
+	}
+
 	traceEndpoints, err := r.traceEndpoints(ctx, r.config, telemetryInDeletion)
 	if err != nil {
 		return fmt.Errorf("failed to get trace endpoints: %w", err)
 	}
 
+	var metricEndpoints *operatorv1alpha1.OTLPEndpoints
+	if r.config.Metrics.Enabled {
+		metricEndpoints, err = r.metricEndpoints(ctx, r.config, telemetryInDeletion)
+		if err != nil {
+			return fmt.Errorf("failed to get metric endpoints: %w", err)
+		}
+	}
+
 	telemetry.Status.GatewayEndpoints = operatorv1alpha1.GatewayEndpoints{
-		Traces: traceEndpoints,
+		Logs:    logEndpoints,
+		Traces:  traceEndpoints,
+		Metrics: metricEndpoints,
 	}
 
 	return nil
 }
 
+func (r *Reconciler) logEndpoints(ctx context.Context, config Config, telemetryInDeletion bool) (*operatorv1alpha1.OTLPEndpoints, error) {
+	cond, err := r.healthCheckers.logs.Check(ctx, telemetryInDeletion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check log components: %w", err)
+	}
+	if !isGatewayAccepting(cond, conditions.ReasonLogGatewayDeploymentReady) {
+		return nil, nil //nolint:nilnil //it is ok in this context, even if it is not go idiomatic
+	}
+
+	return makeOTLPEndpoints(config.Logs.OTLPServiceName, config.Logs.Namespace), nil
+}
+
 func (r *Reconciler) traceEndpoints(ctx context.Context, config Config, telemetryInDeletion bool) (*operatorv1alpha1.OTLPEndpoints, error) {
 	cond, err := r.healthCheckers.traces.Check(ctx, telemetryInDeletion)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check trace components: %w", err)
 	}
-	if cond.Status != metav1.ConditionTrue || cond.Reason != conditions.ReasonTraceGatewayDeploymentReady {
+	if !isGatewayAccepting(cond, conditions.ReasonTraceGatewayDeploymentReady) {
 		return nil, nil //nolint:nilnil //it is ok in this context, even if it is not go idiomatic
 	}
 
 	return makeOTLPEndpoints(config.Traces.OTLPServiceName, config.Traces.Namespace), nil
 }
 
+func (r *Reconciler) metricEndpoints(ctx context.Context, config Config, telemetryInDeletion bool) (*operatorv1alpha1.OTLPEndpoints, error) {
+	cond, err := r.healthCheckers.metrics.Check(ctx, telemetryInDeletion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check metric components: %w", err)
+	}
+	if !isGatewayAccepting(cond, conditions.ReasonMetricGatewayDeploymentReady) {
+		return nil, nil //nolint:nilnil //it is ok in this context, even if it is not go idiomatic
+	}
+
+	return makeOTLPEndpoints(config.Metrics.OTLPServiceName, config.Metrics.Namespace), nil
+}
+
+// isGatewayAccepting returns true if the gateway is ready. Data loss is reported for running pipelines only, so the gateway accepts data and the endpoints are still exposed.
+func isGatewayAccepting(cond *metav1.Condition, readyReason string) bool {
+	gatewayReady := cond.Status == metav1.ConditionTrue && cond.Reason == readyReason
+	return gatewayReady || isFlowHealthReason(cond.Reason)
+}
+
+func isFlowHealthReason(reason string) bool {
+	return reason == conditions.ReasonAllDataDropped || reason == conditions.ReasonSomeDataDropped || reason == conditions.ReasonBufferFillingUp
+}
+
 func makeOTLPEndpoints(serviceName, namespace string) *operatorv1alpha1.OTLPEndpoints {
 	return &operatorv1alpha1.OTLPEndpoints{
 		HTTP: fmt.Sprintf("http://%s.%s:%d", serviceName, namespace, ports.OTLPHTTP),
@@ -120,7 +218,9 @@ func (r *Reconciler) dependentCRsFound(ctx context.Context) bool {
 	return r.resourcesExist(ctx, &telemetryv1alpha1.LogParserList{}) ||
 		r.resourcesExist(ctx, &telemetryv1alpha1.LogPipelineList{}) ||
 		r.resourcesExist(ctx, &telemetryv1alpha1.MetricPipelineList{}) ||
-		r.resourcesExist(ctx, &telemetryv1alpha1.TracePipelineList{})
+		r.resourcesExist(ctx, &telemetryv1alpha1.TracePipelineList{}) ||
+		r.resourcesExist(ctx, &telemetryv1alpha1.NamespacedLogPipelineList{}) ||
+		r.resourcesExist(ctx, &telemetryv1alpha1.NamespacedTracePipelineList{})
 }
 
 func (r *Reconciler) resourcesExist(ctx context.Context, list client.ObjectList) bool {
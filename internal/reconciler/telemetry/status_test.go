@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -41,7 +42,7 @@ func TestUpdateStatus(t *testing.T) {
 			name: "all components are healthy",
 			config: &Config{
 				Traces:  TracesConfig{OTLPServiceName: "traces", Namespace: "telemetry-system"},
-				Metrics: MetricsConfig{Enabled: true},
+				Metrics: MetricsConfig{Enabled: true, OTLPServiceName: "metrics", Namespace: "telemetry-system"},
 			},
 			telemetry:            &operatorv1alpha1.Telemetry{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
 			logsCheckerReturn:    &metav1.Condition{Type: "LogComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonFluentBitDSReady},
@@ -53,16 +54,22 @@ func TestUpdateStatus(t *testing.T) {
 				{Type: "MetricComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonMetricGatewayDeploymentReady},
 				{Type: "TraceComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonTraceGatewayDeploymentReady},
 			},
-			expectedEndpoints: operatorv1alpha1.GatewayEndpoints{Traces: &operatorv1alpha1.OTLPEndpoints{
-				GRPC: "http://traces.telemetry-system:4317",
-				HTTP: "http://traces.telemetry-system:4318",
-			}},
+			expectedEndpoints: operatorv1alpha1.GatewayEndpoints{
+				Traces: &operatorv1alpha1.OTLPEndpoints{
+					GRPC: "http://traces.telemetry-system:4317",
+					HTTP: "http://traces.telemetry-system:4318",
+				},
+				Metrics: &operatorv1alpha1.OTLPEndpoints{
+					GRPC: "http://metrics.telemetry-system:4317",
+					HTTP: "http://metrics.telemetry-system:4318",
+				},
+			},
 		},
 		{
 			name: "non trace components are unhealthy",
 			config: &Config{
 				Traces:  TracesConfig{OTLPServiceName: "traces", Namespace: "telemetry-system"},
-				Metrics: MetricsConfig{Enabled: true},
+				Metrics: MetricsConfig{Enabled: true, OTLPServiceName: "metrics", Namespace: "telemetry-system"},
 			},
 			telemetry:            &operatorv1alpha1.Telemetry{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
 			logsCheckerReturn:    &metav1.Condition{Type: "LogComponentsHealthy", Status: metav1.ConditionFalse, Reason: conditions.ReasonFluentBitDSNotReady},
@@ -74,15 +81,21 @@ func TestUpdateStatus(t *testing.T) {
 				{Type: "MetricComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonMetricGatewayDeploymentReady},
 				{Type: "TraceComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonTraceGatewayDeploymentReady},
 			},
-			expectedEndpoints: operatorv1alpha1.GatewayEndpoints{Traces: &operatorv1alpha1.OTLPEndpoints{
-				GRPC: "http://traces.telemetry-system:4317",
-				HTTP: "http://traces.telemetry-system:4318",
-			}},
+			expectedEndpoints: operatorv1alpha1.GatewayEndpoints{
+				Traces: &operatorv1alpha1.OTLPEndpoints{
+					GRPC: "http://traces.telemetry-system:4317",
+					HTTP: "http://traces.telemetry-system:4318",
+				},
+				Metrics: &operatorv1alpha1.OTLPEndpoints{
+					GRPC: "http://metrics.telemetry-system:4317",
+					HTTP: "http://metrics.telemetry-system:4318",
+				},
+			},
 		},
 		{
 			name: "trace components are unhealthy",
 			config: &Config{
-				Metrics: MetricsConfig{Enabled: true},
+				Metrics: MetricsConfig{Enabled: true, OTLPServiceName: "metrics", Namespace: "telemetry-system"},
 			},
 			telemetry:            &operatorv1alpha1.Telemetry{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
 			logsCheckerReturn:    &metav1.Condition{Type: "LogComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonFluentBitDSReady},
@@ -94,6 +107,39 @@ func TestUpdateStatus(t *testing.T) {
 				{Type: "MetricComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonMetricGatewayDeploymentReady},
 				{Type: "TraceComponentsHealthy", Status: metav1.ConditionFalse, Reason: conditions.ReasonTraceGatewayDeploymentNotReady},
 			},
+			expectedEndpoints: operatorv1alpha1.GatewayEndpoints{
+				Metrics: &operatorv1alpha1.OTLPEndpoints{
+					GRPC: "http://metrics.telemetry-system:4317",
+					HTTP: "http://metrics.telemetry-system:4318",
+				},
+			},
+		},
+		{
+			name: "trace pipelines drop data",
+			config: &Config{
+				Traces:  TracesConfig{OTLPServiceName: "traces", Namespace: "telemetry-system"},
+				Metrics: MetricsConfig{Enabled: true, OTLPServiceName: "metrics", Namespace: "telemetry-system"},
+			},
+			telemetry:            &operatorv1alpha1.Telemetry{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+			logsCheckerReturn:    &metav1.Condition{Type: "LogComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonFluentBitDSReady},
+			metricsCheckerReturn: &metav1.Condition{Type: "MetricComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonMetricGatewayDeploymentReady},
+			tracesCheckerReturn:  &metav1.Condition{Type: "TraceComponentsHealthy", Status: metav1.ConditionFalse, Reason: conditions.ReasonSomeDataDropped},
+			expectedState:        operatorv1alpha1.StateWarning,
+			expectedConditions: []metav1.Condition{
+				{Type: "LogComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonFluentBitDSReady},
+				{Type: "MetricComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonMetricGatewayDeploymentReady},
+				{Type: "TraceComponentsHealthy", Status: metav1.ConditionFalse, Reason: conditions.ReasonSomeDataDropped},
+			},
+			expectedEndpoints: operatorv1alpha1.GatewayEndpoints{
+				Traces: &operatorv1alpha1.OTLPEndpoints{
+					GRPC: "http://traces.telemetry-system:4317",
+					HTTP: "http://traces.telemetry-system:4318",
+				},
+				Metrics: &operatorv1alpha1.OTLPEndpoints{
+					GRPC: "http://metrics.telemetry-system:4317",
+					HTTP: "http://metrics.telemetry-system:4318",
+				},
+			},
 		},
 		{
 			name: "metrics are unhealthy but not enabled",
@@ -115,6 +161,26 @@ func TestUpdateStatus(t *testing.T) {
 				HTTP: "http://traces.telemetry-system:4318",
 			}},
 		},
+		{
+			name: "log gateway is ready",
+			config: &Config{
+				Logs:    LogsConfig{OTLPServiceName: "logs", Namespace: "telemetry-system"},
+				Traces:  TracesConfig{OTLPServiceName: "traces", Namespace: "telemetry-system"},
+				Metrics: MetricsConfig{Enabled: false},
+			},
+			telemetry:           &operatorv1alpha1.Telemetry{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+			logsCheckerReturn:   &metav1.Condition{Type: "LogComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonLogGatewayDeploymentReady},
+			tracesCheckerReturn: &metav1.Condition{Type: "TraceComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonNoPipelineDeployed},
+			expectedState:       operatorv1alpha1.StateReady,
+			expectedConditions: []metav1.Condition{
+				{Type: "LogComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonLogGatewayDeploymentReady},
+				{Type: "TraceComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonNoPipelineDeployed},
+			},
+			expectedEndpoints: operatorv1alpha1.GatewayEndpoints{Logs: &operatorv1alpha1.OTLPEndpoints{
+				GRPC: "http://logs.telemetry-system:4317",
+				HTTP: "http://logs.telemetry-system:4318",
+			}},
+		},
 		{
 			name:                 "logs component check error",
 			telemetry:            &operatorv1alpha1.Telemetry{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
@@ -126,7 +192,7 @@ func TestUpdateStatus(t *testing.T) {
 		{
 			name: "metrics component check error",
 			config: &Config{
-				Metrics: MetricsConfig{Enabled: true},
+				Metrics: MetricsConfig{Enabled: true, OTLPServiceName: "metrics", Namespace: "telemetry-system"},
 			},
 			telemetry:           &operatorv1alpha1.Telemetry{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
 			logsCheckerReturn:   &metav1.Condition{Type: "LogComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonFluentBitDSReady},
@@ -140,7 +206,7 @@ func TestUpdateStatus(t *testing.T) {
 		{
 			name: "traces component check error",
 			config: &Config{
-				Metrics: MetricsConfig{Enabled: true},
+				Metrics: MetricsConfig{Enabled: true, OTLPServiceName: "metrics", Namespace: "telemetry-system"},
 			},
 			telemetry:            &operatorv1alpha1.Telemetry{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
 			logsCheckerReturn:    &metav1.Condition{Type: "LogComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonFluentBitDSReady},
@@ -156,7 +222,7 @@ func TestUpdateStatus(t *testing.T) {
 			name: "deleting with no dependent resources",
 			config: &Config{
 				Traces:  TracesConfig{OTLPServiceName: "traces", Namespace: "telemetry-system"},
-				Metrics: MetricsConfig{Enabled: true},
+				Metrics: MetricsConfig{Enabled: true, OTLPServiceName: "metrics", Namespace: "telemetry-system"},
 			},
 			telemetry: &operatorv1alpha1.Telemetry{
 				ObjectMeta: metav1.ObjectMeta{
@@ -174,16 +240,22 @@ func TestUpdateStatus(t *testing.T) {
 				{Type: "MetricComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonMetricGatewayDeploymentReady},
 				{Type: "TraceComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonTraceGatewayDeploymentReady},
 			},
-			expectedEndpoints: operatorv1alpha1.GatewayEndpoints{Traces: &operatorv1alpha1.OTLPEndpoints{
-				GRPC: "http://traces.telemetry-system:4317",
-				HTTP: "http://traces.telemetry-system:4318",
-			}},
+			expectedEndpoints: operatorv1alpha1.GatewayEndpoints{
+				Traces: &operatorv1alpha1.OTLPEndpoints{
+					GRPC: "http://traces.telemetry-system:4317",
+					HTTP: "http://traces.telemetry-system:4318",
+				},
+				Metrics: &operatorv1alpha1.OTLPEndpoints{
+					GRPC: "http://metrics.telemetry-system:4317",
+					HTTP: "http://metrics.telemetry-system:4318",
+				},
+			},
 		},
 		{
 			name: "deleting with dependent resources",
 			config: &Config{
 				Traces:  TracesConfig{OTLPServiceName: "traces", Namespace: "telemetry-system"},
-				Metrics: MetricsConfig{Enabled: true},
+				Metrics: MetricsConfig{Enabled: true, OTLPServiceName: "metrics", Namespace: "telemetry-system"},
 			},
 			telemetry: &operatorv1alpha1.Telemetry{
 				ObjectMeta: metav1.ObjectMeta{
@@ -198,6 +270,12 @@ func TestUpdateStatus(t *testing.T) {
 			resources: []client.Object{
 				pointerFrom(testutils.NewTracePipelineBuilder().Build()),
 			},
+			expectedEndpoints: operatorv1alpha1.GatewayEndpoints{
+				Metrics: &operatorv1alpha1.OTLPEndpoints{
+					GRPC: "http://metrics.telemetry-system:4317",
+					HTTP: "http://metrics.telemetry-system:4318",
+				},
+			},
 			expectedState: operatorv1alpha1.StateWarning,
 			expectedConditions: []metav1.Condition{
 				{Type: "LogComponentsHealthy", Status: metav1.ConditionTrue, Reason: conditions.ReasonFluentBitDSReady},
@@ -263,6 +341,122 @@ func TestUpdateStatus(t *testing.T) {
 	}
 }
 
+func TestUpdatePersistentQueueCondition(t *testing.T) {
+	singleReplica := operatorv1alpha1.Scaling{
+		Type:   operatorv1alpha1.StaticScalingStrategyType,
+		Static: &operatorv1alpha1.StaticScaling{Replicas: 1},
+	}
+	persistentQueue := &operatorv1alpha1.PersistentQueue{PersistentVolumeClaimName: "queue"}
+
+	t.Run("no condition without persistent volume claim", func(t *testing.T) {
+		telemetry := &operatorv1alpha1.Telemetry{
+			Status: operatorv1alpha1.TelemetryStatus{
+				Conditions: []metav1.Condition{{Type: conditions.TypePersistentQueueHealthy, Status: metav1.ConditionFalse}},
+			},
+		}
+		updatePersistentQueueCondition(telemetry)
+		require.Nil(t, meta.FindStatusCondition(telemetry.Status.Conditions, conditions.TypePersistentQueueHealthy))
+	})
+
+	t.Run("persistent volume claim used with one static replica", func(t *testing.T) {
+		telemetry := &operatorv1alpha1.Telemetry{
+			Spec: operatorv1alpha1.TelemetrySpec{
+				Trace: &operatorv1alpha1.TraceSpec{
+					Gateway: operatorv1alpha1.TraceGatewaySpec{Scaling: singleReplica, PersistentQueue: persistentQueue},
+				},
+			},
+		}
+		updatePersistentQueueCondition(telemetry)
+
+		cond := meta.FindStatusCondition(telemetry.Status.Conditions, conditions.TypePersistentQueueHealthy)
+		require.NotNil(t, cond)
+		require.Equal(t, metav1.ConditionTrue, cond.Status)
+		require.Equal(t, conditions.ReasonPersistentVolumeClaimUsed, cond.Reason)
+	})
+
+	t.Run("persistent volume claim ignored without one static replica", func(t *testing.T) {
+		telemetry := &operatorv1alpha1.Telemetry{
+			Spec: operatorv1alpha1.TelemetrySpec{
+				Trace: &operatorv1alpha1.TraceSpec{
+					Gateway: operatorv1alpha1.TraceGatewaySpec{Scaling: singleReplica, PersistentQueue: persistentQueue},
+				},
+				Metric: &operatorv1alpha1.MetricSpec{
+					Gateway: operatorv1alpha1.MetricGatewaySpec{PersistentQueue: persistentQueue},
+				},
+			},
+		}
+		updatePersistentQueueCondition(telemetry)
+
+		cond := meta.FindStatusCondition(telemetry.Status.Conditions, conditions.TypePersistentQueueHealthy)
+		require.NotNil(t, cond)
+		require.Equal(t, metav1.ConditionFalse, cond.Status)
+		require.Equal(t, conditions.ReasonPersistentVolumeClaimIgnored, cond.Reason)
+		require.Contains(t, cond.Message, "metric gateway")
+		require.NotContains(t, cond.Message, "trace gateway")
+	})
+}
+
 func pointerFrom[T any](value T) *T {
 	return &value
 }
+
+func TestUpdatePipelineSummaries(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+	_ = operatorv1alpha1.AddToScheme(scheme)
+
+	running := testutils.NewTracePipelineBuilder().WithName("running").WithStatusConditions(
+		*telemetryv1alpha1.NewTracePipelineCondition(conditions.ReasonTraceGatewayDeploymentNotReady, telemetryv1alpha1.TracePipelinePending),
+		*telemetryv1alpha1.NewTracePipelineCondition(conditions.ReasonTraceGatewayDeploymentReady, telemetryv1alpha1.TracePipelineRunning),
+	).Build()
+	pending := testutils.NewTracePipelineBuilder().WithName("pending").WithStatusConditions(
+		*telemetryv1alpha1.NewTracePipelineCondition(conditions.ReasonReferencedSecretMissing, telemetryv1alpha1.TracePipelinePending),
+	).Build()
+	blocked := testutils.NewTracePipelineBuilder().WithName("blocked").WithStatusConditions(
+		*telemetryv1alpha1.NewTracePipelineCondition(conditions.ReasonMaxPipelinesExceeded, telemetryv1alpha1.TracePipelinePending),
+	).Build()
+	suspended := testutils.NewTracePipelineBuilder().WithName("suspended").WithStatusConditions(
+		*telemetryv1alpha1.NewTracePipelineCondition(conditions.ReasonPipelineSuspended, telemetryv1alpha1.TracePipelineSuspended),
+	).Build()
+	unreconciled := testutils.NewTracePipelineBuilder().WithName("new").Build()
+	logPipeline := testutils.NewLogPipelineBuilder().WithName("logs").WithStatusConditions(
+		*telemetryv1alpha1.NewLogPipelineCondition(conditions.ReasonFluentBitDSReady, telemetryv1alpha1.LogPipelineRunning),
+	).Build()
+
+	namespacedBlocked := telemetryv1alpha1.NamespacedTracePipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "blocked", Namespace: "team-a"},
+		Status: telemetryv1alpha1.TracePipelineStatus{Conditions: []telemetryv1alpha1.TracePipelineCondition{
+			*telemetryv1alpha1.NewTracePipelineCondition(conditions.ReasonMaxPipelinesExceeded, telemetryv1alpha1.TracePipelinePending),
+		}},
+	}
+	namespacedLogPipeline := telemetryv1alpha1.NamespacedLogPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "logs", Namespace: "team-a"},
+		Status: telemetryv1alpha1.LogPipelineStatus{Conditions: []telemetryv1alpha1.LogPipelineCondition{
+			*telemetryv1alpha1.NewLogPipelineCondition(conditions.ReasonLogGatewayDeploymentReady, telemetryv1alpha1.LogPipelineRunning),
+		}},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&running, &pending, &blocked, &suspended, &unreconciled, &logPipeline, &namespacedBlocked, &namespacedLogPipeline).Build()
+
+	t.Run("metrics disabled", func(t *testing.T) {
+		r := &Reconciler{Client: fakeClient, Scheme: scheme}
+		telemetry := &operatorv1alpha1.Telemetry{}
+
+		require.NoError(t, r.updatePipelineSummaries(context.Background(), telemetry))
+
+		require.Equal(t, operatorv1alpha1.PipelineSummaries{
+			Logs:   &operatorv1alpha1.PipelineSummary{Total: 2, Running: 2},
+			Traces: &operatorv1alpha1.PipelineSummary{Total: 6, Running: 1, Pending: 3, Suspended: 1, Blocked: []string{"blocked", "team-a/blocked"}},
+		}, telemetry.Status.Pipelines)
+	})
+
+	t.Run("metrics enabled", func(t *testing.T) {
+		r := &Reconciler{Client: fakeClient, Scheme: scheme, config: Config{Metrics: MetricsConfig{Enabled: true}}}
+		telemetry := &operatorv1alpha1.Telemetry{}
+
+		require.NoError(t, r.updatePipelineSummaries(context.Background(), telemetry))
+
+		require.Equal(t, &operatorv1alpha1.PipelineSummary{}, telemetry.Status.Pipelines.Metrics)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"slices"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -50,6 +51,15 @@ func (t *traceComponentsChecker) determineReason(pipelines []v1alpha1.TracePipel
 		return conditions.ReasonReferencedSecretMissing
 	}
 
+	// The data flow is only evaluated for running pipelines, so data loss is reported after all configuration problems are solved
+	for _, reason := range []string{conditions.ReasonAllDataDropped, conditions.ReasonSomeDataDropped, conditions.ReasonBufferFillingUp} {
+		if found := slices.ContainsFunc(pipelines, func(p v1alpha1.TracePipeline) bool {
+			return t.hasFlowHealthReason(p, reason)
+		}); found {
+			return reason
+		}
+	}
+
 	return conditions.ReasonTraceGatewayDeploymentReady
 }
 
@@ -62,6 +72,11 @@ func (t *traceComponentsChecker) isPendingWithReason(p v1alpha1.TracePipeline, r
 	return lastCondition.Type == v1alpha1.TracePipelinePending && lastCondition.Reason == reason
 }
 
+func (t *traceComponentsChecker) hasFlowHealthReason(p v1alpha1.TracePipeline, reason string) bool {
+	cond := meta.FindStatusCondition(p.Status.HealthConditions, conditions.TypeFlowHealthy)
+	return cond != nil && cond.Reason == reason
+}
+
 func (t *traceComponentsChecker) createMessageForReason(pipelines []v1alpha1.TracePipeline, reason string) string {
 	if reason != conditions.ReasonResourceBlocksDeletion {
 		return conditions.CommonMessageFor(reason)
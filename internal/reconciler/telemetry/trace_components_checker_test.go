@@ -48,6 +48,54 @@ func TestTraceComponentsCheck(t *testing.T) {
 				Message: "Trace gateway Deployment is ready",
 			},
 		},
+		{
+			name: "should not be healthy if one pipeline drops all data",
+			pipelines: []telemetryv1alpha1.TracePipeline{
+				testutils.NewTracePipelineBuilder().WithStatusConditions(testutils.TraceRunningCondition()).
+					WithHealthConditions(testutils.FlowHealthCondition(conditions.ReasonSomeDataDropped)).Build(),
+				testutils.NewTracePipelineBuilder().WithStatusConditions(testutils.TraceRunningCondition()).
+					WithHealthConditions(testutils.FlowHealthCondition(conditions.ReasonAllDataDropped)).Build(),
+			},
+			telemetryInDeletion: false,
+			expectedCondition: &metav1.Condition{
+				Type:    "TraceComponentsHealthy",
+				Status:  "False",
+				Reason:  "AllDataDropped",
+				Message: "All data dropped: the backend is unreachable or rejects all data",
+			},
+		},
+		{
+			name: "should not be healthy if one pipeline buffer is filling up",
+			pipelines: []telemetryv1alpha1.TracePipeline{
+				testutils.NewTracePipelineBuilder().WithStatusConditions(testutils.TraceRunningCondition()).
+					WithHealthConditions(testutils.FlowHealthCondition(conditions.ReasonFlowHealthy)).Build(),
+				testutils.NewTracePipelineBuilder().WithStatusConditions(testutils.TraceRunningCondition()).
+					WithHealthConditions(testutils.FlowHealthCondition(conditions.ReasonBufferFillingUp)).Build(),
+			},
+			telemetryInDeletion: false,
+			expectedCondition: &metav1.Condition{
+				Type:    "TraceComponentsHealthy",
+				Status:  "False",
+				Reason:  "BufferFillingUp",
+				Message: "Buffer nearing capacity: the incoming data rate exceeds the export rate",
+			},
+		},
+		{
+			name: "should prioritize configuration problems over data loss",
+			pipelines: []telemetryv1alpha1.TracePipeline{
+				testutils.NewTracePipelineBuilder().WithStatusConditions(testutils.TraceRunningCondition()).
+					WithHealthConditions(testutils.FlowHealthCondition(conditions.ReasonAllDataDropped)).Build(),
+				testutils.NewTracePipelineBuilder().WithStatusConditions(
+					testutils.TracePendingCondition(conditions.ReasonReferencedSecretMissing)).Build(),
+			},
+			telemetryInDeletion: false,
+			expectedCondition: &metav1.Condition{
+				Type:    "TraceComponentsHealthy",
+				Status:  "False",
+				Reason:  "ReferencedSecretMissing",
+				Message: "One or more referenced Secrets are missing",
+			},
+		},
 		{
 			name: "should not be healthy if one pipeline refs missing secret",
 			pipelines: []telemetryv1alpha1.TracePipeline{
@@ -86,7 +134,7 @@ func TestTraceComponentsCheck(t *testing.T) {
 				testutils.NewTracePipelineBuilder().WithStatusConditions(
 					testutils.TracePendingCondition(conditions.ReasonTraceGatewayDeploymentNotReady), testutils.TraceRunningCondition()).Build(),
 				testutils.NewTracePipelineBuilder().WithStatusConditions(
-					testutils.TracePendingCondition(conditions.ReasonWaitingForLock)).Build(),
+					testutils.TracePendingCondition(conditions.ReasonMaxPipelinesExceeded)).Build(),
 			},
 			telemetryInDeletion: false,
 			expectedCondition: &metav1.Condition{
@@ -19,8 +19,12 @@ package tracepipeline
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"gopkg.in/yaml.v3"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,9 +32,13 @@ import (
 
 	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/flowhealth"
 	"github.com/kyma-project/telemetry-manager/internal/kubernetes"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/trace/gateway"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
 	"github.com/kyma-project/telemetry-manager/internal/overrides"
+	"github.com/kyma-project/telemetry-manager/internal/persistentqueue"
+	"github.com/kyma-project/telemetry-manager/internal/pipelinelimit"
 	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
 	"github.com/kyma-project/telemetry-manager/internal/secretref"
 )
@@ -41,6 +49,12 @@ type Config struct {
 	Gateway                otelcollector.GatewayConfig
 	OverridesConfigMapName types.NamespacedName
 	MaxPipelines           int
+	// MaxPipelinesPerNamespace limits the number of NamespacedTracePipelines per Namespace. If 0, no limit is applied.
+	MaxPipelinesPerNamespace int
+
+	// MetricGatewayServiceName is the name of the OTLP Service of the metric gateway, to which the metrics derived from spans are sent.
+	// If empty, no metrics are derived from spans.
+	MetricGatewayServiceName string
 }
 
 //go:generate mockery --name DeploymentProber --filename deployment_prober.go
@@ -48,18 +62,25 @@ type DeploymentProber interface {
 	IsReady(ctx context.Context, name types.NamespacedName) (bool, error)
 }
 
+//go:generate mockery --name FlowHealthProber --filename flow_health_prober.go
+type FlowHealthProber interface {
+	Probe(ctx context.Context, exporterIDs []string) (flowhealth.ProbeResult, error)
+}
+
 type Reconciler struct {
 	client.Client
 	config           Config
 	prober           DeploymentProber
+	flowHealthProber FlowHealthProber
 	overridesHandler overrides.GlobalConfigHandler
 }
 
-func NewReconciler(client client.Client, config Config, prober DeploymentProber, overridesHandler overrides.GlobalConfigHandler) *Reconciler {
+func NewReconciler(client client.Client, config Config, prober DeploymentProber, flowHealthProber FlowHealthProber, overridesHandler overrides.GlobalConfigHandler) *Reconciler {
 	return &Reconciler{
 		Client:           client,
 		config:           config,
 		prober:           prober,
+		flowHealthProber: flowHealthProber,
 		overridesHandler: overridesHandler,
 	}
 }
@@ -80,20 +101,28 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, nil
 	}
 
-	var tracePipeline telemetryv1alpha1.TracePipeline
-	if err := r.Get(ctx, req.NamespacedName, &tracePipeline); err != nil {
+	pipelineName := req.Name
+	if req.Namespace != "" {
+		pipelineName = telemetryv1alpha1.NamespacedPipelineName(req.Namespace, req.Name)
+	}
+
+	tracePipeline, err := r.getPipeline(ctx, pipelineName)
+	if err != nil {
+		if apierrors.IsNotFound(err) && req.Namespace != "" {
+			return ctrl.Result{}, r.deleteGatewayIfNoPipelineLeft(ctx)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	return ctrl.Result{}, r.doReconcile(ctx, &tracePipeline)
+	return ctrl.Result{}, r.doReconcile(ctx, tracePipeline)
 }
 
 func (r *Reconciler) doReconcile(ctx context.Context, pipeline *telemetryv1alpha1.TracePipeline) error {
 	var err error
-	lockAcquired := true
+	withinLimit := true
 
 	defer func() {
-		if statusErr := r.updateStatus(ctx, pipeline.Name, lockAcquired); statusErr != nil {
+		if statusErr := r.updateStatus(ctx, pipeline.Name, withinLimit); statusErr != nil {
 			if err != nil {
 				err = fmt.Errorf("failed while updating status: %v: %v", statusErr, err)
 			} else {
@@ -102,28 +131,31 @@ func (r *Reconciler) doReconcile(ctx context.Context, pipeline *telemetryv1alpha
 		}
 	}()
 
-	lock := kubernetes.NewResourceCountLock(r.Client, types.NamespacedName{
-		Name:      "telemetry-tracepipeline-lock",
-		Namespace: r.config.Gateway.Namespace,
-	}, r.config.MaxPipelines)
-	if err = lock.TryAcquireLock(ctx, pipeline); err != nil {
-		lockAcquired = false
+	allPipelines, err := r.listPipelines(ctx)
+	if err != nil {
 		return err
 	}
 
-	var allPipelinesList telemetryv1alpha1.TracePipelineList
-	if err = r.List(ctx, &allPipelinesList); err != nil {
-		return fmt.Errorf("failed to list trace pipelines: %w", err)
-	}
-	deployablePipelines, err := getDeployableTracePipelines(ctx, allPipelinesList.Items, r, lock)
-	if err != nil {
-		return fmt.Errorf("failed to fetch deployable trace pipelines: %w", err)
+	if pipeline.DeletionTimestamp.IsZero() && !slices.Contains(SelectPipelines(allPipelines, r.config.MaxPipelines, r.config.MaxPipelinesPerNamespace), pipeline.Name) {
+		withinLimit = false
+		logf.FromContext(ctx).V(1).Info("Skipping reconciliation: maximum pipeline count limit exceeded")
+		return nil
 	}
+
+	deployablePipelines := getDeployableTracePipelines(ctx, allPipelines, r, r.config.MaxPipelines, r.config.MaxPipelinesPerNamespace)
 	if len(deployablePipelines) == 0 {
 		logf.FromContext(ctx).V(1).Info("Skipping reconciliation: no trace pipeline ready for deployment")
 		return nil
 	}
 
+	if len(withoutSuspended(deployablePipelines)) == 0 {
+		logf.FromContext(ctx).V(1).Info("Deleting trace gateway deployment: all trace pipelines are suspended")
+		if err = r.deleteGatewayDeployment(ctx); err != nil {
+			return fmt.Errorf("failed to delete trace gateway deployment: %w", err)
+		}
+		return nil
+	}
+
 	if err = r.reconcileTraceGateway(ctx, pipeline, deployablePipelines); err != nil {
 		return fmt.Errorf("failed to reconcile trace gateway: %w", err)
 	}
@@ -131,11 +163,51 @@ func (r *Reconciler) doReconcile(ctx context.Context, pipeline *telemetryv1alpha
 	return nil
 }
 
+// getPipeline returns the TracePipeline with the given name. If the name is the name of a converted NamespacedTracePipeline, the NamespacedTracePipeline is converted.
+func (r *Reconciler) getPipeline(ctx context.Context, pipelineName string) (*telemetryv1alpha1.TracePipeline, error) {
+	if namespace, name, ok := telemetryv1alpha1.SplitNamespacedPipelineName(pipelineName); ok {
+		var namespacedPipeline telemetryv1alpha1.NamespacedTracePipeline
+		if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &namespacedPipeline); err != nil {
+			return nil, err
+		}
+		pipeline := namespacedPipeline.ToTracePipeline()
+		return &pipeline, nil
+	}
+
+	var pipeline telemetryv1alpha1.TracePipeline
+	if err := r.Get(ctx, types.NamespacedName{Name: pipelineName}, &pipeline); err != nil {
+		return nil, err
+	}
+	return &pipeline, nil
+}
+
+// listPipelines returns all TracePipelines and all converted NamespacedTracePipelines, which are rendered together into the gateway configuration.
+func (r *Reconciler) listPipelines(ctx context.Context) ([]telemetryv1alpha1.TracePipeline, error) {
+	var pipelines telemetryv1alpha1.TracePipelineList
+	if err := r.List(ctx, &pipelines); err != nil {
+		return nil, fmt.Errorf("failed to list trace pipelines: %w", err)
+	}
+
+	var namespacedPipelines telemetryv1alpha1.NamespacedTracePipelineList
+	if err := r.List(ctx, &namespacedPipelines); err != nil {
+		return nil, fmt.Errorf("failed to list namespaced trace pipelines: %w", err)
+	}
+
+	allPipelines := pipelines.Items
+	for i := range namespacedPipelines.Items {
+		allPipelines = append(allPipelines, namespacedPipelines.Items[i].ToTracePipeline())
+	}
+	return allPipelines, nil
+}
+
 // getDeployableTracePipelines returns the list of trace pipelines that are ready to be rendered into the otel collector configuration. A pipeline is deployable if it is not being deleted, all secret references exist, and is not above the pipeline limit.
-func getDeployableTracePipelines(ctx context.Context, allPipelines []telemetryv1alpha1.TracePipeline, client client.Client, lock *kubernetes.ResourceCountLock) ([]telemetryv1alpha1.TracePipeline, error) {
+// A suspended pipeline is deployable, so that it keeps its slot within the pipeline limit and its Secrets are synced, but it is not rendered.
+func getDeployableTracePipelines(ctx context.Context, allPipelines []telemetryv1alpha1.TracePipeline, client client.Client, maxPipelines, maxPipelinesPerNamespace int) []telemetryv1alpha1.TracePipeline {
+	selectedPipelines := SelectPipelines(allPipelines, maxPipelines, maxPipelinesPerNamespace)
+
 	var deployablePipelines []telemetryv1alpha1.TracePipeline
 	for i := range allPipelines {
-		if !allPipelines[i].GetDeletionTimestamp().IsZero() {
+		if !slices.Contains(selectedPipelines, allPipelines[i].Name) {
 			continue
 		}
 
@@ -143,64 +215,234 @@ func getDeployableTracePipelines(ctx context.Context, allPipelines []telemetryv1
 			continue
 		}
 
-		hasLock, err := lock.IsLockHolder(ctx, &allPipelines[i])
-		if err != nil {
-			return nil, err
-		}
+		deployablePipelines = append(deployablePipelines, allPipelines[i])
+	}
+	return deployablePipelines
+}
 
-		if hasLock {
-			deployablePipelines = append(deployablePipelines, allPipelines[i])
+// SelectPipelines returns the names of the pipelines that are within the pipeline limit, ranked by their priority and age.
+// Converted NamespacedTracePipelines must also be within the limit of their Namespace. Pipelines that are being deleted do not count towards the limit.
+func SelectPipelines(allPipelines []telemetryv1alpha1.TracePipeline, maxPipelines, maxPipelinesPerNamespace int) []string {
+	var candidates []pipelinelimit.Pipeline
+	for i := range allPipelines {
+		if !allPipelines[i].GetDeletionTimestamp().IsZero() {
+			continue
 		}
+
+		candidates = append(candidates, pipelinelimit.Pipeline{
+			Name:              allPipelines[i].Name,
+			Namespace:         allPipelines[i].Namespace,
+			Priority:          allPipelines[i].Spec.Priority,
+			CreationTimestamp: allPipelines[i].CreationTimestamp,
+		})
 	}
-	return deployablePipelines, nil
+	return pipelinelimit.Select(pipelinelimit.SelectPerNamespace(candidates, maxPipelinesPerNamespace), maxPipelines)
 }
 
+// reconcileTraceGateway renders the pipelines that are not suspended into the gateway configuration, and syncs the Secrets of all given pipelines into the gateway Secret.
 func (r *Reconciler) reconcileTraceGateway(ctx context.Context, pipeline *telemetryv1alpha1.TracePipeline, allPipelines []telemetryv1alpha1.TracePipeline) error {
-	scaling := otelcollector.GatewayScalingConfig{
-		Replicas:                       r.getReplicaCountFromTelemetry(ctx),
-		ResourceRequirementsMultiplier: len(allPipelines),
+	activePipelines := withoutSuspended(allPipelines)
+
+	scaling := r.getScalingFromTelemetry(ctx)
+	scaling.ResourceRequirementsMultiplier = len(activePipelines)
+
+	receivers := r.getReceiversFromTelemetry(ctx)
+	buildOpts := gateway.BuildOptions{
+		Enrichments: r.getEnrichmentsFromTelemetry(ctx),
+		Cluster:     r.getClusterFromTelemetry(ctx),
+		Receivers:   receivers,
+	}
+	if IsScaledOut(scaling) && r.config.Gateway.LoadBalancingServiceName != "" {
+		buildOpts.LoadBalancingHostname = fmt.Sprintf("%s.%s.svc.cluster.local", r.config.Gateway.LoadBalancingServiceName, r.config.Gateway.Namespace)
+	}
+	if r.config.MetricGatewayServiceName != "" {
+		buildOpts.MetricGatewayEndpoint = fmt.Sprintf("%s.%s.svc.cluster.local:%d", r.config.MetricGatewayServiceName, r.config.Gateway.Namespace, ports.OTLPGRPC)
 	}
 
-	collectorConfig, collectorEnvVars, err := gateway.MakeConfig(ctx, r.Client, allPipelines)
+	collectorConfig, _, err := gateway.MakeConfig(ctx, r.Client, activePipelines, buildOpts)
 	if err != nil {
 		return fmt.Errorf("failed to create collector config: %w", err)
 	}
 
+	collectorEnvVars, err := gateway.MakeEnvVars(ctx, r.Client, allPipelines)
+	if err != nil {
+		return fmt.Errorf("failed to create collector env vars: %w", err)
+	}
+
 	collectorConfigYAML, err := yaml.Marshal(collectorConfig)
 	if err != nil {
 		return fmt.Errorf("failed to marshal collector config: %w", err)
 	}
 
 	if err := otelcollector.ApplyGatewayResources(ctx,
-		kubernetes.NewOwnerReferenceSetter(r.Client, pipeline),
-		r.config.Gateway.WithScaling(scaling).WithCollectorConfig(string(collectorConfigYAML), collectorEnvVars)); err != nil {
+		r.ownerReferenceSetter(pipeline),
+		r.config.Gateway.WithScaling(scaling).
+			WithPersistentQueue(r.getPersistentQueue(ctx, collectorConfig.Extensions.FileStorage != nil)).
+			WithLegacyReceivers(collectorConfig.Receivers.Zipkin != nil, collectorConfig.Receivers.Jaeger != nil).
+			WithCollectorConfig(string(collectorConfigYAML), collectorEnvVars)); err != nil {
 		return fmt.Errorf("failed to apply gateway resources: %w", err)
 	}
 
 	return nil
 }
 
-func (r *Reconciler) getReplicaCountFromTelemetry(ctx context.Context) int32 {
+// deleteGatewayDeployment deletes the gateway Deployment, so that no spans are shipped if all remaining pipelines are suspended.
+// The other gateway resources are kept, so that the gateway is restored with the next reconciliation once a pipeline is resumed.
+func (r *Reconciler) deleteGatewayDeployment(ctx context.Context) error {
+	deployment := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: r.config.Gateway.BaseName, Namespace: r.config.Gateway.Namespace}}
+	return client.IgnoreNotFound(r.Delete(ctx, &deployment))
+}
+
+// deleteGatewayIfNoPipelineLeft deletes the gateway resources once the last pipeline is gone.
+// NamespacedTracePipelines do not own the gateway resources, so the resources are not garbage collected after the last NamespacedTracePipeline is deleted.
+func (r *Reconciler) deleteGatewayIfNoPipelineLeft(ctx context.Context) error {
+	allPipelines, err := r.listPipelines(ctx)
+	if err != nil {
+		return err
+	}
+
+	if slices.ContainsFunc(allPipelines, func(p telemetryv1alpha1.TracePipeline) bool {
+		return p.DeletionTimestamp.IsZero()
+	}) {
+		return nil
+	}
+
+	logf.FromContext(ctx).V(1).Info("Deleting trace gateway resources: no trace pipeline left")
+	if err := otelcollector.DeleteGatewayResources(ctx, r.Client, &r.config.Gateway); err != nil {
+		return fmt.Errorf("failed to delete trace gateway resources: %w", err)
+	}
+	return nil
+}
+
+// withoutSuspended returns the pipelines that are not suspended. Suspended pipelines are not rendered into the gateway configuration,
+// but their Secrets are still synced into the gateway Secret, so that they can be resumed without further changes.
+func withoutSuspended(pipelines []telemetryv1alpha1.TracePipeline) []telemetryv1alpha1.TracePipeline {
+	var active []telemetryv1alpha1.TracePipeline
+	for i := range pipelines {
+		if !pipelines[i].Spec.Suspend {
+			active = append(active, pipelines[i])
+		}
+	}
+	return active
+}
+
+// ownerReferenceSetter returns a client that sets the pipeline as owner of the created resources.
+// A NamespacedTracePipeline cannot own the resources in the gateway Namespace, so they are created without an owner reference.
+func (r *Reconciler) ownerReferenceSetter(pipeline *telemetryv1alpha1.TracePipeline) client.Client {
+	if pipeline.Namespace != "" {
+		return r.Client
+	}
+	return kubernetes.NewOwnerReferenceSetter(r.Client, pipeline)
+}
+
+// IsScaledOut returns true if the gateway runs or can run with more than one replica.
+func IsScaledOut(scaling otelcollector.GatewayScalingConfig) bool {
+	if scaling.Autoscaling != nil {
+		return scaling.Autoscaling.MaxReplicas > 1
+	}
+	return scaling.Replicas > 1
+}
+
+func (r *Reconciler) getScalingFromTelemetry(ctx context.Context) otelcollector.GatewayScalingConfig {
 	var telemetries operatorv1alpha1.TelemetryList
 	if err := r.List(ctx, &telemetries); err != nil {
 		logf.FromContext(ctx).V(1).Error(err, "Failed to list telemetry: using default scaling")
-		return defaultReplicaCount
+		return GatewayScaling(nil)
 	}
 	for i := range telemetries.Items {
-		telemetrySpec := telemetries.Items[i].Spec
-		if telemetrySpec.Trace == nil {
-			continue
+		if telemetries.Items[i].Spec.Trace != nil {
+			return GatewayScaling(&telemetries.Items[i])
 		}
+	}
+	return GatewayScaling(nil)
+}
 
-		scaling := telemetrySpec.Trace.Gateway.Scaling
-		if scaling.Type != operatorv1alpha1.StaticScalingStrategyType {
-			continue
-		}
+// GatewayScaling returns the scaling of the trace gateway configured in the Telemetry resource.
+// If the Telemetry resource is nil or does not configure a valid scaling, the gateway runs with two static replicas.
+func GatewayScaling(telemetry *operatorv1alpha1.Telemetry) otelcollector.GatewayScalingConfig {
+	defaultScaling := otelcollector.GatewayScalingConfig{Replicas: defaultReplicaCount}
+	if telemetry == nil || telemetry.Spec.Trace == nil {
+		return defaultScaling
+	}
 
+	scaling := telemetry.Spec.Trace.Gateway.Scaling
+	switch scaling.Type {
+	case operatorv1alpha1.StaticScalingStrategyType:
 		static := scaling.Static
 		if static != nil && static.Replicas > 0 {
-			return static.Replicas
+			return otelcollector.GatewayScalingConfig{Replicas: static.Replicas}
+		}
+	case operatorv1alpha1.AutoscalingStrategyType:
+		var autoscaling operatorv1alpha1.AutoscalingScaling
+		if scaling.Autoscaling != nil {
+			autoscaling = *scaling.Autoscaling
+		}
+		return otelcollector.GatewayScalingConfig{
+			Autoscaling: otelcollector.NewGatewayAutoscalingConfig(autoscaling.MinReplicas, autoscaling.MaxReplicas,
+				autoscaling.TargetCPUUtilizationPercentage, autoscaling.TargetMemoryUtilizationPercentage),
 		}
 	}
-	return defaultReplicaCount
+	return defaultScaling
+}
+
+// getPersistentQueue returns the volume config for the persistent sending queues, or nil if no output uses a persistent queue.
+// The PersistentVolumeClaim configured in the Telemetry resource is only used if the gateway runs with one static replica.
+func (r *Reconciler) getPersistentQueue(ctx context.Context, required bool) *otelcollector.PersistentQueueConfig {
+	var telemetries operatorv1alpha1.TelemetryList
+	if err := r.List(ctx, &telemetries); err != nil {
+		logf.FromContext(ctx).V(1).Error(err, "Failed to list telemetry: using emptyDir volume for persistent queue")
+		return persistentqueue.MakeConfig(required, nil, operatorv1alpha1.Scaling{})
+	}
+	for i := range telemetries.Items {
+		if traceSpec := telemetries.Items[i].Spec.Trace; traceSpec != nil && traceSpec.Gateway.PersistentQueue != nil {
+			return persistentqueue.MakeConfig(required, traceSpec.Gateway.PersistentQueue, traceSpec.Gateway.Scaling)
+		}
+	}
+	return persistentqueue.MakeConfig(required, nil, operatorv1alpha1.Scaling{})
+}
+
+// getEnrichmentsFromTelemetry returns the additional Kubernetes metadata configured in the Telemetry resource, or nil if none is configured.
+func (r *Reconciler) getEnrichmentsFromTelemetry(ctx context.Context) *operatorv1alpha1.EnrichmentSpec {
+	var telemetries operatorv1alpha1.TelemetryList
+	if err := r.List(ctx, &telemetries); err != nil {
+		logf.FromContext(ctx).V(1).Error(err, "Failed to list telemetry: using default enrichments")
+		return nil
+	}
+	for i := range telemetries.Items {
+		if enrichments := telemetries.Items[i].Spec.Enrichments; enrichments != nil {
+			return enrichments
+		}
+	}
+	return nil
+}
+
+// getClusterFromTelemetry returns the cluster name and attributes configured in the Telemetry resource, or nil if none are configured.
+func (r *Reconciler) getClusterFromTelemetry(ctx context.Context) *operatorv1alpha1.ClusterSpec {
+	var telemetries operatorv1alpha1.TelemetryList
+	if err := r.List(ctx, &telemetries); err != nil {
+		logf.FromContext(ctx).V(1).Error(err, "Failed to list telemetry: using default cluster name")
+		return nil
+	}
+	for i := range telemetries.Items {
+		if cluster := telemetries.Items[i].Spec.Cluster; cluster != nil {
+			return cluster
+		}
+	}
+	return nil
+}
+
+// getReceiversFromTelemetry returns the Zipkin and Jaeger receivers configured in the Telemetry resource, or nil if none are configured.
+func (r *Reconciler) getReceiversFromTelemetry(ctx context.Context) *operatorv1alpha1.TraceGatewayReceivers {
+	var telemetries operatorv1alpha1.TelemetryList
+	if err := r.List(ctx, &telemetries); err != nil {
+		logf.FromContext(ctx).V(1).Error(err, "Failed to list telemetry: using default receivers")
+		return nil
+	}
+	for i := range telemetries.Items {
+		telemetrySpec := telemetries.Items[i].Spec
+		if telemetrySpec.Trace != nil && telemetrySpec.Trace.Gateway.Receivers != nil {
+			return telemetrySpec.Trace.Gateway.Receivers
+		}
+	}
+	return nil
 }
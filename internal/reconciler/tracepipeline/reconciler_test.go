@@ -3,24 +3,29 @@ package tracepipeline
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/kubernetes"
+	"github.com/kyma-project/telemetry-manager/internal/logger"
+	"github.com/kyma-project/telemetry-manager/internal/overrides"
+	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
 )
 
 var (
-	lockName = types.NamespacedName{
-		Name:      "lock",
-		Namespace: "default",
-	}
-
 	pipeline1 = telemetryv1alpha1.TracePipeline{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "pipeline-1",
@@ -79,14 +84,9 @@ func TestGetDeployableTracePipelines(t *testing.T) {
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = telemetryv1alpha1.AddToScheme(scheme)
 	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
-	l := kubernetes.NewResourceCountLock(fakeClient, lockName, 2)
-
-	err := l.TryAcquireLock(ctx, &pipeline1)
-	require.NoError(t, err)
 
 	pipelines := []telemetryv1alpha1.TracePipeline{pipeline1}
-	deployablePipelines, err := getDeployableTracePipelines(ctx, pipelines, fakeClient, l)
-	require.NoError(t, err)
+	deployablePipelines := getDeployableTracePipelines(ctx, pipelines, fakeClient, 2, 0)
 	require.Contains(t, deployablePipelines, pipeline1)
 }
 
@@ -96,69 +96,222 @@ func TestMultipleGetDeployableTracePipelines(t *testing.T) {
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = telemetryv1alpha1.AddToScheme(scheme)
 	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
-	l := kubernetes.NewResourceCountLock(fakeClient, lockName, 2)
-
-	err := l.TryAcquireLock(ctx, &pipeline1)
-	require.NoError(t, err)
-
-	err = l.TryAcquireLock(ctx, &pipeline2)
-	require.NoError(t, err)
 
 	pipelines := []telemetryv1alpha1.TracePipeline{pipeline1, pipeline2}
-	deployablePipelines, err := getDeployableTracePipelines(ctx, pipelines, fakeClient, l)
-	require.NoError(t, err)
+	deployablePipelines := getDeployableTracePipelines(ctx, pipelines, fakeClient, 2, 0)
 	require.Contains(t, deployablePipelines, pipeline1)
 	require.Contains(t, deployablePipelines, pipeline2)
 }
 
-func TestMultipleGetDeployableTracePipelinesWithoutLock(t *testing.T) {
+func TestMultipleGetDeployableTracePipelinesAboveLimit(t *testing.T) {
 	ctx := context.Background()
 	scheme := runtime.NewScheme()
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = telemetryv1alpha1.AddToScheme(scheme)
 	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
-	l := kubernetes.NewResourceCountLock(fakeClient, lockName, 2)
-
-	err := l.TryAcquireLock(ctx, &pipeline1)
-	require.NoError(t, err)
 
 	pipelines := []telemetryv1alpha1.TracePipeline{pipeline1, pipeline2}
-	deployablePipelines, err := getDeployableTracePipelines(ctx, pipelines, fakeClient, l)
-	require.NoError(t, err)
+	deployablePipelines := getDeployableTracePipelines(ctx, pipelines, fakeClient, 1, 0)
 	require.Contains(t, deployablePipelines, pipeline1)
 	require.NotContains(t, deployablePipelines, pipeline2)
 }
 
-func TestGetDeployableTracePipelinesWithMissingSecretReference(t *testing.T) {
+func TestGetDeployableTracePipelinesPrefersHigherPriority(t *testing.T) {
 	ctx := context.Background()
 	scheme := runtime.NewScheme()
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = telemetryv1alpha1.AddToScheme(scheme)
 	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
-	l := kubernetes.NewResourceCountLock(fakeClient, lockName, 2)
 
-	err := l.TryAcquireLock(ctx, &pipelineWithSecretRef)
-	require.NoError(t, err)
+	highPriorityPipeline := pipeline2.DeepCopy()
+	highPriorityPipeline.Spec.Priority = 10
+
+	pipelines := []telemetryv1alpha1.TracePipeline{pipeline1, *highPriorityPipeline}
+	deployablePipelines := getDeployableTracePipelines(ctx, pipelines, fakeClient, 1, 0)
+	require.NotContains(t, deployablePipelines, pipeline1)
+	require.Contains(t, deployablePipelines, *highPriorityPipeline)
+}
+
+func TestGetDeployableTracePipelinesIgnoresDeletedPipelines(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	deletedPipeline := pipeline1.DeepCopy()
+	deletedPipeline.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	pipelines := []telemetryv1alpha1.TracePipeline{*deletedPipeline, pipeline2}
+	deployablePipelines := getDeployableTracePipelines(ctx, pipelines, fakeClient, 1, 0)
+	require.NotContains(t, deployablePipelines, *deletedPipeline)
+	require.Contains(t, deployablePipelines, pipeline2)
+}
+
+func TestGetDeployableTracePipelinesIncludesSuspendedPipelines(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	suspendedPipeline := pipeline1.DeepCopy()
+	suspendedPipeline.Spec.Suspend = true
+
+	pipelines := []telemetryv1alpha1.TracePipeline{*suspendedPipeline, pipeline2}
+	deployablePipelines := getDeployableTracePipelines(ctx, pipelines, fakeClient, 2, 0)
+	require.Contains(t, deployablePipelines, *suspendedPipeline)
+	require.Contains(t, deployablePipelines, pipeline2)
+	require.Equal(t, []telemetryv1alpha1.TracePipeline{pipeline2}, withoutSuspended(deployablePipelines))
+}
+
+func TestGetDeployableTracePipelinesSuspendedPipelineKeepsSlot(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	suspendedPipeline := pipeline1.DeepCopy()
+	suspendedPipeline.Spec.Suspend = true
+
+	pipelines := []telemetryv1alpha1.TracePipeline{*suspendedPipeline, pipeline2}
+	deployablePipelines := getDeployableTracePipelines(ctx, pipelines, fakeClient, 1, 0)
+	require.Equal(t, []telemetryv1alpha1.TracePipeline{*suspendedPipeline}, deployablePipelines)
+}
+
+func TestGetDeployableTracePipelinesWithMissingSecretReference(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
 
 	pipelines := []telemetryv1alpha1.TracePipeline{pipelineWithSecretRef}
-	deployablePipelines, err := getDeployableTracePipelines(ctx, pipelines, fakeClient, l)
-	require.NoError(t, err)
+	deployablePipelines := getDeployableTracePipelines(ctx, pipelines, fakeClient, 2, 0)
 	require.NotContains(t, deployablePipelines, pipelineWithSecretRef)
 }
 
-func TestGetDeployableTracePipelinesWithoutLock(t *testing.T) {
+func TestGetDeployableTracePipelinesAboveNamespaceLimit(t *testing.T) {
 	ctx := context.Background()
 	scheme := runtime.NewScheme()
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = telemetryv1alpha1.AddToScheme(scheme)
 	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
-	l := kubernetes.NewResourceCountLock(fakeClient, lockName, 2)
 
-	err := l.TryAcquireLock(ctx, &pipelineWithSecretRef)
-	require.NoError(t, err)
+	newNamespacedPipeline := func(namespace, name string) telemetryv1alpha1.TracePipeline {
+		namespacedPipeline := telemetryv1alpha1.NamespacedTracePipeline{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: telemetryv1alpha1.NamespacedTracePipelineSpec{
+				Output: telemetryv1alpha1.TracePipelineOutput{
+					Otlp: &telemetryv1alpha1.OtlpOutput{
+						Endpoint: telemetryv1alpha1.ValueType{Value: "localhost"},
+					},
+				},
+			},
+		}
+		return namespacedPipeline.ToTracePipeline()
+	}
+	tenantPipeline1 := newNamespacedPipeline("team-a", "pipeline-1")
+	tenantPipeline2 := newNamespacedPipeline("team-a", "pipeline-2")
+	otherTenantPipeline := newNamespacedPipeline("team-b", "pipeline-1")
 
-	pipelines := []telemetryv1alpha1.TracePipeline{pipeline1}
-	deployablePipelines, err := getDeployableTracePipelines(ctx, pipelines, fakeClient, l)
-	require.NoError(t, err)
-	require.NotContains(t, deployablePipelines, pipeline1)
+	pipelines := []telemetryv1alpha1.TracePipeline{pipeline1, tenantPipeline1, tenantPipeline2, otherTenantPipeline}
+	deployablePipelines := getDeployableTracePipelines(ctx, pipelines, fakeClient, 0, 1)
+	require.Len(t, deployablePipelines, 3)
+	require.Contains(t, deployablePipelines, pipeline1)
+	require.Contains(t, deployablePipelines, tenantPipeline1)
+	require.Contains(t, deployablePipelines, otherTenantPipeline)
+}
+
+func TestReconcileDeletedNamespacedPipeline(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+
+	gatewayName := types.NamespacedName{Name: "telemetry-trace-collector", Namespace: "kyma-system"}
+	namespacedPipeline := telemetryv1alpha1.NamespacedTracePipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipeline-1", Namespace: "team-a"},
+		Spec: telemetryv1alpha1.NamespacedTracePipelineSpec{
+			Output: telemetryv1alpha1.TracePipelineOutput{
+				Otlp: &telemetryv1alpha1.OtlpOutput{
+					Endpoint: telemetryv1alpha1.ValueType{Value: "http://localhost:4317"},
+				},
+			},
+		},
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespacedPipeline.Namespace, Name: namespacedPipeline.Name}}
+
+	newReconciler := func(objs ...client.Object) *Reconciler {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+		return NewReconciler(fakeClient,
+			Config{
+				Gateway: otelcollector.GatewayConfig{
+					Config:          otelcollector.Config{BaseName: gatewayName.Name, Namespace: gatewayName.Namespace},
+					OTLPServiceName: "telemetry-otlp-traces",
+				},
+				OverridesConfigMapName: types.NamespacedName{Name: "overrides", Namespace: gatewayName.Namespace},
+			},
+			nil,
+			nil,
+			overrides.New(logger.NewLogReconfigurer(zap.NewAtomicLevel()), &kubernetes.ConfigmapProber{Client: fakeClient}))
+	}
+
+	t.Run("deletes the gateway resources after the last pipeline is deleted", func(t *testing.T) {
+		sut := newReconciler()
+		convertedPipeline := namespacedPipeline.ToTracePipeline()
+		require.NoError(t, sut.reconcileTraceGateway(ctx, &convertedPipeline, []telemetryv1alpha1.TracePipeline{convertedPipeline}))
+		require.NoError(t, sut.Get(ctx, gatewayName, &appsv1.Deployment{}))
+
+		_, err := sut.Reconcile(ctx, request)
+		require.NoError(t, err)
+
+		require.True(t, apierrors.IsNotFound(sut.Get(ctx, gatewayName, &appsv1.Deployment{})))
+		require.True(t, apierrors.IsNotFound(sut.Get(ctx, gatewayName, &corev1.Secret{})))
+		require.True(t, apierrors.IsNotFound(sut.Get(ctx, types.NamespacedName{Name: "telemetry-otlp-traces", Namespace: gatewayName.Namespace}, &corev1.Service{})))
+	})
+
+	t.Run("keeps the gateway resources if other pipelines are left", func(t *testing.T) {
+		sut := newReconciler(pipeline1.DeepCopy())
+		require.NoError(t, sut.reconcileTraceGateway(ctx, &pipeline1, []telemetryv1alpha1.TracePipeline{pipeline1}))
+
+		_, err := sut.Reconcile(ctx, request)
+		require.NoError(t, err)
+
+		require.NoError(t, sut.Get(ctx, gatewayName, &appsv1.Deployment{}))
+		require.NoError(t, sut.Get(ctx, gatewayName, &corev1.Secret{}))
+	})
+}
+
+func TestReconcileTraceGatewayWithSuspendedPipeline(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+
+	gatewayName := types.NamespacedName{Name: "telemetry-trace-collector", Namespace: "kyma-system"}
+	sut := Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		config: Config{
+			Gateway: otelcollector.GatewayConfig{
+				Config:          otelcollector.Config{BaseName: gatewayName.Name, Namespace: gatewayName.Namespace},
+				OTLPServiceName: "telemetry-otlp-traces",
+			},
+		},
+	}
+
+	suspendedPipeline := pipeline2.DeepCopy()
+	suspendedPipeline.Spec.Suspend = true
+	require.NoError(t, sut.reconcileTraceGateway(ctx, &pipeline1, []telemetryv1alpha1.TracePipeline{pipeline1, *suspendedPipeline}))
+
+	var configMap corev1.ConfigMap
+	require.NoError(t, sut.Get(ctx, gatewayName, &configMap))
+	require.Contains(t, configMap.Data["relay.conf"], "traces/pipeline-1")
+	require.NotContains(t, configMap.Data["relay.conf"], "traces/pipeline-2")
+
+	var secret corev1.Secret
+	require.NoError(t, sut.Get(ctx, gatewayName, &secret))
+	require.Contains(t, secret.Data, "OTLP_ENDPOINT_PIPELINE_1")
+	require.Contains(t, secret.Data, "OTLP_ENDPOINT_PIPELINE_2")
 }
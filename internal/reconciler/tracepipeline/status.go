@@ -3,22 +3,68 @@ package tracepipeline
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/conditions"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/trace/gateway"
 	"github.com/kyma-project/telemetry-manager/internal/secretref"
 )
 
-func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, lockAcquired bool) error {
+func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, withinLimit bool) error {
+	if err := r.updateStatusOutputs(ctx, pipelineName); err != nil {
+		return err
+	}
+	if err := r.updateStatusConditions(ctx, pipelineName, withinLimit); err != nil {
+		return err
+	}
+	return r.updateStatusFlowHealth(ctx, pipelineName)
+}
+
+func (r *Reconciler) updateStatusOutputs(ctx context.Context, pipelineName string) error {
+	pipeline, err := r.getPipeline(ctx, pipelineName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get TracePipeline: %v", err)
+	}
+
+	if pipeline.DeletionTimestamp != nil {
+		return nil
+	}
+
+	var desiredOutputs []telemetryv1alpha1.OutputStatus
+	for _, output := range pipeline.Spec.AllOutputs() {
+		reason := conditions.ReasonOutputReady
+		if secretref.ReferencesNonExistentSecret(ctx, r.Client, &output) {
+			reason = conditions.ReasonReferencedSecretMissing
+		}
+		desiredOutputs = append(desiredOutputs, telemetryv1alpha1.OutputStatus{Name: output.Name, Reason: reason})
+	}
+
+	if !reflect.DeepEqual(pipeline.Status.Outputs, desiredOutputs) {
+		pipeline.Status.Outputs = desiredOutputs
+		if err := r.updatePipelineStatus(ctx, pipeline); err != nil {
+			return fmt.Errorf("failed to update TracePipeline outputs status: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) updateStatusConditions(ctx context.Context, pipelineName string, withinLimit bool) error {
 	log := logf.FromContext(ctx)
 
-	var pipeline telemetryv1alpha1.TracePipeline
-	if err := r.Get(ctx, types.NamespacedName{Name: pipelineName}, &pipeline); err != nil {
+	pipeline, err := r.getPipeline(ctx, pipelineName)
+	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil
 		}
@@ -30,18 +76,41 @@ func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, lock
 		return nil
 	}
 
-	if !lockAcquired {
-		pending := telemetryv1alpha1.NewTracePipelineCondition(conditions.ReasonWaitingForLock, telemetryv1alpha1.TracePipelinePending)
+	if !withinLimit {
+		allPipelines, err := r.listPipelines(ctx)
+		if err != nil {
+			return err
+		}
+
+		pending := telemetryv1alpha1.NewTracePipelineCondition(conditions.ReasonMaxPipelinesExceeded, telemetryv1alpha1.TracePipelinePending)
+		pending.Message = conditions.MessageForMaxPipelinesExceeded(visiblePipelineNames(pipeline, SelectPipelines(allPipelines, r.config.MaxPipelines, r.config.MaxPipelinesPerNamespace)))
 
 		if pipeline.Status.HasCondition(telemetryv1alpha1.TracePipelineRunning) {
 			log.V(1).Info(fmt.Sprintf("Updating the status of %s to %s. Resetting previous conditions", pipeline.Name, pending.Type))
 			pipeline.Status.Conditions = []telemetryv1alpha1.TracePipelineCondition{}
 		}
 
-		return setCondition(ctx, r.Client, &pipeline, pending)
+		return r.setCondition(ctx, pipeline, pending)
 	}
 
-	referencesNonExistentSecret := secretref.ReferencesNonExistentSecret(ctx, r.Client, &pipeline)
+	if pipeline.Spec.Suspend {
+		if isSuspended(pipeline) {
+			return nil
+		}
+
+		suspended := telemetryv1alpha1.NewTracePipelineCondition(conditions.ReasonPipelineSuspended, telemetryv1alpha1.TracePipelineSuspended)
+		log.V(1).Info(fmt.Sprintf("Updating the status of %s to %s. Resetting previous conditions", pipeline.Name, suspended.Type))
+		pipeline.Status.Conditions = []telemetryv1alpha1.TracePipelineCondition{}
+
+		return r.setCondition(ctx, pipeline, suspended)
+	}
+
+	if pipeline.Status.HasCondition(telemetryv1alpha1.TracePipelineSuspended) {
+		log.V(1).Info(fmt.Sprintf("Resuming %s. Resetting previous conditions", pipeline.Name))
+		pipeline.Status.Conditions = []telemetryv1alpha1.TracePipelineCondition{}
+	}
+
+	referencesNonExistentSecret := secretref.ReferencesNonExistentSecret(ctx, r.Client, pipeline)
 	if referencesNonExistentSecret {
 		pending := telemetryv1alpha1.NewTracePipelineCondition(conditions.ReasonReferencedSecretMissing, telemetryv1alpha1.TracePipelinePending)
 
@@ -50,7 +119,7 @@ func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, lock
 			pipeline.Status.Conditions = []telemetryv1alpha1.TracePipelineCondition{}
 		}
 
-		return setCondition(ctx, r.Client, &pipeline, pending)
+		return r.setCondition(ctx, pipeline, pending)
 	}
 
 	gatewayReady, err := r.prober.IsReady(ctx, types.NamespacedName{Name: r.config.Gateway.BaseName, Namespace: r.config.Gateway.Namespace})
@@ -64,7 +133,7 @@ func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, lock
 		}
 
 		running := telemetryv1alpha1.NewTracePipelineCondition(conditions.ReasonTraceGatewayDeploymentReady, telemetryv1alpha1.TracePipelineRunning)
-		return setCondition(ctx, r.Client, &pipeline, running)
+		return r.setCondition(ctx, pipeline, running)
 	}
 
 	pending := telemetryv1alpha1.NewTracePipelineCondition(conditions.ReasonTraceGatewayDeploymentNotReady, telemetryv1alpha1.TracePipelinePending)
@@ -74,18 +143,127 @@ func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, lock
 		pipeline.Status.Conditions = []telemetryv1alpha1.TracePipelineCondition{}
 	}
 
-	return setCondition(ctx, r.Client, &pipeline, pending)
+	return r.setCondition(ctx, pipeline, pending)
+}
+
+// updateStatusFlowHealth evaluates the data flow of a running pipeline. A failed evaluation does not fail the reconciliation, but is reported with an Unknown condition.
+func (r *Reconciler) updateStatusFlowHealth(ctx context.Context, pipelineName string) error {
+	pipeline, err := r.getPipeline(ctx, pipelineName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get TracePipeline: %v", err)
+	}
+
+	if pipeline.DeletionTimestamp != nil {
+		return nil
+	}
+
+	existing := pipeline.Status.DeepCopy().HealthConditions
+	if isRunning(pipeline) {
+		meta.SetStatusCondition(&pipeline.Status.HealthConditions, r.evaluateFlowHealth(ctx, pipeline))
+	} else {
+		meta.RemoveStatusCondition(&pipeline.Status.HealthConditions, conditions.TypeFlowHealthy)
+	}
+
+	if reflect.DeepEqual(existing, pipeline.Status.HealthConditions) {
+		return nil
+	}
+
+	if err := r.updatePipelineStatus(ctx, pipeline); err != nil {
+		return fmt.Errorf("failed to update TracePipeline flow health status: %v", err)
+	}
+	return nil
+}
+
+func (r *Reconciler) evaluateFlowHealth(ctx context.Context, pipeline *telemetryv1alpha1.TracePipeline) metav1.Condition {
+	var exporterIDs []string
+	for _, output := range pipeline.Spec.AllOutputs() {
+		exporterIDs = append(exporterIDs, gateway.ExporterID(pipeline.Name, output))
+	}
+
+	status := metav1.ConditionFalse
+	var reason string
+	result, err := r.flowHealthProber.Probe(ctx, exporterIDs)
+	switch {
+	case err != nil:
+		logf.FromContext(ctx).V(1).Info(fmt.Sprintf("Failed to probe the flow health of %s: %v", pipeline.Name, err))
+		status = metav1.ConditionUnknown
+		reason = conditions.ReasonFlowHealthProbingFailed
+	case result.AllDataDropped:
+		reason = conditions.ReasonAllDataDropped
+	case result.SomeDataDropped:
+		reason = conditions.ReasonSomeDataDropped
+	case result.BufferFillingUp:
+		reason = conditions.ReasonBufferFillingUp
+	default:
+		status = metav1.ConditionTrue
+		reason = conditions.ReasonFlowHealthy
+	}
+
+	return metav1.Condition{
+		Type:               conditions.TypeFlowHealthy,
+		Status:             status,
+		Reason:             reason,
+		Message:            conditions.CommonMessageFor(reason),
+		ObservedGeneration: pipeline.Generation,
+	}
 }
 
-func setCondition(ctx context.Context, client client.Client, pipeline *telemetryv1alpha1.TracePipeline, condition *telemetryv1alpha1.TracePipelineCondition) error {
+func isRunning(pipeline *telemetryv1alpha1.TracePipeline) bool {
+	pipelineConditions := pipeline.Status.Conditions
+	return len(pipelineConditions) > 0 && pipelineConditions[len(pipelineConditions)-1].Type == telemetryv1alpha1.TracePipelineRunning
+}
+
+func isSuspended(pipeline *telemetryv1alpha1.TracePipeline) bool {
+	pipelineConditions := pipeline.Status.Conditions
+	return len(pipelineConditions) > 0 && pipelineConditions[len(pipelineConditions)-1].Type == telemetryv1alpha1.TracePipelineSuspended
+}
+
+func (r *Reconciler) setCondition(ctx context.Context, pipeline *telemetryv1alpha1.TracePipeline, condition *telemetryv1alpha1.TracePipelineCondition) error {
 	log := logf.FromContext(ctx)
 
 	log.V(1).Info(fmt.Sprintf("Updating the status of %s to %s", pipeline.Name, condition.Type))
 
 	pipeline.Status.SetCondition(*condition)
 
-	if err := client.Status().Update(ctx, pipeline); err != nil {
+	if err := r.updatePipelineStatus(ctx, pipeline); err != nil {
 		return fmt.Errorf("failed to update TracePipeline status to %s: %v", condition.Type, err)
 	}
 	return nil
 }
+
+// updatePipelineStatus persists the status of the pipeline. The status of a converted NamespacedTracePipeline is written to the NamespacedTracePipeline.
+func (r *Reconciler) updatePipelineStatus(ctx context.Context, pipeline *telemetryv1alpha1.TracePipeline) error {
+	namespace, name, ok := telemetryv1alpha1.SplitNamespacedPipelineName(pipeline.Name)
+	if !ok {
+		return r.Status().Update(ctx, pipeline)
+	}
+
+	var namespacedPipeline telemetryv1alpha1.NamespacedTracePipeline
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &namespacedPipeline); err != nil {
+		return err
+	}
+	namespacedPipeline.ResourceVersion = pipeline.ResourceVersion
+	namespacedPipeline.Status = pipeline.Status
+	return r.Status().Update(ctx, &namespacedPipeline)
+}
+
+// visiblePipelineNames returns the names of the selected pipelines that can be shown in the status of the given pipeline.
+// A NamespacedTracePipeline only sees the selected pipelines of its own Namespace, so that the names of other tenants are not exposed.
+func visiblePipelineNames(pipeline *telemetryv1alpha1.TracePipeline, selectedPipelines []string) []string {
+	namespace, _, ok := telemetryv1alpha1.SplitNamespacedPipelineName(pipeline.Name)
+	if !ok {
+		return selectedPipelines
+	}
+
+	var visible []string
+	for _, selected := range selectedPipelines {
+		if selectedNamespace, selectedName, isNamespaced := telemetryv1alpha1.SplitNamespacedPipelineName(selected); isNamespaced && selectedNamespace == namespace {
+			visible = append(visible, selectedName)
+		}
+	}
+	return visible
+}
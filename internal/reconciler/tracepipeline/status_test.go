@@ -2,11 +2,13 @@ package tracepipeline
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -15,10 +17,18 @@ import (
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/conditions"
+	"github.com/kyma-project/telemetry-manager/internal/flowhealth"
 	"github.com/kyma-project/telemetry-manager/internal/reconciler/tracepipeline/mocks"
 	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
 )
 
+func healthyFlowHealthProber() *mocks.FlowHealthProber {
+	flowHealthProberStub := &mocks.FlowHealthProber{}
+	flowHealthProberStub.On("Probe", mock.Anything, mock.Anything).Return(flowhealth.ProbeResult{}, nil)
+	return flowHealthProberStub
+}
+
 func TestUpdateStatus(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = clientgoscheme.AddToScheme(scheme)
@@ -47,7 +57,8 @@ func TestUpdateStatus(t *testing.T) {
 			config: Config{Gateway: otelcollector.GatewayConfig{
 				Config: otelcollector.Config{BaseName: "trace-gateway"},
 			}},
-			prober: proberStub,
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
 		}
 		err := sut.updateStatus(context.Background(), pipeline.Name, true)
 		require.NoError(t, err)
@@ -82,7 +93,8 @@ func TestUpdateStatus(t *testing.T) {
 			config: Config{Gateway: otelcollector.GatewayConfig{
 				Config: otelcollector.Config{BaseName: "trace-gateway"},
 			}},
-			prober: proberStub,
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
 		}
 		err := sut.updateStatus(context.Background(), pipeline.Name, true)
 		require.NoError(t, err)
@@ -123,7 +135,8 @@ func TestUpdateStatus(t *testing.T) {
 			config: Config{Gateway: otelcollector.GatewayConfig{
 				Config: otelcollector.Config{BaseName: "trace-gateway"},
 			}},
-			prober: proberStub,
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
 		}
 		err := sut.updateStatus(context.Background(), pipeline.Name, true)
 		require.NoError(t, err)
@@ -172,7 +185,8 @@ func TestUpdateStatus(t *testing.T) {
 			config: Config{Gateway: otelcollector.GatewayConfig{
 				Config: otelcollector.Config{BaseName: "trace-gateway"},
 			}},
-			prober: proberStub,
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
 		}
 
 		err := sut.updateStatus(context.Background(), pipeline.Name, true)
@@ -224,7 +238,8 @@ func TestUpdateStatus(t *testing.T) {
 			config: Config{Gateway: otelcollector.GatewayConfig{
 				Config: otelcollector.Config{BaseName: "trace-gateway"},
 			}},
-			prober: proberStub,
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
 		}
 
 		err := sut.updateStatus(context.Background(), pipeline.Name, true)
@@ -237,7 +252,7 @@ func TestUpdateStatus(t *testing.T) {
 		require.Equal(t, updatedPipeline.Status.Conditions[0].Reason, conditions.ReasonTraceGatewayDeploymentReady)
 	})
 
-	t.Run("should add pending condition if waiting for lock", func(t *testing.T) {
+	t.Run("should add pending condition if maximum pipeline count limit exceeded", func(t *testing.T) {
 		pipelineName := "pipeline"
 		pipeline := &telemetryv1alpha1.TracePipeline{
 			ObjectMeta: metav1.ObjectMeta{
@@ -250,7 +265,10 @@ func TestUpdateStatus(t *testing.T) {
 					},
 				}},
 		}
-		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).WithStatusSubresource(pipeline).Build()
+		winnerPipeline := pipeline.DeepCopy()
+		winnerPipeline.Name = "winner"
+		winnerPipeline.Spec.Priority = 10
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline, winnerPipeline).WithStatusSubresource(pipeline, winnerPipeline).Build()
 
 		proberStub := &mocks.DeploymentProber{}
 		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(false, nil)
@@ -259,8 +277,11 @@ func TestUpdateStatus(t *testing.T) {
 			Client: fakeClient,
 			config: Config{Gateway: otelcollector.GatewayConfig{
 				Config: otelcollector.Config{BaseName: "trace-gateway"},
-			}},
-			prober: proberStub,
+			},
+				MaxPipelines: 1,
+			},
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
 		}
 		err := sut.updateStatus(context.Background(), pipeline.Name, false)
 		require.NoError(t, err)
@@ -269,7 +290,8 @@ func TestUpdateStatus(t *testing.T) {
 		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipelineName}, &updatedPipeline)
 		require.Len(t, updatedPipeline.Status.Conditions, 1)
 		require.Equal(t, updatedPipeline.Status.Conditions[0].Type, telemetryv1alpha1.TracePipelinePending)
-		require.Equal(t, updatedPipeline.Status.Conditions[0].Reason, conditions.ReasonWaitingForLock)
+		require.Equal(t, updatedPipeline.Status.Conditions[0].Reason, conditions.ReasonMaxPipelinesExceeded)
+		require.Equal(t, "Maximum pipeline count limit exceeded. Deployed pipelines: winner", updatedPipeline.Status.Conditions[0].Message)
 	})
 
 	t.Run("should add pending condition if acquired lock but trace gateway is not ready", func(t *testing.T) {
@@ -295,7 +317,8 @@ func TestUpdateStatus(t *testing.T) {
 			config: Config{Gateway: otelcollector.GatewayConfig{
 				Config: otelcollector.Config{BaseName: "trace-gateway"},
 			}},
-			prober: proberStub,
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
 		}
 		err := sut.updateStatus(context.Background(), pipeline.Name, false)
 		require.NoError(t, err)
@@ -308,4 +331,352 @@ func TestUpdateStatus(t *testing.T) {
 		require.Equal(t, updatedPipeline.Status.Conditions[0].Type, telemetryv1alpha1.TracePipelinePending)
 		require.Equal(t, updatedPipeline.Status.Conditions[0].Reason, conditions.ReasonTraceGatewayDeploymentNotReady)
 	})
+
+	t.Run("should reset conditions and add suspended condition if pipeline is suspended", func(t *testing.T) {
+		pipelineName := "pipeline"
+		pipeline := &telemetryv1alpha1.TracePipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: pipelineName,
+			},
+			Spec: telemetryv1alpha1.TracePipelineSpec{
+				Suspend: true,
+				Output: telemetryv1alpha1.TracePipelineOutput{
+					Otlp: &telemetryv1alpha1.OtlpOutput{
+						Endpoint: telemetryv1alpha1.ValueType{Value: "localhost"},
+					},
+				}},
+			Status: telemetryv1alpha1.TracePipelineStatus{
+				Conditions: []telemetryv1alpha1.TracePipelineCondition{
+					{Reason: conditions.ReasonTraceGatewayDeploymentNotReady, Type: telemetryv1alpha1.TracePipelinePending},
+					{Reason: conditions.ReasonTraceGatewayDeploymentReady, Type: telemetryv1alpha1.TracePipelineRunning},
+				},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).WithStatusSubresource(pipeline).Build()
+
+		proberStub := &mocks.DeploymentProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{Gateway: otelcollector.GatewayConfig{
+				Config: otelcollector.Config{BaseName: "trace-gateway"},
+			}},
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
+		}
+		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.TracePipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipelineName}, &updatedPipeline)
+		require.Len(t, updatedPipeline.Status.Conditions, 1)
+		require.Equal(t, updatedPipeline.Status.Conditions[0].Type, telemetryv1alpha1.TracePipelineSuspended)
+		require.Equal(t, updatedPipeline.Status.Conditions[0].Reason, conditions.ReasonPipelineSuspended)
+	})
+
+	t.Run("should reset conditions and add pending condition if pipeline is resumed", func(t *testing.T) {
+		pipelineName := "pipeline"
+		pipeline := &telemetryv1alpha1.TracePipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: pipelineName,
+			},
+			Spec: telemetryv1alpha1.TracePipelineSpec{
+				Output: telemetryv1alpha1.TracePipelineOutput{
+					Otlp: &telemetryv1alpha1.OtlpOutput{
+						Endpoint: telemetryv1alpha1.ValueType{Value: "localhost"},
+					},
+				}},
+			Status: telemetryv1alpha1.TracePipelineStatus{
+				Conditions: []telemetryv1alpha1.TracePipelineCondition{
+					{Reason: conditions.ReasonPipelineSuspended, Type: telemetryv1alpha1.TracePipelineSuspended},
+				},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).WithStatusSubresource(pipeline).Build()
+
+		proberStub := &mocks.DeploymentProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(false, nil)
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{Gateway: otelcollector.GatewayConfig{
+				Config: otelcollector.Config{BaseName: "trace-gateway"},
+			}},
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
+		}
+		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.TracePipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipelineName}, &updatedPipeline)
+		require.Len(t, updatedPipeline.Status.Conditions, 1)
+		require.Equal(t, updatedPipeline.Status.Conditions[0].Type, telemetryv1alpha1.TracePipelinePending)
+		require.Equal(t, updatedPipeline.Status.Conditions[0].Reason, conditions.ReasonTraceGatewayDeploymentNotReady)
+	})
+
+	t.Run("should report the status of every output", func(t *testing.T) {
+		pipelineName := "pipeline"
+		pipeline := &telemetryv1alpha1.TracePipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: pipelineName,
+			},
+			Spec: telemetryv1alpha1.TracePipelineSpec{
+				Output: telemetryv1alpha1.TracePipelineOutput{
+					Otlp: &telemetryv1alpha1.OtlpOutput{
+						Endpoint: telemetryv1alpha1.ValueType{Value: "localhost"},
+					},
+				},
+				AdditionalOutputs: []telemetryv1alpha1.NamedTracePipelineOutput{
+					{
+						Name: "archive",
+						TracePipelineOutput: telemetryv1alpha1.TracePipelineOutput{
+							Otlp: &telemetryv1alpha1.OtlpOutput{
+								Endpoint: telemetryv1alpha1.ValueType{
+									ValueFrom: &telemetryv1alpha1.ValueFromSource{
+										SecretKeyRef: &telemetryv1alpha1.SecretKeyRef{
+											Name:      "some-secret",
+											Namespace: "some-namespace",
+											Key:       "host",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).WithStatusSubresource(pipeline).Build()
+
+		proberStub := &mocks.DeploymentProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{Gateway: otelcollector.GatewayConfig{
+				Config: otelcollector.Config{BaseName: "trace-gateway"},
+			}},
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
+		}
+		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.TracePipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipelineName}, &updatedPipeline)
+		require.Equal(t, []telemetryv1alpha1.OutputStatus{
+			{Name: "default", Reason: conditions.ReasonOutputReady},
+			{Name: "archive", Reason: conditions.ReasonReferencedSecretMissing},
+		}, updatedPipeline.Status.Outputs)
+	})
+
+	t.Run("should set flow health condition of a running pipeline", func(t *testing.T) {
+		tests := []struct {
+			name           string
+			probeResult    flowhealth.ProbeResult
+			probeErr       error
+			expectedStatus metav1.ConditionStatus
+			expectedReason string
+		}{
+			{
+				name:           "healthy",
+				expectedStatus: metav1.ConditionTrue,
+				expectedReason: conditions.ReasonFlowHealthy,
+			},
+			{
+				name:           "buffer filling up",
+				probeResult:    flowhealth.ProbeResult{BufferFillingUp: true},
+				expectedStatus: metav1.ConditionFalse,
+				expectedReason: conditions.ReasonBufferFillingUp,
+			},
+			{
+				name:           "some data dropped",
+				probeResult:    flowhealth.ProbeResult{SomeDataDropped: true, BufferFillingUp: true},
+				expectedStatus: metav1.ConditionFalse,
+				expectedReason: conditions.ReasonSomeDataDropped,
+			},
+			{
+				name:           "all data dropped",
+				probeResult:    flowhealth.ProbeResult{AllDataDropped: true, SomeDataDropped: true},
+				expectedStatus: metav1.ConditionFalse,
+				expectedReason: conditions.ReasonAllDataDropped,
+			},
+			{
+				name:           "probing failed",
+				probeErr:       errors.New("connection refused"),
+				expectedStatus: metav1.ConditionUnknown,
+				expectedReason: conditions.ReasonFlowHealthProbingFailed,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				pipeline := testutils.NewTracePipelineBuilder().WithName("pipeline").Build()
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline).WithStatusSubresource(&pipeline).Build()
+
+				proberStub := &mocks.DeploymentProber{}
+				proberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+				flowHealthProberStub := &mocks.FlowHealthProber{}
+				flowHealthProberStub.On("Probe", mock.Anything, []string{"otlp/pipeline"}).Return(tt.probeResult, tt.probeErr)
+
+				sut := Reconciler{
+					Client: fakeClient,
+					config: Config{Gateway: otelcollector.GatewayConfig{
+						Config: otelcollector.Config{BaseName: "trace-gateway"},
+					}},
+					prober:           proberStub,
+					flowHealthProber: flowHealthProberStub,
+				}
+				err := sut.updateStatus(context.Background(), pipeline.Name, true)
+				require.NoError(t, err)
+
+				var updatedPipeline telemetryv1alpha1.TracePipeline
+				_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+				cond := meta.FindStatusCondition(updatedPipeline.Status.HealthConditions, conditions.TypeFlowHealthy)
+				require.NotNil(t, cond)
+				require.Equal(t, tt.expectedStatus, cond.Status)
+				require.Equal(t, tt.expectedReason, cond.Reason)
+			})
+		}
+	})
+
+	t.Run("should probe the flow health of every output", func(t *testing.T) {
+		pipeline := testutils.NewTracePipelineBuilder().WithName("pipeline").WithAdditionalZipkinOutput("legacy", "http://zipkin:9411/api/v2/spans").Build()
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline).WithStatusSubresource(&pipeline).Build()
+
+		proberStub := &mocks.DeploymentProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+		flowHealthProberStub := &mocks.FlowHealthProber{}
+		flowHealthProberStub.On("Probe", mock.Anything, []string{"otlp/pipeline", "zipkin/pipeline_legacy"}).Return(flowhealth.ProbeResult{}, nil)
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{Gateway: otelcollector.GatewayConfig{
+				Config: otelcollector.Config{BaseName: "trace-gateway"},
+			}},
+			prober:           proberStub,
+			flowHealthProber: flowHealthProberStub,
+		}
+		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.TracePipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+		cond := meta.FindStatusCondition(updatedPipeline.Status.HealthConditions, conditions.TypeFlowHealthy)
+		require.NotNil(t, cond)
+		require.Equal(t, conditions.ReasonFlowHealthy, cond.Reason)
+		require.Equal(t, []telemetryv1alpha1.OutputStatus{
+			{Name: "default", Reason: conditions.ReasonOutputReady},
+			{Name: "legacy", Reason: conditions.ReasonOutputReady},
+		}, updatedPipeline.Status.Outputs)
+	})
+
+	t.Run("should not set flow health condition of a pending pipeline", func(t *testing.T) {
+		pipeline := testutils.NewTracePipelineBuilder().WithName("pipeline").Build()
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline).WithStatusSubresource(&pipeline).Build()
+
+		proberStub := &mocks.DeploymentProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(false, nil)
+		flowHealthProberStub := &mocks.FlowHealthProber{}
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{Gateway: otelcollector.GatewayConfig{
+				Config: otelcollector.Config{BaseName: "trace-gateway"},
+			}},
+			prober:           proberStub,
+			flowHealthProber: flowHealthProberStub,
+		}
+		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.TracePipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+		require.Empty(t, updatedPipeline.Status.HealthConditions)
+		flowHealthProberStub.AssertNotCalled(t, "Probe", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should update the status of a namespaced pipeline", func(t *testing.T) {
+		pipeline := &telemetryv1alpha1.NamespacedTracePipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pipeline",
+				Namespace: "team-a",
+			},
+			Spec: telemetryv1alpha1.NamespacedTracePipelineSpec{
+				Output: telemetryv1alpha1.TracePipelineOutput{
+					Otlp: &telemetryv1alpha1.OtlpOutput{
+						Endpoint: telemetryv1alpha1.ValueType{Value: "localhost"},
+					},
+				}},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).WithStatusSubresource(pipeline).Build()
+
+		proberStub := &mocks.DeploymentProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{Gateway: otelcollector.GatewayConfig{
+				Config: otelcollector.Config{BaseName: "trace-gateway"},
+			}},
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
+		}
+		err := sut.updateStatus(context.Background(), telemetryv1alpha1.NamespacedPipelineName("team-a", "pipeline"), true)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.NamespacedTracePipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: "pipeline", Namespace: "team-a"}, &updatedPipeline)
+		require.Len(t, updatedPipeline.Status.Conditions, 1)
+		require.Equal(t, telemetryv1alpha1.TracePipelineRunning, updatedPipeline.Status.Conditions[0].Type)
+		require.Len(t, updatedPipeline.Status.Outputs, 1)
+	})
+
+	t.Run("should only name pipelines of the same namespace if a namespaced pipeline exceeds the limit", func(t *testing.T) {
+		newPipeline := func(namespace, name string, priority int32) *telemetryv1alpha1.NamespacedTracePipeline {
+			return &telemetryv1alpha1.NamespacedTracePipeline{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: telemetryv1alpha1.NamespacedTracePipelineSpec{
+					Priority: priority,
+					Output: telemetryv1alpha1.TracePipelineOutput{
+						Otlp: &telemetryv1alpha1.OtlpOutput{
+							Endpoint: telemetryv1alpha1.ValueType{Value: "localhost"},
+						},
+					}},
+			}
+		}
+		pipeline := newPipeline("team-a", "pipeline", 0)
+		winnerPipeline := newPipeline("team-a", "winner", 10)
+		otherTenantPipeline := newPipeline("team-b", "other", 0)
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(pipeline, winnerPipeline, otherTenantPipeline).
+			WithStatusSubresource(pipeline, winnerPipeline, otherTenantPipeline).Build()
+
+		proberStub := &mocks.DeploymentProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(false, nil)
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{Gateway: otelcollector.GatewayConfig{
+				Config: otelcollector.Config{BaseName: "trace-gateway"},
+			},
+				MaxPipelinesPerNamespace: 1,
+			},
+			prober:           proberStub,
+			flowHealthProber: healthyFlowHealthProber(),
+		}
+		err := sut.updateStatus(context.Background(), telemetryv1alpha1.NamespacedPipelineName("team-a", "pipeline"), false)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.NamespacedTracePipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: "pipeline", Namespace: "team-a"}, &updatedPipeline)
+		require.Len(t, updatedPipeline.Status.Conditions, 1)
+		require.Equal(t, conditions.ReasonMaxPipelinesExceeded, updatedPipeline.Status.Conditions[0].Reason)
+		require.Equal(t, "Maximum pipeline count limit exceeded. Deployed pipelines: winner", updatedPipeline.Status.Conditions[0].Message)
+	})
 }
@@ -0,0 +1,157 @@
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/reconciler/logpipeline"
+	"github.com/kyma-project/telemetry-manager/internal/reconciler/metricpipeline"
+	"github.com/kyma-project/telemetry-manager/internal/reconciler/tracepipeline"
+)
+
+var decoder = newDecoder()
+
+func newDecoder() runtime.Decoder {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(telemetryv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(operatorv1alpha1.AddToScheme(scheme))
+	return serializer.NewCodecFactory(scheme).UniversalDeserializer()
+}
+
+// Input contains the pipelines that are rendered together and the Telemetry resource that configures them.
+// Converted NamespacedLogPipelines and NamespacedTracePipelines are part of the LogPipelines and TracePipelines.
+type Input struct {
+	Telemetry       *operatorv1alpha1.Telemetry
+	TracePipelines  []telemetryv1alpha1.TracePipeline
+	MetricPipelines []telemetryv1alpha1.MetricPipeline
+	LogPipelines    []telemetryv1alpha1.LogPipeline
+}
+
+// Decode adds the resources of a YAML or JSON stream with one or more documents to the input.
+// Resources of other kinds, for example, Secrets, are ignored.
+func (in *Input) Decode(r io.Reader) error {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read document: %w", err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if runtime.IsNotRegisteredError(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to decode document: %w", err)
+		}
+
+		if err := in.add(obj); err != nil {
+			return err
+		}
+	}
+}
+
+func (in *Input) add(obj runtime.Object) error {
+	switch o := obj.(type) {
+	case *operatorv1alpha1.Telemetry:
+		if in.Telemetry != nil {
+			return fmt.Errorf("more than one Telemetry resource found")
+		}
+		in.Telemetry = o
+	case *telemetryv1alpha1.TracePipeline:
+		in.TracePipelines = append(in.TracePipelines, *o)
+	case *telemetryv1alpha1.NamespacedTracePipeline:
+		in.TracePipelines = append(in.TracePipelines, o.ToTracePipeline())
+	case *telemetryv1alpha1.MetricPipeline:
+		in.MetricPipelines = append(in.MetricPipelines, *o)
+	case *telemetryv1alpha1.LogPipeline:
+		in.LogPipelines = append(in.LogPipelines, *o)
+	case *telemetryv1alpha1.NamespacedLogPipeline:
+		in.LogPipelines = append(in.LogPipelines, o.ToLogPipeline())
+	}
+	return nil
+}
+
+func (in *Input) contains(pipelineName string) bool {
+	filtered := in.only(pipelineName)
+	return len(filtered.TracePipelines)+len(filtered.MetricPipelines)+len(filtered.LogPipelines) > 0
+}
+
+// only returns the input with the pipelines of the given name. The name is unique per signal type, so a trace,
+// a metric, and a log pipeline with the same name are all kept.
+func (in *Input) only(pipelineName string) *Input {
+	filtered := Input{Telemetry: in.Telemetry}
+	for i := range in.TracePipelines {
+		if in.TracePipelines[i].Name == pipelineName {
+			filtered.TracePipelines = append(filtered.TracePipelines, in.TracePipelines[i])
+		}
+	}
+	for i := range in.MetricPipelines {
+		if in.MetricPipelines[i].Name == pipelineName {
+			filtered.MetricPipelines = append(filtered.MetricPipelines, in.MetricPipelines[i])
+		}
+	}
+	for i := range in.LogPipelines {
+		if in.LogPipelines[i].Name == pipelineName {
+			filtered.LogPipelines = append(filtered.LogPipelines, in.LogPipelines[i])
+		}
+	}
+	return &filtered
+}
+
+// withinLimits returns the input with the pipelines that are within the pipeline limits of the given options.
+// The pipelines are selected like in the reconcilers, so that the rendered configuration matches the one in the cluster.
+func (in *Input) withinLimits(opts Options) *Input {
+	selectedTracePipelines := tracepipeline.SelectPipelines(in.TracePipelines, opts.MaxTracePipelines, opts.MaxPipelinesPerNamespace)
+	selectedMetricPipelines := metricpipeline.SelectPipelines(in.MetricPipelines, opts.MaxMetricPipelines)
+	selectedLogPipelines := logpipeline.SelectPipelines(in.LogPipelines, opts.MaxLogPipelines, opts.MaxPipelinesPerNamespace)
+
+	filtered := Input{Telemetry: in.Telemetry}
+	for i := range in.TracePipelines {
+		if slices.Contains(selectedTracePipelines, in.TracePipelines[i].Name) {
+			filtered.TracePipelines = append(filtered.TracePipelines, in.TracePipelines[i])
+		}
+	}
+	for i := range in.MetricPipelines {
+		if slices.Contains(selectedMetricPipelines, in.MetricPipelines[i].Name) {
+			filtered.MetricPipelines = append(filtered.MetricPipelines, in.MetricPipelines[i])
+		}
+	}
+	for i := range in.LogPipelines {
+		if slices.Contains(selectedLogPipelines, in.LogPipelines[i].Name) {
+			filtered.LogPipelines = append(filtered.LogPipelines, in.LogPipelines[i])
+		}
+	}
+	return &filtered
+}
+
+func (in *Input) secretRefs() []telemetryv1alpha1.SecretKeyRef {
+	var refs []telemetryv1alpha1.SecretKeyRef
+	for i := range in.TracePipelines {
+		refs = append(refs, in.TracePipelines[i].GetSecretRefs()...)
+	}
+	for i := range in.MetricPipelines {
+		refs = append(refs, in.MetricPipelines[i].GetSecretRefs()...)
+	}
+	for i := range in.LogPipelines {
+		refs = append(refs, in.LogPipelines[i].GetSecretRefs()...)
+	}
+	return refs
+}
@@ -0,0 +1,328 @@
+// Package render renders the effective OpenTelemetry Collector and Fluent Bit configuration of telemetry pipelines
+// without access to a cluster. It runs the same config builders as the reconcilers, so that pipeline changes can be reviewed
+// by diffing the rendered configuration.
+package render
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/types"
+
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/fluentbit/config/builder"
+	logagent "github.com/kyma-project/telemetry-manager/internal/otelcollector/config/log/agent"
+	loggateway "github.com/kyma-project/telemetry-manager/internal/otelcollector/config/log/gateway"
+	metricagent "github.com/kyma-project/telemetry-manager/internal/otelcollector/config/metric/agent"
+	metricgateway "github.com/kyma-project/telemetry-manager/internal/otelcollector/config/metric/gateway"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/otlpexporter"
+	tracegateway "github.com/kyma-project/telemetry-manager/internal/otelcollector/config/trace/gateway"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
+	"github.com/kyma-project/telemetry-manager/internal/reconciler/logpipeline"
+	"github.com/kyma-project/telemetry-manager/internal/reconciler/metricpipeline"
+	"github.com/kyma-project/telemetry-manager/internal/reconciler/tracepipeline"
+	"github.com/kyma-project/telemetry-manager/internal/resources/fluentbit"
+	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
+)
+
+// RedactedValue replaces the values of all environment variables, because they are resolved from Secrets or contain credentials.
+const RedactedValue = "<redacted>"
+
+// Options configures the environment in which the pipelines are rendered.
+type Options struct {
+	// Namespace is the Namespace of Telemetry Manager, in which the gateways and agents run.
+	Namespace string
+	// Pipeline is the name of the only pipeline that is rendered. For a namespaced pipeline, it is the name of the converted pipeline.
+	// If it is empty, all pipelines are rendered together.
+	Pipeline string
+	// IstioActive renders the metric agent as if Istio was installed in the cluster.
+	IstioActive bool
+
+	// MaxTracePipelines, MaxMetricPipelines, and MaxLogPipelines are the pipeline limits of Telemetry Manager.
+	// Pipelines over the limit are not rendered, like in the cluster. If 0, no limit is applied.
+	MaxTracePipelines  int
+	MaxMetricPipelines int
+	MaxLogPipelines    int
+	// MaxPipelinesPerNamespace limits the number of converted NamespacedTracePipelines and NamespacedLogPipelines per Namespace. If 0, no limit is applied.
+	MaxPipelinesPerNamespace int
+}
+
+// Document is a rendered configuration file, together with the ConfigMap or Secret it is stored in by Telemetry Manager.
+type Document struct {
+	Kind    string
+	Name    types.NamespacedName
+	Key     string
+	Content string
+}
+
+// Render renders the configuration of all gateways, agents, and Fluent Bit for the pipelines of the given input.
+// Suspended pipelines and pipelines over the pipeline limits are not rendered, like in the cluster. Secret values are never read: every referenced Secret key
+// resolves to a placeholder, and the environment variables of the collectors are listed with redacted values.
+func Render(ctx context.Context, input *Input, opts Options) ([]Document, error) {
+	if opts.Pipeline != "" && !input.contains(opts.Pipeline) {
+		return nil, fmt.Errorf("pipeline %s not found", opts.Pipeline)
+	}
+
+	input = input.withinLimits(opts)
+	if opts.Pipeline != "" {
+		if !input.contains(opts.Pipeline) {
+			return nil, fmt.Errorf("pipeline %s exceeds the pipeline limit and is not deployed", opts.Pipeline)
+		}
+		input = input.only(opts.Pipeline)
+	}
+
+	r := renderer{
+		reader:    newRedactedSecretReader(input.secretRefs()),
+		telemetry: input.Telemetry,
+		opts:      opts,
+	}
+
+	var docs []Document
+	for _, render := range []func(context.Context, *Input) ([]Document, error){r.renderTraces, r.renderMetrics, r.renderLogs} {
+		rendered, err := render(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, rendered...)
+	}
+	return docs, nil
+}
+
+// Write writes the documents as a YAML stream. Every document starts with a comment that names the ConfigMap or Secret it is stored in,
+// so that the output of two revisions of a pipeline can be diffed.
+func Write(w io.Writer, docs []Document) error {
+	for _, doc := range docs {
+		header := fmt.Sprintf("# %s %s", doc.Kind, doc.Name)
+		if doc.Key != "" {
+			header += fmt.Sprintf(", key %s", doc.Key)
+		}
+
+		content := doc.Content
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s\n%s", header, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type renderer struct {
+	reader    *redactedSecretReader
+	telemetry *operatorv1alpha1.Telemetry
+	opts      Options
+}
+
+func (r *renderer) renderTraces(ctx context.Context, input *Input) ([]Document, error) {
+	var pipelines []telemetryv1alpha1.TracePipeline
+	for i := range input.TracePipelines {
+		if !input.TracePipelines[i].Spec.Suspend {
+			pipelines = append(pipelines, input.TracePipelines[i])
+		}
+	}
+	if len(pipelines) == 0 {
+		return nil, nil
+	}
+
+	opts := tracegateway.BuildOptions{
+		MetricGatewayEndpoint: fmt.Sprintf("%s.%s.svc.cluster.local:%d", otelcollector.MetricOTLPServiceName, r.opts.Namespace, ports.OTLPGRPC),
+	}
+	if r.telemetry != nil {
+		opts.Enrichments = r.telemetry.Spec.Enrichments
+		opts.Cluster = r.telemetry.Spec.Cluster
+		if r.telemetry.Spec.Trace != nil {
+			opts.Receivers = r.telemetry.Spec.Trace.Gateway.Receivers
+		}
+	}
+	if tracepipeline.IsScaledOut(tracepipeline.GatewayScaling(r.telemetry)) {
+		opts.LoadBalancingHostname = fmt.Sprintf("%s.%s.svc.cluster.local", otelcollector.TraceLoadBalancingServiceName, r.opts.Namespace)
+	}
+
+	cfg, envVars, err := tracegateway.MakeConfig(ctx, r.reader, pipelines, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render trace gateway config: %w", err)
+	}
+	return r.collectorDocuments(otelcollector.TraceGatewayName, cfg, envVars)
+}
+
+func (r *renderer) renderMetrics(ctx context.Context, input *Input) ([]Document, error) {
+	var pipelines []telemetryv1alpha1.MetricPipeline
+	for i := range input.MetricPipelines {
+		if !input.MetricPipelines[i].Spec.Suspend {
+			pipelines = append(pipelines, input.MetricPipelines[i])
+		}
+	}
+	if len(pipelines) == 0 {
+		return nil, nil
+	}
+
+	var opts metricgateway.BuildOptions
+	if r.telemetry != nil {
+		opts.Enrichments = r.telemetry.Spec.Enrichments
+		opts.Cluster = r.telemetry.Spec.Cluster
+	}
+
+	cfg, envVars, err := metricgateway.MakeConfig(ctx, r.reader, pipelines, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render metric gateway config: %w", err)
+	}
+	docs, err := r.collectorDocuments(otelcollector.MetricGatewayName, cfg, envVars)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.ContainsFunc(pipelines, func(pipeline telemetryv1alpha1.MetricPipeline) bool {
+		return metricpipeline.IsMetricAgentRequired(&pipeline)
+	}) {
+		return docs, nil
+	}
+
+	agentCfg := metricagent.MakeConfig(r.name(otelcollector.MetricOTLPServiceName), pipelines, r.opts.IstioActive)
+	agentDoc, err := r.configDocument(otelcollector.MetricAgentName, agentCfg)
+	if err != nil {
+		return nil, err
+	}
+	return append(docs, agentDoc), nil
+}
+
+func (r *renderer) renderLogs(ctx context.Context, input *Input) ([]Document, error) {
+	var pipelines, otlpPipelines []telemetryv1alpha1.LogPipeline
+	for i := range input.LogPipelines {
+		if input.LogPipelines[i].Spec.Suspend {
+			continue
+		}
+		pipelines = append(pipelines, input.LogPipelines[i])
+		if input.LogPipelines[i].Spec.HasOtlpOutput() {
+			otlpPipelines = append(otlpPipelines, input.LogPipelines[i])
+		}
+	}
+	fluentBitPipelines, otelAgentPipelines := logpipeline.SplitPipelinesByAgent(pipelines, logpipeline.LogAgent(r.telemetry))
+
+	var docs []Document
+	if len(fluentBitPipelines) > 0 {
+		fluentBitDocs, err := r.fluentBitDocuments(fluentBitPipelines)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, fluentBitDocs...)
+	}
+
+	if len(otelAgentPipelines) > 0 {
+		agentCfg := logagent.MakeConfig(otelAgentPipelines, logagent.BuildOptions{
+			GatewayServiceName: r.name(otelcollector.LogOTLPServiceName),
+			AgentName:          r.name(otelcollector.LogAgentName),
+		})
+		agentDoc, err := r.configDocument(otelcollector.LogAgentName, agentCfg)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, agentDoc)
+	}
+
+	if len(otlpPipelines) > 0 {
+		var opts loggateway.BuildOptions
+		if r.telemetry != nil {
+			opts.Cluster = r.telemetry.Spec.Cluster
+		}
+		cfg, envVars, err := loggateway.MakeConfig(ctx, r.reader, otlpPipelines, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render log gateway config: %w", err)
+		}
+		gatewayDocs, err := r.collectorDocuments(otelcollector.LogGatewayName, cfg, envVars)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, gatewayDocs...)
+	}
+
+	return docs, nil
+}
+
+// fluentBitDocuments renders one section per pipeline, like the sections ConfigMap, and the parsers of the multiline and parse options.
+func (r *renderer) fluentBitDocuments(pipelines []telemetryv1alpha1.LogPipeline) ([]Document, error) {
+	defaults := builder.PipelineDefaults{
+		InputTag:          "tele",
+		MemoryBufferLimit: "10M",
+		StorageType:       "filesystem",
+		FsBufferLimit:     "1G",
+		LogGatewayHost:    fmt.Sprintf("%s.%s", otelcollector.LogOTLPServiceName, r.opts.Namespace),
+	}
+	if r.telemetry != nil && r.telemetry.Spec.Cluster != nil {
+		defaults.ClusterName = r.telemetry.Spec.Cluster.Name
+		defaults.ClusterAttributes = r.telemetry.Spec.Cluster.Attributes
+	}
+
+	var docs []Document
+	for i := range pipelines {
+		section, err := builder.BuildFluentBitConfig(&pipelines[i], defaults)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render fluent bit section of pipeline %s: %w", pipelines[i].Name, err)
+		}
+		docs = append(docs, Document{
+			Kind:    "ConfigMap",
+			Name:    r.name(fluentbit.SectionsConfigMapName),
+			Key:     pipelines[i].Name + ".conf",
+			Content: section,
+		})
+	}
+
+	if parsers := builder.BuildFluentBitPipelineParsersConfig(pipelines); parsers != "" {
+		docs = append(docs, Document{
+			Kind:    "ConfigMap",
+			Name:    r.name(fluentbit.ParsersConfigMapName),
+			Key:     fluentbit.PipelineParsersConfigMapKey,
+			Content: parsers,
+		})
+	}
+	return docs, nil
+}
+
+// collectorDocuments returns the collector configuration and the environment variables of a gateway. Only the names of the
+// environment variables are rendered.
+func (r *renderer) collectorDocuments(baseName string, cfg any, envVars otlpexporter.EnvVars) ([]Document, error) {
+	configDoc, err := r.configDocument(baseName, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(envVars))
+	for name := range envVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&sb, "%s: %s\n", name, RedactedValue)
+	}
+
+	return []Document{configDoc, {
+		Kind:    "Secret",
+		Name:    r.name(baseName),
+		Content: sb.String(),
+	}}, nil
+}
+
+func (r *renderer) configDocument(baseName string, cfg any) (Document, error) {
+	configYAML, err := yaml.Marshal(cfg)
+	if err != nil {
+		return Document{}, fmt.Errorf("failed to marshal %s config: %w", baseName, err)
+	}
+	return Document{
+		Kind:    "ConfigMap",
+		Name:    r.name(baseName),
+		Key:     otelcollector.ConfigMapKey,
+		Content: string(configYAML),
+	}, nil
+}
+
+func (r *renderer) name(name string) types.NamespacedName {
+	return types.NamespacedName{Name: name, Namespace: r.opts.Namespace}
+}
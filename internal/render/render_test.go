@@ -0,0 +1,205 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const pipelinesYAML = `
+apiVersion: telemetry.kyma-project.io/v1alpha1
+kind: TracePipeline
+metadata:
+  name: backend
+spec:
+  output:
+    otlp:
+      endpoint:
+        valueFrom:
+          secretKeyRef:
+            name: creds
+            namespace: default
+            key: endpoint
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: creds
+  namespace: default
+stringData:
+  endpoint: https://secret.example.com
+---
+apiVersion: telemetry.kyma-project.io/v1alpha1
+kind: TracePipeline
+metadata:
+  name: suspended
+spec:
+  suspend: true
+  output:
+    otlp:
+      endpoint:
+        value: https://suspended.example.com
+---
+apiVersion: telemetry.kyma-project.io/v1alpha1
+kind: NamespacedTracePipeline
+metadata:
+  name: backend
+  namespace: team-a
+spec:
+  output:
+    otlp:
+      endpoint:
+        value: https://team-a.example.com
+---
+apiVersion: telemetry.kyma-project.io/v1alpha1
+kind: LogPipeline
+metadata:
+  name: http
+spec:
+  output:
+    http:
+      host:
+        value: logs.example.com
+`
+
+func decode(t *testing.T, yamls ...string) *Input {
+	var input Input
+	for _, y := range yamls {
+		require.NoError(t, input.Decode(strings.NewReader(y)))
+	}
+	return &input
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("should convert namespaced pipelines and ignore other resources", func(t *testing.T) {
+		input := decode(t, pipelinesYAML)
+
+		require.Nil(t, input.Telemetry)
+		require.Len(t, input.TracePipelines, 3)
+		require.Equal(t, "team-a__backend", input.TracePipelines[2].Name)
+		require.Len(t, input.LogPipelines, 1)
+		require.Empty(t, input.MetricPipelines)
+	})
+
+	t.Run("should fail if more than one Telemetry resource is given", func(t *testing.T) {
+		telemetryYAML := `
+apiVersion: operator.kyma-project.io/v1alpha1
+kind: Telemetry
+metadata:
+  name: default
+`
+		var input Input
+		require.NoError(t, input.Decode(strings.NewReader(telemetryYAML)))
+		require.Error(t, input.Decode(strings.NewReader(telemetryYAML)))
+	})
+}
+
+func TestRender(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should render the config of all pipelines that are not suspended", func(t *testing.T) {
+		docs, err := Render(ctx, decode(t, pipelinesYAML), Options{Namespace: "kyma-system"})
+		require.NoError(t, err)
+
+		require.Len(t, docs, 3)
+		require.Equal(t, "ConfigMap", docs[0].Kind)
+		require.Equal(t, "telemetry-trace-collector", docs[0].Name.Name)
+		require.Equal(t, "kyma-system", docs[0].Name.Namespace)
+		require.Equal(t, "relay.conf", docs[0].Key)
+		require.Contains(t, docs[0].Content, "otlp/backend:")
+		require.Contains(t, docs[0].Content, "otlp/team-a__backend:")
+		require.NotContains(t, docs[0].Content, "suspended")
+
+		require.Equal(t, "Secret", docs[1].Kind)
+		require.Equal(t, "telemetry-trace-collector", docs[1].Name.Name)
+		require.Equal(t, "OTLP_ENDPOINT_BACKEND: <redacted>\nOTLP_ENDPOINT_TEAM_A__BACKEND: <redacted>\n", docs[1].Content)
+
+		require.Equal(t, "telemetry-fluent-bit-sections", docs[2].Name.Name)
+		require.Equal(t, "http.conf", docs[2].Key)
+		require.Contains(t, docs[2].Content, "logs.example.com")
+	})
+
+	t.Run("should render a single pipeline", func(t *testing.T) {
+		docs, err := Render(ctx, decode(t, pipelinesYAML), Options{Namespace: "kyma-system", Pipeline: "team-a__backend"})
+		require.NoError(t, err)
+
+		require.Len(t, docs, 2)
+		require.Contains(t, docs[0].Content, "otlp/team-a__backend:")
+		require.NotContains(t, docs[0].Content, "otlp/backend:")
+	})
+
+	t.Run("should fail if the pipeline does not exist", func(t *testing.T) {
+		_, err := Render(ctx, decode(t, pipelinesYAML), Options{Namespace: "kyma-system", Pipeline: "unknown"})
+		require.Error(t, err)
+	})
+
+	t.Run("should not render pipelines over the pipeline limit", func(t *testing.T) {
+		opts := Options{Namespace: "kyma-system", MaxTracePipelines: 2}
+		docs, err := Render(ctx, decode(t, pipelinesYAML), opts)
+		require.NoError(t, err)
+
+		require.Len(t, docs, 3)
+		require.Contains(t, docs[0].Content, "otlp/backend:")
+		require.NotContains(t, docs[0].Content, "team-a__backend")
+
+		opts.Pipeline = "team-a__backend"
+		_, err = Render(ctx, decode(t, pipelinesYAML), opts)
+		require.ErrorContains(t, err, "exceeds the pipeline limit")
+	})
+
+	t.Run("should apply the Telemetry resource", func(t *testing.T) {
+		telemetryYAML := `
+apiVersion: operator.kyma-project.io/v1alpha1
+kind: Telemetry
+metadata:
+  name: default
+spec:
+  cluster:
+    name: my-cluster
+  log:
+    agent: OpenTelemetry
+`
+		otlpLogPipelineYAML := `
+apiVersion: telemetry.kyma-project.io/v1alpha1
+kind: LogPipeline
+metadata:
+  name: otlp
+spec:
+  output:
+    otlp:
+      endpoint:
+        value: https://otlp.example.com
+`
+		docs, err := Render(ctx, decode(t, telemetryYAML, otlpLogPipelineYAML), Options{Namespace: "kyma-system"})
+		require.NoError(t, err)
+
+		require.Len(t, docs, 3)
+		require.Equal(t, "telemetry-log-agent", docs[0].Name.Name)
+		require.Equal(t, "telemetry-log-gateway", docs[1].Name.Name)
+		require.Contains(t, docs[1].Content, "my-cluster")
+		require.Equal(t, "telemetry-log-gateway", docs[2].Name.Name)
+		require.Equal(t, "Secret", docs[2].Kind)
+	})
+}
+
+func TestWrite(t *testing.T) {
+	docs := []Document{
+		{Kind: "ConfigMap", Name: types.NamespacedName{Name: "telemetry-fluent-bit-sections", Namespace: "kyma-system"}, Key: "http.conf", Content: "[OUTPUT]\n    name http\n"},
+		{Kind: "Secret", Name: types.NamespacedName{Name: "telemetry-log-gateway", Namespace: "kyma-system"}, Content: "OTLP_ENDPOINT_OTLP: <redacted>"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, docs))
+	require.Equal(t, `---
+# ConfigMap kyma-system/telemetry-fluent-bit-sections, key http.conf
+[OUTPUT]
+    name http
+---
+# Secret kyma-system/telemetry-log-gateway
+OTLP_ENDPOINT_OTLP: <redacted>
+`, buf.String())
+}
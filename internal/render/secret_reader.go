@@ -0,0 +1,50 @@
+package render
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+// redactedSecretReader serves the referenced Secrets to the config builders without access to a cluster.
+// Every referenced key resolves to RedactedValue, so no Secret value can end up in the rendered configuration.
+type redactedSecretReader struct {
+	refs []telemetryv1alpha1.SecretKeyRef
+}
+
+func newRedactedSecretReader(refs []telemetryv1alpha1.SecretKeyRef) *redactedSecretReader {
+	return &redactedSecretReader{refs: refs}
+}
+
+func (r *redactedSecretReader) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return fmt.Errorf("reading %T is not supported", obj)
+	}
+
+	data := make(map[string][]byte)
+	for _, ref := range r.refs {
+		if ref.Name == key.Name && ref.Namespace == key.Namespace {
+			data[ref.Key] = []byte(RedactedValue)
+		}
+	}
+	if len(data) == 0 {
+		return apierrors.NewNotFound(corev1.Resource("secrets"), key.Name)
+	}
+
+	*secret = corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		Data:       data,
+	}
+	return nil
+}
+
+func (r *redactedSecretReader) List(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+	return fmt.Errorf("listing %T is not supported", list)
+}
@@ -17,6 +17,15 @@ import (
 const checksumAnnotationKey = "checksum/logpipeline-config"
 const istioExcludeInboundPorts = "traffic.sidecar.istio.io/excludeInboundPorts"
 
+// Names of the ConfigMaps that hold the sections and parsers rendered for the LogPipelines.
+const (
+	SectionsConfigMapName = "telemetry-fluent-bit-sections"
+	ParsersConfigMapName  = "telemetry-fluent-bit-parsers"
+)
+
+// PipelineParsersConfigMapKey is the key of the parsers ConfigMap that holds the parsers rendered for the multiline and parse options of LogPipelines.
+const PipelineParsersConfigMapKey = "pipeline-parsers.conf"
+
 type DaemonSetConfig struct {
 	FluentBitImage              string
 	FluentBitConfigPrepperImage string
@@ -332,6 +341,7 @@ func MakeConfigMap(name types.NamespacedName, includeSections bool) *corev1.Conf
     Log_Level warn
     Parsers_File custom_parsers.conf
     Parsers_File dynamic-parsers/parsers.conf
+    Parsers_File dynamic-parsers/pipeline-parsers.conf
     HTTP_Server On
     HTTP_Listen 0.0.0.0
     HTTP_Port 2020
@@ -397,7 +407,7 @@ func MakeParserConfigmap(name types.NamespacedName) *corev1.ConfigMap {
 			Namespace: name.Namespace,
 			Labels:    labels(),
 		},
-		Data: map[string]string{"parsers.conf": ""},
+		Data: map[string]string{"parsers.conf": "", PipelineParsersConfigMapKey: ""},
 	}
 }
 
@@ -0,0 +1,133 @@
+package otelcollector
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/telemetry-manager/internal/configchecksum"
+	"github.com/kyma-project/telemetry-manager/internal/kubernetes"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+)
+
+// ApplyClusterCollectorResources applies the resources of the cluster collector, which watches the state of the Kubernetes resources of the whole cluster.
+// The collector must run as a single replica, otherwise the metrics would be duplicated.
+func ApplyClusterCollectorResources(ctx context.Context, c client.Client, cfg *ClusterCollectorConfig) error {
+	name := types.NamespacedName{Namespace: cfg.Namespace, Name: cfg.BaseName}
+
+	if err := applyCommonResources(ctx, c, name, makeClusterCollectorClusterRole(name)); err != nil {
+		return fmt.Errorf("failed to create common resource: %w", err)
+	}
+
+	configMap := makeConfigMap(name, cfg.CollectorConfig)
+	if err := kubernetes.CreateOrUpdateConfigMap(ctx, c, configMap); err != nil {
+		return fmt.Errorf("failed to create configmap: %w", err)
+	}
+
+	configChecksum := configchecksum.Calculate([]corev1.ConfigMap{*configMap}, []corev1.Secret{})
+	if err := kubernetes.CreateOrUpdateDeployment(ctx, c, makeClusterCollectorDeployment(cfg, configChecksum)); err != nil {
+		return fmt.Errorf("failed to create deployment: %w", err)
+	}
+
+	return nil
+}
+
+func makeClusterCollectorClusterRole(name types.NamespacedName) *rbacv1.ClusterRole {
+	clusterRole := rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+			Labels:    defaultLabels(name.Name),
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"events", "namespaces", "nodes", "pods", "replicationcontrollers", "resourcequotas", "services"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{"apps"},
+				Resources: []string{"daemonsets", "deployments", "replicasets", "statefulsets"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{"batch"},
+				Resources: []string{"jobs", "cronjobs"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{"autoscaling"},
+				Resources: []string{"horizontalpodautoscalers"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{"events.k8s.io"},
+				Resources: []string{"events"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}
+	return &clusterRole
+}
+
+func makeClusterCollectorDeployment(cfg *ClusterCollectorConfig, configChecksum string) *appsv1.Deployment {
+	selectorLabels := defaultLabels(cfg.BaseName)
+	podLabels := maps.Clone(selectorLabels)
+	podLabels["sidecar.istio.io/inject"] = "false"
+
+	annotations := map[string]string{"checksum/config": configChecksum}
+	resources := makeClusterCollectorResourceRequirements(cfg)
+	podSpec := makePodSpec(cfg.BaseName, cfg.Deployment.Image,
+		withPriorityClass(cfg.Deployment.PriorityClassName),
+		withResources(resources),
+		withEnvVarFromSource(config.EnvVarCurrentPodIP, fieldPathPodIP),
+		withEnvVarFromSource(config.EnvVarCurrentNodeName, fieldPathNodeName),
+	)
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfg.BaseName,
+			Namespace: cfg.Namespace,
+			Labels:    selectorLabels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(1),
+			// A rolling update would run two collectors at the same time and duplicate the metrics.
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selectorLabels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podLabels,
+					Annotations: annotations,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
+
+func makeClusterCollectorResourceRequirements(cfg *ClusterCollectorConfig) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Limits: map[corev1.ResourceName]resource.Quantity{
+			corev1.ResourceCPU:    cfg.Deployment.CPULimit,
+			corev1.ResourceMemory: cfg.Deployment.MemoryLimit,
+		},
+		Requests: map[corev1.ResourceName]resource.Quantity{
+			corev1.ResourceCPU:    cfg.Deployment.CPURequest,
+			corev1.ResourceMemory: cfg.Deployment.MemoryRequest,
+		},
+	}
+}
@@ -0,0 +1,98 @@
+package otelcollector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestApplyClusterCollectorResources(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().Build()
+	namespace := "my-namespace"
+	name := "my-cluster-collector"
+	cfg := "dummy otel collector config"
+
+	clusterCollectorConfig := &ClusterCollectorConfig{
+		Config: Config{
+			BaseName:        name,
+			Namespace:       namespace,
+			CollectorConfig: cfg,
+		},
+	}
+
+	err := ApplyClusterCollectorResources(ctx, client, clusterCollectorConfig)
+	require.NoError(t, err)
+
+	t.Run("should create collector config configmap", func(t *testing.T) {
+		var cms corev1.ConfigMapList
+		require.NoError(t, client.List(ctx, &cms))
+		require.Len(t, cms.Items, 1)
+
+		cm := cms.Items[0]
+		require.Equal(t, name, cm.Name)
+		require.Equal(t, namespace, cm.Namespace)
+		require.Equal(t, cfg, cm.Data["relay.conf"])
+	})
+
+	t.Run("should create a single replica deployment", func(t *testing.T) {
+		var deps appsv1.DeploymentList
+		require.NoError(t, client.List(ctx, &deps))
+		require.Len(t, deps.Items, 1)
+
+		dep := deps.Items[0]
+		require.Equal(t, name, dep.Name)
+		require.Equal(t, namespace, dep.Namespace)
+		require.Equal(t, int32(1), *dep.Spec.Replicas)
+		require.Equal(t, appsv1.RecreateDeploymentStrategyType, dep.Spec.Strategy.Type, "must not run two collectors during an update")
+
+		require.Equal(t, map[string]string{
+			"app.kubernetes.io/name": name,
+		}, dep.Spec.Selector.MatchLabels, "must have expected deployment selector labels")
+		require.Equal(t, map[string]string{
+			"app.kubernetes.io/name":  name,
+			"sidecar.istio.io/inject": "false",
+		}, dep.Spec.Template.ObjectMeta.Labels, "must have expected pod labels")
+		require.NotEmpty(t, dep.Spec.Template.ObjectMeta.Annotations["checksum/config"])
+
+		require.Len(t, dep.Spec.Template.Spec.Containers, 1)
+		container := dep.Spec.Template.Spec.Containers[0]
+		require.NotNil(t, container.LivenessProbe, "liveness probe must be defined")
+		require.NotNil(t, container.ReadinessProbe, "readiness probe must be defined")
+		require.True(t, *container.SecurityContext.ReadOnlyRootFilesystem, "must use readonly fs")
+	})
+
+	t.Run("should create clusterrole", func(t *testing.T) {
+		var crs rbacv1.ClusterRoleList
+		require.NoError(t, client.List(ctx, &crs))
+		require.Len(t, crs.Items, 1)
+
+		cr := crs.Items[0]
+		require.Equal(t, name, cr.Name)
+		require.Contains(t, cr.Rules, rbacv1.PolicyRule{
+			APIGroups: []string{"apps"},
+			Resources: []string{"daemonsets", "deployments", "replicasets", "statefulsets"},
+			Verbs:     []string{"get", "list", "watch"},
+		})
+		for _, rule := range cr.Rules {
+			require.Equal(t, []string{"get", "list", "watch"}, rule.Verbs, "must only have read access")
+		}
+	})
+
+	t.Run("should create clusterrolebinding and serviceaccount", func(t *testing.T) {
+		var crbs rbacv1.ClusterRoleBindingList
+		require.NoError(t, client.List(ctx, &crbs))
+		require.Len(t, crbs.Items, 1)
+		require.Equal(t, name, crbs.Items[0].RoleRef.Name)
+
+		var sas corev1.ServiceAccountList
+		require.NoError(t, client.List(ctx, &sas))
+		require.Len(t, sas.Items, 1)
+		require.Equal(t, name, sas.Items[0].Name)
+	})
+}
@@ -18,6 +18,45 @@ type GatewayConfig struct {
 	Scaling              GatewayScalingConfig
 	OTLPServiceName      string
 	CanReceiveOpenCensus bool
+
+	// LoadBalancingServiceName is the name of a headless Service that resolves to all gateway replicas.
+	// If empty, no such Service is created.
+	LoadBalancingServiceName string
+
+	// PersistentQueue defines the volume for the persistent sending queues. If nil, no volume is mounted.
+	PersistentQueue *PersistentQueueConfig
+
+	// PrometheusPorts are the ports on which the gateway exposes metrics for scraping.
+	// If empty, no Service and no NetworkPolicy for these ports are created.
+	PrometheusPorts []int32
+
+	// CanReceiveZipkin and CanReceiveJaeger define whether the Services for the Zipkin and Jaeger receivers are created.
+	CanReceiveZipkin bool
+	CanReceiveJaeger bool
+}
+
+type PersistentQueueConfig struct {
+	// PersistentVolumeClaimName is the name of the claim that backs the volume. If empty, an emptyDir volume is used.
+	PersistentVolumeClaimName string
+}
+
+func (cfg *GatewayConfig) WithPersistentQueue(pq *PersistentQueueConfig) *GatewayConfig {
+	cfgCopy := *cfg
+	cfgCopy.PersistentQueue = pq
+	return &cfgCopy
+}
+
+func (cfg *GatewayConfig) WithPrometheusPorts(prometheusPorts []int32) *GatewayConfig {
+	cfgCopy := *cfg
+	cfgCopy.PrometheusPorts = prometheusPorts
+	return &cfgCopy
+}
+
+func (cfg *GatewayConfig) WithLegacyReceivers(zipkin, jaeger bool) *GatewayConfig {
+	cfgCopy := *cfg
+	cfgCopy.CanReceiveZipkin = zipkin
+	cfgCopy.CanReceiveJaeger = jaeger
+	return &cfgCopy
 }
 
 func (cfg *GatewayConfig) WithScaling(s GatewayScalingConfig) *GatewayConfig {
@@ -54,6 +93,47 @@ type GatewayScalingConfig struct {
 	// This value is multiplied with a base resource requirement to calculate the actual CPU and memory limits.
 	// A value of 1 applies the base limits; values greater than 1 increase those limits proportionally.
 	ResourceRequirementsMultiplier int
+
+	// Autoscaling configures a HorizontalPodAutoscaler for the gateway. If set, Replicas is ignored and the number of replicas is managed by the autoscaler.
+	Autoscaling *GatewayAutoscalingConfig
+}
+
+type GatewayAutoscalingConfig struct {
+	MinReplicas int32
+	MaxReplicas int32
+
+	// TargetCPUUtilization and TargetMemoryUtilization are the average utilization of the requested resources in percent. Zero disables the metric.
+	TargetCPUUtilization    int32
+	TargetMemoryUtilization int32
+}
+
+const (
+	defaultAutoscalingMinReplicas          int32 = 2
+	defaultAutoscalingMaxReplicas          int32 = 10
+	defaultAutoscalingTargetCPUUtilization int32 = 80
+)
+
+// NewGatewayAutoscalingConfig returns an autoscaling configuration in which unset (zero) values are replaced by defaults.
+// If only the maximum is set and it is lower than the default minimum, the minimum is lowered to the maximum.
+// The target memory utilization has no default, so memory is only considered if it is set.
+func NewGatewayAutoscalingConfig(minReplicas, maxReplicas, targetCPUUtilization, targetMemoryUtilization int32) *GatewayAutoscalingConfig {
+	if minReplicas == 0 {
+		minReplicas = defaultAutoscalingMinReplicas
+	}
+	if maxReplicas == 0 {
+		maxReplicas = max(defaultAutoscalingMaxReplicas, minReplicas)
+	}
+	minReplicas = min(minReplicas, maxReplicas)
+	if targetCPUUtilization == 0 {
+		targetCPUUtilization = defaultAutoscalingTargetCPUUtilization
+	}
+
+	return &GatewayAutoscalingConfig{
+		MinReplicas:             minReplicas,
+		MaxReplicas:             maxReplicas,
+		TargetCPUUtilization:    targetCPUUtilization,
+		TargetMemoryUtilization: targetMemoryUtilization,
+	}
 }
 
 type AgentConfig struct {
@@ -76,3 +156,24 @@ type DaemonSetConfig struct {
 	MemoryLimit       resource.Quantity
 	MemoryRequest     resource.Quantity
 }
+
+type ClusterCollectorConfig struct {
+	Config
+
+	Deployment ClusterCollectorDeploymentConfig
+}
+
+func (cfg *ClusterCollectorConfig) WithCollectorConfig(collectorCfgYAML string) *ClusterCollectorConfig {
+	cfgCopy := *cfg
+	cfgCopy.CollectorConfig = collectorCfgYAML
+	return &cfgCopy
+}
+
+type ClusterCollectorDeploymentConfig struct {
+	Image             string
+	PriorityClassName string
+	CPULimit          resource.Quantity
+	CPURequest        resource.Quantity
+	MemoryLimit       resource.Quantity
+	MemoryRequest     resource.Quantity
+}
@@ -0,0 +1,29 @@
+package otelcollector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGatewayAutoscalingConfig(t *testing.T) {
+	tests := []struct {
+		name                                     string
+		minReplicas, maxReplicas                 int32
+		expectedMinReplicas, expectedMaxReplicas int32
+	}{
+		{name: "defaults", expectedMinReplicas: 2, expectedMaxReplicas: 10},
+		{name: "min and max set", minReplicas: 3, maxReplicas: 5, expectedMinReplicas: 3, expectedMaxReplicas: 5},
+		{name: "min above default max", minReplicas: 12, expectedMinReplicas: 12, expectedMaxReplicas: 12},
+		{name: "max below default min", maxReplicas: 1, expectedMinReplicas: 1, expectedMaxReplicas: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewGatewayAutoscalingConfig(tt.minReplicas, tt.maxReplicas, 0, 0)
+			require.Equal(t, tt.expectedMinReplicas, cfg.MinReplicas)
+			require.Equal(t, tt.expectedMaxReplicas, cfg.MaxReplicas)
+			require.Equal(t, defaultAutoscalingTargetCPUUtilization, cfg.TargetCPUUtilization)
+		})
+	}
+}
@@ -85,7 +85,7 @@ func makeConfigMap(name types.NamespacedName, collectorConfig string) *corev1.Co
 			Labels:    defaultLabels(name.Name),
 		},
 		Data: map[string]string{
-			configMapKey: collectorConfig,
+			ConfigMapKey: collectorConfig,
 		},
 	}
 }
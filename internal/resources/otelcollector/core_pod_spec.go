@@ -8,8 +8,10 @@ import (
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
 )
 
+// ConfigMapKey is the key of the collector ConfigMap that holds the collector configuration.
+const ConfigMapKey = "relay.conf"
+
 const (
-	configMapKey           = "relay.conf"
 	collectorUser          = 10001
 	collectorContainerName = "collector"
 )
@@ -55,6 +57,18 @@ func withResources(resources corev1.ResourceRequirements) podSpecOption {
 	}
 }
 
+// withRunAsRoot runs the collector as root user, which is required to read the container log files of the node.
+func withRunAsRoot() podSpecOption {
+	return func(pod *corev1.PodSpec) {
+		pod.SecurityContext.RunAsUser = pointer.Int64(0)
+		pod.SecurityContext.RunAsNonRoot = pointer.Bool(false)
+		for i := range pod.Containers {
+			pod.Containers[i].SecurityContext.RunAsUser = pointer.Int64(0)
+			pod.Containers[i].SecurityContext.RunAsNonRoot = pointer.Bool(false)
+		}
+	}
+}
+
 func withVolumeMount(volumeMount corev1.VolumeMount) podSpecOption {
 	return func(pod *corev1.PodSpec) {
 		for i := range pod.Containers {
@@ -75,7 +89,7 @@ func makePodSpec(baseName, image string, opts ...podSpecOption) corev1.PodSpec {
 			{
 				Name:  collectorContainerName,
 				Image: image,
-				Args:  []string{"--config=/conf/" + configMapKey},
+				Args:  []string{"--config=/conf/" + ConfigMapKey},
 				EnvFrom: []corev1.EnvFromSource{
 					{
 						SecretRef: &corev1.SecretEnvSource{
@@ -128,7 +142,7 @@ func makePodSpec(baseName, image string, opts ...podSpecOption) corev1.PodSpec {
 						LocalObjectReference: corev1.LocalObjectReference{
 							Name: baseName,
 						},
-						Items: []corev1.KeyToPath{{Key: configMapKey, Path: configMapKey}},
+						Items: []corev1.KeyToPath{{Key: ConfigMapKey, Path: ConfigMapKey}},
 					},
 				},
 			},
@@ -6,7 +6,10 @@ import (
 	"maps"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -53,6 +56,183 @@ func ApplyGatewayResources(ctx context.Context, c client.Client, cfg *GatewayCon
 		}
 	}
 
+	if err := applyLoadBalancingResources(ctx, c, cfg); err != nil {
+		return err
+	}
+
+	if err := applyLegacyReceiverServices(ctx, c, cfg); err != nil {
+		return err
+	}
+
+	if err := applyPrometheusResources(ctx, c, cfg); err != nil {
+		return err
+	}
+
+	if err := applyAutoscalingResources(ctx, c, cfg); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteGatewayResources deletes all resources created by ApplyGatewayResources. It is used if no pipeline is left that needs the gateway.
+func DeleteGatewayResources(ctx context.Context, c client.Client, cfg *GatewayConfig) error {
+	name := types.NamespacedName{Namespace: cfg.Namespace, Name: cfg.BaseName}
+	objectMeta := metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace}
+
+	objects := []client.Object{
+		&appsv1.Deployment{ObjectMeta: objectMeta},
+		&autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: objectMeta},
+		&policyv1.PodDisruptionBudget{ObjectMeta: objectMeta},
+		makeSecret(name, nil),
+		makeConfigMap(name, ""),
+		makeOTLPService(cfg),
+		makeOpenCensusService(name),
+		makeZipkinService(name),
+		makeJaegerService(name),
+		makeLegacyReceiversNetworkPolicy(name, nil),
+		makeLoadBalancingNetworkPolicy(name),
+		makePrometheusService(name, nil),
+		makePrometheusNetworkPolicy(name, nil),
+		makeMetricsService(name),
+		makeDenyPprofNetworkPolicy(name),
+		makeServiceAccount(name),
+		makeClusterRoleBinding(name),
+		makeGatewayClusterRole(name),
+	}
+	if cfg.LoadBalancingServiceName != "" {
+		objects = append(objects, makeLoadBalancingService(cfg))
+	}
+
+	for _, obj := range objects {
+		if err := client.IgnoreNotFound(c.Delete(ctx, obj)); err != nil {
+			return fmt.Errorf("failed to delete %T %s: %w", obj, obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// applyLoadBalancingResources creates the headless Service and the NetworkPolicy that let the gateway replicas route spans to each other by trace ID.
+// The NetworkPolicy is deleted if load balancing is not configured.
+func applyLoadBalancingResources(ctx context.Context, c client.Client, cfg *GatewayConfig) error {
+	name := types.NamespacedName{Namespace: cfg.Namespace, Name: cfg.BaseName}
+	networkPolicy := makeLoadBalancingNetworkPolicy(name)
+
+	if cfg.LoadBalancingServiceName == "" {
+		if err := client.IgnoreNotFound(c.Delete(ctx, networkPolicy)); err != nil {
+			return fmt.Errorf("failed to delete load balancing network policy: %w", err)
+		}
+		return nil
+	}
+
+	if err := kubernetes.CreateOrUpdateService(ctx, c, makeLoadBalancingService(cfg)); err != nil {
+		return fmt.Errorf("failed to create load balancing service: %w", err)
+	}
+
+	if err := kubernetes.CreateOrUpdateNetworkPolicy(ctx, c, networkPolicy); err != nil {
+		return fmt.Errorf("failed to create load balancing network policy: %w", err)
+	}
+
+	return nil
+}
+
+// applyLegacyReceiverServices creates the Services of the enabled Zipkin and Jaeger receivers, and deletes the Services of the disabled ones.
+// The ports of the enabled receivers are opened by a dedicated NetworkPolicy, which is deleted if no receiver is enabled.
+func applyLegacyReceiverServices(ctx context.Context, c client.Client, cfg *GatewayConfig) error {
+	name := types.NamespacedName{Namespace: cfg.Namespace, Name: cfg.BaseName}
+
+	var receiverPorts []intstr.IntOrString
+	if cfg.CanReceiveZipkin {
+		receiverPorts = append(receiverPorts, intstr.FromInt32(ports.Zipkin))
+	}
+	if cfg.CanReceiveJaeger {
+		receiverPorts = append(receiverPorts, intstr.FromInt32(ports.JaegerGRPC), intstr.FromInt32(ports.JaegerHTTP))
+	}
+
+	networkPolicy := makeLegacyReceiversNetworkPolicy(name, receiverPorts)
+	if len(receiverPorts) == 0 {
+		if err := client.IgnoreNotFound(c.Delete(ctx, networkPolicy)); err != nil {
+			return fmt.Errorf("failed to delete legacy receivers network policy: %w", err)
+		}
+	} else if err := kubernetes.CreateOrUpdateNetworkPolicy(ctx, c, networkPolicy); err != nil {
+		return fmt.Errorf("failed to create legacy receivers network policy: %w", err)
+	}
+
+	services := []struct {
+		service *corev1.Service
+		enabled bool
+	}{
+		{service: makeZipkinService(name), enabled: cfg.CanReceiveZipkin},
+		{service: makeJaegerService(name), enabled: cfg.CanReceiveJaeger},
+	}
+
+	for _, s := range services {
+		if !s.enabled {
+			if err := client.IgnoreNotFound(c.Delete(ctx, s.service)); err != nil {
+				return fmt.Errorf("failed to delete service %s: %w", s.service.Name, err)
+			}
+			continue
+		}
+
+		if err := kubernetes.CreateOrUpdateService(ctx, c, s.service); err != nil {
+			return fmt.Errorf("failed to create service %s: %w", s.service.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// applyPrometheusResources creates the Service and the NetworkPolicy for the ports on which the gateway exposes metrics for scraping, and deletes them if no such port is configured.
+func applyPrometheusResources(ctx context.Context, c client.Client, cfg *GatewayConfig) error {
+	name := types.NamespacedName{Namespace: cfg.Namespace, Name: cfg.BaseName}
+	service := makePrometheusService(name, cfg.PrometheusPorts)
+	networkPolicy := makePrometheusNetworkPolicy(name, cfg.PrometheusPorts)
+
+	if len(cfg.PrometheusPorts) == 0 {
+		if err := client.IgnoreNotFound(c.Delete(ctx, service)); err != nil {
+			return fmt.Errorf("failed to delete prometheus service: %w", err)
+		}
+		if err := client.IgnoreNotFound(c.Delete(ctx, networkPolicy)); err != nil {
+			return fmt.Errorf("failed to delete prometheus network policy: %w", err)
+		}
+		return nil
+	}
+
+	if err := kubernetes.CreateOrUpdateService(ctx, c, service); err != nil {
+		return fmt.Errorf("failed to create prometheus service: %w", err)
+	}
+
+	if err := kubernetes.CreateOrUpdateNetworkPolicy(ctx, c, networkPolicy); err != nil {
+		return fmt.Errorf("failed to create prometheus network policy: %w", err)
+	}
+
+	return nil
+}
+
+// applyAutoscalingResources creates the HorizontalPodAutoscaler and the PodDisruptionBudget of the gateway if autoscaling is configured, and deletes them otherwise.
+func applyAutoscalingResources(ctx context.Context, c client.Client, cfg *GatewayConfig) error {
+	name := types.NamespacedName{Namespace: cfg.Namespace, Name: cfg.BaseName}
+
+	if cfg.Scaling.Autoscaling == nil {
+		objectMeta := metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace}
+		if err := client.IgnoreNotFound(c.Delete(ctx, &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: objectMeta})); err != nil {
+			return fmt.Errorf("failed to delete horizontal pod autoscaler: %w", err)
+		}
+		if err := client.IgnoreNotFound(c.Delete(ctx, &policyv1.PodDisruptionBudget{ObjectMeta: objectMeta})); err != nil {
+			return fmt.Errorf("failed to delete pod disruption budget: %w", err)
+		}
+		return nil
+	}
+
+	if err := kubernetes.CreateOrUpdateHorizontalPodAutoscaler(ctx, c, makeHorizontalPodAutoscaler(name, cfg.Scaling.Autoscaling)); err != nil {
+		return fmt.Errorf("failed to create horizontal pod autoscaler: %w", err)
+	}
+
+	if err := kubernetes.CreateOrUpdatePodDisruptionBudget(ctx, c, makePodDisruptionBudget(name)); err != nil {
+		return fmt.Errorf("failed to create pod disruption budget: %w", err)
+	}
+
 	return nil
 }
 
@@ -87,13 +267,33 @@ func makeGatewayDeployment(cfg *GatewayConfig, configChecksum string) *appsv1.De
 	annotations := map[string]string{"checksum/config": configChecksum}
 	resources := makeGatewayResourceRequirements(cfg)
 	affinity := makePodAffinity(selectorLabels)
-	podSpec := makePodSpec(cfg.BaseName, cfg.Deployment.Image,
+	opts := []podSpecOption{
 		withPriorityClass(cfg.Deployment.PriorityClassName),
 		withResources(resources),
 		withAffinity(affinity),
 		withEnvVarFromSource(config.EnvVarCurrentPodIP, fieldPathPodIP),
 		withEnvVarFromSource(config.EnvVarCurrentNodeName, fieldPathNodeName),
-	)
+	}
+	if cfg.PersistentQueue != nil {
+		opts = append(opts,
+			withVolume(makePersistentQueueVolume(cfg.PersistentQueue)),
+			withVolumeMount(corev1.VolumeMount{Name: persistentQueueVolumeName, MountPath: config.PersistentQueueDirectory}),
+		)
+	}
+	podSpec := makePodSpec(cfg.BaseName, cfg.Deployment.Image, opts...)
+
+	// If autoscaling is configured, the replicas are left to the HorizontalPodAutoscaler.
+	var replicas *int32
+	if cfg.Scaling.Autoscaling == nil {
+		replicas = pointer.Int32(cfg.Scaling.Replicas)
+	}
+
+	// A rolling update would start a second Pod on the same PersistentVolumeClaim, which usually cannot be attached to another Node
+	// and is locked by the file storage of the running Pod.
+	var strategy appsv1.DeploymentStrategy
+	if cfg.PersistentQueue != nil && cfg.PersistentQueue.PersistentVolumeClaimName != "" {
+		strategy.Type = appsv1.RecreateDeploymentStrategyType
+	}
 
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -102,7 +302,8 @@ func makeGatewayDeployment(cfg *GatewayConfig, configChecksum string) *appsv1.De
 			Labels:    selectorLabels,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: pointer.Int32(cfg.Scaling.Replicas),
+			Replicas: replicas,
+			Strategy: strategy,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: selectorLabels,
 			},
@@ -117,6 +318,84 @@ func makeGatewayDeployment(cfg *GatewayConfig, configChecksum string) *appsv1.De
 	}
 }
 
+const persistentQueueVolumeName = "persistent-queue"
+
+func makePersistentQueueVolume(pq *PersistentQueueConfig) corev1.Volume {
+	if pq.PersistentVolumeClaimName == "" {
+		return corev1.Volume{
+			Name:         persistentQueueVolumeName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}
+	}
+
+	return corev1.Volume{
+		Name: persistentQueueVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pq.PersistentVolumeClaimName},
+		},
+	}
+}
+
+func makeHorizontalPodAutoscaler(name types.NamespacedName, autoscaling *GatewayAutoscalingConfig) *autoscalingv2.HorizontalPodAutoscaler {
+	var metrics []autoscalingv2.MetricSpec
+	if autoscaling.TargetCPUUtilization > 0 {
+		metrics = append(metrics, makeResourceUtilizationMetric(corev1.ResourceCPU, autoscaling.TargetCPUUtilization))
+	}
+	if autoscaling.TargetMemoryUtilization > 0 {
+		metrics = append(metrics, makeResourceUtilizationMetric(corev1.ResourceMemory, autoscaling.TargetMemoryUtilization))
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+			Labels:    defaultLabels(name.Name),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       name.Name,
+			},
+			MinReplicas: pointer.Int32(autoscaling.MinReplicas),
+			MaxReplicas: autoscaling.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}
+}
+
+func makeResourceUtilizationMetric(resourceName corev1.ResourceName, targetUtilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: resourceName,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: pointer.Int32(targetUtilization),
+			},
+		},
+	}
+}
+
+// makePodDisruptionBudget allows evicting only one gateway replica at a time, so that voluntary disruptions like Node drains never take down the whole gateway.
+func makePodDisruptionBudget(name types.NamespacedName) *policyv1.PodDisruptionBudget {
+	maxUnavailable := intstr.FromInt32(1)
+
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+			Labels:    defaultLabels(name.Name),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: defaultLabels(name.Name),
+			},
+		},
+	}
+}
+
 func makeGatewayResourceRequirements(cfg *GatewayConfig) corev1.ResourceRequirements {
 	memoryRequest := cfg.Deployment.BaseMemoryRequest.DeepCopy()
 	memoryLimit := cfg.Deployment.BaseMemoryLimit.DeepCopy()
@@ -195,6 +474,148 @@ func makeOpenCensusService(name types.NamespacedName) *corev1.Service {
 	}
 }
 
+func makeZipkinService(name types.NamespacedName) *corev1.Service {
+	labels := defaultLabels(name.Name)
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name + "-zipkin",
+			Namespace: name.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http-zipkin",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       ports.Zipkin,
+					TargetPort: intstr.FromInt32(ports.Zipkin),
+				},
+			},
+			Selector: labels,
+			Type:     corev1.ServiceTypeClusterIP,
+		},
+	}
+}
+
+func makeJaegerService(name types.NamespacedName) *corev1.Service {
+	labels := defaultLabels(name.Name)
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name + "-jaeger",
+			Namespace: name.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "grpc-jaeger",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       ports.JaegerGRPC,
+					TargetPort: intstr.FromInt32(ports.JaegerGRPC),
+				},
+				{
+					Name:       "http-jaeger-thrift",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       ports.JaegerHTTP,
+					TargetPort: intstr.FromInt32(ports.JaegerHTTP),
+				},
+			},
+			Selector: labels,
+			Type:     corev1.ServiceTypeClusterIP,
+		},
+	}
+}
+
+// makeLoadBalancingService creates a headless Service, so that the gateway replicas can discover each other and route spans by trace ID.
+func makeLoadBalancingService(cfg *GatewayConfig) *corev1.Service {
+	labels := defaultLabels(cfg.BaseName)
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfg.LoadBalancingServiceName,
+			Namespace: cfg.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "grpc-loadbalancing",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       ports.LoadBalancing,
+					TargetPort: intstr.FromInt32(ports.LoadBalancing),
+				},
+			},
+			Selector:  labels,
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: corev1.ClusterIPNone,
+		},
+	}
+}
+
+// makeLegacyReceiversNetworkPolicy allows ingress traffic to the ports of the enabled Zipkin and Jaeger receivers, which are not covered by the common NetworkPolicy of the collector.
+func makeLegacyReceiversNetworkPolicy(name types.NamespacedName, receiverPorts []intstr.IntOrString) *networkingv1.NetworkPolicy {
+	labels := defaultLabels(name.Name)
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name + "-legacy-receivers-allow-ingress",
+			Namespace: name.Namespace,
+			Labels:    labels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+			},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							IPBlock: &networkingv1.IPBlock{CIDR: "0.0.0.0/0"},
+						},
+					},
+					Ports: makeNetworkPolicyPorts(receiverPorts),
+				},
+			},
+		},
+	}
+}
+
+// makeLoadBalancingNetworkPolicy allows ingress traffic to the load balancing port only from the replicas of the same gateway.
+func makeLoadBalancingNetworkPolicy(name types.NamespacedName) *networkingv1.NetworkPolicy {
+	labels := defaultLabels(name.Name)
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name + "-loadbalancing-allow-ingress",
+			Namespace: name.Namespace,
+			Labels:    labels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+			},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							PodSelector: &metav1.LabelSelector{MatchLabels: labels},
+						},
+					},
+					Ports: makeNetworkPolicyPorts([]intstr.IntOrString{intstr.FromInt32(ports.LoadBalancing)}),
+				},
+			},
+		},
+	}
+}
+
 func makeOTLPService(cfg *GatewayConfig) *corev1.Service {
 	labels := defaultLabels(cfg.BaseName)
 
@@ -224,3 +645,69 @@ func makeOTLPService(cfg *GatewayConfig) *corev1.Service {
 		},
 	}
 }
+
+// makePrometheusService creates a headless Service for the Prometheus ports. Every gateway replica only exposes the metrics that it received,
+// so the scraper must discover and scrape all replicas.
+func makePrometheusService(name types.NamespacedName, prometheusPorts []int32) *corev1.Service {
+	labels := defaultLabels(name.Name)
+
+	var servicePorts []corev1.ServicePort
+	for _, port := range prometheusPorts {
+		servicePorts = append(servicePorts, corev1.ServicePort{
+			Name:       fmt.Sprintf("http-prom-%d", port),
+			Protocol:   corev1.ProtocolTCP,
+			Port:       port,
+			TargetPort: intstr.FromInt32(port),
+		})
+	}
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name + "-prometheus",
+			Namespace: name.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports:     servicePorts,
+			Selector:  labels,
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: corev1.ClusterIPNone,
+		},
+	}
+}
+
+// makePrometheusNetworkPolicy allows ingress traffic to the Prometheus ports, which are not covered by the common NetworkPolicy of the collector.
+func makePrometheusNetworkPolicy(name types.NamespacedName, prometheusPorts []int32) *networkingv1.NetworkPolicy {
+	labels := defaultLabels(name.Name)
+
+	var policyPorts []intstr.IntOrString
+	for _, port := range prometheusPorts {
+		policyPorts = append(policyPorts, intstr.FromInt32(port))
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name + "-prometheus-allow-ingress",
+			Namespace: name.Namespace,
+			Labels:    labels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+			},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							IPBlock: &networkingv1.IPBlock{CIDR: "0.0.0.0/0"},
+						},
+					},
+					Ports: makeNetworkPolicyPorts(policyPorts),
+				},
+			},
+		},
+	}
+}
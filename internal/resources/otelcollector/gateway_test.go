@@ -6,12 +6,16 @@ import (
 
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -26,6 +30,7 @@ func TestApplyGatewayResources(t *testing.T) {
 		"OTLP_ENDPOINT":     []byte("otlpEndpoint"),
 	}
 	otlpServiceName := "telemetry"
+	loadBalancingServiceName := "telemetry-loadbalancing"
 	var replicas int32 = 3
 	baseCPURequest := resource.MustParse("150m")
 	baseCPULimit := resource.MustParse("300m")
@@ -39,8 +44,9 @@ func TestApplyGatewayResources(t *testing.T) {
 			CollectorConfig:  cfg,
 			CollectorEnvVars: envVars,
 		},
-		OTLPServiceName:      otlpServiceName,
-		CanReceiveOpenCensus: true,
+		OTLPServiceName:          otlpServiceName,
+		CanReceiveOpenCensus:     true,
+		LoadBalancingServiceName: loadBalancingServiceName,
 		Scaling: GatewayScalingConfig{
 			Replicas: replicas,
 		},
@@ -202,12 +208,9 @@ func TestApplyGatewayResources(t *testing.T) {
 	})
 
 	t.Run("should create networkpolicy", func(t *testing.T) {
-		var nps networkingv1.NetworkPolicyList
-		require.NoError(t, client.List(ctx, &nps))
-		require.Len(t, nps.Items, 1)
+		var np networkingv1.NetworkPolicy
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name + "-pprof-deny-ingress"}, &np))
 
-		np := nps.Items[0]
-		require.NotNil(t, np)
 		require.Equal(t, name+"-pprof-deny-ingress", np.Name)
 		require.Equal(t, namespace, np.Namespace)
 		require.Equal(t, map[string]string{
@@ -218,6 +221,22 @@ func TestApplyGatewayResources(t *testing.T) {
 		require.Len(t, np.Spec.Ingress[0].Ports, 5)
 	})
 
+	t.Run("should create load balancing networkpolicy", func(t *testing.T) {
+		var np networkingv1.NetworkPolicy
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name + "-loadbalancing-allow-ingress"}, &np))
+
+		require.Equal(t, map[string]string{
+			"app.kubernetes.io/name": name,
+		}, np.Spec.PodSelector.MatchLabels)
+		require.Len(t, np.Spec.Ingress, 1)
+		require.Equal(t, map[string]string{
+			"app.kubernetes.io/name": name,
+		}, np.Spec.Ingress[0].From[0].PodSelector.MatchLabels)
+		require.Nil(t, np.Spec.Ingress[0].From[0].IPBlock)
+		require.Len(t, np.Spec.Ingress[0].Ports, 1)
+		require.Equal(t, intstr.FromInt32(4319), *np.Spec.Ingress[0].Ports[0].Port)
+	})
+
 	t.Run("should create metrics service", func(t *testing.T) {
 		var svc corev1.Service
 		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name + "-metrics"}, &svc))
@@ -296,4 +315,372 @@ func TestApplyGatewayResources(t *testing.T) {
 			TargetPort: intstr.FromInt32(55678),
 		}, svc.Spec.Ports[0])
 	})
+
+	t.Run("should create headless load balancing service", func(t *testing.T) {
+		var svc corev1.Service
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: loadBalancingServiceName}, &svc))
+
+		require.NotNil(t, svc)
+		require.Equal(t, loadBalancingServiceName, svc.Name)
+		require.Equal(t, namespace, svc.Namespace)
+		require.Equal(t, map[string]string{
+			"app.kubernetes.io/name": name,
+		}, svc.Spec.Selector)
+		require.Equal(t, corev1.ClusterIPNone, svc.Spec.ClusterIP)
+		require.Len(t, svc.Spec.Ports, 1)
+		require.Equal(t, corev1.ServicePort{
+			Name:       "grpc-loadbalancing",
+			Protocol:   corev1.ProtocolTCP,
+			Port:       4319,
+			TargetPort: intstr.FromInt32(4319),
+		}, svc.Spec.Ports[0])
+	})
+
+	t.Run("should not create autoscaling resources", func(t *testing.T) {
+		var hpas autoscalingv2.HorizontalPodAutoscalerList
+		require.NoError(t, client.List(ctx, &hpas))
+		require.Empty(t, hpas.Items)
+
+		var pdbs policyv1.PodDisruptionBudgetList
+		require.NoError(t, client.List(ctx, &pdbs))
+		require.Empty(t, pdbs.Items)
+	})
+}
+
+func TestApplyGatewayResourcesWithAutoscaling(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().Build()
+	namespace := "my-namespace"
+	name := "my-gateway"
+
+	gatewayConfig := &GatewayConfig{
+		Config: Config{
+			BaseName:  name,
+			Namespace: namespace,
+		},
+		OTLPServiceName: "telemetry",
+		Scaling: GatewayScalingConfig{
+			Replicas:    2,
+			Autoscaling: NewGatewayAutoscalingConfig(3, 0, 0, 70),
+		},
+	}
+
+	require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig))
+
+	t.Run("should leave the replicas to the autoscaler", func(t *testing.T) {
+		var dep appsv1.Deployment
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &dep))
+		require.Nil(t, dep.Spec.Replicas)
+	})
+
+	t.Run("should create horizontal pod autoscaler", func(t *testing.T) {
+		var hpa autoscalingv2.HorizontalPodAutoscaler
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &hpa))
+
+		require.Equal(t, map[string]string{
+			"app.kubernetes.io/name": name,
+		}, hpa.Labels)
+		require.Equal(t, autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: name}, hpa.Spec.ScaleTargetRef)
+		require.Equal(t, int32(3), *hpa.Spec.MinReplicas)
+		require.Equal(t, int32(10), hpa.Spec.MaxReplicas)
+		require.Len(t, hpa.Spec.Metrics, 2)
+		require.Equal(t, corev1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name)
+		require.Equal(t, int32(80), *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+		require.Equal(t, corev1.ResourceMemory, hpa.Spec.Metrics[1].Resource.Name)
+		require.Equal(t, int32(70), *hpa.Spec.Metrics[1].Resource.Target.AverageUtilization)
+	})
+
+	t.Run("should create pod disruption budget", func(t *testing.T) {
+		var pdb policyv1.PodDisruptionBudget
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &pdb))
+
+		require.Equal(t, intstr.FromInt32(1), *pdb.Spec.MaxUnavailable)
+		require.Equal(t, map[string]string{
+			"app.kubernetes.io/name": name,
+		}, pdb.Spec.Selector.MatchLabels)
+	})
+
+	t.Run("should keep the replicas set by the autoscaler", func(t *testing.T) {
+		var dep appsv1.Deployment
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &dep))
+		dep.Spec.Replicas = pointer.Int32(5)
+		require.NoError(t, client.Update(ctx, &dep))
+
+		require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig))
+
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &dep))
+		require.Equal(t, int32(5), *dep.Spec.Replicas)
+	})
+
+	t.Run("should delete autoscaling resources when switching to static scaling", func(t *testing.T) {
+		require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig.WithScaling(GatewayScalingConfig{Replicas: 2})))
+
+		var dep appsv1.Deployment
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &dep))
+		require.Equal(t, int32(2), *dep.Spec.Replicas)
+
+		var hpas autoscalingv2.HorizontalPodAutoscalerList
+		require.NoError(t, client.List(ctx, &hpas))
+		require.Empty(t, hpas.Items)
+
+		var pdbs policyv1.PodDisruptionBudgetList
+		require.NoError(t, client.List(ctx, &pdbs))
+		require.Empty(t, pdbs.Items)
+	})
+}
+
+func TestApplyGatewayResourcesWithPersistentQueue(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().Build()
+	namespace := "my-namespace"
+	name := "my-gateway"
+
+	gatewayConfig := &GatewayConfig{
+		Config: Config{
+			BaseName:  name,
+			Namespace: namespace,
+		},
+		OTLPServiceName: "telemetry",
+		Scaling:         GatewayScalingConfig{Replicas: 1},
+		PersistentQueue: &PersistentQueueConfig{},
+	}
+
+	t.Run("should mount an emptyDir volume", func(t *testing.T) {
+		require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig))
+
+		var dep appsv1.Deployment
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &dep))
+		podSpec := dep.Spec.Template.Spec
+
+		require.Contains(t, podSpec.Volumes, corev1.Volume{
+			Name:         "persistent-queue",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+		require.Contains(t, podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "persistent-queue",
+			MountPath: "/var/lib/otelcol/queue",
+		})
+		require.Empty(t, dep.Spec.Strategy.Type, "an emptyDir volume does not prevent a rolling update")
+	})
+
+	t.Run("should mount the persistent volume claim", func(t *testing.T) {
+		require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig.WithPersistentQueue(&PersistentQueueConfig{PersistentVolumeClaimName: "queue"})))
+
+		var dep appsv1.Deployment
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &dep))
+
+		require.Contains(t, dep.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: "persistent-queue",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "queue"},
+			},
+		})
+		require.Equal(t, appsv1.RecreateDeploymentStrategyType, dep.Spec.Strategy.Type, "must not run two Pods on the same claim during an update")
+	})
+}
+
+func TestApplyGatewayResourcesWithoutLoadBalancing(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().Build()
+	namespace := "my-namespace"
+	name := "my-gateway"
+
+	gatewayConfig := &GatewayConfig{
+		Config: Config{
+			BaseName:  name,
+			Namespace: namespace,
+		},
+		OTLPServiceName:          "telemetry",
+		LoadBalancingServiceName: "telemetry-loadbalancing",
+		Scaling:                  GatewayScalingConfig{Replicas: 2},
+	}
+	require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig))
+
+	gatewayConfig.LoadBalancingServiceName = ""
+	require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig))
+
+	var np networkingv1.NetworkPolicy
+	err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name + "-loadbalancing-allow-ingress"}, &np)
+	require.True(t, apierrors.IsNotFound(err))
+}
+
+func TestDeleteGatewayResources(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().Build()
+	namespace := "my-namespace"
+	name := "my-gateway"
+
+	gatewayConfig := &GatewayConfig{
+		Config: Config{
+			BaseName:  name,
+			Namespace: namespace,
+		},
+		OTLPServiceName:          "telemetry",
+		CanReceiveOpenCensus:     true,
+		LoadBalancingServiceName: "telemetry-loadbalancing",
+		Scaling:                  GatewayScalingConfig{Replicas: 2},
+	}
+	require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig))
+	require.NoError(t, DeleteGatewayResources(ctx, client, gatewayConfig))
+
+	t.Run("should delete the deployment and its configuration", func(t *testing.T) {
+		err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &appsv1.Deployment{})
+		require.True(t, apierrors.IsNotFound(err))
+		err = client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &corev1.Secret{})
+		require.True(t, apierrors.IsNotFound(err))
+		err = client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &corev1.ConfigMap{})
+		require.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("should delete all services and network policies", func(t *testing.T) {
+		var services corev1.ServiceList
+		require.NoError(t, client.List(ctx, &services))
+		require.Empty(t, services.Items)
+
+		var nps networkingv1.NetworkPolicyList
+		require.NoError(t, client.List(ctx, &nps))
+		require.Empty(t, nps.Items)
+	})
+
+	t.Run("should delete the rbac resources", func(t *testing.T) {
+		var clusterRoles rbacv1.ClusterRoleList
+		require.NoError(t, client.List(ctx, &clusterRoles))
+		require.Empty(t, clusterRoles.Items)
+
+		var serviceAccounts corev1.ServiceAccountList
+		require.NoError(t, client.List(ctx, &serviceAccounts))
+		require.Empty(t, serviceAccounts.Items)
+	})
+
+	t.Run("should succeed if the resources are already gone", func(t *testing.T) {
+		require.NoError(t, DeleteGatewayResources(ctx, client, gatewayConfig))
+	})
+}
+
+func TestApplyGatewayResourcesWithPrometheusPorts(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().Build()
+	namespace := "my-namespace"
+	name := "my-gateway"
+
+	gatewayConfig := &GatewayConfig{
+		Config: Config{
+			BaseName:  name,
+			Namespace: namespace,
+		},
+		OTLPServiceName: "telemetry",
+		Scaling:         GatewayScalingConfig{Replicas: 2},
+		PrometheusPorts: []int32{8889, 8890},
+	}
+
+	require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig))
+
+	t.Run("should create headless prometheus service", func(t *testing.T) {
+		var svc corev1.Service
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name + "-prometheus"}, &svc))
+
+		require.Equal(t, corev1.ClusterIPNone, svc.Spec.ClusterIP)
+		require.Equal(t, map[string]string{
+			"app.kubernetes.io/name": name,
+		}, svc.Spec.Selector)
+		require.Equal(t, []corev1.ServicePort{
+			{Name: "http-prom-8889", Protocol: corev1.ProtocolTCP, Port: 8889, TargetPort: intstr.FromInt32(8889)},
+			{Name: "http-prom-8890", Protocol: corev1.ProtocolTCP, Port: 8890, TargetPort: intstr.FromInt32(8890)},
+		}, svc.Spec.Ports)
+	})
+
+	t.Run("should allow ingress to the prometheus ports", func(t *testing.T) {
+		var np networkingv1.NetworkPolicy
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name + "-prometheus-allow-ingress"}, &np))
+
+		require.Len(t, np.Spec.Ingress, 1)
+		require.Len(t, np.Spec.Ingress[0].Ports, 2)
+		require.Equal(t, intstr.FromInt32(8889), *np.Spec.Ingress[0].Ports[0].Port)
+		require.Equal(t, intstr.FromInt32(8890), *np.Spec.Ingress[0].Ports[1].Port)
+	})
+
+	t.Run("should delete prometheus resources when no port is configured", func(t *testing.T) {
+		require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig.WithPrometheusPorts(nil)))
+
+		var svc corev1.Service
+		err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name + "-prometheus"}, &svc)
+		require.True(t, apierrors.IsNotFound(err))
+
+		var np networkingv1.NetworkPolicy
+		err = client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name + "-prometheus-allow-ingress"}, &np)
+		require.True(t, apierrors.IsNotFound(err))
+	})
+}
+
+func TestApplyGatewayResourcesWithLegacyReceivers(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().Build()
+	namespace := "my-namespace"
+	name := "my-gateway"
+
+	gatewayConfig := &GatewayConfig{
+		Config: Config{
+			BaseName:  name,
+			Namespace: namespace,
+		},
+		OTLPServiceName:  "telemetry",
+		Scaling:          GatewayScalingConfig{Replicas: 2},
+		CanReceiveZipkin: true,
+		CanReceiveJaeger: true,
+	}
+
+	require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig))
+
+	t.Run("should create zipkin service", func(t *testing.T) {
+		var svc corev1.Service
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name + "-zipkin"}, &svc))
+
+		require.Equal(t, map[string]string{
+			"app.kubernetes.io/name": name,
+		}, svc.Spec.Selector)
+		require.Equal(t, []corev1.ServicePort{
+			{Name: "http-zipkin", Protocol: corev1.ProtocolTCP, Port: 9411, TargetPort: intstr.FromInt32(9411)},
+		}, svc.Spec.Ports)
+	})
+
+	t.Run("should create jaeger service", func(t *testing.T) {
+		var svc corev1.Service
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name + "-jaeger"}, &svc))
+
+		require.Equal(t, map[string]string{
+			"app.kubernetes.io/name": name,
+		}, svc.Spec.Selector)
+		require.Equal(t, []corev1.ServicePort{
+			{Name: "grpc-jaeger", Protocol: corev1.ProtocolTCP, Port: 14250, TargetPort: intstr.FromInt32(14250)},
+			{Name: "http-jaeger-thrift", Protocol: corev1.ProtocolTCP, Port: 14268, TargetPort: intstr.FromInt32(14268)},
+		}, svc.Spec.Ports)
+	})
+
+	t.Run("should delete the services of disabled receivers", func(t *testing.T) {
+		require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig.WithLegacyReceivers(false, true)))
+
+		var svc corev1.Service
+		err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name + "-zipkin"}, &svc)
+		require.True(t, apierrors.IsNotFound(err))
+
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name + "-jaeger"}, &svc))
+	})
+
+	t.Run("should only open the ports of enabled receivers", func(t *testing.T) {
+		require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig.WithLegacyReceivers(false, true)))
+
+		var np networkingv1.NetworkPolicy
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name + "-legacy-receivers-allow-ingress"}, &np))
+		require.Len(t, np.Spec.Ingress[0].Ports, 2)
+		require.Equal(t, intstr.FromInt32(14250), *np.Spec.Ingress[0].Ports[0].Port)
+		require.Equal(t, intstr.FromInt32(14268), *np.Spec.Ingress[0].Ports[1].Port)
+	})
+
+	t.Run("should delete the network policy if no receiver is enabled", func(t *testing.T) {
+		require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig.WithLegacyReceivers(false, false)))
+
+		var np networkingv1.NetworkPolicy
+		err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name + "-legacy-receivers-allow-ingress"}, &np)
+		require.True(t, apierrors.IsNotFound(err))
+	})
 }
@@ -0,0 +1,124 @@
+package otelcollector
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/telemetry-manager/internal/configchecksum"
+	"github.com/kyma-project/telemetry-manager/internal/kubernetes"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	configlogagent "github.com/kyma-project/telemetry-manager/internal/otelcollector/config/log/agent"
+)
+
+const (
+	logsVolumeName        = "varlogpods"
+	checkpointsVolumeName = "checkpoints"
+)
+
+// ApplyLogAgentResources applies the resources of the OpenTelemetry Collector DaemonSet that tails the container logs on every node.
+func ApplyLogAgentResources(ctx context.Context, c client.Client, cfg *AgentConfig) error {
+	name := types.NamespacedName{Namespace: cfg.Namespace, Name: cfg.BaseName}
+
+	if err := applyCommonResources(ctx, c, name, makeLogAgentClusterRole(name)); err != nil {
+		return fmt.Errorf("failed to create common resource: %w", err)
+	}
+
+	configMap := makeConfigMap(name, cfg.CollectorConfig)
+	if err := kubernetes.CreateOrUpdateConfigMap(ctx, c, configMap); err != nil {
+		return fmt.Errorf("failed to create configmap: %w", err)
+	}
+
+	configChecksum := configchecksum.Calculate([]corev1.ConfigMap{*configMap}, []corev1.Secret{})
+	if err := kubernetes.CreateOrUpdateDaemonSet(ctx, c, makeLogAgentDaemonSet(cfg, configChecksum)); err != nil {
+		return fmt.Errorf("failed to create daemonset: %w", err)
+	}
+
+	return nil
+}
+
+func makeLogAgentClusterRole(name types.NamespacedName) *rbacv1.ClusterRole {
+	clusterRole := rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+			Labels:    defaultLabels(name.Name),
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"namespaces", "pods"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{"apps"},
+				Resources: []string{"replicasets"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}
+	return &clusterRole
+}
+
+func makeLogAgentDaemonSet(cfg *AgentConfig, configChecksum string) *appsv1.DaemonSet {
+	selectorLabels := defaultLabels(cfg.BaseName)
+	podLabels := maps.Clone(selectorLabels)
+	podLabels["sidecar.istio.io/inject"] = "false"
+
+	annotations := map[string]string{"checksum/config": configChecksum}
+
+	checkpointsHostPathType := corev1.HostPathDirectoryOrCreate
+	resources := makeAgentResourceRequirements(cfg)
+	podSpec := makePodSpec(cfg.BaseName, cfg.DaemonSet.Image,
+		withPriorityClass(cfg.DaemonSet.PriorityClassName),
+		withResources(resources),
+		withRunAsRoot(),
+		withEnvVarFromSource(config.EnvVarCurrentPodIP, fieldPathPodIP),
+		withEnvVarFromSource(config.EnvVarCurrentNodeName, fieldPathNodeName),
+		withVolume(corev1.Volume{Name: logsVolumeName, VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{Path: configlogagent.LogsDirectory},
+		}}),
+		withVolumeMount(corev1.VolumeMount{
+			Name:      logsVolumeName,
+			MountPath: configlogagent.LogsDirectory,
+			ReadOnly:  true,
+		}),
+		withVolume(corev1.Volume{Name: checkpointsVolumeName, VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{
+				Path: fmt.Sprintf("/var/%s", cfg.BaseName),
+				Type: &checkpointsHostPathType,
+			},
+		}}),
+		withVolumeMount(corev1.VolumeMount{
+			Name:      checkpointsVolumeName,
+			MountPath: configlogagent.CheckpointDirectory,
+		}),
+	)
+
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfg.BaseName,
+			Namespace: cfg.Namespace,
+			Labels:    selectorLabels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selectorLabels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podLabels,
+					Annotations: annotations,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package otelcollector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestApplyLogAgentResources(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().Build()
+	namespace := "my-namespace"
+	name := "my-log-agent"
+	cfg := "dummy otel collector config"
+
+	agentConfig := &AgentConfig{
+		Config: Config{
+			BaseName:        name,
+			Namespace:       namespace,
+			CollectorConfig: cfg,
+		},
+	}
+
+	err := ApplyLogAgentResources(ctx, client, agentConfig)
+	require.NoError(t, err)
+
+	t.Run("should create collector config configmap", func(t *testing.T) {
+		var cms corev1.ConfigMapList
+		require.NoError(t, client.List(ctx, &cms))
+		require.Len(t, cms.Items, 1)
+		require.Equal(t, cfg, cms.Items[0].Data["relay.conf"])
+	})
+
+	t.Run("should create a daemonset that reads the container logs", func(t *testing.T) {
+		var dss appsv1.DaemonSetList
+		require.NoError(t, client.List(ctx, &dss))
+		require.Len(t, dss.Items, 1)
+
+		ds := dss.Items[0]
+		require.Equal(t, name, ds.Name)
+		require.Equal(t, namespace, ds.Namespace)
+		require.Equal(t, "false", ds.Spec.Template.ObjectMeta.Labels["sidecar.istio.io/inject"])
+		require.NotEmpty(t, ds.Spec.Template.ObjectMeta.Annotations["checksum/config"])
+
+		podSpec := ds.Spec.Template.Spec
+		require.Equal(t, int64(0), *podSpec.SecurityContext.RunAsUser)
+		require.False(t, *podSpec.SecurityContext.RunAsNonRoot)
+
+		container := podSpec.Containers[0]
+		require.Equal(t, int64(0), *container.SecurityContext.RunAsUser)
+		require.True(t, *container.SecurityContext.ReadOnlyRootFilesystem)
+		require.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: "varlogpods", MountPath: "/var/log/pods", ReadOnly: true})
+		require.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: "checkpoints", MountPath: "/var/lib/otelcol/checkpoints"})
+
+		hostPathType := corev1.HostPathDirectoryOrCreate
+		require.Contains(t, podSpec.Volumes, corev1.Volume{Name: "checkpoints", VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{Path: "/var/my-log-agent", Type: &hostPathType},
+		}})
+
+		envVars := container.Env
+		require.Len(t, envVars, 2)
+		require.Equal(t, "MY_NODE_NAME", envVars[1].Name)
+	})
+
+	t.Run("should create clusterrole to look up the pod metadata", func(t *testing.T) {
+		var crs rbacv1.ClusterRoleList
+		require.NoError(t, client.List(ctx, &crs))
+		require.Len(t, crs.Items, 1)
+
+		expectedRules := []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"namespaces", "pods"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{"apps"},
+				Resources: []string{"replicasets"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		}
+		require.Equal(t, expectedRules, crs.Items[0].Rules)
+	})
+}
@@ -0,0 +1,17 @@
+package otelcollector
+
+// Names of the collectors and their Services that Telemetry Manager deploys into its Namespace.
+const (
+	TraceGatewayName              = "telemetry-trace-collector"
+	TraceOTLPServiceName          = "telemetry-otlp-traces"
+	TraceLoadBalancingServiceName = "telemetry-trace-collector-loadbalancing"
+
+	MetricGatewayName          = "telemetry-metric-gateway"
+	MetricAgentName            = "telemetry-metric-agent"
+	MetricClusterCollectorName = "telemetry-metric-cluster-collector"
+	MetricOTLPServiceName      = "telemetry-otlp-metrics"
+
+	LogGatewayName     = "telemetry-log-gateway"
+	LogAgentName       = "telemetry-log-agent"
+	LogOTLPServiceName = "telemetry-otlp-logs"
+)
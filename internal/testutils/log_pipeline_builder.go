@@ -14,7 +14,9 @@ import (
 type LogPipelineBuilder struct {
 	randSource rand.Source
 
-	name string
+	name              string
+	otlpEndpoint      string
+	additionalOutputs []telemetryv1alpha1.NamedOutput
 
 	conditions []telemetryv1alpha1.LogPipelineCondition
 }
@@ -30,6 +32,17 @@ func (b *LogPipelineBuilder) WithName(name string) *LogPipelineBuilder {
 	return b
 }
 
+// WithOtlpOutput configures an OTLP output with the given endpoint.
+func (b *LogPipelineBuilder) WithOtlpOutput(endpoint string) *LogPipelineBuilder {
+	b.otlpEndpoint = endpoint
+	return b
+}
+
+func (b *LogPipelineBuilder) WithAdditionalOutput(name string, output telemetryv1alpha1.Output) *LogPipelineBuilder {
+	b.additionalOutputs = append(b.additionalOutputs, telemetryv1alpha1.NamedOutput{Name: name, Output: output})
+	return b
+}
+
 func LogPendingCondition(reason string) telemetryv1alpha1.LogPipelineCondition {
 	return telemetryv1alpha1.LogPipelineCondition{
 		Reason: reason,
@@ -54,11 +67,24 @@ func (b *LogPipelineBuilder) Build() telemetryv1alpha1.LogPipeline {
 	if name == "" {
 		name = fmt.Sprintf("test-%d", b.randSource.Int63())
 	}
+
+	var output telemetryv1alpha1.Output
+	if b.otlpEndpoint != "" {
+		output.Otlp = &telemetryv1alpha1.OtlpOutput{
+			Endpoint: telemetryv1alpha1.ValueType{
+				Value: b.otlpEndpoint,
+			},
+		}
+	}
+
 	return telemetryv1alpha1.LogPipeline{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: name,
 		},
-		Spec: telemetryv1alpha1.LogPipelineSpec{},
+		Spec: telemetryv1alpha1.LogPipelineSpec{
+			Output:            output,
+			AdditionalOutputs: b.additionalOutputs,
+		},
 		Status: telemetryv1alpha1.LogPipelineStatus{
 			Conditions: b.conditions,
 		},
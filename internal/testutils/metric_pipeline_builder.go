@@ -14,15 +14,31 @@ import (
 type MetricPipelineBuilder struct {
 	randSource rand.Source
 
-	name              string
-	endpoint          string
-	runtimeInputOn    bool
-	prometheusInputOn bool
-	istioInputOn      bool
-	basicAuthUser     string
-	basicAuthPassword string
+	name                        string
+	endpoint                    string
+	runtimeInputOn              bool
+	prometheusInputOn           bool
+	istioInputOn                bool
+	clusterInputOn              bool
+	runtimeNamespaces           *telemetryv1alpha1.NamespaceSelector
+	prometheusNamespaces        *telemetryv1alpha1.NamespaceSelector
+	istioNamespaces             *telemetryv1alpha1.NamespaceSelector
+	clusterNamespaces           *telemetryv1alpha1.NamespaceSelector
+	runtimeNodeMetrics          bool
+	runtimeVolumeMetrics        bool
+	clusterEvents               bool
+	prometheusInterval          *metav1.Duration
+	prometheusSampleLimit       *int
+	prometheusDiagnosticMetrics bool
+	prometheusTargets           []telemetryv1alpha1.PrometheusScrapeTarget
+	basicAuthUser               string
+	basicAuthPassword           string
+	additionalOutputs           []telemetryv1alpha1.NamedMetricPipelineOutput
+	filters                     *telemetryv1alpha1.MetricPipelineFilters
+	transforms                  *telemetryv1alpha1.MetricPipelineTransforms
 
-	conditions []telemetryv1alpha1.MetricPipelineCondition
+	conditions       []telemetryv1alpha1.MetricPipelineCondition
+	healthConditions []metav1.Condition
 }
 
 func NewMetricPipelineBuilder() *MetricPipelineBuilder {
@@ -57,12 +73,106 @@ func (b *MetricPipelineBuilder) WithIstioInputOn(on bool) *MetricPipelineBuilder
 	return b
 }
 
+func (b *MetricPipelineBuilder) WithClusterInputOn(on bool) *MetricPipelineBuilder {
+	b.clusterInputOn = on
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithRuntimeInputNodeMetrics(enabled bool) *MetricPipelineBuilder {
+	b.runtimeNodeMetrics = enabled
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithRuntimeInputVolumeMetrics(enabled bool) *MetricPipelineBuilder {
+	b.runtimeVolumeMetrics = enabled
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithRuntimeInputNamespaces(selector telemetryv1alpha1.NamespaceSelector) *MetricPipelineBuilder {
+	b.runtimeNamespaces = &selector
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithPrometheusInputNamespaces(selector telemetryv1alpha1.NamespaceSelector) *MetricPipelineBuilder {
+	b.prometheusNamespaces = &selector
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithPrometheusInputInterval(interval time.Duration) *MetricPipelineBuilder {
+	b.prometheusInterval = &metav1.Duration{Duration: interval}
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithPrometheusInputSampleLimit(limit int) *MetricPipelineBuilder {
+	b.prometheusSampleLimit = &limit
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithPrometheusInputDiagnosticMetrics(enabled bool) *MetricPipelineBuilder {
+	b.prometheusDiagnosticMetrics = enabled
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithPrometheusInputTargets(targets ...telemetryv1alpha1.PrometheusScrapeTarget) *MetricPipelineBuilder {
+	b.prometheusTargets = append(b.prometheusTargets, targets...)
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithIstioInputNamespaces(selector telemetryv1alpha1.NamespaceSelector) *MetricPipelineBuilder {
+	b.istioNamespaces = &selector
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithClusterInputNamespaces(selector telemetryv1alpha1.NamespaceSelector) *MetricPipelineBuilder {
+	b.clusterNamespaces = &selector
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithClusterInputEvents(enabled bool) *MetricPipelineBuilder {
+	b.clusterEvents = enabled
+	return b
+}
+
 func (b *MetricPipelineBuilder) WithBasicAuth(user, password string) *MetricPipelineBuilder {
 	b.basicAuthUser = user
 	b.basicAuthPassword = password
 	return b
 }
 
+func (b *MetricPipelineBuilder) WithAdditionalOutput(name, endpoint string) *MetricPipelineBuilder {
+	b.additionalOutputs = append(b.additionalOutputs, telemetryv1alpha1.NamedMetricPipelineOutput{
+		Name: name,
+		MetricPipelineOutput: telemetryv1alpha1.MetricPipelineOutput{
+			Otlp: &telemetryv1alpha1.OtlpOutput{
+				Endpoint: telemetryv1alpha1.ValueType{Value: endpoint},
+			},
+		},
+	})
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithAdditionalPrometheusRemoteWriteOutput(name, endpoint string) *MetricPipelineBuilder {
+	b.additionalOutputs = append(b.additionalOutputs, telemetryv1alpha1.NamedMetricPipelineOutput{
+		Name: name,
+		MetricPipelineOutput: telemetryv1alpha1.MetricPipelineOutput{
+			PrometheusRemoteWrite: &telemetryv1alpha1.PrometheusRemoteWriteOutput{
+				Endpoint: telemetryv1alpha1.ValueType{Value: endpoint},
+			},
+		},
+	})
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithAdditionalPrometheusOutput(name string, port int32) *MetricPipelineBuilder {
+	b.additionalOutputs = append(b.additionalOutputs, telemetryv1alpha1.NamedMetricPipelineOutput{
+		Name: name,
+		MetricPipelineOutput: telemetryv1alpha1.MetricPipelineOutput{
+			Prometheus: &telemetryv1alpha1.PrometheusOutput{Port: port},
+		},
+	})
+	return b
+}
+
 func MetricPendingCondition(reason string) telemetryv1alpha1.MetricPipelineCondition {
 	return telemetryv1alpha1.MetricPipelineCondition{
 		Reason: reason,
@@ -77,11 +187,26 @@ func MetricRunningCondition() telemetryv1alpha1.MetricPipelineCondition {
 	}
 }
 
+func (b *MetricPipelineBuilder) WithFilters(filters telemetryv1alpha1.MetricPipelineFilters) *MetricPipelineBuilder {
+	b.filters = &filters
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithTransforms(transforms telemetryv1alpha1.MetricPipelineTransforms) *MetricPipelineBuilder {
+	b.transforms = &transforms
+	return b
+}
+
 func (b *MetricPipelineBuilder) WithStatusConditions(conditions ...telemetryv1alpha1.MetricPipelineCondition) *MetricPipelineBuilder {
 	b.conditions = conditions
 	return b
 }
 
+func (b *MetricPipelineBuilder) WithHealthConditions(conditions ...metav1.Condition) *MetricPipelineBuilder {
+	b.healthConditions = conditions
+	return b
+}
+
 func (b *MetricPipelineBuilder) Build() telemetryv1alpha1.MetricPipeline {
 	name := b.name
 	if name == "" {
@@ -95,13 +220,29 @@ func (b *MetricPipelineBuilder) Build() telemetryv1alpha1.MetricPipeline {
 			Input: telemetryv1alpha1.MetricPipelineInput{
 				Application: telemetryv1alpha1.MetricPipelineApplicationInput{
 					Runtime: telemetryv1alpha1.MetricPipelineContainerRuntimeInput{
-						Enabled: b.runtimeInputOn,
+						Enabled:    b.runtimeInputOn,
+						Namespaces: b.runtimeNamespaces,
+						Resources: telemetryv1alpha1.MetricPipelineRuntimeResources{
+							Node:   telemetryv1alpha1.MetricPipelineRuntimeResource{Enabled: b.runtimeNodeMetrics},
+							Volume: telemetryv1alpha1.MetricPipelineRuntimeResource{Enabled: b.runtimeVolumeMetrics},
+						},
 					},
 					Prometheus: telemetryv1alpha1.MetricPipelinePrometheusInput{
-						Enabled: b.prometheusInputOn,
+						Enabled:           b.prometheusInputOn,
+						Namespaces:        b.prometheusNamespaces,
+						Interval:          b.prometheusInterval,
+						SampleLimit:       b.prometheusSampleLimit,
+						DiagnosticMetrics: telemetryv1alpha1.DiagnosticMetrics{Enabled: b.prometheusDiagnosticMetrics},
+						Targets:           b.prometheusTargets,
 					},
 					Istio: telemetryv1alpha1.MetricPipelineIstioInput{
-						Enabled: b.istioInputOn,
+						Enabled:    b.istioInputOn,
+						Namespaces: b.istioNamespaces,
+					},
+					Cluster: telemetryv1alpha1.MetricPipelineClusterInput{
+						Enabled:    b.clusterInputOn,
+						Namespaces: b.clusterNamespaces,
+						Events:     telemetryv1alpha1.MetricPipelineClusterEvents{Enabled: b.clusterEvents},
 					},
 				},
 			},
@@ -122,9 +263,13 @@ func (b *MetricPipelineBuilder) Build() telemetryv1alpha1.MetricPipeline {
 					},
 				},
 			},
+			AdditionalOutputs: b.additionalOutputs,
+			Filters:           b.filters,
+			Transforms:        b.transforms,
 		},
 		Status: telemetryv1alpha1.MetricPipelineStatus{
-			Conditions: b.conditions,
+			Conditions:       b.conditions,
+			HealthConditions: b.healthConditions,
 		},
 	}
 }
@@ -18,8 +18,12 @@ type TracePipelineBuilder struct {
 	endpoint          string
 	basicAuthUser     string
 	basicAuthPassword string
+	namespaces        *telemetryv1alpha1.NamespaceSelector
+	sampling          *telemetryv1alpha1.TracePipelineSampling
+	additionalOutputs []telemetryv1alpha1.NamedTracePipelineOutput
 
-	conditions []telemetryv1alpha1.TracePipelineCondition
+	conditions       []telemetryv1alpha1.TracePipelineCondition
+	healthConditions []metav1.Condition
 }
 
 func NewTracePipelineBuilder() *TracePipelineBuilder {
@@ -45,6 +49,41 @@ func (b *TracePipelineBuilder) WithBasicAuth(user, password string) *TracePipeli
 	return b
 }
 
+func (b *TracePipelineBuilder) WithNamespaces(selector telemetryv1alpha1.NamespaceSelector) *TracePipelineBuilder {
+	b.namespaces = &selector
+	return b
+}
+
+func (b *TracePipelineBuilder) WithSampling(sampling telemetryv1alpha1.TracePipelineSampling) *TracePipelineBuilder {
+	b.sampling = &sampling
+	return b
+}
+
+func (b *TracePipelineBuilder) WithAdditionalOutput(name, endpoint string) *TracePipelineBuilder {
+	b.additionalOutputs = append(b.additionalOutputs, telemetryv1alpha1.NamedTracePipelineOutput{
+		Name: name,
+		TracePipelineOutput: telemetryv1alpha1.TracePipelineOutput{
+			Otlp: &telemetryv1alpha1.OtlpOutput{
+				Endpoint: telemetryv1alpha1.ValueType{Value: endpoint},
+			},
+		},
+	})
+	return b
+}
+
+func (b *TracePipelineBuilder) WithAdditionalZipkinOutput(name, endpoint string) *TracePipelineBuilder {
+	b.additionalOutputs = append(b.additionalOutputs, telemetryv1alpha1.NamedTracePipelineOutput{
+		Name: name,
+		TracePipelineOutput: telemetryv1alpha1.TracePipelineOutput{
+			Zipkin: &telemetryv1alpha1.ZipkinOutput{
+				Endpoint: telemetryv1alpha1.ValueType{Value: endpoint},
+				Format:   "json",
+			},
+		},
+	})
+	return b
+}
+
 func TracePendingCondition(reason string) telemetryv1alpha1.TracePipelineCondition {
 	return telemetryv1alpha1.TracePipelineCondition{
 		Reason: reason,
@@ -59,11 +98,29 @@ func TraceRunningCondition() telemetryv1alpha1.TracePipelineCondition {
 	}
 }
 
+// FlowHealthCondition returns a data flow health condition with the given reason.
+func FlowHealthCondition(reason string) metav1.Condition {
+	status := metav1.ConditionFalse
+	if reason == conditions.ReasonFlowHealthy {
+		status = metav1.ConditionTrue
+	}
+	return metav1.Condition{
+		Type:   conditions.TypeFlowHealthy,
+		Status: status,
+		Reason: reason,
+	}
+}
+
 func (b *TracePipelineBuilder) WithStatusConditions(conditions ...telemetryv1alpha1.TracePipelineCondition) *TracePipelineBuilder {
 	b.conditions = conditions
 	return b
 }
 
+func (b *TracePipelineBuilder) WithHealthConditions(conditions ...metav1.Condition) *TracePipelineBuilder {
+	b.healthConditions = conditions
+	return b
+}
+
 func (b *TracePipelineBuilder) Build() telemetryv1alpha1.TracePipeline {
 	name := b.name
 	if name == "" {
@@ -75,6 +132,9 @@ func (b *TracePipelineBuilder) Build() telemetryv1alpha1.TracePipeline {
 			Name: name,
 		},
 		Spec: telemetryv1alpha1.TracePipelineSpec{
+			Input: telemetryv1alpha1.TracePipelineInput{
+				Namespaces: b.namespaces,
+			},
 			Output: telemetryv1alpha1.TracePipelineOutput{
 				Otlp: &telemetryv1alpha1.OtlpOutput{
 					Endpoint: telemetryv1alpha1.ValueType{
@@ -92,9 +152,12 @@ func (b *TracePipelineBuilder) Build() telemetryv1alpha1.TracePipeline {
 					},
 				},
 			},
+			AdditionalOutputs: b.additionalOutputs,
+			Sampling:          b.sampling,
 		},
 		Status: telemetryv1alpha1.TracePipelineStatus{
-			Conditions: b.conditions,
+			Conditions:       b.conditions,
+			HealthConditions: b.healthConditions,
 		},
 	}
 }
@@ -53,6 +53,7 @@ func dnsNames(webhookService types.NamespacedName) (host string, alternativeDNSN
 func makeValidatingWebhookConfig(certificate []byte, config Config) admissionregistrationv1.ValidatingWebhookConfiguration {
 	logPipelinePath := "/validate-logpipeline"
 	logParserPath := "/validate-logparser"
+	metricPipelinePath := "/validate-metricpipeline"
 	failurePolicy := admissionregistrationv1.Fail
 	matchPolicy := admissionregistrationv1.Exact
 	sideEffects := admissionregistrationv1.SideEffectClassNone
@@ -135,6 +136,34 @@ func makeValidatingWebhookConfig(certificate []byte, config Config) admissionreg
 					},
 				},
 			},
+			{
+				AdmissionReviewVersions: []string{"v1beta1", "v1"},
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service: &admissionregistrationv1.ServiceReference{
+						Name:      config.ServiceName.Name,
+						Namespace: config.ServiceName.Namespace,
+						Port:      &servicePort,
+						Path:      &metricPipelinePath,
+					},
+					CABundle: certificate,
+				},
+				FailurePolicy:  &failurePolicy,
+				MatchPolicy:    &matchPolicy,
+				Name:           "validation.metricpipelines.telemetry.kyma-project.io",
+				SideEffects:    &sideEffects,
+				TimeoutSeconds: &timeout,
+				Rules: []admissionregistrationv1.RuleWithOperations{
+					{
+						Operations: operations,
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   apiGroups,
+							APIVersions: apiVersions,
+							Scope:       &scope,
+							Resources:   []string{"metricpipelines"},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -65,10 +65,11 @@ func TestEnsureCertificate(t *testing.T) {
 	require.Equal(t, name, validatingWebhookConfiguration.Name)
 	require.Equal(t, labels, validatingWebhookConfiguration.Labels)
 
-	require.Equal(t, 2, len(validatingWebhookConfiguration.Webhooks))
+	require.Equal(t, 3, len(validatingWebhookConfiguration.Webhooks))
 
 	require.Equal(t, int32(15), *validatingWebhookConfiguration.Webhooks[0].TimeoutSeconds)
 	require.Equal(t, int32(15), *validatingWebhookConfiguration.Webhooks[1].TimeoutSeconds)
+	require.Equal(t, int32(15), *validatingWebhookConfiguration.Webhooks[2].TimeoutSeconds)
 
 	var chainChecker certChainCheckerImpl
 	certValid, err := chainChecker.checkRoot(context.Background(), serverCert, validatingWebhookConfiguration.Webhooks[0].ClientConfig.CABundle)
@@ -79,26 +80,37 @@ func TestEnsureCertificate(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, certValid)
 
+	certValid, err = chainChecker.checkRoot(context.Background(), serverCert, validatingWebhookConfiguration.Webhooks[2].ClientConfig.CABundle)
+	require.NoError(t, err)
+	require.True(t, certValid)
+
 	require.Equal(t, webhookService.Name, validatingWebhookConfiguration.Webhooks[0].ClientConfig.Service.Name)
 	require.Equal(t, webhookService.Name, validatingWebhookConfiguration.Webhooks[1].ClientConfig.Service.Name)
+	require.Equal(t, webhookService.Name, validatingWebhookConfiguration.Webhooks[2].ClientConfig.Service.Name)
 
 	require.Equal(t, webhookService.Namespace, validatingWebhookConfiguration.Webhooks[0].ClientConfig.Service.Namespace)
 	require.Equal(t, webhookService.Namespace, validatingWebhookConfiguration.Webhooks[1].ClientConfig.Service.Namespace)
+	require.Equal(t, webhookService.Namespace, validatingWebhookConfiguration.Webhooks[2].ClientConfig.Service.Namespace)
 
 	require.Equal(t, int32(443), *validatingWebhookConfiguration.Webhooks[0].ClientConfig.Service.Port)
 	require.Equal(t, int32(443), *validatingWebhookConfiguration.Webhooks[1].ClientConfig.Service.Port)
+	require.Equal(t, int32(443), *validatingWebhookConfiguration.Webhooks[2].ClientConfig.Service.Port)
 
 	require.Equal(t, "/validate-logpipeline", *validatingWebhookConfiguration.Webhooks[0].ClientConfig.Service.Path)
 	require.Equal(t, "/validate-logparser", *validatingWebhookConfiguration.Webhooks[1].ClientConfig.Service.Path)
+	require.Equal(t, "/validate-metricpipeline", *validatingWebhookConfiguration.Webhooks[2].ClientConfig.Service.Path)
 
 	require.Contains(t, validatingWebhookConfiguration.Webhooks[0].Rules[0].APIGroups, "telemetry.kyma-project.io")
 	require.Contains(t, validatingWebhookConfiguration.Webhooks[1].Rules[0].APIGroups, "telemetry.kyma-project.io")
+	require.Contains(t, validatingWebhookConfiguration.Webhooks[2].Rules[0].APIGroups, "telemetry.kyma-project.io")
 
 	require.Contains(t, validatingWebhookConfiguration.Webhooks[0].Rules[0].APIVersions, "v1alpha1")
 	require.Contains(t, validatingWebhookConfiguration.Webhooks[1].Rules[0].APIVersions, "v1alpha1")
+	require.Contains(t, validatingWebhookConfiguration.Webhooks[2].Rules[0].APIVersions, "v1alpha1")
 
 	require.Contains(t, validatingWebhookConfiguration.Webhooks[0].Rules[0].Resources, "logpipelines")
 	require.Contains(t, validatingWebhookConfiguration.Webhooks[1].Rules[0].Resources, "logparsers")
+	require.Contains(t, validatingWebhookConfiguration.Webhooks[2].Rules[0].Resources, "metricpipelines")
 
 }
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"net/http"
 	"os"
 	"strings"
@@ -30,8 +31,10 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/fields"
@@ -50,6 +53,7 @@ import (
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	operatorcontrollers "github.com/kyma-project/telemetry-manager/controllers/operator"
 	telemetrycontrollers "github.com/kyma-project/telemetry-manager/controllers/telemetry"
+	"github.com/kyma-project/telemetry-manager/internal/flowhealth"
 	"github.com/kyma-project/telemetry-manager/internal/fluentbit/config/builder"
 	"github.com/kyma-project/telemetry-manager/internal/kubernetes"
 	"github.com/kyma-project/telemetry-manager/internal/logger"
@@ -66,6 +70,7 @@ import (
 	logparserwebhook "github.com/kyma-project/telemetry-manager/webhook/logparser"
 	logpipelinewebhook "github.com/kyma-project/telemetry-manager/webhook/logpipeline"
 	logpipelinevalidation "github.com/kyma-project/telemetry-manager/webhook/logpipeline/validation"
+	metricpipelinewebhook "github.com/kyma-project/telemetry-manager/webhook/metricpipeline"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -89,9 +94,10 @@ var (
 	configureLogLevelOnFly *logger.LogLevel
 	telemetryNamespace     string
 
-	maxLogPipelines    int
-	maxTracePipelines  int
-	maxMetricPipelines int
+	maxLogPipelines        int
+	maxTracePipelines      int
+	maxMetricPipelines     int
+	maxNamespacedPipelines int
 
 	traceGatewayImage                string
 	traceGatewayPriorityClass        string
@@ -126,6 +132,17 @@ var (
 	metricGatewayMemoryRequest        string
 	metricGatewayDynamicMemoryRequest string
 
+	logGatewayImage                string
+	logGatewayPriorityClass        string
+	logGatewayCPULimit             string
+	logGatewayDynamicCPULimit      string
+	logGatewayMemoryLimit          string
+	logGatewayDynamicMemoryLimit   string
+	logGatewayCPURequest           string
+	logGatewayDynamicCPURequest    string
+	logGatewayMemoryRequest        string
+	logGatewayDynamicMemoryRequest string
+
 	enableTelemetryManagerModule bool
 	enableWebhook                bool
 	mutex                        sync.Mutex
@@ -139,10 +156,6 @@ const (
 
 	fluentBitDaemonSet = "telemetry-fluent-bit"
 	webhookServiceName = "telemetry-operator-webhook"
-
-	metricOTLPServiceName = "telemetry-otlp-metrics"
-
-	traceOTLPServiceName = "telemetry-otlp-traces"
 )
 
 //nolint:gochecknoinits // Runtime's scheme addition is required.
@@ -173,6 +186,11 @@ func getEnvOrDefault(envVar string, defaultValue string) string {
 //+kubebuilder:rbac:groups=telemetry.kyma-project.io,resources=metricpipelines,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=telemetry.kyma-project.io,resources=metricpipelines/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=telemetry.kyma-project.io,resources=metricpipelines/finalizers,verbs=update
+//+kubebuilder:rbac:groups=telemetry.kyma-project.io,resources=namespacedlogpipelines,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=telemetry.kyma-project.io,resources=namespacedlogpipelines/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=telemetry.kyma-project.io,resources=namespacedlogpipelines/finalizers,verbs=update
+//+kubebuilder:rbac:groups=telemetry.kyma-project.io,resources=namespacedtracepipelines,verbs=get;list;watch
+//+kubebuilder:rbac:groups=telemetry.kyma-project.io,resources=namespacedtracepipelines/status,verbs=get;update;patch
 
 //+kubebuilder:rbac:groups=operator.kyma-project.io,namespace=system,resources=telemetries,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=operator.kyma-project.io,namespace=system,resources=telemetries/status,verbs=get;update;patch
@@ -192,6 +210,8 @@ func getEnvOrDefault(envVar string, defaultValue string) string {
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=replicationcontrollers,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch
 //+kubebuilder:rbac:urls=/metrics,verbs=get
 //+kubebuilder:rbac:urls=/metrics/cadvisor,verbs=get
 
@@ -200,6 +220,9 @@ func getEnvOrDefault(envVar string, defaultValue string) string {
 //+kubebuilder:rbac:groups=apps,namespace=system,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=apps,namespace=system,resources=daemonsets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=apps,resources=deployments;daemonsets;statefulsets,verbs=get;list;watch
+
+//+kubebuilder:rbac:groups=batch,resources=jobs;cronjobs,verbs=get;list;watch
 
 //+kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;list;watch;create;update;patch;delete
 
@@ -209,7 +232,14 @@ func getEnvOrDefault(envVar string, defaultValue string) string {
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.k8s.io,namespace=system,resources=networkpolicies,verbs=create;update;patch;delete
 
-// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=autoscaling,namespace=system,resources=horizontalpodautoscalers,verbs=create;update;patch;delete
+
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=policy,namespace=system,resources=poddisruptionbudgets,verbs=create;update;patch;delete
+
+// +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;patch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=get;list;watch
 
 func main() {
 	flag.BoolVar(&enableLogging, "enable-logging", true, "Enable configurable logging.")
@@ -254,8 +284,20 @@ func main() {
 	flag.StringVar(&fluentBitExporterVersion, "fluent-bit-exporter-image", fluentBitExporterImage, "Image for exporting fluent bit filesystem usage")
 	flag.StringVar(&fluentBitPriorityClassName, "fluent-bit-priority-class-name", "", "Name of the priority class of fluent bit ")
 
+	flag.StringVar(&logGatewayImage, "log-gateway-image", otelImage, "Image for logs OpenTelemetry Collector")
+	flag.StringVar(&logGatewayPriorityClass, "log-gateway-priority-class", "", "Priority class name for logs OpenTelemetry Collector")
+	flag.StringVar(&logGatewayCPULimit, "log-gateway-cpu-limit", "700m", "CPU limit for logs OpenTelemetry Collector")
+	flag.StringVar(&logGatewayDynamicCPULimit, "log-gateway-dynamic-cpu-limit", "300m", "Additional CPU limit for logs OpenTelemetry Collector per LogPipeline with OTLP output")
+	flag.StringVar(&logGatewayMemoryLimit, "log-gateway-memory-limit", "500Mi", "Memory limit for logs OpenTelemetry Collector")
+	flag.StringVar(&logGatewayDynamicMemoryLimit, "log-gateway-dynamic-memory-limit", "500Mi", "Additional memory limit for logs OpenTelemetry Collector per LogPipeline with OTLP output")
+	flag.StringVar(&logGatewayCPURequest, "log-gateway-cpu-request", "25m", "CPU request for logs OpenTelemetry Collector")
+	flag.StringVar(&logGatewayDynamicCPURequest, "log-gateway-dynamic-cpu-request", "0", "Additional CPU request for logs OpenTelemetry Collector per LogPipeline with OTLP output")
+	flag.StringVar(&logGatewayMemoryRequest, "log-gateway-memory-request", "32Mi", "Memory request for logs OpenTelemetry Collector")
+	flag.StringVar(&logGatewayDynamicMemoryRequest, "log-gateway-dynamic-memory-request", "0", "Additional memory request for logs OpenTelemetry Collector per LogPipeline with OTLP output")
+
 	flag.StringVar(&deniedOutputPlugins, "fluent-bit-denied-output-plugins", "", "Comma separated list of denied output plugins even if allowUnsupportedPlugins is enabled. If empty, all output plugins are allowed.")
 	flag.IntVar(&maxLogPipelines, "fluent-bit-max-pipelines", 5, "Maximum number of LogPipelines to be created. If 0, no limit is applied.")
+	flag.IntVar(&maxNamespacedPipelines, "max-namespaced-pipelines", 2, "Maximum number of NamespacedLogPipelines and NamespacedTracePipelines per Namespace. If 0, no limit is applied.")
 
 	flag.BoolVar(&enableWebhook, "validating-webhook-enabled", false, "Create validating webhook for LogPipelines and LogParsers.")
 
@@ -317,14 +359,17 @@ func main() {
 			// The operator handles various resource that are namespace-scoped, and additionally some resources that are cluster-scoped (clusterroles, clusterrolebindings, etc.).
 			// For namespace-scoped resources we want to restrict the operator permissions to only fetch resources from a given namespace.
 			ByObject: map[client.Object]cache.ByObject{
-				&appsv1.Deployment{}:          {Field: setNamespaceFieldSelector()},
-				&appsv1.ReplicaSet{}:          {Field: setNamespaceFieldSelector()},
-				&appsv1.DaemonSet{}:           {Field: setNamespaceFieldSelector()},
-				&corev1.ConfigMap{}:           {Field: setNamespaceFieldSelector()},
-				&corev1.ServiceAccount{}:      {Field: setNamespaceFieldSelector()},
-				&corev1.Service{}:             {Field: setNamespaceFieldSelector()},
-				&networkingv1.NetworkPolicy{}: {Field: setNamespaceFieldSelector()},
-				&corev1.Secret{}:              {Field: setNamespaceFieldSelector()},
+				&appsv1.Deployment{}:                     {Field: setNamespaceFieldSelector()},
+				&appsv1.ReplicaSet{}:                     {Field: setNamespaceFieldSelector()},
+				&appsv1.DaemonSet{}:                      {Field: setNamespaceFieldSelector()},
+				&corev1.ConfigMap{}:                      {Field: setNamespaceFieldSelector()},
+				&corev1.ServiceAccount{}:                 {Field: setNamespaceFieldSelector()},
+				&corev1.Service{}:                        {Field: setNamespaceFieldSelector()},
+				&networkingv1.NetworkPolicy{}:            {Field: setNamespaceFieldSelector()},
+				&autoscalingv2.HorizontalPodAutoscaler{}: {Field: setNamespaceFieldSelector()},
+				&policyv1.PodDisruptionBudget{}:          {Field: setNamespaceFieldSelector()},
+				&corev1.Secret{}:                         {Field: setNamespaceFieldSelector()},
+				&corev1.Pod{}:                            {Field: setNamespaceFieldSelector()},
 			},
 		},
 		Client: client.Options{
@@ -369,6 +414,9 @@ func main() {
 
 	if enableMetrics {
 		setupLog.Info("Starting with metrics controller")
+
+		mgr.GetWebhookServer().Register("/validate-metricpipeline", &k8sWebhook.Admission{Handler: createMetricPipelineValidator()})
+
 		if err = createMetricPipelineReconciler(mgr.GetClient()).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "Failed to create controller", "controller", "MetricPipeline")
 			os.Exit(1)
@@ -449,10 +497,10 @@ func validateFlags() error {
 
 func createLogPipelineReconciler(client client.Client) *telemetrycontrollers.LogPipelineReconciler {
 	config := logpipeline.Config{
-		SectionsConfigMap:     types.NamespacedName{Name: "telemetry-fluent-bit-sections", Namespace: telemetryNamespace},
+		SectionsConfigMap:     types.NamespacedName{Name: fluentbit.SectionsConfigMapName, Namespace: telemetryNamespace},
 		FilesConfigMap:        types.NamespacedName{Name: "telemetry-fluent-bit-files", Namespace: telemetryNamespace},
 		LuaConfigMap:          types.NamespacedName{Name: "telemetry-fluent-bit-luascripts", Namespace: telemetryNamespace},
-		ParsersConfigMap:      types.NamespacedName{Name: "telemetry-fluent-bit-parsers", Namespace: telemetryNamespace},
+		ParsersConfigMap:      types.NamespacedName{Name: fluentbit.ParsersConfigMapName, Namespace: telemetryNamespace},
 		EnvSecret:             types.NamespacedName{Name: "telemetry-fluent-bit-env", Namespace: telemetryNamespace},
 		OutputTLSConfigSecret: types.NamespacedName{Name: "telemetry-fluent-bit-output-tls-config", Namespace: telemetryNamespace},
 		DaemonSet:             types.NamespacedName{Name: fluentBitDaemonSet, Namespace: telemetryNamespace},
@@ -468,18 +516,53 @@ func createLogPipelineReconciler(client client.Client) *telemetrycontrollers.Log
 			CPURequest:                  resource.MustParse(fluentBitCPURequest),
 			MemoryRequest:               resource.MustParse(fluentBitMemoryRequest),
 		},
+		Gateway: otelcollector.GatewayConfig{
+			Config: otelcollector.Config{
+				Namespace: telemetryNamespace,
+				BaseName:  otelcollector.LogGatewayName,
+			},
+			Deployment: otelcollector.DeploymentConfig{
+				Image:                logGatewayImage,
+				PriorityClassName:    logGatewayPriorityClass,
+				BaseCPULimit:         resource.MustParse(logGatewayCPULimit),
+				DynamicCPULimit:      resource.MustParse(logGatewayDynamicCPULimit),
+				BaseMemoryLimit:      resource.MustParse(logGatewayMemoryLimit),
+				DynamicMemoryLimit:   resource.MustParse(logGatewayDynamicMemoryLimit),
+				BaseCPURequest:       resource.MustParse(logGatewayCPURequest),
+				DynamicCPURequest:    resource.MustParse(logGatewayDynamicCPURequest),
+				BaseMemoryRequest:    resource.MustParse(logGatewayMemoryRequest),
+				DynamicMemoryRequest: resource.MustParse(logGatewayDynamicMemoryRequest),
+			},
+			OTLPServiceName: otelcollector.LogOTLPServiceName,
+		},
+		Agent: otelcollector.AgentConfig{
+			Config: otelcollector.Config{
+				Namespace: telemetryNamespace,
+				BaseName:  otelcollector.LogAgentName,
+			},
+			DaemonSet: otelcollector.DaemonSetConfig{
+				Image:             logGatewayImage,
+				PriorityClassName: fluentBitPriorityClassName,
+				CPULimit:          resource.MustParse("1"),
+				MemoryLimit:       resource.MustParse("1Gi"),
+				CPURequest:        resource.MustParse("15m"),
+				MemoryRequest:     resource.MustParse("50Mi"),
+			},
+		},
+		MaxPipelines:             maxLogPipelines,
+		MaxPipelinesPerNamespace: maxNamespacedPipelines,
 	}
 	overridesHandler := overrides.New(configureLogLevelOnFly, &kubernetes.ConfigmapProber{Client: client})
 
 	return telemetrycontrollers.NewLogPipelineReconciler(
 		client,
-		logpipeline.NewReconciler(client, config, &kubernetes.DaemonSetProber{Client: client}, overridesHandler),
+		logpipeline.NewReconciler(client, config, &kubernetes.DaemonSetProber{Client: client}, &kubernetes.DeploymentProber{Client: client}, overridesHandler),
 		config)
 }
 
 func createLogParserReconciler(client client.Client) *telemetrycontrollers.LogParserReconciler {
 	config := logparser.Config{
-		ParsersConfigMap: types.NamespacedName{Name: "telemetry-fluent-bit-parsers", Namespace: telemetryNamespace},
+		ParsersConfigMap: types.NamespacedName{Name: fluentbit.ParsersConfigMapName, Namespace: telemetryNamespace},
 		DaemonSet:        types.NamespacedName{Name: fluentBitDaemonSet, Namespace: telemetryNamespace},
 	}
 	overridesHandler := overrides.New(configureLogLevelOnFly, &kubernetes.ConfigmapProber{Client: client})
@@ -515,12 +598,16 @@ func createLogParserValidator(client client.Client) *logparserwebhook.Validating
 		admission.NewDecoder(scheme))
 }
 
+func createMetricPipelineValidator() *metricpipelinewebhook.ValidatingWebhookHandler {
+	return metricpipelinewebhook.NewValidatingWebhookHandler(admission.NewDecoder(scheme))
+}
+
 func createTracePipelineReconciler(client client.Client) *telemetrycontrollers.TracePipelineReconciler {
 	config := tracepipeline.Config{
 		Gateway: otelcollector.GatewayConfig{
 			Config: otelcollector.Config{
 				Namespace: telemetryNamespace,
-				BaseName:  "telemetry-trace-collector",
+				BaseName:  otelcollector.TraceGatewayName,
 			},
 			Deployment: otelcollector.DeploymentConfig{
 				Image:                traceGatewayImage,
@@ -534,17 +621,22 @@ func createTracePipelineReconciler(client client.Client) *telemetrycontrollers.T
 				BaseMemoryRequest:    resource.MustParse(traceGatewayMemoryRequest),
 				DynamicMemoryRequest: resource.MustParse(traceGatewayDynamicMemoryRequest),
 			},
-			OTLPServiceName:      traceOTLPServiceName,
-			CanReceiveOpenCensus: true,
+			OTLPServiceName:          otelcollector.TraceOTLPServiceName,
+			CanReceiveOpenCensus:     true,
+			LoadBalancingServiceName: otelcollector.TraceLoadBalancingServiceName,
 		},
-		OverridesConfigMapName: types.NamespacedName{Name: overridesConfigMapName, Namespace: telemetryNamespace},
-		MaxPipelines:           maxTracePipelines,
+		OverridesConfigMapName:   types.NamespacedName{Name: overridesConfigMapName, Namespace: telemetryNamespace},
+		MaxPipelines:             maxTracePipelines,
+		MaxPipelinesPerNamespace: maxNamespacedPipelines,
+	}
+	if enableMetrics {
+		config.MetricGatewayServiceName = otelcollector.MetricOTLPServiceName
 	}
 	overridesHandler := overrides.New(configureLogLevelOnFly, &kubernetes.ConfigmapProber{Client: client})
 
 	return telemetrycontrollers.NewTracePipelineReconciler(
 		client,
-		tracepipeline.NewReconciler(client, config, &kubernetes.DeploymentProber{Client: client}, overridesHandler),
+		tracepipeline.NewReconciler(client, config, &kubernetes.DeploymentProber{Client: client}, flowhealth.NewProber(client, types.NamespacedName{Name: config.Gateway.BaseName, Namespace: config.Gateway.Namespace}, flowhealth.SignalTraces), overridesHandler),
 	)
 }
 
@@ -553,7 +645,7 @@ func createMetricPipelineReconciler(client client.Client) *telemetrycontrollers.
 		Agent: otelcollector.AgentConfig{
 			Config: otelcollector.Config{
 				Namespace: telemetryNamespace,
-				BaseName:  "telemetry-metric-agent",
+				BaseName:  otelcollector.MetricAgentName,
 			},
 			DaemonSet: otelcollector.DaemonSetConfig{
 				Image:             metricGatewayImage,
@@ -564,10 +656,24 @@ func createMetricPipelineReconciler(client client.Client) *telemetrycontrollers.
 				MemoryRequest:     resource.MustParse("50Mi"),
 			},
 		},
+		ClusterCollector: otelcollector.ClusterCollectorConfig{
+			Config: otelcollector.Config{
+				Namespace: telemetryNamespace,
+				BaseName:  otelcollector.MetricClusterCollectorName,
+			},
+			Deployment: otelcollector.ClusterCollectorDeploymentConfig{
+				Image:             metricGatewayImage,
+				PriorityClassName: metricGatewayPriorityClass,
+				CPULimit:          resource.MustParse("500m"),
+				MemoryLimit:       resource.MustParse("512Mi"),
+				CPURequest:        resource.MustParse("10m"),
+				MemoryRequest:     resource.MustParse("50Mi"),
+			},
+		},
 		Gateway: otelcollector.GatewayConfig{
 			Config: otelcollector.Config{
 				Namespace: telemetryNamespace,
-				BaseName:  "telemetry-metric-gateway",
+				BaseName:  otelcollector.MetricGatewayName,
 			},
 			Deployment: otelcollector.DeploymentConfig{
 				Image:                metricGatewayImage,
@@ -581,7 +687,7 @@ func createMetricPipelineReconciler(client client.Client) *telemetrycontrollers.
 				BaseMemoryRequest:    resource.MustParse(metricGatewayMemoryRequest),
 				DynamicMemoryRequest: resource.MustParse(metricGatewayDynamicMemoryRequest),
 			},
-			OTLPServiceName: metricOTLPServiceName,
+			OTLPServiceName: otelcollector.MetricOTLPServiceName,
 		},
 		OverridesConfigMapName: types.NamespacedName{Name: overridesConfigMapName, Namespace: telemetryNamespace},
 		MaxPipelines:           maxMetricPipelines,
@@ -591,7 +697,7 @@ func createMetricPipelineReconciler(client client.Client) *telemetrycontrollers.
 
 	return telemetrycontrollers.NewMetricPipelineReconciler(
 		client,
-		metricpipeline.NewReconciler(client, config, &kubernetes.DeploymentProber{Client: client}, overridesHandler))
+		metricpipeline.NewReconciler(client, config, &kubernetes.DeploymentProber{Client: client}, flowhealth.NewProber(client, types.NamespacedName{Name: config.Gateway.BaseName, Namespace: config.Gateway.Namespace}, flowhealth.SignalMetrics), overridesHandler))
 }
 
 func createDryRunConfig() dryrun.Config {
@@ -607,6 +713,7 @@ func createPipelineDefaults() builder.PipelineDefaults {
 		MemoryBufferLimit: fluentBitMemoryBufferLimit,
 		StorageType:       "filesystem",
 		FsBufferLimit:     fluentBitFsBufferLimit,
+		LogGatewayHost:    fmt.Sprintf("%s.%s", otelcollector.LogOTLPServiceName, telemetryNamespace),
 	}
 }
 
@@ -616,13 +723,17 @@ func parsePlugins(s string) []string {
 
 func createTelemetryReconciler(client client.Client, scheme *runtime.Scheme, webhookConfig telemetry.WebhookConfig) *operatorcontrollers.TelemetryReconciler {
 	config := telemetry.Config{
+		Logs: telemetry.LogsConfig{
+			OTLPServiceName: otelcollector.LogOTLPServiceName,
+			Namespace:       telemetryNamespace,
+		},
 		Traces: telemetry.TracesConfig{
-			OTLPServiceName: traceOTLPServiceName,
+			OTLPServiceName: otelcollector.TraceOTLPServiceName,
 			Namespace:       telemetryNamespace,
 		},
 		Metrics: telemetry.MetricsConfig{
 			Enabled:         enableMetrics,
-			OTLPServiceName: metricOTLPServiceName,
+			OTLPServiceName: otelcollector.MetricOTLPServiceName,
 			Namespace:       telemetryNamespace,
 		},
 		Webhook: webhookConfig,
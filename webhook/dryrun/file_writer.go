@@ -53,6 +53,9 @@ func (f *fileWriterImpl) preparePipelineDryRun(ctx context.Context, workDir stri
 	if err := f.writeParsers(ctx, workDir); err != nil {
 		return nil, err
 	}
+	if err := f.writePipelineParsers(pipeline, workDir); err != nil {
+		return nil, err
+	}
 
 	return func() { deleteWorkDir(ctx, workDir) }, nil
 }
@@ -126,6 +129,16 @@ func (f *fileWriterImpl) writeParsers(ctx context.Context, basePath string) erro
 	return writeFile(filepath.Join(dynamicParsersDir, "parsers.conf"), parsersConfig)
 }
 
+func (f *fileWriterImpl) writePipelineParsers(pipeline *telemetryv1alpha1.LogPipeline, basePath string) error {
+	dynamicParsersDir := filepath.Join(basePath, "dynamic-parsers")
+	if err := makeDir(dynamicParsersDir); err != nil {
+		return err
+	}
+
+	pipelineParsersConfig := builder.BuildFluentBitPipelineParsersConfig([]telemetryv1alpha1.LogPipeline{*pipeline})
+	return writeFile(filepath.Join(dynamicParsersDir, resources.PipelineParsersConfigMapKey), pipelineParsersConfig)
+}
+
 func (f *fileWriterImpl) writeParsersWithParser(ctx context.Context, basePath string, parser *telemetryv1alpha1.LogParser) error {
 	dynamicParsersDir := filepath.Join(basePath, "dynamic-parsers")
 	if err := makeDir(dynamicParsersDir); err != nil {
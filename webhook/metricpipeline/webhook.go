@@ -0,0 +1,50 @@
+package metricpipeline
+
+import (
+	"context"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/webhook/logpipeline"
+)
+
+// +kubebuilder:webhook:path=/validate-metricpipeline,mutating=false,failurePolicy=fail,sideEffects=None,groups=telemetry.kyma-project.io,resources=metricpipelines,verbs=create;update,versions=v1alpha1,name=vmetricpipeline.kb.io,admissionReviewVersions=v1
+type ValidatingWebhookHandler struct {
+	decoder *admission.Decoder
+}
+
+func NewValidatingWebhookHandler(decoder *admission.Decoder) *ValidatingWebhookHandler {
+	return &ValidatingWebhookHandler{
+		decoder: decoder,
+	}
+}
+
+func (v *ValidatingWebhookHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	log := logf.FromContext(ctx)
+
+	metricPipeline := &telemetryv1alpha1.MetricPipeline{}
+	if err := v.decoder.Decode(req, metricPipeline); err != nil {
+		log.Error(err, "Failed to decode MetricPipeline")
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := metricPipeline.Validate(); err != nil {
+		log.Error(err, "MetricPipeline rejected")
+		return admission.Response{
+			AdmissionResponse: admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Code:    int32(http.StatusForbidden),
+					Reason:  logpipeline.StatusReasonConfigurationError,
+					Message: err.Error(),
+				},
+			},
+		}
+	}
+	return admission.Allowed("MetricPipeline validation successful")
+}
@@ -0,0 +1,77 @@
+package metricpipeline
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/webhook/logpipeline"
+)
+
+func TestHandle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, telemetryv1alpha1.AddToScheme(scheme))
+	sut := NewValidatingWebhookHandler(admission.NewDecoder(scheme))
+
+	tests := []struct {
+		name    string
+		spec    telemetryv1alpha1.MetricPipelineSpec
+		allowed bool
+	}{
+		{
+			name: "valid filters and transforms",
+			spec: telemetryv1alpha1.MetricPipelineSpec{
+				Filters: &telemetryv1alpha1.MetricPipelineFilters{
+					Conditions: []string{`IsMatch(attributes["path"], "^/healthz")`},
+				},
+				Transforms: &telemetryv1alpha1.MetricPipelineTransforms{
+					Statements: []string{`delete_key(attributes, "pod_uid")`},
+				},
+			},
+			allowed: true,
+		},
+		{
+			name: "invalid filter condition",
+			spec: telemetryv1alpha1.MetricPipelineSpec{
+				Filters: &telemetryv1alpha1.MetricPipelineFilters{
+					Conditions: []string{`attributes["path"] ==`},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline := telemetryv1alpha1.MetricPipeline{
+				TypeMeta:   metav1.TypeMeta{APIVersion: telemetryv1alpha1.GroupVersion.String(), Kind: "MetricPipeline"},
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       tt.spec,
+			}
+			raw, err := json.Marshal(pipeline)
+			require.NoError(t, err)
+
+			response := sut.Handle(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			})
+
+			require.Equal(t, tt.allowed, response.Allowed)
+			if !tt.allowed {
+				require.Equal(t, int32(http.StatusForbidden), response.Result.Code)
+				require.Equal(t, metav1.StatusReason(logpipeline.StatusReasonConfigurationError), response.Result.Reason)
+			}
+		})
+	}
+}